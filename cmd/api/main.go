@@ -1,6 +1,11 @@
 package main
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/luongtruong20201/bookmark-management/internal/infrastructure"
 	"github.com/luongtruong20201/bookmark-management/pkg/common"
 )
@@ -15,8 +20,17 @@ import (
 //	@BasePath		/
 
 // main is the entry point of the application. It initializes the configuration,
-// creates a new API instance, and starts the server.
+// sets up tracing, creates a new API instance, and starts the server until SIGINT or
+// SIGTERM is received. Pending spans are flushed before the process exits.
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracing := infrastructure.CreateTracing(ctx)
+	defer func() {
+		common.HandleError(shutdownTracing(context.Background()))
+	}()
+
 	api := infrastructure.CreateAPI()
-	common.HandleError(api.Start())
+	common.HandleError(api.Start(ctx))
 }
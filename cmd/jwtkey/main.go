@@ -0,0 +1,166 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/luongtruong20201/bookmark-management/pkg/jwt"
+)
+
+// defaultKeysDir is the key directory used when neither -dir nor JWT_KEYS_DIR is set.
+const defaultKeysDir = "./keys"
+
+const usage = `usage: jwtkey <command> [-dir DIR] [arguments]
+
+Manages the JWT signing keys of a key directory (JWT_KEYS_DIR).
+
+commands:
+  generate [-alg ALG] [-bits N] [-promote]  create a new key, optionally making it the signing key
+  promote <kid>                             make the key the signing key
+  retire <kid>                              keep the key for verification only
+  list                                      list the keys, marking the signing key with *
+  keypair [-alg ALG] [-bits N] [-out FILE] [-pub FILE]
+                                            write a key pair to PEM files, for
+                                            JWT_PRIVATE_KEY_PATH setups without a key directory
+
+ALG is RS256 (default), ES256, ES384, ES512 or EdDSA; -bits sets the RSA key size.
+
+A rotation generates a key, deploys it so every instance accepts it, promotes it,
+and retires the previous key once the tokens it signed have expired.
+`
+
+// main runs the key management command given on the command line.
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command in args and returns the process exit code: 0 on success,
+// 1 when the command failed and 2 on invalid usage.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprint(stderr, usage) }
+	dir := fs.String("dir", keysDirFromEnv(), "key directory")
+	alg := fs.String("alg", jwt.AlgorithmRS256, "signing algorithm of generated keys")
+	bits := fs.Int("bits", jwt.DefaultRSABits, "RSA key size of generated keys")
+	promote := fs.Bool("promote", false, "promote the generated key immediately")
+	out := fs.String("out", "private.pem", "private key file written by keypair")
+	pub := fs.String("pub", "public.pem", "public key file written by keypair")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "generate":
+		var kid string
+		kid, err = jwt.GenerateKey(*dir, *alg, *bits)
+		if err == nil && *promote {
+			err = jwt.PromoteKey(*dir, kid)
+		}
+		if err == nil {
+			fmt.Fprintln(stdout, kid)
+		}
+	case "promote":
+		if fs.NArg() != 1 {
+			fs.Usage()
+			return 2
+		}
+		err = jwt.PromoteKey(*dir, fs.Arg(0))
+	case "retire":
+		if fs.NArg() != 1 {
+			fs.Usage()
+			return 2
+		}
+		err = jwt.RetireKey(*dir, fs.Arg(0))
+	case "list":
+		err = list(*dir, stdout)
+	case "keypair":
+		err = keypair(*alg, *bits, *out, *pub)
+	default:
+		fs.Usage()
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %s\n", args[0], err)
+		return 1
+	}
+
+	return 0
+}
+
+// list prints the IDs and algorithms of the keys of the directory, one per line,
+// marking the signing key and the keys that can only verify tokens.
+func list(dir string, w io.Writer) error {
+	keys, activeID, err := jwt.ListKeys(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		alg := key.SigningMethod().Alg()
+		switch {
+		case key.ID == activeID:
+			fmt.Fprintf(w, "* %s %s\n", key.ID, alg)
+		case key.Private == nil:
+			fmt.Fprintf(w, "  %s %s (verify only)\n", key.ID, alg)
+		default:
+			fmt.Fprintf(w, "  %s %s\n", key.ID, alg)
+		}
+	}
+
+	return nil
+}
+
+// keypair generates a private key for the signing algorithm and writes it and its public
+// key to the given PEM files. Existing files are not overwritten.
+func keypair(alg string, bits int, privatePath, publicPath string) error {
+	privateKey, err := jwt.NewPrivateKey(alg, bits)
+	if err != nil {
+		return err
+	}
+	privatePEM, err := jwt.MarshalPrivateKey(privateKey)
+	if err != nil {
+		return err
+	}
+	publicPEM, err := jwt.MarshalPublicKey(privateKey.Public())
+	if err != nil {
+		return err
+	}
+
+	if err := writeNewFile(privatePath, privatePEM, 0o600); err != nil {
+		return err
+	}
+
+	return writeNewFile(publicPath, publicPEM, 0o644)
+}
+
+// writeNewFile writes data to a file that must not exist yet.
+func writeNewFile(path string, data []byte, perm os.FileMode) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(data)
+	return err
+}
+
+// keysDirFromEnv returns the key directory configured for the service, if any.
+func keysDirFromEnv() string {
+	cfg, err := jwt.NewConfig()
+	if err != nil || cfg.KeysDir == "" {
+		return defaultKeysDir
+	}
+
+	return cfg.KeysDir
+}
@@ -1,7 +1,253 @@
 package main
 
-import "github.com/luongtruong20201/bookmark-management/internal/infrastructure"
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
 
+	"github.com/luongtruong20201/bookmark-management/internal/infrastructure"
+	"github.com/luongtruong20201/bookmark-management/pkg/migration"
+)
+
+// sourceDir is the directory of the migrations embedded in the binaries, where create
+// scaffolds new migrations when no path is given.
+const sourceDir = "./migrations"
+
+const usage = `usage: migrate <command> [-path PATH] [-dry-run] [arguments]
+
+Manages the database schema with the SQL migrations found at PATH, a golang-migrate
+source URL such as file://./migrations (MIGRATIONS_PATH), or with the migrations
+embedded in the binary when PATH is empty.
+
+commands:
+  up                apply all the pending migrations
+  down <n>          revert the last n applied migrations
+  goto <version>    apply or revert migrations until the schema is at the version;
+                    version 0 reverts every migration
+  version           print the version of the schema
+  status            list the migrations, marking the applied ones
+  force <version>   mark the schema as clean at the version, after fixing a failed
+                    migration by hand; version -1 marks no migration as applied
+  create <name>     scaffold the up and down files of a new migration in PATH, or in
+                    ./migrations, embedded at the next build
+  precheck          report the data that would make pending migrations fail
+
+-dry-run prints the migrations that up, down and goto would run, without running them.
+
+The API server applies the pending migrations when it starts; set DB_AUTO_MIGRATE=false
+to leave migrating to this command. It refuses to start when the schema is ahead of its
+migrations.
+`
+
+// main runs the migration command given on the command line.
 func main() {
-	_ = infrastructure.CreateSqlDBAndMigrate()
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command in args and returns the process exit code: 0 on success,
+// 1 when the command failed and 2 on invalid usage. The precheck command has its own
+// exit codes, see precheck.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	command := args[0]
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() { fmt.Fprint(stderr, usage) }
+	path := fs.String("path", pathFromEnv(), "source URL of the migrations")
+	dryRun := fs.Bool("dry-run", false, "print the migrations to run without running them")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	arity := map[string]int{
+		"up": 0, "down": 1, "goto": 1, "version": 0, "status": 0, "force": 1, "create": 1, "precheck": 0,
+	}
+	if expected, ok := arity[command]; !ok || fs.NArg() != expected {
+		fs.Usage()
+		return 2
+	}
+	var number int
+	if command == "down" || command == "goto" || command == "force" {
+		var err error
+		if number, err = strconv.Atoi(fs.Arg(0)); err != nil || (command == "goto" && number < 0) {
+			fmt.Fprintf(stderr, "%s: invalid number %q\n", command, fs.Arg(0))
+			return 2
+		}
+	}
+
+	var err error
+	switch command {
+	case "create":
+		err = create(*path, fs.Arg(0), stdout)
+	case "precheck":
+		return precheck(infrastructure.CreateSqlDB(), stdout)
+	default:
+		err = withMigrator(*path, func(migrator migration.Migrator) error {
+			return migrate(migrator, command, number, *dryRun, stdout)
+		})
+	}
+
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %s\n", command, err)
+		return 1
+	}
+
+	return 0
+}
+
+// withMigrator connects to the database and calls fn with a Migrator for the migrations
+// found at the path.
+func withMigrator(path string, fn func(migration.Migrator) error) error {
+	migrator, err := infrastructure.CreateMigrator(context.Background(), infrastructure.CreateSqlDB(), path)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	return fn(migrator)
+}
+
+// migrate runs a command needing the database, or prints the migrations it would run
+// on a dry run. The number is the argument of down, goto and force.
+func migrate(migrator migration.Migrator, command string, number int, dryRun bool, w io.Writer) error {
+	switch command {
+	case "version":
+		return printVersion(migrator, w)
+	case "status":
+		return printStatus(migrator, w)
+	case "force":
+		if err := migrator.Force(number); err != nil {
+			return err
+		}
+		return printVersion(migrator, w)
+	}
+
+	var steps []migration.Step
+	var err error
+	switch command {
+	case "up":
+		steps, err = migrator.PlanUp()
+	case "down":
+		steps, err = migrator.PlanDown(number)
+	case "goto":
+		steps, err = migrator.PlanGoto(uint(number))
+	}
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		printSteps(steps, "would apply", "would revert", w)
+		return nil
+	}
+
+	switch command {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		err = migrator.Down(number)
+	case "goto":
+		err = migrator.Goto(uint(number))
+	}
+	if err != nil {
+		return err
+	}
+	printSteps(steps, "applied", "reverted", w)
+
+	return printVersion(migrator, w)
+}
+
+// printSteps prints the migrations of the steps, one per line.
+func printSteps(steps []migration.Step, applyVerb, revertVerb string, w io.Writer) {
+	if len(steps) == 0 {
+		fmt.Fprintln(w, "no change")
+		return
+	}
+
+	for _, step := range steps {
+		verb := revertVerb
+		if step.Up {
+			verb = applyVerb
+		}
+		fmt.Fprintf(w, "%s %d %s\n", verb, step.Version, step.Name)
+	}
+}
+
+// printVersion prints the version of the schema, flagging a dirty schema.
+func printVersion(migrator migration.Migrator, w io.Writer) error {
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		fmt.Fprintf(w, "version %d (dirty)\n", version)
+	} else {
+		fmt.Fprintf(w, "version %d\n", version)
+	}
+
+	return nil
+}
+
+// printStatus prints the version of the schema, then the migrations marked as applied or
+// pending, one per line.
+func printStatus(migrator migration.Migrator, w io.Writer) error {
+	if err := printVersion(migrator, w); err != nil {
+		return err
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range status.Migrations {
+		state := "pending"
+		if m.Applied {
+			state = "applied"
+		}
+		fmt.Fprintf(w, "  %-7s %d %s\n", state, m.Version, m.Name)
+	}
+
+	return nil
+}
+
+// create scaffolds a new migration in the directory of the path, or in the directory of
+// the embedded migrations, and prints its files.
+func create(path, name string, w io.Writer) error {
+	dir := sourceDir
+	if path != "" {
+		var err error
+		if dir, err = migration.Dir(path); err != nil {
+			return err
+		}
+	}
+
+	upPath, downPath, err := migration.Create(dir, name, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, upPath)
+	fmt.Fprintln(w, downPath)
+
+	return nil
+}
+
+// pathFromEnv returns the migrations source configured for the service, empty for the
+// embedded migrations.
+func pathFromEnv() string {
+	cfg, err := migration.NewConfig()
+	if err != nil {
+		return ""
+	}
+
+	return cfg.Path
 }
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	userRepository "github.com/luongtruong20201/bookmark-management/internal/repositories/user"
+	"gorm.io/gorm"
+)
+
+// precheck reports the users whose username or email address collide once letter case is
+// ignored. Such users prevent the case-insensitive unique indexes from being created and
+// must be renamed or merged by hand before migrating. It returns the process exit code:
+// 0 when the migration can proceed, 1 when collisions were found and 2 when the check failed.
+func precheck(db *gorm.DB, w io.Writer) int {
+	collisions, err := userRepository.NewUser(db).FindIdentityCollisions(context.Background())
+	if err != nil {
+		fmt.Fprintf(w, "precheck failed: %s\n", err)
+		return 2
+	}
+
+	if len(collisions) == 0 {
+		fmt.Fprintln(w, "no case-insensitive username or email collisions found")
+		return 0
+	}
+
+	fmt.Fprintf(w, "found %d case-insensitive username or email collisions:\n", len(collisions))
+	for _, collision := range collisions {
+		fmt.Fprintf(w, "\n%s %q is shared by %d users:\n", collision.Field, collision.Value, len(collision.Users))
+		for _, user := range collision.Users {
+			fmt.Fprintf(w, "  id=%s username=%q email=%q created_at=%s\n",
+				user.ID, user.Username, user.Email, user.CreatedAt.Format(time.RFC3339))
+		}
+	}
+
+	return 1
+}
@@ -15,24 +15,120 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "List the public keys verifying the tokens issued by the service, identified by the kid token header",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "JSON Web Key Set",
+                "responses": {
+                    "200": {
+                        "description": "JSON Web Key Set",
+                        "schema": {
+                            "$ref": "#/definitions/jwt.JWKS"
+                        }
+                    }
+                }
+            }
+        },
         "/gen-pass": {
             "get": {
-                "description": "Generate a random alphanumeric password",
+                "description": "Generate a random password (10 letters and digits by default) or a diceware-style passphrase, with an entropy estimate",
+                "produces": [
+                    "text/plain",
+                    "application/json"
+                ],
                 "tags": [
                     "password"
                 ],
                 "summary": "Generate password",
+                "parameters": [
+                    {
+                        "enum": [
+                            "password",
+                            "passphrase"
+                        ],
+                        "type": "string",
+                        "default": "password",
+                        "description": "Generation mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 128,
+                        "minimum": 8,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Password length",
+                        "name": "length",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include symbols",
+                        "name": "symbols",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Exclude ambiguous characters such as l, 1, O and 0",
+                        "name": "exclude_ambiguous",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Require at least one character of each selected class",
+                        "name": "require_each_class",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 20,
+                        "minimum": 3,
+                        "type": "integer",
+                        "default": 6,
+                        "description": "Passphrase word count",
+                        "name": "words",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-",
+                        "description": "Passphrase word separator",
+                        "name": "separator",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "text",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Generated password",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/password.genPassResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid options",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
@@ -54,6 +150,15 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "503": {
+                        "description": "Service shutting down",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
@@ -92,32 +197,72 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request body",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
             }
         },
-        "/v1/bookmarks": {
+        "/livez": {
+            "get": {
+                "description": "Report whether the process is up",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "Liveness report",
+                        "schema": {
+                            "$ref": "#/definitions/healthcheck.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Check Postgres, Redis, the migration version and the JWT keys, and report whether the service can serve requests",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "Readiness report, with a DEGRADED status when an optional dependency is unhealthy",
+                        "schema": {
+                            "$ref": "#/definitions/healthcheck.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "A dependency is unhealthy or the service is shutting down",
+                        "schema": {
+                            "$ref": "#/definitions/healthcheck.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/stats": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a paginated list of bookmarks for the authenticated user",
+                "description": "Get system-wide counts of users, bookmarks and active shortened links (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -125,57 +270,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookmark"
-                ],
-                "summary": "List bookmarks",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page",
-                        "name": "pageSize",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "System statistics",
                 "responses": {
                     "200": {
-                        "description": "List of bookmarks with pagination",
+                        "description": "System statistics",
                         "schema": {
-                            "$ref": "#/definitions/bookmark.getBookmarksResponse"
+                            "$ref": "#/definitions/admin.Stats"
                         }
                     },
-                    "400": {
-                        "description": "Invalid pagination parameters",
+                    "401": {
+                        "description": "Unauthorized (missing/invalid token)",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized (missing/invalid token)",
+                    "403": {
+                        "description": "Forbidden (not an administrator)",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/v1/admin/users": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new bookmark for the authenticated user",
+                "description": "Get a paginated list of users, optionally filtered by a search term (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -183,56 +316,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookmark"
+                    "admin"
                 ],
-                "summary": "Create bookmark",
+                "summary": "List users",
                 "parameters": [
                     {
-                        "description": "Bookmark create request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/bookmark.createBookmarkInput"
-                        }
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term matched against username, email and display name",
+                        "name": "search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Create a bookmark successfully",
+                        "description": "List of users with pagination",
                         "schema": {
-                            "$ref": "#/definitions/bookmark.createBookmarkResponse"
+                            "$ref": "#/definitions/admin.listUsersResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation error",
+                        "description": "Invalid query parameters",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized (missing/invalid token)",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (not an administrator)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
             }
         },
-        "/v1/bookmarks/{id}": {
+        "/v1/admin/users/{id}/disable": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing bookmark for the authenticated user",
+                "description": "Disable a user account (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -240,67 +388,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookmark"
+                    "admin"
                 ],
-                "summary": "Update bookmark",
+                "summary": "Disable user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bookmark ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Bookmark update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/bookmark.updateBookmarkInput"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated bookmark",
+                        "description": "Updated user",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/model.User"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation error",
+                        "description": "Invalid request or own account",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized (missing/invalid token)",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (not an administrator)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "404": {
-                        "description": "Bookmark not found",
+                        "description": "User not found",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/admin/users/{id}/enable": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a bookmark for the authenticated user",
+                "description": "Re-enable a disabled user account (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -308,13 +455,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookmark"
+                    "admin"
                 ],
-                "summary": "Delete bookmark",
+                "summary": "Enable user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bookmark ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -322,41 +469,52 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully deleted bookmark",
+                        "description": "Updated user",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/model.User"
                         }
                     },
                     "400": {
-                        "description": "Invalid request or validation error",
+                        "description": "Invalid request",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized (missing/invalid token)",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (not an administrator)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "404": {
-                        "description": "Bookmark not found",
+                        "description": "User not found",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
             }
         },
-        "/v1/links/{code}": {
-            "get": {
-                "description": "Retrieve and redirect to the original URL using the shortened code",
+        "/v1/admin/users/{id}/password-reset": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Require a user to change their password before the next login and sign them out of all devices (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -364,44 +522,650 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "url"
+                    "admin"
                 ],
-                "summary": "Get original URL by code",
+                "summary": "Force password reset",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Short URL code",
-                        "name": "code",
+                        "description": "User ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "301": {
-                        "description": "Permanent redirect to original URL"
+                    "200": {
+                        "description": "Password reset required",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
                     },
                     "400": {
-                        "description": "Code not found or invalid",
+                        "description": "Invalid request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (missing/invalid token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden (not an administrator)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
             }
         },
-        "/v1/self/info": {
+        "/v1/bookmarks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a paginated list of bookmarks for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookmark"
+                ],
+                "summary": "List bookmarks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Read from the primary database, to see a write just made",
+                        "name": "X-Read-Primary",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of bookmarks with pagination",
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.getBookmarksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (missing/invalid token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new bookmark for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookmark"
+                ],
+                "summary": "Create bookmark",
+                "parameters": [
+                    {
+                        "description": "Bookmark create request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.createBookmarkInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Create a bookmark successfully",
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.createBookmarkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (missing/invalid token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/bookmarks/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing bookmark for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookmark"
+                ],
+                "summary": "Update bookmark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Bookmark update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bookmark.updateBookmarkInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated bookmark",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (missing/invalid token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a bookmark for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bookmark"
+                ],
+                "summary": "Delete bookmark",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bookmark ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted bookmark",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (missing/invalid token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Bookmark not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/links/{code}": {
+            "get": {
+                "description": "Retrieve and redirect to the original URL using the shortened code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "url"
+                ],
+                "summary": "Get original URL by code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short URL code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Read from the primary database, to see a bookmark just created",
+                        "name": "X-Read-Primary",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "301": {
+                        "description": "Permanent redirect to original URL"
+                    },
+                    "400": {
+                        "description": "Missing code",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Code not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/password/strength": {
+            "post": {
+                "description": "Evaluate a password against the password policy: minimum length, strength score, username and email, and breached passwords",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "password"
+                ],
+                "summary": "Check password strength",
+                "parameters": [
+                    {
+                        "description": "Password and optional account details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/password.checkStrengthRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password evaluation",
+                        "schema": {
+                            "$ref": "#/definitions/password.checkStrengthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/self": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Schedule the deletion of the authenticated user's account after confirming the password, or with a token issued within the last minutes when the password is empty",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "self"
+                ],
+                "summary": "Delete account",
+                "parameters": [
+                    {
+                        "description": "Password confirmation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/account.deleteAccountBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Deletion scheduled",
+                        "schema": {
+                            "$ref": "#/definitions/account.deleteAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid password or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (missing/invalid token) or login too old to confirm without the password",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/self/deletion": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel the scheduled deletion of the authenticated user's account",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "self"
+                ],
+                "summary": "Cancel account deletion",
+                "responses": {
+                    "200": {
+                        "description": "Deletion cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (missing/invalid token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "No deletion scheduled",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/self/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the archive produced by the latest export of the authenticated user",
+                "produces": [
+                    "application/json",
+                    "application/zip"
+                ],
+                "tags": [
+                    "self"
+                ],
+                "summary": "Download data export",
+                "responses": {
+                    "200": {
+                        "description": "Export archive",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (missing/invalid token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "No export requested or the archive has expired",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Export has not completed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start an export of the profile, bookmarks and short links of the authenticated user as a JSON document or a ZIP archive",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "self"
+                ],
+                "summary": "Request a data export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Archive format (json or zip, default json)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Export job",
+                        "schema": {
+                            "$ref": "#/definitions/export.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (missing/invalid token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/self/export/status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the status of the latest export of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "self"
+                ],
+                "summary": "Get data export status",
+                "responses": {
+                    "200": {
+                        "description": "Export job",
+                        "schema": {
+                            "$ref": "#/definitions/export.Job"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (missing/invalid token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "No export requested",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/self/info": {
             "get": {
                 "security": [
                     {
@@ -426,13 +1190,19 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized (missing/invalid token)",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
@@ -475,27 +1245,122 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request body or validation error",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized (missing/invalid token)",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Username or email already taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/self/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the active sessions of the authenticated user, most recently seen first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "self"
+                ],
+                "summary": "List sessions",
+                "responses": {
+                    "200": {
+                        "description": "Active sessions",
+                        "schema": {
+                            "$ref": "#/definitions/session.listSessionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (missing/invalid token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/self/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sign out a device by revoking one of the authenticated user's sessions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "self"
+                ],
+                "summary": "Revoke session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session revoked",
                         "schema": {
                             "$ref": "#/definitions/response.Message"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized (missing/invalid token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
                     }
                 }
             }
         },
         "/v1/users/login": {
             "post": {
-                "description": "Authenticate user with username and password, returns JWT token",
+                "description": "Authenticate user with username or email address and password, returns JWT token. The identifier is matched regardless of letter case.",
                 "consumes": [
                     "application/json"
                 ],
@@ -525,15 +1390,211 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid credentials or validation error",
+                        "description": "Invalid credentials or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Account disabled or password reset required",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed login attempts",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds to wait before trying again"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/oidc/{provider}/callback": {
+            "get": {
+                "description": "Exchange the authorization code returned by the identity provider for a JWT token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Complete external login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identity provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State returned by the identity provider",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully authenticated, returns JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/oidc.callbackResponseBody"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing parameters, invalid state or login rejected by the provider",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication with the identity provider failed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Email not verified by the identity provider, account disabled or password reset required",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Identity provider not configured",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "An account uses the email without having verified it",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/oidc/{provider}/login": {
+            "get": {
+                "description": "Redirect to the identity provider to sign in using the authorization code flow with PKCE",
+                "tags": [
+                    "user"
+                ],
+                "summary": "Start external login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identity provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the identity provider"
+                    },
+                    "404": {
+                        "description": "Identity provider not configured",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/password": {
+            "put": {
+                "description": "Change the password of an account using its current credentials; also clears a forced password reset",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Change password",
+                "parameters": [
+                    {
+                        "description": "Current credentials and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.changePasswordRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password changed",
+                        "schema": {
+                            "$ref": "#/definitions/response.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid credentials, validation error or password rejected by the password policy",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Account disabled",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed login attempts",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds to wait before trying again"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
@@ -571,15 +1632,21 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation error",
+                        "description": "Invalid request body, validation error or password rejected by the password policy",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Username or email already taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/response.Message"
+                            "$ref": "#/definitions/response.Problem"
                         }
                     }
                 }
@@ -587,6 +1654,59 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "account.deleteAccountBody": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "P@ssw0rd"
+                }
+            }
+        },
+        "account.deleteAccountResponse": {
+            "type": "object",
+            "properties": {
+                "deletion_scheduled_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Account deletion scheduled"
+                }
+            }
+        },
+        "admin.Stats": {
+            "type": "object",
+            "properties": {
+                "bookmarks": {
+                    "type": "integer",
+                    "example": 3400
+                },
+                "links": {
+                    "type": "integer",
+                    "example": 560
+                },
+                "users": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "admin.listUsersResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.User"
+                    }
+                },
+                "pagination": {
+                    "$ref": "#/definitions/response.PaginationMetadata"
+                }
+            }
+        },
         "bookmark.createBookmarkInput": {
             "type": "object",
             "required": [
@@ -647,6 +1767,121 @@ const docTemplate = `{
                 }
             }
         },
+        "export.Job": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "healthcheck.CheckResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "timeout"
+                },
+                "latency": {
+                    "type": "string",
+                    "example": "1.2ms"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "postgres"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "OK"
+                }
+            }
+        },
+        "healthcheck.Report": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/healthcheck.CheckResult"
+                    }
+                },
+                "instance_id": {
+                    "type": "string",
+                    "example": "instance-1"
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "bookmark-api"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "OK"
+                }
+            }
+        },
+        "jwt.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string",
+                    "example": "RS256"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string",
+                    "example": "AQAB"
+                },
+                "kid": {
+                    "type": "string",
+                    "example": "20250301100000-NzbLsXh8"
+                },
+                "kty": {
+                    "type": "string",
+                    "example": "RSA"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string",
+                    "example": "sig"
+                },
+                "x": {
+                    "type": "string"
+                },
+                "y": {
+                    "type": "string"
+                }
+            }
+        },
+        "jwt.JWKS": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jwt.JWK"
+                    }
+                }
+            }
+        },
         "model.Bookmark": {
             "type": "object",
             "properties": {
@@ -676,15 +1911,30 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "deletion_scheduled_at": {
+                    "type": "string"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
                 "display_name": {
                     "type": "string"
                 },
                 "email": {
                     "type": "string"
                 },
+                "email_verified": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "string"
                 },
+                "password_reset_required": {
+                    "type": "boolean"
+                },
+                "role": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 },
@@ -693,6 +1943,97 @@ const docTemplate = `{
                 }
             }
         },
+        "oidc.callbackResponseBody": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "password.checkStrengthRequestBody": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john.doe@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "Blue-Otter-Canyon-42"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
+        "password.checkStrengthResponse": {
+            "type": "object",
+            "properties": {
+                "crack_time": {
+                    "description": "CrackTime is a human readable estimate of the time an offline attack takes.",
+                    "type": "string",
+                    "example": "3 months"
+                },
+                "entropy_bits": {
+                    "description": "EntropyBits is the estimated entropy of the password.",
+                    "type": "number",
+                    "example": 41.2
+                },
+                "score": {
+                    "description": "Score is the zxcvbn strength score, from 0 (guessable in a few attempts) to 4.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "violations": {
+                    "description": "Violations lists the broken rules; the password is accepted when it is empty.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/passwordpolicy.Violation"
+                    }
+                }
+            }
+        },
+        "password.genPassResponse": {
+            "type": "object",
+            "properties": {
+                "entropy_bits": {
+                    "type": "number",
+                    "example": 62
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "passphrase"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "maple-otter-quartz-bloom-cedar-tango"
+                }
+            }
+        },
+        "passwordpolicy.Violation": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "too_weak"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "password is too easy to guess"
+                }
+            }
+        },
         "response.Message": {
             "type": "object",
             "properties": {
@@ -719,6 +2060,74 @@ const docTemplate = `{
                 }
             }
         },
+        "response.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "invalid_input"
+                },
+                "details": {},
+                "message": {
+                    "type": "string",
+                    "example": "Input error"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "6f1c3f0e-2b1d-4c1a-9a51-3a0f4c1d2e7b"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Bad Request"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "about:blank"
+                }
+            }
+        },
+        "session.listSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/session.sessionResponse"
+                    }
+                }
+            }
+        },
+        "session.sessionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0b8f3c5e-2d4a-4e6b-9f1c-7a3d5e8b2c40"
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5)"
+                }
+            }
+        },
         "shorten.urlShortenReq": {
             "type": "object",
             "required": [
@@ -749,6 +2158,30 @@ const docTemplate = `{
                 }
             }
         },
+        "user.changePasswordRequestBody": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password",
+                "username"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "password123"
+                },
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "Blue-Otter-Canyon-42"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "johndoe"
+                }
+            }
+        },
         "user.createUserInputBody": {
             "type": "object",
             "required": [
@@ -768,8 +2201,8 @@ const docTemplate = `{
                 },
                 "password": {
                     "type": "string",
-                    "minLength": 6,
-                    "example": "password123"
+                    "maxLength": 128,
+                    "example": "Blue-Otter-Canyon-42"
                 },
                 "username": {
                     "type": "string",
@@ -786,6 +2219,7 @@ const docTemplate = `{
             "properties": {
                 "password": {
                     "type": "string",
+                    "maxLength": 128,
                     "example": "password123"
                 },
                 "username": {
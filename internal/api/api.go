@@ -1,34 +1,64 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/luongtruong20201/bookmark-management/docs"
 	_ "github.com/luongtruong20201/bookmark-management/docs"
 	"github.com/luongtruong20201/bookmark-management/internal/api/middlewares"
+	accountHandler "github.com/luongtruong20201/bookmark-management/internal/handlers/account"
+	adminHandler "github.com/luongtruong20201/bookmark-management/internal/handlers/admin"
 	bookmarkHandler "github.com/luongtruong20201/bookmark-management/internal/handlers/bookmark"
 	healthcheckHandler "github.com/luongtruong20201/bookmark-management/internal/handlers/healthcheck"
+	jwksHandler "github.com/luongtruong20201/bookmark-management/internal/handlers/jwks"
+	oidcHandler "github.com/luongtruong20201/bookmark-management/internal/handlers/oidc"
 	passwordHandler "github.com/luongtruong20201/bookmark-management/internal/handlers/password"
+	sessionHandler "github.com/luongtruong20201/bookmark-management/internal/handlers/session"
 	shortenHandler "github.com/luongtruong20201/bookmark-management/internal/handlers/shorten"
 	urlHandler "github.com/luongtruong20201/bookmark-management/internal/handlers/shorten"
 	userHandler "github.com/luongtruong20201/bookmark-management/internal/handlers/user"
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
 	bookmarkRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/bookmark"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/breach"
 	"github.com/luongtruong20201/bookmark-management/internal/repositories/cache"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/export"
 	healthcheckRepository "github.com/luongtruong20201/bookmark-management/internal/repositories/healthcheck"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/lock"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/loginattempt"
+	oidcRepository "github.com/luongtruong20201/bookmark-management/internal/repositories/oidc"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/ratelimit"
+	sessionRepository "github.com/luongtruong20201/bookmark-management/internal/repositories/session"
 	urlRepository "github.com/luongtruong20201/bookmark-management/internal/repositories/url"
 	userRepository "github.com/luongtruong20201/bookmark-management/internal/repositories/user"
+	accountService "github.com/luongtruong20201/bookmark-management/internal/services/account"
+	adminService "github.com/luongtruong20201/bookmark-management/internal/services/admin"
 	"github.com/luongtruong20201/bookmark-management/internal/services/bookmark"
 	bookmarkService "github.com/luongtruong20201/bookmark-management/internal/services/bookmark"
 	healthcheckService "github.com/luongtruong20201/bookmark-management/internal/services/healthcheck"
+	"github.com/luongtruong20201/bookmark-management/internal/services/loginlimit"
+	oidcService "github.com/luongtruong20201/bookmark-management/internal/services/oidc"
 	passwordService "github.com/luongtruong20201/bookmark-management/internal/services/password"
+	"github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
 	urlService "github.com/luongtruong20201/bookmark-management/internal/services/shorten"
 	userService "github.com/luongtruong20201/bookmark-management/internal/services/user"
+	"github.com/luongtruong20201/bookmark-management/migrations"
+	"github.com/luongtruong20201/bookmark-management/pkg/common"
 	jwtPkg "github.com/luongtruong20201/bookmark-management/pkg/jwt"
+	"github.com/luongtruong20201/bookmark-management/pkg/metrics"
+	"github.com/luongtruong20201/bookmark-management/pkg/migration"
+	oidcPkg "github.com/luongtruong20201/bookmark-management/pkg/oidc"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
+	sqldb "github.com/luongtruong20201/bookmark-management/pkg/sql"
 	"github.com/luongtruong20201/bookmark-management/pkg/stringutils"
 	"github.com/luongtruong20201/bookmark-management/pkg/utils"
 	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"gorm.io/gorm"
@@ -37,19 +67,31 @@ import (
 // Engine defines the interface for the API engine.
 // It provides methods to start the server and serve HTTP requests.
 type Engine interface {
-	Start() error
+	// Start serves HTTP requests until the context is cancelled, then shuts the server down
+	// gracefully and closes the database and Redis clients.
+	Start(ctx context.Context) error
 	http.Handler
 }
 
 // handlers holds all HTTP handlers for the API endpoints.
 // It groups together handlers for password generation, health checks,
-// URL shortening, and user management.
+// URL shortening, user management, external identity provider login,
+// account data export and deletion, session management, administration, and the
+// publication of the token verification keys, along
+// with the JWT authentication and rate limiting middlewares.
 type handlers struct {
 	password    passwordHandler.Password
 	healthCheck healthcheckHandler.Healthcheck
 	shorten     shortenHandler.ShortenURL
 	user        userHandler.User
 	bookmark    bookmarkHandler.Handler
+	oidc        oidcHandler.OIDC
+	admin       adminHandler.Handler
+	account     accountHandler.Handler
+	session     sessionHandler.Handler
+	jwks        jwksHandler.JWKS
+	jwtAuth     middlewares.JWTAuth
+	rateLimit   middlewares.RateLimit
 }
 
 // EngineOpts holds the configuration options for creating a new API engine instance.
@@ -63,39 +105,110 @@ type handlers struct {
 //   - DB: GORM database connection for persistent data storage
 //   - JWTGenerator: JWT token generator for creating authentication tokens
 //   - JWTValidator: JWT token validator for verifying authentication tokens
+//   - OIDCProviders: OpenID Connect providers keyed by name, used for external login
+//   - LoginLimit: Login throttling settings; the defaults from loginlimit.NewConfig are used when nil
+//   - RateLimit: Rate limiting policies for all routes; rate limiting is disabled when nil
+//   - Account: Data export and account deletion settings; the defaults from account.NewConfig are used when nil
+//   - Session: Session tracking settings; the defaults from session.NewConfig are used when nil
+//   - PasswordPolicy: Password policy settings; the defaults from passwordpolicy.NewConfig are used when nil
+//   - PasswordHash: Password hashing settings; utils.DefaultHashConfig is used when nil
+//   - HealthCheck: Readiness check settings; the defaults from healthcheck.NewConfig are used when nil
 type EngineOpts struct {
-	Engine       *gin.Engine
-	Cfg          *Config
-	Redis        *redis.Client
-	DB           *gorm.DB
-	JWTGenerator jwtPkg.JWTGenerator
-	JWTValidator jwtPkg.JWTValidator
+	Engine         *gin.Engine
+	Cfg            *Config
+	Redis          redis.UniversalClient
+	DB             *gorm.DB
+	JWTGenerator   jwtPkg.JWTGenerator
+	JWTValidator   jwtPkg.JWTValidator
+	OIDCProviders  map[string]oidcPkg.Provider
+	LoginLimit     *loginlimit.Config
+	RateLimit      *middlewares.RateLimitConfig
+	Account        *accountService.Config
+	Session        *sessionService.Config
+	PasswordPolicy *passwordpolicy.Config
+	PasswordHash   *utils.HashConfig
+	HealthCheck    *healthcheckService.Config
 }
 
 // api represents the API server instance.
 // It contains the Redis client for caching, database connection,
-// Gin router engine, and configuration settings.
+// Gin router engine, and configuration settings. The account service is kept to run
+// the purge of deleted accounts alongside the server, and the healthcheck service to report
+// the shutdown.
 type api struct {
-	redis        *redis.Client
-	db           *gorm.DB
-	app          *gin.Engine
-	cfg          *Config
-	jwtGenerator jwtPkg.JWTGenerator
-	jwtValidator jwtPkg.JWTValidator
+	redis          redis.UniversalClient
+	db             *gorm.DB
+	app            *gin.Engine
+	cfg            *Config
+	jwtGenerator   jwtPkg.JWTGenerator
+	jwtValidator   jwtPkg.JWTValidator
+	oidcProviders  map[string]oidcPkg.Provider
+	loginLimit     *loginlimit.Config
+	rateLimit      *middlewares.RateLimitConfig
+	account        *accountService.Config
+	accountSvc     accountService.Service
+	healthSvc      healthcheckService.Healthcheck
+	session        *sessionService.Config
+	passwordPolicy *passwordpolicy.Config
+	passwordHash   *utils.HashConfig
+	healthCheck    *healthcheckService.Config
 }
 
 // New creates a new API engine instance with the provided configuration.
-// It initializes the Gin router and registers all endpoints.
+// It initializes the Gin router and registers all endpoints. Like the infrastructure
+// constructors, it panics when a configuration left nil in the options cannot be loaded
+// from the environment.
 func New(opts *EngineOpts) Engine {
 	a := &api{
-		redis:        opts.Redis,
-		db:           opts.DB,
-		app:          opts.Engine,
-		cfg:          opts.Cfg,
-		jwtGenerator: opts.JWTGenerator,
-		jwtValidator: opts.JWTValidator,
+		redis:          opts.Redis,
+		db:             opts.DB,
+		app:            opts.Engine,
+		cfg:            opts.Cfg,
+		jwtGenerator:   opts.JWTGenerator,
+		jwtValidator:   opts.JWTValidator,
+		oidcProviders:  opts.OIDCProviders,
+		loginLimit:     opts.LoginLimit,
+		rateLimit:      opts.RateLimit,
+		account:        opts.Account,
+		session:        opts.Session,
+		passwordPolicy: opts.PasswordPolicy,
+		passwordHash:   opts.PasswordHash,
+		healthCheck:    opts.HealthCheck,
+	}
+	var err error
+	if a.loginLimit == nil {
+		a.loginLimit, err = loginlimit.NewConfig()
+		common.HandleError(err)
+	}
+	if a.account == nil {
+		a.account, err = accountService.NewConfig()
+		common.HandleError(err)
+	}
+	if a.session == nil {
+		a.session, err = sessionService.NewConfig()
+		common.HandleError(err)
+	}
+	if a.passwordPolicy == nil {
+		a.passwordPolicy, err = passwordpolicy.NewConfig()
+		common.HandleError(err)
+	}
+	if a.passwordHash == nil {
+		a.passwordHash = utils.DefaultHashConfig()
+	}
+	if a.healthCheck == nil {
+		a.healthCheck, err = healthcheckService.NewConfig()
+		common.HandleError(err)
+	}
+	if a.rateLimit == nil {
+		a.rateLimit = &middlewares.RateLimitConfig{Enabled: false}
 	}
 
+	// The client IP keys the login and rate limits, so it is only read from the forwarding
+	// headers set by the configured proxies.
+	common.HandleError(a.app.SetTrustedProxies(a.cfg.TrustedProxies))
+	// Handlers pass the Gin context to the services, so it must expose the span, logger
+	// and deadline of the request context.
+	a.app.ContextWithFallback = true
 	a.initRoutes()
 	return a
 }
@@ -105,61 +218,144 @@ func New(opts *EngineOpts) Engine {
 // handlers for password generation, health checks, URL shortening, and user management.
 func (a *api) initHandlers() *handlers {
 	passSvc := passwordService.NewPassword()
-	passHandler := passwordHandler.NewPassword(passSvc)
+	breachedPasswords := breach.NewStorage(a.passwordPolicy.BreachedListDir)
+	passwordPolicy := passwordpolicy.NewPolicy(breachedPasswords, a.passwordPolicy)
+	passHandler := passwordHandler.NewPassword(passSvc, passwordPolicy)
 
-	healthCheckRepo := healthcheckRepository.NewHealthCheck(a.redis)
-	healthcheckSvc := healthcheckService.NewHealthcheck(a.cfg.ServiceName, a.cfg.InstanceId, healthCheckRepo)
-	healthcheckHandler := healthcheckHandler.NewHealthcheck(healthcheckSvc)
+	healthCheckRepo := healthcheckRepository.NewHealthCheck()
+	a.registerHealthCheckers(healthCheckRepo)
+	a.healthSvc = healthcheckService.NewHealthcheck(a.cfg.ServiceName, a.cfg.InstanceId, healthCheckRepo)
+	healthcheckHandler := healthcheckHandler.NewHealthcheck(a.healthSvc)
 
 	keyGen := stringutils.NewKeyGen()
 	shortenRepo := urlRepository.NewURLStorage(a.redis)
 	bookmarkRepo := bookmarkRepo.NewBookmark(a.db)
-	shortenSvc := urlService.NewShortenURL(keyGen, shortenRepo, bookmarkRepo)
+	shortenSvc := urlService.NewTracedShortenURL(urlService.NewShortenURL(keyGen, shortenRepo, bookmarkRepo))
 	shortenHandler := urlHandler.NewShortenURL(shortenSvc)
 
-	hasher := utils.NewHasher()
+	hasher := utils.NewHasher(a.passwordHash)
 	userRepo := userRepository.NewUser(a.db)
-	userSvc := userService.NewUser(userRepo, hasher, a.jwtGenerator)
-	userHandler := userHandler.NewUser(userSvc)
+	sessionStorage := sessionRepository.NewStorage(a.redis)
+	sessionSvc := sessionService.NewService(sessionStorage, a.session)
+	sessionHandler := sessionHandler.NewSessionHandler(sessionSvc)
+	userSvc := userService.NewTracedUser(userService.NewUser(userRepo, hasher, a.jwtGenerator, sessionSvc, passwordPolicy))
+	loginAttemptStorage := loginattempt.NewStorage(a.redis)
+	loginLimiter := loginlimit.NewLimiter(loginAttemptStorage, userRepo, a.loginLimit)
+	userHandler := userHandler.NewUser(userSvc, loginLimiter)
+
+	oidcStateStorage := oidcRepository.NewStateStorage(a.redis)
+	oidcSvc := oidcService.NewOIDC(a.oidcProviders, oidcStateStorage, userSvc)
+	oidcHandler := oidcHandler.NewOIDC(oidcSvc)
 
-	bookmarkService := bookmarkService.NewBookmarkSvc(bookmarkRepo, keyGen)
+	bookmarkService := bookmarkService.NewTracedService(bookmarkService.NewBookmarkSvc(bookmarkRepo, keyGen), "bookmarkSvc")
 	cacheDB := cache.NewRedisCache(a.redis)
-	bookmarkCache := bookmark.NewBookmarkCache(bookmarkService, cacheDB)
+	bookmarkCache := bookmark.NewTracedService(bookmark.NewBookmarkCache(bookmarkService, cacheDB), "bookmarkCache")
 	bookmarkHandler := bookmarkHandler.NewBookmarkHandler(bookmarkCache)
 
+	adminSvc := adminService.NewAdmin(userRepo, bookmarkRepo, shortenRepo, sessionSvc)
+	adminHandler := adminHandler.NewAdminHandler(adminSvc)
+
+	exportStorage := export.NewStorage(a.redis)
+	a.accountSvc = accountService.NewService(userRepo, bookmarkRepo, shortenRepo, exportStorage, cacheDB, lock.NewLocker(a.redis), hasher, a.account)
+	accountHandler := accountHandler.NewAccountHandler(a.accountSvc)
+
+	jwksHandler := jwksHandler.NewJWKS(a.jwtValidator)
+	jwtAuth := middlewares.NewJWTAuth(a.jwtValidator, userSvc, sessionSvc)
+	rateLimit := middlewares.NewRateLimit(ratelimit.NewLimiter(a.redis), a.rateLimit)
+
 	return &handlers{
 		password:    passHandler,
 		healthCheck: healthcheckHandler,
 		shorten:     shortenHandler,
 		user:        userHandler,
 		bookmark:    bookmarkHandler,
+		oidc:        oidcHandler,
+		admin:       adminHandler,
+		account:     accountHandler,
+		session:     sessionHandler,
+		jwks:        jwksHandler,
+		jwtAuth:     jwtAuth,
+		rateLimit:   rateLimit,
+	}
+}
+
+// registerHealthCheckers registers a readiness checker for each configured dependency:
+// Postgres, its read replicas and its migration version, Redis, and the JWT signing and
+// verification keys. The read replicas are optional, as their reads fall back to the
+// primary. The schema must be at least at the latest migration embedded in the binary.
+func (a *api) registerHealthCheckers(registry healthcheckRepository.HealthCheck) {
+	if a.db != nil {
+		migrationVersion, err := migration.LatestVersion(migrations.FS)
+		common.HandleError(err)
+		registry.Register(healthcheckRepository.NewPostgresChecker(a.db), a.healthCheck.PostgresTimeout)
+		for i, replica := range sqldb.Replicas(a.db) {
+			registry.RegisterOptional(healthcheckRepository.NewPostgresReplicaChecker(i+1, replica), a.healthCheck.PostgresTimeout)
+		}
+		registry.Register(healthcheckRepository.NewMigrationChecker(a.db, migrationVersion), a.healthCheck.MigrationTimeout)
+	}
+	if a.redis != nil {
+		registry.Register(healthcheckRepository.NewRedisChecker(a.redis), a.healthCheck.RedisTimeout)
+	}
+	if a.jwtGenerator != nil && a.jwtValidator != nil {
+		registry.Register(healthcheckRepository.NewJWTKeysChecker(a.jwtGenerator, a.jwtValidator), a.healthCheck.JWTKeysTimeout)
 	}
 }
 
 // initRoutes registers all API routes with their corresponding handlers.
-// It sets up endpoints for password generation, health checks, URL shortening,
-// user registration, and Swagger documentation.
+// It sets up endpoints for password generation, health checks,
+// URL shortening, user registration, and Swagger documentation. Every request gets a request ID
+// and an access log line, is traced, recorded in the HTTP metrics, recovered from panics, reads
+// from the primary database when it asks to with the X-Read-Primary header, and is subject to
+// the default rate limit policy; authenticated routes are additionally limited per user.
+// Requests matching no route get a 404 problem.
 func (a *api) initRoutes() {
 	handlers := a.initHandlers()
 
+	a.app.Use(
+		middlewares.RequestID(),
+		middlewares.AccessLog(),
+		middlewares.Tracing(),
+		middlewares.Metrics(),
+		middlewares.Recovery(),
+		middlewares.ReadPrimary(),
+		handlers.rateLimit.Limit(middlewares.RateLimitPolicyDefault),
+	)
+
+	a.app.NoRoute(func(c *gin.Context) {
+		response.Abort(c, response.NewProblem(http.StatusNotFound, response.CodeNotFound, "Route not found"))
+	})
+
 	a.app.GET("/gen-pass", handlers.password.GenPass)
 	a.app.GET("/health-check", handlers.healthCheck.Check)
+	a.app.GET("/livez", handlers.healthCheck.Live)
+	a.app.GET("/readyz", handlers.healthCheck.Ready)
+	a.app.GET("/.well-known/jwks.json", handlers.jwks.GetJWKS)
 
 	v1Public := a.app.Group("/v1")
 	{
-		v1Public.POST("/links/shorten", handlers.shorten.ShortenURL)
+		v1Public.POST("/links/shorten", handlers.jwtAuth.OptionalJWTAuth(), handlers.rateLimit.Limit(middlewares.RateLimitPolicyShorten), handlers.shorten.ShortenURL)
 		v1Public.GET("/links/redirect/:code", handlers.shorten.GetURL)
 
 		v1Public.POST("/users/register", handlers.user.RegisterUser)
 		v1Public.POST("/users/login", handlers.user.Login)
+		v1Public.PUT("/users/password", handlers.user.ChangePassword)
+		v1Public.POST("/password/strength", handlers.password.CheckStrength)
+		v1Public.GET("/users/oidc/:provider/login", handlers.oidc.Login)
+		v1Public.GET("/users/oidc/:provider/callback", handlers.oidc.Callback)
 	}
 
-	jwtMiddleware := middlewares.NewJWTAuth(a.jwtValidator)
 	v1Private := a.app.Group("/v1")
-	v1Private.Use(jwtMiddleware.JWTAuth())
+	v1Private.Use(handlers.jwtAuth.JWTAuth(), handlers.rateLimit.Limit(middlewares.RateLimitPolicyAuthenticated))
 	{
 		v1Private.GET("/self/info", handlers.user.GetProfile)
 		v1Private.PUT("/self/info", handlers.user.UpdateProfile)
+		v1Private.DELETE("/self", handlers.account.DeleteAccount)
+		v1Private.DELETE("/self/deletion", handlers.account.CancelDeletion)
+		v1Private.POST("/self/export", handlers.account.RequestExport)
+		v1Private.GET("/self/export", handlers.account.DownloadExport)
+		v1Private.GET("/self/export/status", handlers.account.GetExportStatus)
+		v1Private.GET("/self/sessions", handlers.session.ListSessions)
+		v1Private.DELETE("/self/sessions/:id", handlers.session.RevokeSession)
 
 		v1Private.GET("/bookmarks", handlers.bookmark.GetBookmarks)
 		v1Private.POST("/bookmarks", handlers.bookmark.Create)
@@ -167,14 +363,107 @@ func (a *api) initRoutes() {
 		v1Private.DELETE("/bookmarks/:id", handlers.bookmark.DeleteBookmark)
 	}
 
+	v1Admin := a.app.Group("/v1/admin")
+	v1Admin.Use(
+		handlers.jwtAuth.JWTAuth(),
+		handlers.rateLimit.Limit(middlewares.RateLimitPolicyAuthenticated),
+		middlewares.RequireRole(model.RoleAdmin),
+	)
+	{
+		v1Admin.GET("/users", handlers.admin.ListUsers)
+		v1Admin.PUT("/users/:id/disable", handlers.admin.DisableUser)
+		v1Admin.PUT("/users/:id/enable", handlers.admin.EnableUser)
+		v1Admin.POST("/users/:id/password-reset", handlers.admin.ForcePasswordReset)
+		v1Admin.GET("/stats", handlers.admin.GetStats)
+	}
+
 	a.app.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	docs.SwaggerInfo.Host = a.cfg.AppHostname
 }
 
-// Start starts the HTTP server on the port specified in the configuration.
-func (a *api) Start() error {
-	return a.app.Run(fmt.Sprintf(":%s", a.cfg.AppPort))
+// Start starts the purge of deleted accounts in the background, the HTTP server on the
+// port specified in the configuration and, unless MetricsPort is empty, the Prometheus
+// metrics server on its own port. When the context is cancelled, the health check reports
+// the service as shutting down for ShutdownDelay, then the servers stop accepting
+// connections and drain in-flight requests for up to ShutdownTimeout. The purge is stopped
+// and, once its pass in progress has returned, the database and Redis clients are closed last.
+func (a *api) Start(ctx context.Context) error {
+	purgeCtx, cancelPurge := context.WithCancel(context.Background())
+	purgeDone := make(chan struct{})
+	go func() {
+		defer close(purgeDone)
+		a.accountSvc.RunPurger(purgeCtx)
+	}()
+	stopPurge := func() {
+		cancelPurge()
+		<-purgeDone
+	}
+
+	servers := []*http.Server{{
+		Addr:              fmt.Sprintf(":%s", a.cfg.AppPort),
+		Handler:           a.app,
+		ReadTimeout:       a.cfg.ReadTimeout,
+		ReadHeaderTimeout: a.cfg.ReadHeaderTimeout,
+		WriteTimeout:      a.cfg.WriteTimeout,
+		IdleTimeout:       a.cfg.IdleTimeout,
+		MaxHeaderBytes:    a.cfg.MaxHeaderBytes,
+	}}
+	if a.cfg.MetricsPort != "" {
+		servers = append(servers, &http.Server{
+			Addr:              fmt.Sprintf(":%s", a.cfg.MetricsPort),
+			Handler:           metrics.Handler(),
+			ReadHeaderTimeout: a.cfg.ReadHeaderTimeout,
+		})
+	}
+	serveErr := make(chan error, len(servers))
+	for _, server := range servers {
+		go func() {
+			serveErr <- server.ListenAndServe()
+		}()
+	}
+
+	select {
+	case err := <-serveErr:
+		errs := []error{err}
+		for _, server := range servers {
+			errs = append(errs, server.Close())
+		}
+		stopPurge()
+		return errors.Join(append(errs, a.close())...)
+	case <-ctx.Done():
+	}
+
+	log.Info().Dur("delay", a.cfg.ShutdownDelay).Dur("timeout", a.cfg.ShutdownTimeout).Msg("shutting down")
+	a.healthSvc.Shutdown()
+	time.Sleep(a.cfg.ShutdownDelay)
+
+	shutdownCtx, cancel := context.WithCancel(context.Background())
+	if a.cfg.ShutdownTimeout > 0 {
+		shutdownCtx, cancel = context.WithTimeout(context.Background(), a.cfg.ShutdownTimeout)
+	}
+	defer cancel()
+	// The metrics server is shut down last, so the drain of the API server can be scraped.
+	var errs []error
+	for _, server := range servers {
+		errs = append(errs, server.Shutdown(shutdownCtx))
+	}
+	stopPurge()
+
+	return errors.Join(append(errs, a.close())...)
+}
+
+// close closes the database, its read replicas and the Redis client.
+func (a *api) close() error {
+	var errs []error
+	if a.db != nil {
+		errs = append(errs, sqldb.Close(a.db))
+	}
+	if a.redis != nil {
+		errs = append(errs, a.redis.Close())
+	}
+
+	return errors.Join(errs...)
 }
 
 // ServeHTTP implements the http.Handler interface, allowing the API to be used
@@ -1,16 +1,39 @@
 package api
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/kelseyhightower/envconfig"
 )
 
 // Config holds the application configuration loaded from environment variables.
+//
+// ReadTimeout, ReadHeaderTimeout, WriteTimeout, IdleTimeout and MaxHeaderBytes configure the
+// HTTP server; a zero timeout means no timeout. On SIGINT or SIGTERM the health check reports
+// the service as shutting down for ShutdownDelay, so load balancers stop routing to it, then
+// in-flight requests are drained for up to ShutdownTimeout; zero waits for them indefinitely.
+//
+// The Prometheus metrics are served on MetricsPort rather than AppPort, so the scrape endpoint
+// can be kept off the public network; an empty MetricsPort disables it.
+//
+// TrustedProxies lists the IP addresses or CIDR ranges, comma separated, of the reverse
+// proxies whose X-Forwarded-For and X-Real-IP headers give the client IP used by the login
+// and rate limits. No proxy is trusted by default, as any client can set these headers.
 type Config struct {
-	AppPort     string `default:"8080" envconfig:"APP_PORT"`
-	ServiceName string `default:"bookmark-api" envconfig:"SERVICE_NAME"`
-	InstanceId  string `default:"" envconfig:"APP_INSTANCE_ID"`
-	AppHostname string `default:"" envconfig:"APP_HOSTNAME"`
+	AppPort           string        `default:"8080" envconfig:"APP_PORT"`
+	MetricsPort       string        `default:"9090" envconfig:"APP_METRICS_PORT"`
+	TrustedProxies    []string      `default:"" envconfig:"APP_TRUSTED_PROXIES"`
+	ServiceName       string        `default:"bookmark-api" envconfig:"SERVICE_NAME"`
+	InstanceId        string        `default:"" envconfig:"APP_INSTANCE_ID"`
+	AppHostname       string        `default:"" envconfig:"APP_HOSTNAME"`
+	ReadTimeout       time.Duration `default:"15s" envconfig:"APP_READ_TIMEOUT"`
+	ReadHeaderTimeout time.Duration `default:"5s" envconfig:"APP_READ_HEADER_TIMEOUT"`
+	WriteTimeout      time.Duration `default:"30s" envconfig:"APP_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `default:"120s" envconfig:"APP_IDLE_TIMEOUT"`
+	MaxHeaderBytes    int           `default:"1048576" envconfig:"APP_MAX_HEADER_BYTES"`
+	ShutdownDelay     time.Duration `default:"5s" envconfig:"APP_SHUTDOWN_DELAY"`
+	ShutdownTimeout   time.Duration `default:"30s" envconfig:"APP_SHUTDOWN_TIMEOUT"`
 }
 
 // NewConfig creates a new configuration instance by reading environment variables.
@@ -0,0 +1,45 @@
+package middlewares
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+// AccessLog returns a Gin handler function that writes an access log line through the
+// request logger once the handlers have run, with the method, route template, path,
+// status, latency, response size and client IP. Server errors are logged at error level,
+// client errors at warn level and other requests at info level.
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		startedAt := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := c.Writer.Status()
+
+		level := zerolog.InfoLevel
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = zerolog.ErrorLevel
+		case status >= http.StatusBadRequest:
+			level = zerolog.WarnLevel
+		}
+
+		logger.FromContext(c.Request.Context()).WithLevel(level).
+			Str("method", c.Request.Method).
+			Str("route", route).
+			Str("path", c.Request.URL.Path).
+			Int("status", status).
+			Dur("latency", time.Since(startedAt)).
+			Int("bytes", max(c.Writer.Size(), 0)).
+			Str("ip", c.ClientIP()).
+			Msg("request served")
+	}
+}
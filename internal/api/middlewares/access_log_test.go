@@ -0,0 +1,91 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessLog(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name          string
+		path          string
+		expectedRoute string
+		expectedLevel string
+		expectedCode  int
+		expectedBytes float64
+	}{
+		{
+			name:          "success - info level",
+			path:          "/access-log/200",
+			expectedRoute: "/access-log/:status",
+			expectedLevel: "info",
+			expectedCode:  http.StatusOK,
+			expectedBytes: 2,
+		},
+		{
+			name:          "success - warn level for client errors",
+			path:          "/access-log/400",
+			expectedRoute: "/access-log/:status",
+			expectedLevel: "warn",
+			expectedCode:  http.StatusBadRequest,
+			expectedBytes: 2,
+		},
+		{
+			name:          "success - error level for server errors",
+			path:          "/access-log/500",
+			expectedRoute: "/access-log/:status",
+			expectedLevel: "error",
+			expectedCode:  http.StatusInternalServerError,
+			expectedBytes: 2,
+		},
+		{
+			name:          "success - unmatched route",
+			path:          "/access-log-missing",
+			expectedRoute: unmatchedRoute,
+			expectedLevel: "warn",
+			expectedCode:  http.StatusNotFound,
+			expectedBytes: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			app := gin.New()
+			app.Use(AccessLog())
+			app.GET("/access-log/:status", func(c *gin.Context) {
+				status := map[string]int{"200": http.StatusOK, "400": http.StatusBadRequest, "500": http.StatusInternalServerError}[c.Param("status")]
+				c.String(status, "ok")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			req = req.WithContext(logger.WithContext(req.Context(), zerolog.New(&buf)))
+			app.ServeHTTP(httptest.NewRecorder(), req)
+
+			var line map[string]any
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+			assert.Equal(t, tc.expectedLevel, line["level"])
+			assert.Equal(t, http.MethodGet, line["method"])
+			assert.Equal(t, tc.expectedRoute, line["route"])
+			assert.Equal(t, tc.path, line["path"])
+			assert.Equal(t, float64(tc.expectedCode), line["status"])
+			assert.Equal(t, tc.expectedBytes, line["bytes"])
+			assert.Contains(t, line, "latency")
+			assert.Equal(t, "request served", line["message"])
+		})
+	}
+}
@@ -1,20 +1,62 @@
 // Package middlewares provides reusable HTTP middlewares for the API layer,
-// including JWT authentication for protecting authenticated routes.
+// including JWT authentication and role checks for protecting authenticated routes,
+// Redis backed rate limiting, and the request ID, access log, panic recovery, metrics and
+// tracing middlewares applied to every request.
 package middlewares
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/session"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
 	jwtPkg "github.com/luongtruong20201/bookmark-management/pkg/jwt"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
 )
 
+// tokenErrorDescriptions maps the token validation errors to the error descriptions
+// returned in the WWW-Authenticate header, most specific first.
+var tokenErrorDescriptions = []struct {
+	err         error
+	description string
+}{
+	{jwtPkg.ErrTokenExpired, "The access token expired"},
+	{jwtPkg.ErrTokenNotValidYet, "The access token is not valid yet"},
+	{jwtPkg.ErrTokenSignature, "The access token signature is invalid"},
+	{jwtPkg.ErrTokenAudience, "The access token is not intended for this service"},
+	{jwtPkg.ErrTokenIssuer, "The access token issuer is not trusted"},
+	{jwtPkg.ErrTokenClaimMissing, "The access token is missing a required claim"},
+}
+
+// UserStatusChecker reports whether the account behind a valid token has been disabled.
+// It is satisfied by the user service.
+//
+//go:generate mockery --name UserStatusChecker --filename user_status_checker.go
+type UserStatusChecker interface {
+	IsUserDisabled(ctx context.Context, userID string) (bool, error)
+}
+
+// SessionChecker verifies that the session a token was issued with has not expired or
+// been revoked. It is satisfied by the session service.
+//
+//go:generate mockery --name SessionChecker --filename session_checker.go
+type SessionChecker interface {
+	Check(ctx context.Context, userID, sessionID string) error
+}
+
 // JWTAuth defines the interface for JWT authentication middleware.
 // Implementations must validate Bearer tokens from the Authorization header
 // and, on success, populate the Gin context with the authenticated user ID.
 type JWTAuth interface {
 	JWTAuth() gin.HandlerFunc
+	// OptionalJWTAuth authenticates the request like JWTAuth when an Authorization header is
+	// present and lets anonymous requests through otherwise.
+	OptionalJWTAuth() gin.HandlerFunc
 }
 
 // jwtAuth implements the JWTAuth interface and provides JWT authentication middleware
@@ -22,55 +64,136 @@ type JWTAuth interface {
 // the Gin context with JWT claims for authenticated requests.
 type jwtAuth struct {
 	jwtValidator jwtPkg.JWTValidator
+	userStatus   UserStatusChecker
+	sessions     SessionChecker
 }
 
 // NewJWTAuth creates a new JWT authentication middleware instance using the
-// provided JWT validator. The returned middleware can be attached to protected
-// routes to enforce authentication.
-func NewJWTAuth(jwtValidator jwtPkg.JWTValidator) JWTAuth {
+// provided JWT validator, user status checker and session checker. The returned
+// middleware can be attached to protected routes to enforce authentication.
+func NewJWTAuth(jwtValidator jwtPkg.JWTValidator, userStatus UserStatusChecker, sessions SessionChecker) JWTAuth {
 	return &jwtAuth{
 		jwtValidator: jwtValidator,
+		userStatus:   userStatus,
+		sessions:     sessions,
 	}
 }
 
 // JWTAuth returns a Gin handler function that:
 //   - extracts the Authorization header in "Bearer <token>" format,
 //   - validates the JWT using the configured validator,
-//   - reads the "sub" claim as the user ID and stores it in the context as "userID",
-//   - aborts the request with 401 status if any step fails.
+//   - reads the "sub" claim as the user ID and stores the claims in the context as "claims",
+//     and the user ID in the request logger as "uid",
+//   - rejects users whose account has been disabled with a 403 problem,
+//   - checks the "sid" claim against the session store and rejects tokens without a session,
+//     or whose session has been revoked or has expired, with a 401 problem,
+//   - aborts the request with a 401 problem if any other step fails, describing the failure
+//     in the WWW-Authenticate header as defined by RFC 6750.
 func (m *jwtAuth) JWTAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
-			c.Abort()
+			c.Header("WWW-Authenticate", "Bearer")
+			response.Abort(c, response.NewProblem(http.StatusUnauthorized, response.CodeUnauthorized, "Authorization header is required"))
 			return
 		}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format is wrong"})
-			c.Abort()
+		m.authenticate(c, authHeader)
+	}
+}
+
+// OptionalJWTAuth returns a Gin handler function for routes that also serve anonymous
+// callers. Requests without an Authorization header continue without claims; any other
+// request is authenticated exactly like JWTAuth, so a malformed or invalid token is still
+// rejected instead of being silently treated as anonymous.
+func (m *jwtAuth) OptionalJWTAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.Next()
 			return
 		}
 
-		tokenStr := parts[1]
-		tokenContent, err := m.jwtValidator.ValidateToken(tokenStr)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
+		m.authenticate(c, authHeader)
+	}
+}
+
+// authenticate validates the Authorization header value, checks the account status and the
+// session, and stores the claims in the context. It aborts the request on any failure.
+func (m *jwtAuth) authenticate(c *gin.Context, authHeader string) {
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		abortUnauthorized(c, "Authorization header format is wrong", "invalid_request", "The Authorization header must be formatted as \"Bearer <token>\"")
+		return
+	}
+
+	tokenStr := parts[1]
+	tokenContent, err := m.jwtValidator.ValidateToken(tokenStr)
+	if err != nil {
+		abortUnauthorized(c, "Invalid token", "invalid_token", tokenErrorDescription(err))
+		return
+	}
+
+	userID, ok := tokenContent["sub"].(string)
+	if !ok || userID == "" {
+		abortUnauthorized(c, "Invalid token", "invalid_token", "The access token has no valid subject")
+		return
+	}
+
+	disabled, err := m.userStatus.IsUserDisabled(c, userID)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			abortUnauthorized(c, "Invalid token", "invalid_token", "The access token subject no longer exists")
 			return
 		}
+		logger.FromContext(c).Error().Err(err).Str("uid", userID).Msg("failed to check user status")
+		response.InternalError(c)
+		return
+	}
+	if disabled {
+		response.Abort(c, response.NewProblem(http.StatusForbidden, response.CodeAccountDisabled, "User account is disabled"))
+		return
+	}
 
-		userID, ok := tokenContent["sub"]
-		_, okStr := userID.(string)
-		if !ok || !okStr || userID == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
+	// A token without a session could not be revoked by logging out, so it is rejected.
+	sessionID, ok := tokenContent["sid"].(string)
+	if !ok || sessionID == "" {
+		abortUnauthorized(c, "Invalid token", "invalid_token", "The access token has no valid session")
+		return
+	}
+	if err := m.sessions.Check(c, userID, sessionID); err != nil {
+		if errors.Is(err, session.ErrSessionNotFound) {
+			abortUnauthorized(c, "Session has been revoked or expired", "invalid_token", "The session of the access token has been revoked or expired")
 			return
 		}
+		logger.FromContext(c).Error().Err(err).Str("uid", userID).Msg("failed to check session")
+		response.InternalError(c)
+		return
+	}
+
+	ctx := c.Request.Context()
+	l := logger.FromContext(ctx).With().Str("uid", userID).Logger()
+	c.Request = c.Request.WithContext(logger.WithContext(ctx, l))
+
+	c.Set("claims", tokenContent)
+	c.Next()
+}
 
-		c.Set("claims", tokenContent)
-		c.Next()
+// abortUnauthorized aborts the request with a 401 problem with the given message, and sets the
+// WWW-Authenticate header to a Bearer challenge with the RFC 6750 error code and description.
+func abortUnauthorized(c *gin.Context, message, errorCode, description string) {
+	c.Header("WWW-Authenticate", fmt.Sprintf("Bearer error=%q, error_description=%q", errorCode, description))
+	response.Abort(c, response.NewProblem(http.StatusUnauthorized, response.CodeUnauthorized, message))
+}
+
+// tokenErrorDescription returns the WWW-Authenticate error description of a token
+// validation error.
+func tokenErrorDescription(err error) string {
+	for _, d := range tokenErrorDescriptions {
+		if errors.Is(err, d.err) {
+			return d.description
+		}
 	}
+
+	return "The access token is malformed or invalid"
 }
@@ -9,8 +9,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	middlewareMocks "github.com/luongtruong20201/bookmark-management/internal/api/middlewares/mocks"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/session"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	jwtPkg "github.com/luongtruong20201/bookmark-management/pkg/jwt"
 	"github.com/luongtruong20201/bookmark-management/pkg/jwt/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestJWTAuth_JWTAuth(t *testing.T) {
@@ -19,8 +24,9 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	const (
-		mockUserID = "550e8400-e29b-41d4-a716-446655440000"
-		mockToken  = "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9.test"
+		mockUserID    = "550e8400-e29b-41d4-a716-446655440000"
+		mockSessionID = "0b8f3c5e-2d4a-4e6b-9f1c-7a3d5e8b2c40"
+		mockToken     = "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9.test"
 	)
 
 	var (
@@ -31,13 +37,113 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 		name           string
 		authHeader     string
 		setupMock      func(t *testing.T) *mocks.JWTValidator
+		setupStatus    func(t *testing.T) *middlewareMocks.UserStatusChecker
+		setupSession   func(t *testing.T) *middlewareMocks.SessionChecker
 		expectedStatus int
 		expectedBody   map[string]interface{}
 		expectedUserID interface{}
 		shouldAbort    bool
+		// expectedChallenge is the expected WWW-Authenticate header, checked when set.
+		expectedChallenge string
 	}{
 		{
-			name:       "success - valid Bearer token with sub claim",
+			name:       "success - valid Bearer token with sub and sid claims",
+			authHeader: "Bearer " + mockToken,
+			setupMock: func(t *testing.T) *mocks.JWTValidator {
+				mockValidator := mocks.NewJWTValidator(t)
+				mockValidator.On("ValidateToken", mockToken).
+					Return(jwt.MapClaims{"sub": mockUserID, "sid": mockSessionID}, nil).Once()
+				return mockValidator
+			},
+			setupStatus: func(t *testing.T) *middlewareMocks.UserStatusChecker {
+				statusMock := middlewareMocks.NewUserStatusChecker(t)
+				statusMock.On("IsUserDisabled", mock.Anything, mockUserID).Return(false, nil).Once()
+				return statusMock
+			},
+			setupSession: func(t *testing.T) *middlewareMocks.SessionChecker {
+				sessionMock := middlewareMocks.NewSessionChecker(t)
+				sessionMock.On("Check", mock.Anything, mockUserID, mockSessionID).Return(nil).Once()
+				return sessionMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedUserID: mockUserID,
+			shouldAbort:    false,
+		},
+		{
+			name:       "error - session revoked",
+			authHeader: "Bearer " + mockToken,
+			setupMock: func(t *testing.T) *mocks.JWTValidator {
+				mockValidator := mocks.NewJWTValidator(t)
+				mockValidator.On("ValidateToken", mockToken).
+					Return(jwt.MapClaims{"sub": mockUserID, "sid": mockSessionID}, nil).Once()
+				return mockValidator
+			},
+			setupStatus: func(t *testing.T) *middlewareMocks.UserStatusChecker {
+				statusMock := middlewareMocks.NewUserStatusChecker(t)
+				statusMock.On("IsUserDisabled", mock.Anything, mockUserID).Return(false, nil).Once()
+				return statusMock
+			},
+			setupSession: func(t *testing.T) *middlewareMocks.SessionChecker {
+				sessionMock := middlewareMocks.NewSessionChecker(t)
+				sessionMock.On("Check", mock.Anything, mockUserID, mockSessionID).Return(session.ErrSessionNotFound).Once()
+				return sessionMock
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]interface{}{
+				"code":    "unauthorized",
+				"message": "Session has been revoked or expired",
+			},
+			shouldAbort: true,
+		},
+		{
+			name:       "error - session lookup fails",
+			authHeader: "Bearer " + mockToken,
+			setupMock: func(t *testing.T) *mocks.JWTValidator {
+				mockValidator := mocks.NewJWTValidator(t)
+				mockValidator.On("ValidateToken", mockToken).
+					Return(jwt.MapClaims{"sub": mockUserID, "sid": mockSessionID}, nil).Once()
+				return mockValidator
+			},
+			setupStatus: func(t *testing.T) *middlewareMocks.UserStatusChecker {
+				statusMock := middlewareMocks.NewUserStatusChecker(t)
+				statusMock.On("IsUserDisabled", mock.Anything, mockUserID).Return(false, nil).Once()
+				return statusMock
+			},
+			setupSession: func(t *testing.T) *middlewareMocks.SessionChecker {
+				sessionMock := middlewareMocks.NewSessionChecker(t)
+				sessionMock.On("Check", mock.Anything, mockUserID, mockSessionID).Return(errors.New("redis error")).Once()
+				return sessionMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]interface{}{
+				"code":    "internal_error",
+				"message": "Processing Error",
+			},
+			shouldAbort: true,
+		},
+		{
+			name:       "error - sid claim is not a string",
+			authHeader: "Bearer " + mockToken,
+			setupMock: func(t *testing.T) *mocks.JWTValidator {
+				mockValidator := mocks.NewJWTValidator(t)
+				mockValidator.On("ValidateToken", mockToken).
+					Return(jwt.MapClaims{"sub": mockUserID, "sid": 12345}, nil).Once()
+				return mockValidator
+			},
+			setupStatus: func(t *testing.T) *middlewareMocks.UserStatusChecker {
+				statusMock := middlewareMocks.NewUserStatusChecker(t)
+				statusMock.On("IsUserDisabled", mock.Anything, mockUserID).Return(false, nil).Once()
+				return statusMock
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]interface{}{
+				"code":    "unauthorized",
+				"message": "Invalid token",
+			},
+			shouldAbort: true,
+		},
+		{
+			name:       "error - token without sid claim",
 			authHeader: "Bearer " + mockToken,
 			setupMock: func(t *testing.T) *mocks.JWTValidator {
 				mockValidator := mocks.NewJWTValidator(t)
@@ -49,10 +155,82 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 					}, nil).Once()
 				return mockValidator
 			},
-			expectedStatus: http.StatusOK,
-			expectedBody:   nil,
-			expectedUserID: mockUserID,
-			shouldAbort:    false,
+			setupStatus: func(t *testing.T) *middlewareMocks.UserStatusChecker {
+				statusMock := middlewareMocks.NewUserStatusChecker(t)
+				statusMock.On("IsUserDisabled", mock.Anything, mockUserID).Return(false, nil).Once()
+				return statusMock
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]interface{}{
+				"code":    "unauthorized",
+				"message": "Invalid token",
+			},
+			expectedUserID:    nil,
+			shouldAbort:       true,
+			expectedChallenge: `Bearer error="invalid_token", error_description="The access token has no valid session"`,
+		},
+		{
+			name:       "error - user account disabled",
+			authHeader: "Bearer " + mockToken,
+			setupMock: func(t *testing.T) *mocks.JWTValidator {
+				mockValidator := mocks.NewJWTValidator(t)
+				mockValidator.On("ValidateToken", mockToken).
+					Return(jwt.MapClaims{"sub": mockUserID}, nil).Once()
+				return mockValidator
+			},
+			setupStatus: func(t *testing.T) *middlewareMocks.UserStatusChecker {
+				statusMock := middlewareMocks.NewUserStatusChecker(t)
+				statusMock.On("IsUserDisabled", mock.Anything, mockUserID).Return(true, nil).Once()
+				return statusMock
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody: map[string]interface{}{
+				"code":    "account_disabled",
+				"message": "User account is disabled",
+			},
+			shouldAbort: true,
+		},
+		{
+			name:       "error - user no longer exists",
+			authHeader: "Bearer " + mockToken,
+			setupMock: func(t *testing.T) *mocks.JWTValidator {
+				mockValidator := mocks.NewJWTValidator(t)
+				mockValidator.On("ValidateToken", mockToken).
+					Return(jwt.MapClaims{"sub": mockUserID}, nil).Once()
+				return mockValidator
+			},
+			setupStatus: func(t *testing.T) *middlewareMocks.UserStatusChecker {
+				statusMock := middlewareMocks.NewUserStatusChecker(t)
+				statusMock.On("IsUserDisabled", mock.Anything, mockUserID).Return(false, dbutils.ErrNotFoundType).Once()
+				return statusMock
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]interface{}{
+				"code":    "unauthorized",
+				"message": "Invalid token",
+			},
+			shouldAbort: true,
+		},
+		{
+			name:       "error - user status lookup fails",
+			authHeader: "Bearer " + mockToken,
+			setupMock: func(t *testing.T) *mocks.JWTValidator {
+				mockValidator := mocks.NewJWTValidator(t)
+				mockValidator.On("ValidateToken", mockToken).
+					Return(jwt.MapClaims{"sub": mockUserID}, nil).Once()
+				return mockValidator
+			},
+			setupStatus: func(t *testing.T) *middlewareMocks.UserStatusChecker {
+				statusMock := middlewareMocks.NewUserStatusChecker(t)
+				statusMock.On("IsUserDisabled", mock.Anything, mockUserID).Return(false, errors.New("database error")).Once()
+				return statusMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]interface{}{
+				"code":    "internal_error",
+				"message": "Processing Error",
+			},
+			shouldAbort: true,
 		},
 		{
 			name:       "error - missing Authorization header",
@@ -63,10 +241,12 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody: map[string]interface{}{
-				"error": "Authorization header is required",
+				"code":    "unauthorized",
+				"message": "Authorization header is required",
 			},
-			expectedUserID: nil,
-			shouldAbort:    true,
+			expectedUserID:    nil,
+			shouldAbort:       true,
+			expectedChallenge: "Bearer",
 		},
 		{
 			name:       "error - Authorization header without Bearer prefix",
@@ -77,7 +257,8 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody: map[string]interface{}{
-				"error": "Authorization header format is wrong",
+				"code":    "unauthorized",
+				"message": "Authorization header format is wrong",
 			},
 			expectedUserID: nil,
 			shouldAbort:    true,
@@ -91,7 +272,8 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody: map[string]interface{}{
-				"error": "Authorization header format is wrong",
+				"code":    "unauthorized",
+				"message": "Authorization header format is wrong",
 			},
 			expectedUserID: nil,
 			shouldAbort:    true,
@@ -107,7 +289,8 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody: map[string]interface{}{
-				"error": "Invalid token",
+				"code":    "unauthorized",
+				"message": "Invalid token",
 			},
 			expectedUserID: nil,
 			shouldAbort:    true,
@@ -121,7 +304,8 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody: map[string]interface{}{
-				"error": "Authorization header format is wrong",
+				"code":    "unauthorized",
+				"message": "Authorization header format is wrong",
 			},
 			expectedUserID: nil,
 			shouldAbort:    true,
@@ -135,7 +319,8 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody: map[string]interface{}{
-				"error": "Authorization header format is wrong",
+				"code":    "unauthorized",
+				"message": "Authorization header format is wrong",
 			},
 			expectedUserID: nil,
 			shouldAbort:    true,
@@ -151,10 +336,72 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody: map[string]interface{}{
-				"error": "Invalid token",
+				"code":    "unauthorized",
+				"message": "Invalid token",
 			},
-			expectedUserID: nil,
-			shouldAbort:    true,
+			expectedUserID:    nil,
+			shouldAbort:       true,
+			expectedChallenge: `Bearer error="invalid_token", error_description="The access token is malformed or invalid"`,
+		},
+		{
+			name:       "error - expired token",
+			authHeader: "Bearer " + mockToken,
+			setupMock: func(t *testing.T) *mocks.JWTValidator {
+				mockValidator := mocks.NewJWTValidator(t)
+				mockValidator.On("ValidateToken", mockToken).
+					Return(nil, jwtPkg.ErrTokenExpired).Once()
+				return mockValidator
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]interface{}{
+				"code":    "unauthorized",
+				"message": "Invalid token",
+			},
+			expectedUserID:    nil,
+			shouldAbort:       true,
+			expectedChallenge: `Bearer error="invalid_token", error_description="The access token expired"`,
+		},
+		{
+			name:       "error - token not valid yet",
+			authHeader: "Bearer " + mockToken,
+			setupMock: func(t *testing.T) *mocks.JWTValidator {
+				mockValidator := mocks.NewJWTValidator(t)
+				mockValidator.On("ValidateToken", mockToken).
+					Return(nil, jwtPkg.ErrTokenNotValidYet).Once()
+				return mockValidator
+			},
+			expectedStatus:    http.StatusUnauthorized,
+			expectedUserID:    nil,
+			shouldAbort:       true,
+			expectedChallenge: `Bearer error="invalid_token", error_description="The access token is not valid yet"`,
+		},
+		{
+			name:       "error - bad token signature",
+			authHeader: "Bearer " + mockToken,
+			setupMock: func(t *testing.T) *mocks.JWTValidator {
+				mockValidator := mocks.NewJWTValidator(t)
+				mockValidator.On("ValidateToken", mockToken).
+					Return(nil, jwtPkg.ErrTokenSignature).Once()
+				return mockValidator
+			},
+			expectedStatus:    http.StatusUnauthorized,
+			expectedUserID:    nil,
+			shouldAbort:       true,
+			expectedChallenge: `Bearer error="invalid_token", error_description="The access token signature is invalid"`,
+		},
+		{
+			name:       "error - wrong token audience",
+			authHeader: "Bearer " + mockToken,
+			setupMock: func(t *testing.T) *mocks.JWTValidator {
+				mockValidator := mocks.NewJWTValidator(t)
+				mockValidator.On("ValidateToken", mockToken).
+					Return(nil, jwtPkg.ErrTokenAudience).Once()
+				return mockValidator
+			},
+			expectedStatus:    http.StatusUnauthorized,
+			expectedUserID:    nil,
+			shouldAbort:       true,
+			expectedChallenge: `Bearer error="invalid_token", error_description="The access token is not intended for this service"`,
 		},
 		{
 			name:       "error - valid token but missing sub claim",
@@ -170,7 +417,8 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody: map[string]interface{}{
-				"error": "Invalid token",
+				"code":    "unauthorized",
+				"message": "Invalid token",
 			},
 			expectedUserID: nil,
 			shouldAbort:    true,
@@ -220,7 +468,8 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody: map[string]interface{}{
-				"error": "Authorization header format is wrong",
+				"code":    "unauthorized",
+				"message": "Authorization header format is wrong",
 			},
 			expectedUserID: nil,
 			shouldAbort:    true,
@@ -234,7 +483,8 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody: map[string]interface{}{
-				"error": "Authorization header format is wrong",
+				"code":    "unauthorized",
+				"message": "Authorization header format is wrong",
 			},
 			expectedUserID: nil,
 			shouldAbort:    true,
@@ -255,7 +505,15 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 			}
 
 			mockValidator := tc.setupMock(t)
-			middleware := NewJWTAuth(mockValidator)
+			statusChecker := middlewareMocks.NewUserStatusChecker(t)
+			if tc.setupStatus != nil {
+				statusChecker = tc.setupStatus(t)
+			}
+			sessionChecker := middlewareMocks.NewSessionChecker(t)
+			if tc.setupSession != nil {
+				sessionChecker = tc.setupSession(t)
+			}
+			middleware := NewJWTAuth(mockValidator, statusChecker, sessionChecker)
 			engine.Use(middleware.JWTAuth())
 			engine.GET("/test", testHandler)
 
@@ -267,12 +525,17 @@ func TestJWTAuth_JWTAuth(t *testing.T) {
 			engine.ServeHTTP(rec, req)
 
 			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedChallenge != "" {
+				assert.Equal(t, tc.expectedChallenge, rec.Header().Get("WWW-Authenticate"))
+			}
 
 			if tc.expectedBody != nil {
 				var responseBody map[string]interface{}
 				err := json.Unmarshal(rec.Body.Bytes(), &responseBody)
 				assert.NoError(t, err)
-				assert.Equal(t, tc.expectedBody, responseBody)
+				for key, value := range tc.expectedBody {
+					assert.Equal(t, value, responseBody[key], key)
+				}
 			}
 
 			if tc.shouldAbort {
@@ -290,8 +553,9 @@ func TestJWTAuth_JWTAuth_Integration(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	const (
-		mockUserID = "550e8400-e29b-41d4-a716-446655440000"
-		mockToken  = "valid.jwt.token"
+		mockUserID    = "550e8400-e29b-41d4-a716-446655440000"
+		mockSessionID = "0b8f3c5e-2d4a-4e6b-9f1c-7a3d5e8b2c40"
+		mockToken     = "valid.jwt.token"
 	)
 
 	t.Run("success - middleware chain with multiple handlers", func(t *testing.T) {
@@ -304,9 +568,14 @@ func TestJWTAuth_JWTAuth_Integration(t *testing.T) {
 		mockValidator.On("ValidateToken", mockToken).
 			Return(jwt.MapClaims{
 				"sub": mockUserID,
+				"sid": mockSessionID,
 			}, nil).Once()
+		statusChecker := middlewareMocks.NewUserStatusChecker(t)
+		statusChecker.On("IsUserDisabled", mock.Anything, mockUserID).Return(false, nil).Once()
+		sessionChecker := middlewareMocks.NewSessionChecker(t)
+		sessionChecker.On("Check", mock.Anything, mockUserID, mockSessionID).Return(nil).Once()
 
-		middleware := NewJWTAuth(mockValidator)
+		middleware := NewJWTAuth(mockValidator, statusChecker, sessionChecker)
 		engine.Use(middleware.JWTAuth())
 
 		handler1Called := false
@@ -350,7 +619,7 @@ func TestJWTAuth_JWTAuth_Integration(t *testing.T) {
 		_, engine := gin.CreateTestContext(rec)
 
 		mockValidator := mocks.NewJWTValidator(t)
-		middleware := NewJWTAuth(mockValidator)
+		middleware := NewJWTAuth(mockValidator, middlewareMocks.NewUserStatusChecker(t), middlewareMocks.NewSessionChecker(t))
 		engine.Use(middleware.JWTAuth())
 
 		handlerCalled := false
@@ -368,6 +637,111 @@ func TestJWTAuth_JWTAuth_Integration(t *testing.T) {
 		var responseBody map[string]interface{}
 		err := json.Unmarshal(rec.Body.Bytes(), &responseBody)
 		assert.NoError(t, err)
-		assert.Equal(t, "Authorization header is required", responseBody["error"])
+		assert.Equal(t, "Authorization header is required", responseBody["message"])
 	})
 }
+
+func TestJWTAuth_OptionalJWTAuth(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	const (
+		mockUserID    = "550e8400-e29b-41d4-a716-446655440000"
+		mockSessionID = "0b8f3c5e-2d4a-4e6b-9f1c-7a3d5e8b2c40"
+		mockToken     = "valid.jwt.token"
+	)
+
+	testCases := []struct {
+		name           string
+		authHeader     string
+		setupMock      func(t *testing.T) *mocks.JWTValidator
+		setupStatus    func(t *testing.T) *middlewareMocks.UserStatusChecker
+		setupSession   func(t *testing.T) *middlewareMocks.SessionChecker
+		expectedStatus int
+		expectedUserID any
+	}{
+		{
+			name:       "success - anonymous request passes without claims",
+			authHeader: "",
+			setupMock: func(t *testing.T) *mocks.JWTValidator {
+				return mocks.NewJWTValidator(t)
+			},
+			setupStatus: func(t *testing.T) *middlewareMocks.UserStatusChecker {
+				return middlewareMocks.NewUserStatusChecker(t)
+			},
+			expectedStatus: http.StatusOK,
+			expectedUserID: nil,
+		},
+		{
+			name:       "success - valid token sets claims",
+			authHeader: "Bearer " + mockToken,
+			setupMock: func(t *testing.T) *mocks.JWTValidator {
+				mockValidator := mocks.NewJWTValidator(t)
+				mockValidator.On("ValidateToken", mockToken).Return(jwt.MapClaims{"sub": mockUserID, "sid": mockSessionID}, nil).Once()
+				return mockValidator
+			},
+			setupStatus: func(t *testing.T) *middlewareMocks.UserStatusChecker {
+				statusMock := middlewareMocks.NewUserStatusChecker(t)
+				statusMock.On("IsUserDisabled", mock.Anything, mockUserID).Return(false, nil).Once()
+				return statusMock
+			},
+			setupSession: func(t *testing.T) *middlewareMocks.SessionChecker {
+				sessionMock := middlewareMocks.NewSessionChecker(t)
+				sessionMock.On("Check", mock.Anything, mockUserID, mockSessionID).Return(nil).Once()
+				return sessionMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedUserID: mockUserID,
+		},
+		{
+			name:       "error - invalid token is rejected",
+			authHeader: "Bearer " + mockToken,
+			setupMock: func(t *testing.T) *mocks.JWTValidator {
+				mockValidator := mocks.NewJWTValidator(t)
+				mockValidator.On("ValidateToken", mockToken).Return(nil, errors.New("invalid token")).Once()
+				return mockValidator
+			},
+			setupStatus: func(t *testing.T) *middlewareMocks.UserStatusChecker {
+				return middlewareMocks.NewUserStatusChecker(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			_, engine := gin.CreateTestContext(rec)
+
+			sessionChecker := middlewareMocks.NewSessionChecker(t)
+			if tc.setupSession != nil {
+				sessionChecker = tc.setupSession(t)
+			}
+			middleware := NewJWTAuth(tc.setupMock(t), tc.setupStatus(t), sessionChecker)
+			engine.Use(middleware.OptionalJWTAuth())
+			engine.GET("/test", func(c *gin.Context) {
+				var userID any
+				if claims, ok := c.Get("claims"); ok {
+					userID = claims.(jwt.MapClaims)["sub"]
+				}
+				c.JSON(http.StatusOK, gin.H{"userID": userID})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tc.authHeader != "" {
+				req.Header.Set("Authorization", tc.authHeader)
+			}
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var body map[string]any
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tc.expectedUserID, body["userID"])
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package middlewares
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/pkg/metrics"
+)
+
+// unmatchedRoute labels the requests that match no route, so that arbitrary paths do
+// not each create a series.
+const unmatchedRoute = "unmatched"
+
+// Metrics returns a Gin handler function that records the count and latency of every
+// request by method, route template and status code once the handlers have run.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		startedAt := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		metrics.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(startedAt))
+	}
+}
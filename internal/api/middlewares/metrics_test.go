@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		path           string
+		expectedSeries string
+	}{
+		{
+			name:           "route template",
+			path:           "/metrics-test/42",
+			expectedSeries: `bookmark_http_requests_total{method="GET",route="/metrics-test/:id",status="201"}`,
+		},
+		{
+			name:           "unmatched route",
+			path:           "/metrics-test-missing",
+			expectedSeries: `bookmark_http_requests_total{method="GET",route="unmatched",status="404"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := gin.New()
+			app.Use(Metrics())
+			app.GET("/metrics-test/:id", func(c *gin.Context) {
+				c.Status(http.StatusCreated)
+			})
+			app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			rec := httptest.NewRecorder()
+			metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+			assert.Contains(t, rec.Body.String(), tc.expectedSeries)
+		})
+	}
+}
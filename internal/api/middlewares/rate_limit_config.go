@@ -0,0 +1,95 @@
+package middlewares
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/ratelimit"
+)
+
+const (
+	// RateLimitPolicyDefault is applied to every route and keys clients by IP.
+	RateLimitPolicyDefault = "default"
+	// RateLimitPolicyAuthenticated is applied to routes that require a JWT and keys clients by user ID.
+	RateLimitPolicyAuthenticated = "authenticated"
+	// RateLimitPolicyShorten is applied to the link shortening endpoint.
+	RateLimitPolicyShorten = "shorten"
+)
+
+// RateLimitPolicies maps policy names to their limits. It is decoded from a comma separated
+// list of "name=rate/period" or "name=rate/period/burst" entries, for example
+// "default=300/1m,shorten=10/1m/5". The burst defaults to the rate.
+type RateLimitPolicies map[string]ratelimit.Limit
+
+// Decode implements envconfig.Decoder.
+func (p *RateLimitPolicies) Decode(value string) error {
+	policies := RateLimitPolicies{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, spec, ok := strings.Cut(entry, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("invalid rate limit policy %q: expected name=rate/period[/burst]", entry)
+		}
+		limit, err := parseLimit(spec)
+		if err != nil {
+			return fmt.Errorf("invalid rate limit policy %q: %w", entry, err)
+		}
+		policies[name] = limit
+	}
+
+	*p = policies
+	return nil
+}
+
+// parseLimit parses a "rate/period[/burst]" specification such as "10/1m/5".
+func parseLimit(spec string) (ratelimit.Limit, error) {
+	parts := strings.Split(spec, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return ratelimit.Limit{}, fmt.Errorf("expected rate/period[/burst]")
+	}
+
+	rate, err := strconv.Atoi(parts[0])
+	if err != nil || rate <= 0 {
+		return ratelimit.Limit{}, fmt.Errorf("rate must be a positive integer")
+	}
+	period, err := time.ParseDuration(parts[1])
+	if err != nil || period <= 0 {
+		return ratelimit.Limit{}, fmt.Errorf("period must be a positive duration")
+	}
+	burst := rate
+	if len(parts) == 3 {
+		burst, err = strconv.Atoi(parts[2])
+		if err != nil || burst <= 0 {
+			return ratelimit.Limit{}, fmt.Errorf("burst must be a positive integer")
+		}
+	}
+
+	return ratelimit.Limit{Rate: rate, Period: period, Burst: burst}, nil
+}
+
+// RateLimitConfig holds the rate limiting settings loaded from environment variables.
+// When FailOpen is true, requests are let through while Redis is unavailable; otherwise
+// they are rejected with 503 status.
+type RateLimitConfig struct {
+	Enabled  bool              `default:"true" envconfig:"RATE_LIMIT_ENABLED"`
+	FailOpen bool              `default:"true" envconfig:"RATE_LIMIT_FAIL_OPEN"`
+	Policies RateLimitPolicies `default:"default=300/1m,authenticated=600/1m,shorten=10/1m/5" envconfig:"RATE_LIMIT_POLICIES"`
+}
+
+// NewRateLimitConfig creates a new rate limit configuration by reading environment variables.
+// Returns an error if a policy cannot be parsed.
+func NewRateLimitConfig() (*RateLimitConfig, error) {
+	cfg := &RateLimitConfig{}
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,92 @@
+package middlewares
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitPolicies_Decode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		value            string
+		expectedPolicies RateLimitPolicies
+		expectError      bool
+	}{
+		{
+			name:  "success - burst defaults to rate",
+			value: "default=300/1m",
+			expectedPolicies: RateLimitPolicies{
+				"default": {Rate: 300, Period: time.Minute, Burst: 300},
+			},
+		},
+		{
+			name:  "success - several policies with burst",
+			value: "default=300/1m, shorten=10/1h/5,",
+			expectedPolicies: RateLimitPolicies{
+				"default": {Rate: 300, Period: time.Minute, Burst: 300},
+				"shorten": {Rate: 10, Period: time.Hour, Burst: 5},
+			},
+		},
+		{
+			name:             "success - empty",
+			value:            "",
+			expectedPolicies: RateLimitPolicies{},
+		},
+		{
+			name:        "error - missing name",
+			value:       "300/1m",
+			expectError: true,
+		},
+		{
+			name:        "error - invalid rate",
+			value:       "default=zero/1m",
+			expectError: true,
+		},
+		{
+			name:        "error - invalid period",
+			value:       "default=10/minute",
+			expectError: true,
+		},
+		{
+			name:        "error - invalid burst",
+			value:       "default=10/1m/-1",
+			expectError: true,
+		},
+		{
+			name:        "error - too many parts",
+			value:       "default=10/1m/5/1",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var policies RateLimitPolicies
+			err := policies.Decode(tc.value)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedPolicies, policies)
+		})
+	}
+}
+
+func TestNewRateLimitConfig(t *testing.T) {
+	t.Setenv("RATE_LIMIT_POLICIES", "shorten=2/1s")
+	t.Setenv("RATE_LIMIT_FAIL_OPEN", "false")
+
+	cfg, err := NewRateLimitConfig()
+	assert.NoError(t, err)
+	assert.True(t, cfg.Enabled)
+	assert.False(t, cfg.FailOpen)
+	assert.Equal(t, RateLimitPolicies{"shorten": ratelimit.Limit{Rate: 2, Period: time.Second, Burst: 2}}, cfg.Policies)
+}
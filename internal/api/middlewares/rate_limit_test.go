@@ -0,0 +1,199 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/ratelimit"
+	limiterMocks "github.com/luongtruong20201/bookmark-management/internal/repositories/ratelimit/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRateLimit_Limit(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	now := time.Now()
+	limit := ratelimit.Limit{Rate: 10, Period: time.Minute, Burst: 5}
+	testCases := []struct {
+		name            string
+		cfg             *RateLimitConfig
+		policy          string
+		claims          jwt.MapClaims
+		headers         map[string]string
+		setupMock       func(t *testing.T) *limiterMocks.Limiter
+		expectedStatus  int
+		expectedHeaders map[string]string
+		expectedBody    map[string]any
+		shouldAbort     bool
+	}{
+		{
+			name:   "allowed - keyed by ip",
+			cfg:    &RateLimitConfig{Enabled: true, Policies: RateLimitPolicies{"shorten": limit}},
+			policy: "shorten",
+			setupMock: func(t *testing.T) *limiterMocks.Limiter {
+				limiterMock := limiterMocks.NewLimiter(t)
+				limiterMock.On("Allow", mock.Anything, "shorten:ip:192.0.2.1", limit, now).
+					Return(&ratelimit.Result{Allowed: true, Remaining: 4, ResetAfter: 5500 * time.Millisecond}, nil).Once()
+				return limiterMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit":     "5",
+				"RateLimit-Remaining": "4",
+				"RateLimit-Reset":     "6",
+				"RateLimit-Policy":    "10;w=60;burst=5",
+			},
+		},
+		{
+			name:   "allowed - keyed by user id",
+			cfg:    &RateLimitConfig{Enabled: true, Policies: RateLimitPolicies{"shorten": limit}},
+			policy: "shorten",
+			claims: jwt.MapClaims{"sub": "user-1"},
+			headers: map[string]string{
+				"X-API-Key": "secret",
+			},
+			setupMock: func(t *testing.T) *limiterMocks.Limiter {
+				limiterMock := limiterMocks.NewLimiter(t)
+				limiterMock.On("Allow", mock.Anything, "shorten:user:user-1", limit, now).
+					Return(&ratelimit.Result{Allowed: true, Remaining: 4}, nil).Once()
+				return limiterMock
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "allowed - client supplied api key is keyed by ip",
+			cfg:    &RateLimitConfig{Enabled: true, Policies: RateLimitPolicies{"shorten": limit}},
+			policy: "shorten",
+			headers: map[string]string{
+				"X-API-Key": "secret",
+			},
+			setupMock: func(t *testing.T) *limiterMocks.Limiter {
+				limiterMock := limiterMocks.NewLimiter(t)
+				limiterMock.On("Allow", mock.Anything, "shorten:ip:192.0.2.1", limit, now).
+					Return(&ratelimit.Result{Allowed: true, Remaining: 4}, nil).Once()
+				return limiterMock
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "rejected - limit exceeded",
+			cfg:    &RateLimitConfig{Enabled: true, Policies: RateLimitPolicies{"shorten": limit}},
+			policy: "shorten",
+			setupMock: func(t *testing.T) *limiterMocks.Limiter {
+				limiterMock := limiterMocks.NewLimiter(t)
+				limiterMock.On("Allow", mock.Anything, "shorten:ip:192.0.2.1", limit, now).
+					Return(&ratelimit.Result{Allowed: false, RetryAfter: 2100 * time.Millisecond, ResetAfter: 30 * time.Second}, nil).Once()
+				return limiterMock
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedHeaders: map[string]string{
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "30",
+				"Retry-After":         "3",
+			},
+			expectedBody: map[string]any{"code": "rate_limited", "message": "Too many requests"},
+			shouldAbort:  true,
+		},
+		{
+			name:   "allowed - limiter fails open",
+			cfg:    &RateLimitConfig{Enabled: true, FailOpen: true, Policies: RateLimitPolicies{"shorten": limit}},
+			policy: "shorten",
+			setupMock: func(t *testing.T) *limiterMocks.Limiter {
+				limiterMock := limiterMocks.NewLimiter(t)
+				limiterMock.On("Allow", mock.Anything, "shorten:ip:192.0.2.1", limit, now).
+					Return(nil, errors.New("redis down")).Once()
+				return limiterMock
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "rejected - limiter fails closed",
+			cfg:    &RateLimitConfig{Enabled: true, FailOpen: false, Policies: RateLimitPolicies{"shorten": limit}},
+			policy: "shorten",
+			setupMock: func(t *testing.T) *limiterMocks.Limiter {
+				limiterMock := limiterMocks.NewLimiter(t)
+				limiterMock.On("Allow", mock.Anything, "shorten:ip:192.0.2.1", limit, now).
+					Return(nil, errors.New("redis down")).Once()
+				return limiterMock
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   map[string]any{"code": "service_unavailable", "message": "Rate limiter unavailable"},
+			shouldAbort:    true,
+		},
+		{
+			name:   "skipped - policy not configured",
+			cfg:    &RateLimitConfig{Enabled: true, Policies: RateLimitPolicies{"shorten": limit}},
+			policy: "unknown",
+			setupMock: func(t *testing.T) *limiterMocks.Limiter {
+				return limiterMocks.NewLimiter(t)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "skipped - rate limiting disabled",
+			cfg:    &RateLimitConfig{Enabled: false, Policies: RateLimitPolicies{"shorten": limit}},
+			policy: "shorten",
+			setupMock: func(t *testing.T) *limiterMocks.Limiter {
+				return limiterMocks.NewLimiter(t)
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			_, engine := gin.CreateTestContext(rec)
+
+			m := &rateLimit{
+				limiter: tc.setupMock(t),
+				cfg:     tc.cfg,
+				now:     func() time.Time { return now },
+			}
+
+			nextCalled := false
+			engine.Use(func(c *gin.Context) {
+				if tc.claims != nil {
+					c.Set("claims", tc.claims)
+				}
+				c.Next()
+			})
+			engine.Use(m.Limit(tc.policy))
+			engine.GET("/test", func(c *gin.Context) {
+				nextCalled = true
+				c.JSON(http.StatusOK, gin.H{"status": "ok"})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			for key, value := range tc.headers {
+				req.Header.Set(key, value)
+			}
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			for key, value := range tc.expectedHeaders {
+				assert.Equal(t, value, rec.Header().Get(key), key)
+			}
+			if tc.expectedBody != nil {
+				var responseBody map[string]any
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responseBody))
+				for key, value := range tc.expectedBody {
+					assert.Equal(t, value, responseBody[key], key)
+				}
+			}
+			assert.Equal(t, !tc.shouldAbort, nextCalled)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package middlewares
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	sqldb "github.com/luongtruong20201/bookmark-management/pkg/sql"
+)
+
+// ReadPrimaryHeader is the request header forcing the reads of a request on the primary
+// database when set to a true value, such as "true" or "1". Clients set it to read their
+// own writes right after making them, as the read replicas may lag behind.
+const ReadPrimaryHeader = "X-Read-Primary"
+
+// ReadPrimary returns a Gin handler function that forces the reads of the request on the
+// primary database when the X-Read-Primary header is true, see sqldb.WithPrimary. Other
+// requests read the listings and redirect lookups from the read replicas.
+func ReadPrimary() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if forced, err := strconv.ParseBool(c.GetHeader(ReadPrimaryHeader)); err == nil && forced {
+			c.Request = c.Request.WithContext(sqldb.WithPrimary(c.Request.Context()))
+		}
+
+		c.Next()
+	}
+}
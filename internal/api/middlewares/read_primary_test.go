@@ -0,0 +1,71 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	sqldb "github.com/luongtruong20201/bookmark-management/pkg/sql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPrimary(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name            string
+		header          string
+		expectedPrimary bool
+	}{
+		{
+			name:            "success - primary forced",
+			header:          "true",
+			expectedPrimary: true,
+		},
+		{
+			name:            "success - primary forced with 1",
+			header:          "1",
+			expectedPrimary: true,
+		},
+		{
+			name:   "success - no header",
+			header: "",
+		},
+		{
+			name:   "success - false header",
+			header: "false",
+		},
+		{
+			name:   "success - invalid header ignored",
+			header: "always",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var primary bool
+			app := gin.New()
+			app.ContextWithFallback = true
+			app.Use(ReadPrimary())
+			app.GET("/read-primary", func(c *gin.Context) {
+				primary = sqldb.PrimaryForced(c)
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/read-primary", nil)
+			if tc.header != "" {
+				req.Header.Set(ReadPrimaryHeader, tc.header)
+			}
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tc.expectedPrimary, primary)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package middlewares
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
+)
+
+// Recovery returns a Gin handler function that recovers a panic of the next handlers,
+// logs it with the stack trace through the request logger and aborts the request with
+// a 500 problem. The response is left untouched when the handler had already started
+// writing it.
+//
+// It must run after AccessLog, Tracing and Metrics so that they record the 500 status.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			logger.FromContext(c.Request.Context()).Error().
+				Str("panic", fmt.Sprint(recovered)).
+				Bytes("stack", debug.Stack()).
+				Msg("recovered from panic")
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			response.InternalError(c)
+		}()
+
+		c.Next()
+	}
+}
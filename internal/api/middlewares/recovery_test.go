@@ -0,0 +1,89 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecovery(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		handler        gin.HandlerFunc
+		expectedStatus int
+		expectedBody   string
+		expectedPanic  string
+	}{
+		{
+			name: "success - no panic",
+			handler: func(c *gin.Context) {
+				c.String(http.StatusOK, "ok")
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ok",
+		},
+		{
+			name: "success - panic before writing",
+			handler: func(c *gin.Context) {
+				panic("boom")
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"code":"internal_error","message":"Processing Error"}`,
+			expectedPanic:  "boom",
+		},
+		{
+			name: "success - panic after writing",
+			handler: func(c *gin.Context) {
+				c.String(http.StatusOK, "partial")
+				panic("late boom")
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "partial",
+			expectedPanic:  "late boom",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			app := gin.New()
+			app.Use(Recovery())
+			app.GET("/recovery", tc.handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/recovery", nil)
+			req = req.WithContext(logger.WithContext(req.Context(), zerolog.New(&buf)))
+			rec := httptest.NewRecorder()
+			assert.NotPanics(t, func() { app.ServeHTTP(rec, req) })
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusInternalServerError {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			} else {
+				assert.Equal(t, tc.expectedBody, rec.Body.String())
+			}
+
+			if tc.expectedPanic == "" {
+				assert.Empty(t, buf.String())
+				return
+			}
+			var line map[string]any
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+			assert.Equal(t, "error", line["level"])
+			assert.Equal(t, tc.expectedPanic, line["panic"])
+			assert.NotEmpty(t, line["stack"])
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
+)
+
+// RequestIDHeader is the header carrying the ID of a request, both ways.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the length of a request ID accepted from the caller.
+const maxRequestIDLength = 128
+
+// RequestID returns a Gin handler function that:
+//   - keeps the X-Request-ID header of the caller, or generates a UUID when it is missing
+//     or not a short printable token,
+//   - echoes the request ID in the X-Request-ID response header and stores it in the Gin
+//     context for the error responses,
+//   - stores in the request context a logger carrying the request ID as "request_id",
+//     read by logger.FromContext; JWTAuth adds the authenticated user ID as "uid".
+//
+// It must be the first middleware of the engine, so that every other log line of the
+// request carries the request ID.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, requestID)
+		c.Set(response.RequestIDKey, requestID)
+
+		ctx := c.Request.Context()
+		l := logger.FromContext(ctx).With().Str("request_id", requestID).Logger()
+		c.Request = c.Request.WithContext(logger.WithContext(ctx, l))
+
+		c.Next()
+	}
+}
+
+// validRequestID reports whether a request ID received from the caller can be kept: it
+// must be non-empty, at most maxRequestIDLength long and made of printable ASCII
+// characters other than spaces, so that it cannot forge log lines or headers.
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] <= ' ' || requestID[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
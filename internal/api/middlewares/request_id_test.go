@@ -0,0 +1,83 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name         string
+		requestID    string
+		expectedKept bool
+	}{
+		{
+			name:         "success - keeps the caller request ID",
+			requestID:    "req-123_abc.def:1",
+			expectedKept: true,
+		},
+		{
+			name:         "success - generates a missing request ID",
+			requestID:    "",
+			expectedKept: false,
+		},
+		{
+			name:         "success - replaces a request ID with spaces",
+			requestID:    "req 123",
+			expectedKept: false,
+		},
+		{
+			name:         "success - replaces a too long request ID",
+			requestID:    strings.Repeat("a", maxRequestIDLength+1),
+			expectedKept: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			app := gin.New()
+			app.Use(RequestID())
+			app.GET("/request-id", func(c *gin.Context) {
+				logger.FromContext(c.Request.Context()).Info().Msg("handled")
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/request-id", nil)
+			req = req.WithContext(logger.WithContext(req.Context(), zerolog.New(&buf)))
+			if tc.requestID != "" {
+				req.Header.Set(RequestIDHeader, tc.requestID)
+			}
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			requestID := rec.Header().Get(RequestIDHeader)
+			if tc.expectedKept {
+				assert.Equal(t, tc.requestID, requestID)
+			} else {
+				_, err := uuid.Parse(requestID)
+				assert.NoError(t, err)
+			}
+
+			var line map[string]any
+			assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+			assert.Equal(t, requestID, line["request_id"])
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
+	"github.com/luongtruong20201/bookmark-management/internal/utils"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
+)
+
+// RequireRole returns a Gin handler function that only lets requests through when the
+// "role" claim of the authenticated user is one of the given roles. It must be attached
+// after JWTAuth, which stores the claims in the context:
+//   - aborts the request with a 401 problem if no claims are present,
+//   - aborts the request with a 403 problem if the role claim is missing or not allowed.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := utils.GetJWTClaimsFromRequest(c)
+		if err != nil {
+			problems.Error(c, err)
+			return
+		}
+
+		role, _ := claims["role"].(string)
+		if !slices.Contains(roles, role) {
+			response.Abort(c, response.NewProblem(http.StatusForbidden, response.CodeForbidden, "Insufficient permissions"))
+			return
+		}
+
+		c.Next()
+	}
+}
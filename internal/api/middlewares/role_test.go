@@ -0,0 +1,105 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireRole(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		claims         any
+		roles          []string
+		expectedStatus int
+		expectedBody   map[string]interface{}
+		shouldAbort    bool
+	}{
+		{
+			name:           "success - role allowed",
+			claims:         jwt.MapClaims{"sub": "user-1", "role": "admin"},
+			roles:          []string{"admin"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "success - one of several roles allowed",
+			claims:         jwt.MapClaims{"sub": "user-1", "role": "user"},
+			roles:          []string{"admin", "user"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error - role not allowed",
+			claims:         jwt.MapClaims{"sub": "user-1", "role": "user"},
+			roles:          []string{"admin"},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   map[string]interface{}{"code": "forbidden", "message": "Insufficient permissions"},
+			shouldAbort:    true,
+		},
+		{
+			name:           "error - role claim missing",
+			claims:         jwt.MapClaims{"sub": "user-1"},
+			roles:          []string{"admin"},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   map[string]interface{}{"code": "forbidden", "message": "Insufficient permissions"},
+			shouldAbort:    true,
+		},
+		{
+			name:           "error - role claim is not a string",
+			claims:         jwt.MapClaims{"sub": "user-1", "role": 1},
+			roles:          []string{"admin"},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   map[string]interface{}{"code": "forbidden", "message": "Insufficient permissions"},
+			shouldAbort:    true,
+		},
+		{
+			name:           "error - no claims in context",
+			roles:          []string{"admin"},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   map[string]interface{}{"code": "unauthorized", "message": "Invalid token"},
+			shouldAbort:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			_, engine := gin.CreateTestContext(rec)
+
+			nextCalled := false
+			engine.Use(func(c *gin.Context) {
+				if tc.claims != nil {
+					c.Set("claims", tc.claims)
+				}
+				c.Next()
+			})
+			engine.Use(RequireRole(tc.roles...))
+			engine.GET("/test", func(c *gin.Context) {
+				nextCalled = true
+				c.JSON(http.StatusOK, gin.H{"status": "ok"})
+			})
+
+			engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				var responseBody map[string]interface{}
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &responseBody))
+				for key, value := range tc.expectedBody {
+					assert.Equal(t, value, responseBody[key], key)
+				}
+			}
+			assert.Equal(t, !tc.shouldAbort, nextCalled)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		path           string
+		expectedName   string
+		expectedStatus int
+		expectedCode   codes.Code
+	}{
+		{
+			name:           "success",
+			path:           "/tracing-test/42",
+			expectedName:   "GET /tracing-test/:id",
+			expectedStatus: http.StatusOK,
+			expectedCode:   codes.Unset,
+		},
+		{
+			name:           "server error",
+			path:           "/tracing-test/fail",
+			expectedName:   "GET /tracing-test/:id",
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   codes.Error,
+		},
+		{
+			name:           "unmatched route",
+			path:           "/tracing-test-missing",
+			expectedName:   "GET unmatched",
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   codes.Unset,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, spans := tracing.InitMockTracer(t)
+			app := gin.New()
+			app.ContextWithFallback = true
+			app.Use(Tracing())
+			var handlerSpan trace.SpanContext
+			app.GET("/tracing-test/:id", func(c *gin.Context) {
+				handlerSpan = trace.SpanContextFromContext(c)
+				if c.Param("id") == "fail" {
+					c.Status(http.StatusInternalServerError)
+					return
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+			app.ServeHTTP(httptest.NewRecorder(), req)
+
+			recorded := spans()
+			assert.Len(t, recorded, 1)
+			assert.Equal(t, tc.expectedName, recorded[0].Name)
+			assert.Equal(t, trace.SpanKindServer, recorded[0].SpanKind)
+			assert.Equal(t, trace.SpanContextFromContext(ctx).SpanID(), recorded[0].Parent.SpanID())
+			assert.Contains(t, recorded[0].Attributes, attribute.Int("http.response.status_code", tc.expectedStatus))
+			assert.Equal(t, tc.expectedCode, recorded[0].Status.Code)
+			if tc.expectedStatus != http.StatusNotFound {
+				assert.Equal(t, recorded[0].SpanContext.SpanID(), handlerSpan.SpanID())
+			}
+		})
+	}
+}
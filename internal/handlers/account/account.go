@@ -0,0 +1,49 @@
+package account
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/services/account"
+)
+
+// exportQuery represents the query parameters accepted when requesting an export.
+type exportQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=json zip" example:"zip"`
+}
+
+// deleteAccountBody represents the request body confirming an account deletion. The
+// password may be left empty by a user who has just logged in, and is at most 128
+// characters long like every password.
+type deleteAccountBody struct {
+	Password string `json:"password" binding:"max=128" example:"P@ssw0rd"`
+}
+
+// deleteAccountResponse represents the response returned when an account deletion is scheduled.
+type deleteAccountResponse struct {
+	Message             string    `json:"message" example:"Account deletion scheduled"`
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
+
+// Handler defines the HTTP handler interface for the self-service data export and account
+// deletion endpoints. All methods expect the caller to be authenticated.
+type Handler interface {
+	RequestExport(c *gin.Context)
+	GetExportStatus(c *gin.Context)
+	DownloadExport(c *gin.Context)
+	DeleteAccount(c *gin.Context)
+	CancelDeletion(c *gin.Context)
+}
+
+// accountHandler implements the Handler interface and wires account service calls
+// to HTTP requests/responses.
+type accountHandler struct {
+	svc account.Service
+}
+
+// NewAccountHandler creates a new account HTTP handler with the given service.
+func NewAccountHandler(svc account.Service) Handler {
+	return &accountHandler{
+		svc: svc,
+	}
+}
@@ -0,0 +1,109 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
+	"github.com/luongtruong20201/bookmark-management/internal/utils"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/luongtruong20201/bookmark-management/pkg/request"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
+)
+
+// DeleteAccount handles the HTTP request to delete the account of the authenticated user.
+// The account is purged, together with its bookmarks and short links, once the grace
+// period has ended; until then the deletion can be cancelled. Users without a password, such
+// as those signing in through an identity provider, leave it empty and confirm the deletion
+// with a recent login instead.
+//
+// @Summary Delete account
+// @Description Schedule the deletion of the authenticated user's account after confirming the password, or with a token issued within the last minutes when the password is empty
+// @Tags self
+// @Accept json
+// @Produce json
+// @Param request body deleteAccountBody true "Password confirmation"
+// @Success 202 {object} deleteAccountResponse "Deletion scheduled"
+// @Failure 400 {object} response.Problem "Invalid password or validation error"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token) or login too old to confirm without the password"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/self [delete]
+// @Security BearerAuth
+func (h *accountHandler) DeleteAccount(c *gin.Context) {
+	body, userID, err := request.BindInputFromRequestWithAuth[deleteAccountBody](c)
+	if err != nil {
+		return
+	}
+
+	at, err := h.svc.RequestDeletion(c, userID, body.Password, authenticatedAt(c))
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			problems.Error(c, utils.ErrInvaidToken)
+			return
+		}
+		if problems.IsUnexpected(err) {
+			logger.FromContext(c).Error().Err(err).Msg("failed to schedule account deletion")
+		}
+		problems.Error(c, err)
+		return
+	}
+
+	logger.FromContext(c).Info().Time("deletion_scheduled_at", at).Msg("account deletion scheduled")
+	c.JSON(http.StatusAccepted, deleteAccountResponse{
+		Message:             "Account deletion scheduled",
+		DeletionScheduledAt: at,
+	})
+}
+
+// CancelDeletion handles the HTTP request to cancel the scheduled deletion of the
+// authenticated user's account during its grace period.
+//
+// @Summary Cancel account deletion
+// @Description Cancel the scheduled deletion of the authenticated user's account
+// @Tags self
+// @Produce json
+// @Success 200 {object} response.Message "Deletion cancelled"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 409 {object} response.Problem "No deletion scheduled"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/self/deletion [delete]
+// @Security BearerAuth
+func (h *accountHandler) CancelDeletion(c *gin.Context) {
+	userID, err := utils.GetUserIDFromRequest(c)
+	if err != nil {
+		problems.Error(c, err)
+		return
+	}
+
+	if err := h.svc.CancelDeletion(c, userID); err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			problems.Error(c, utils.ErrInvaidToken)
+			return
+		}
+		if problems.IsUnexpected(err) {
+			logger.FromContext(c).Error().Err(err).Msg("failed to cancel account deletion")
+		}
+		problems.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Message{Message: "Account deletion cancelled"})
+}
+
+// authenticatedAt returns the issue time of the token of the request, which is when the
+// user logged in, or the zero time when the token has none.
+func authenticatedAt(c *gin.Context) time.Time {
+	claims, err := utils.GetJWTClaimsFromRequest(c)
+	if err != nil {
+		return time.Time{}
+	}
+	issuedAt, err := claims.GetIssuedAt()
+	if err != nil || issuedAt == nil {
+		return time.Time{}
+	}
+
+	return issuedAt.UTC()
+}
@@ -0,0 +1,218 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/luongtruong20201/bookmark-management/internal/services/account"
+	"github.com/luongtruong20201/bookmark-management/internal/services/account/mocks"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountHandler_DeleteAccount(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	scheduledAt := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	issuedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		body           string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name: "success",
+			body: `{"password":"P@ssw0rd"}`,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("RequestDeletion", ctx, testUserID, "P@ssw0rd", issuedAt).Return(scheduledAt, nil).Once()
+				return svc
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody: map[string]any{
+				"message":               "Account deletion scheduled",
+				"deletion_scheduled_at": "2025-04-01T10:00:00Z",
+			},
+		},
+		{
+			name: "error - wrong password",
+			body: `{"password":"wrong"}`,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("RequestDeletion", ctx, testUserID, "wrong", issuedAt).Return(time.Time{}, account.ErrInvalidPassword).Once()
+				return svc
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Bad Request",
+				"status":  float64(http.StatusBadRequest),
+				"code":    "invalid_credentials",
+				"message": "invalid password",
+			},
+		},
+		{
+			name: "success - without password the login confirms",
+			body: `{}`,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("RequestDeletion", ctx, testUserID, "", issuedAt).Return(scheduledAt, nil).Once()
+				return svc
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name: "error - without password the login is too old",
+			body: `{}`,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("RequestDeletion", ctx, testUserID, "", issuedAt).Return(time.Time{}, account.ErrReauthenticationRequired).Once()
+				return svc
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Unauthorized",
+				"status":  float64(http.StatusUnauthorized),
+				"code":    "unauthorized",
+				"message": "confirm your password or log in again to delete the account",
+			},
+		},
+		{
+			name: "error - invalid body",
+			body: `{"password":`,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				return mocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "error - internal error",
+			body: `{"password":"P@ssw0rd"}`,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("RequestDeletion", ctx, testUserID, "P@ssw0rd", issuedAt).Return(time.Time{}, errors.New("database error")).Once()
+				return svc
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Internal Server Error",
+				"status":  float64(http.StatusInternalServerError),
+				"code":    "internal_error",
+				"message": "Processing Error",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/v1/self", bytes.NewBufferString(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			ctx := newTestContext(rec, req, true)
+			ctx.Set("claims", jwt.MapClaims{"sub": testUserID, "iat": float64(issuedAt.Unix())})
+			handler := NewAccountHandler(tc.setupMockSvc(t, ctx))
+
+			handler.DeleteAccount(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				var body map[string]any
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tc.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestAccountHandler_CancelDeletion(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		svcErr         error
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:           "success",
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "Account deletion cancelled",
+			},
+		},
+		{
+			name:           "error - not scheduled",
+			svcErr:         account.ErrDeletionNotScheduled,
+			expectedStatus: http.StatusConflict,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Conflict",
+				"status":  float64(http.StatusConflict),
+				"code":    "conflict",
+				"message": "account deletion is not scheduled",
+			},
+		},
+		{
+			name:           "error - user no longer exists",
+			svcErr:         dbutils.ErrNotFoundType,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Unauthorized",
+				"status":  float64(http.StatusUnauthorized),
+				"code":    "unauthorized",
+				"message": "Invalid token",
+			},
+		},
+		{
+			name:           "error - internal error",
+			svcErr:         errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Internal Server Error",
+				"status":  float64(http.StatusInternalServerError),
+				"code":    "internal_error",
+				"message": "Processing Error",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx := newTestContext(rec, httptest.NewRequest(http.MethodDelete, "/v1/self/deletion", nil), true)
+			svc := mocks.NewService(t)
+			svc.On("CancelDeletion", ctx, testUserID).Return(tc.svcErr).Once()
+			handler := NewAccountHandler(svc)
+
+			handler.CancelDeletion(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			var body map[string]any
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package account
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
+	"github.com/luongtruong20201/bookmark-management/internal/services/account"
+	"github.com/luongtruong20201/bookmark-management/internal/utils"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/luongtruong20201/bookmark-management/pkg/request"
+)
+
+// contentTypes maps the export formats to the content type of their archive.
+var contentTypes = map[string]string{
+	account.FormatJSON: "application/json",
+	account.FormatZIP:  "application/zip",
+}
+
+// RequestExport handles the HTTP request to export the data of the authenticated user.
+// The export runs in the background; the returned job is polled through GetExportStatus.
+//
+// @Summary Request a data export
+// @Description Start an export of the profile, bookmarks and short links of the authenticated user as a JSON document or a ZIP archive
+// @Tags self
+// @Produce json
+// @Param format query string false "Archive format (json or zip, default json)"
+// @Success 202 {object} export.Job "Export job"
+// @Failure 400 {object} response.Problem "Invalid format"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/self/export [post]
+// @Security BearerAuth
+func (h *accountHandler) RequestExport(c *gin.Context) {
+	query, userID, err := request.BindInputFromQueryWithAuth[exportQuery](c)
+	if err != nil {
+		return
+	}
+
+	format := query.Format
+	if format == "" {
+		format = account.FormatJSON
+	}
+
+	job, err := h.svc.RequestExport(c, userID, format)
+	if err != nil {
+		logger.FromContext(c).Error().Err(err).Msg("failed to request export")
+		problems.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetExportStatus handles the HTTP request to poll the latest export of the authenticated user.
+//
+// @Summary Get data export status
+// @Description Get the status of the latest export of the authenticated user
+// @Tags self
+// @Produce json
+// @Success 200 {object} export.Job "Export job"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 404 {object} response.Problem "No export requested"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/self/export/status [get]
+// @Security BearerAuth
+func (h *accountHandler) GetExportStatus(c *gin.Context) {
+	userID, err := utils.GetUserIDFromRequest(c)
+	if err != nil {
+		problems.Error(c, err)
+		return
+	}
+
+	job, err := h.svc.GetExport(c, userID)
+	if err != nil {
+		if problems.IsUnexpected(err) {
+			logger.FromContext(c).Error().Err(err).Msg("failed to get export status")
+		}
+		problems.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// DownloadExport handles the HTTP request to download the archive of the latest completed
+// export of the authenticated user.
+//
+// @Summary Download data export
+// @Description Download the archive produced by the latest export of the authenticated user
+// @Tags self
+// @Produce json,application/zip
+// @Success 200 {file} file "Export archive"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 404 {object} response.Problem "No export requested or the archive has expired"
+// @Failure 409 {object} response.Problem "Export has not completed"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/self/export [get]
+// @Security BearerAuth
+func (h *accountHandler) DownloadExport(c *gin.Context) {
+	userID, err := utils.GetUserIDFromRequest(c)
+	if err != nil {
+		problems.Error(c, err)
+		return
+	}
+
+	job, data, err := h.svc.DownloadExport(c, userID)
+	if err != nil {
+		if problems.IsUnexpected(err) {
+			logger.FromContext(c).Error().Err(err).Msg("failed to download export")
+		}
+		problems.Error(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="export-%s.%s"`, job.ID, job.Format))
+	c.Data(http.StatusOK, contentTypes[job.Format], data)
+}
@@ -0,0 +1,297 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/export"
+	"github.com/luongtruong20201/bookmark-management/internal/services/account"
+	"github.com/luongtruong20201/bookmark-management/internal/services/account/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const testUserID = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+
+// newTestContext creates a Gin context for the request, authenticated as testUserID
+// unless authenticated is false.
+func newTestContext(rec *httptest.ResponseRecorder, req *http.Request, authenticated bool) *gin.Context {
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = req
+	if authenticated {
+		ctx.Set("claims", jwt.MapClaims{"sub": testUserID})
+	}
+	return ctx
+}
+
+func TestAccountHandler_RequestExport(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	pendingJob := &export.Job{ID: "job-1", Status: export.StatusPending, Format: account.FormatZIP}
+
+	testCases := []struct {
+		name           string
+		query          string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:  "success",
+			query: "?format=zip",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("RequestExport", ctx, testUserID, account.FormatZIP).Return(pendingJob, nil).Once()
+				return svc
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody: map[string]any{
+				"id":         "job-1",
+				"status":     "pending",
+				"format":     "zip",
+				"created_at": "0001-01-01T00:00:00Z",
+			},
+		},
+		{
+			name:  "success - defaults to json",
+			query: "",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("RequestExport", ctx, testUserID, account.FormatJSON).Return(&export.Job{ID: "job-2", Status: export.StatusPending, Format: account.FormatJSON}, nil).Once()
+				return svc
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody: map[string]any{
+				"id":         "job-2",
+				"status":     "pending",
+				"format":     "json",
+				"created_at": "0001-01-01T00:00:00Z",
+			},
+		},
+		{
+			name:  "error - unsupported format",
+			query: "?format=xml",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				return mocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "error - internal error",
+			query: "?format=json",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("RequestExport", ctx, testUserID, account.FormatJSON).Return(nil, errors.New("redis error")).Once()
+				return svc
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Internal Server Error",
+				"status":  float64(http.StatusInternalServerError),
+				"code":    "internal_error",
+				"message": "Processing Error",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx := newTestContext(rec, httptest.NewRequest(http.MethodPost, "/v1/self/export"+tc.query, nil), true)
+			handler := NewAccountHandler(tc.setupMockSvc(t, ctx))
+
+			handler.RequestExport(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedBody != nil {
+				var body map[string]any
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tc.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestAccountHandler_GetExportStatus(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		authenticated  bool
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:          "success",
+			authenticated: true,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("GetExport", ctx, testUserID).Return(&export.Job{ID: "job-1", Status: export.StatusFailed, Format: "json", Error: "export failed"}, nil).Once()
+				return svc
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"id":         "job-1",
+				"status":     "failed",
+				"format":     "json",
+				"created_at": "0001-01-01T00:00:00Z",
+				"error":      "export failed",
+			},
+		},
+		{
+			name:          "error - no export",
+			authenticated: true,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("GetExport", ctx, testUserID).Return(nil, export.ErrJobNotFound).Once()
+				return svc
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Not Found",
+				"status":  float64(http.StatusNotFound),
+				"code":    "not_found",
+				"message": "export job not found",
+			},
+		},
+		{
+			name:          "error - internal error",
+			authenticated: true,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("GetExport", ctx, testUserID).Return(nil, errors.New("redis error")).Once()
+				return svc
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Internal Server Error",
+				"status":  float64(http.StatusInternalServerError),
+				"code":    "internal_error",
+				"message": "Processing Error",
+			},
+		},
+		{
+			name: "error - missing claims",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				return mocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Unauthorized",
+				"status":  float64(http.StatusUnauthorized),
+				"code":    "unauthorized",
+				"message": "Invalid token",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx := newTestContext(rec, httptest.NewRequest(http.MethodGet, "/v1/self/export/status", nil), tc.authenticated)
+			handler := NewAccountHandler(tc.setupMockSvc(t, ctx))
+
+			handler.GetExportStatus(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			var body map[string]any
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
+
+func TestAccountHandler_DownloadExport(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name                string
+		setupMockSvc        func(t *testing.T, ctx context.Context) *mocks.Service
+		expectedStatus      int
+		expectedContentType string
+		expectedDisposition string
+		expectedBody        string
+	}{
+		{
+			name: "success - zip",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("DownloadExport", ctx, testUserID).Return(&export.Job{ID: "job-1", Status: export.StatusCompleted, Format: "zip"}, []byte("PK"), nil).Once()
+				return svc
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/zip",
+			expectedDisposition: `attachment; filename="export-job-1.zip"`,
+			expectedBody:        "PK",
+		},
+		{
+			name: "error - export not ready",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("DownloadExport", ctx, testUserID).Return(&export.Job{ID: "job-1", Status: export.StatusPending}, nil, account.ErrExportNotReady).Once()
+				return svc
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"code":"conflict","message":"export is not ready"}`,
+		},
+		{
+			name: "error - archive expired",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("DownloadExport", ctx, testUserID).Return(nil, nil, export.ErrArchiveNotFound).Once()
+				return svc
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"type":"about:blank","title":"Not Found","status":404,"code":"not_found","message":"export archive not found"}`,
+		},
+		{
+			name: "error - internal error",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("DownloadExport", ctx, testUserID).Return(nil, nil, errors.New("redis error")).Once()
+				return svc
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"code":"internal_error","message":"Processing Error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx := newTestContext(rec, httptest.NewRequest(http.MethodGet, "/v1/self/export", nil), true)
+			handler := NewAccountHandler(tc.setupMockSvc(t, ctx))
+
+			handler.DownloadExport(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedBody, rec.Body.String())
+			if tc.expectedContentType != "" {
+				assert.Equal(t, tc.expectedContentType, rec.Header().Get("Content-Type"))
+				assert.Equal(t, tc.expectedDisposition, rec.Header().Get("Content-Disposition"))
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/services/admin"
+)
+
+// Handler defines the HTTP handler interface for administration endpoints.
+// All methods expect the caller to be authenticated with the admin role.
+type Handler interface {
+	ListUsers(c *gin.Context)
+	DisableUser(c *gin.Context)
+	EnableUser(c *gin.Context)
+	ForcePasswordReset(c *gin.Context)
+	GetStats(c *gin.Context)
+}
+
+// adminHandler implements the Handler interface and wires administration
+// service calls to HTTP requests/responses.
+type adminHandler struct {
+	svc admin.Service
+}
+
+// NewAdminHandler creates a new administration HTTP handler with the given service.
+func NewAdminHandler(svc admin.Service) Handler {
+	return &adminHandler{
+		svc: svc,
+	}
+}
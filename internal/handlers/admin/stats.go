@@ -0,0 +1,34 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+)
+
+// GetStats handles the HTTP request to retrieve system-wide counts of users,
+// bookmarks and shortened links.
+//
+// @Summary System statistics
+// @Description Get system-wide counts of users, bookmarks and active shortened links (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} admin.Stats "System statistics"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 403 {object} response.Problem "Forbidden (not an administrator)"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/admin/stats [get]
+// @Security BearerAuth
+func (h *adminHandler) GetStats(c *gin.Context) {
+	stats, err := h.svc.GetStats(c)
+	if err != nil {
+		logger.FromContext(c).Error().Err(err).Msg("failed to get system statistics")
+		problems.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
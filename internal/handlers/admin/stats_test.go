@@ -0,0 +1,72 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/services/admin"
+	"github.com/luongtruong20201/bookmark-management/internal/services/admin/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminHandler_GetStats(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		stats          *admin.Stats
+		svcErr         error
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:           "success",
+			stats:          &admin.Stats{Users: 11, Bookmarks: 8, Links: 3},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"users":     float64(11),
+				"bookmarks": float64(8),
+				"links":     float64(3),
+			},
+		},
+		{
+			name:           "error - internal error",
+			svcErr:         errors.New("redis error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Internal Server Error",
+				"status":  float64(http.StatusInternalServerError),
+				"code":    "internal_error",
+				"message": "Processing Error",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/admin/stats", nil)
+
+			svcMock := mocks.NewService(t)
+			svcMock.On("GetStats", ctx).Return(tc.stats, tc.svcErr).Once()
+			handler := NewAdminHandler(svcMock)
+			handler.GetStats(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			var body map[string]any
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
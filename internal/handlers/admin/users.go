@@ -0,0 +1,168 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/luongtruong20201/bookmark-management/pkg/request"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
+)
+
+// listUsersQuery represents the query parameters accepted by ListUsers.
+type listUsersQuery struct {
+	request.PaginationBase
+	Search string `form:"search" binding:"omitempty,max=255"`
+}
+
+// listUsersResponse represents the response structure for ListUsers endpoint.
+type listUsersResponse struct {
+	Data       []*model.User               `json:"data"`
+	Pagination response.PaginationMetadata `json:"pagination"`
+}
+
+// userIDInput represents the user ID path parameter of the user management endpoints.
+type userIDInput struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+// ListUsers handles the HTTP request to list users for administrators.
+// It supports pagination and an optional case-insensitive search on username,
+// email and display name.
+//
+// @Summary List users
+// @Description Get a paginated list of users, optionally filtered by a search term (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number"
+// @Param pageSize query int false "Items per page"
+// @Param search query string false "Search term matched against username, email and display name"
+// @Success 200 {object} listUsersResponse "List of users with pagination"
+// @Failure 400 {object} response.Problem "Invalid query parameters"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 403 {object} response.Problem "Forbidden (not an administrator)"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/admin/users [get]
+// @Security BearerAuth
+func (h *adminHandler) ListUsers(c *gin.Context) {
+	query, _, err := request.BindInputFromQueryWithAuth[listUsersQuery](c)
+	if err != nil {
+		return
+	}
+
+	page, pageSize := query.ValidateAndNormalize()
+	offset, limit := query.ToOffsetLimit()
+
+	result, err := h.svc.ListUsers(c, query.Search, offset, limit)
+	if err != nil {
+		logger.FromContext(c).Error().Err(err).Msg("failed to list users")
+		problems.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, listUsersResponse{
+		Data:       result.Data,
+		Pagination: response.NewPaginationMetadata(page, pageSize, result.Total),
+	})
+}
+
+// DisableUser handles the HTTP request to disable a user account. Disabled users can
+// no longer log in and their existing tokens are rejected.
+//
+// @Summary Disable user
+// @Description Disable a user account (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} model.User "Updated user"
+// @Failure 400 {object} response.Problem "Invalid request or own account"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 403 {object} response.Problem "Forbidden (not an administrator)"
+// @Failure 404 {object} response.Problem "User not found"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/admin/users/{id}/disable [put]
+// @Security BearerAuth
+func (h *adminHandler) DisableUser(c *gin.Context) {
+	h.setUserDisabled(c, true)
+}
+
+// EnableUser handles the HTTP request to re-enable a disabled user account.
+//
+// @Summary Enable user
+// @Description Re-enable a disabled user account (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} model.User "Updated user"
+// @Failure 400 {object} response.Problem "Invalid request"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 403 {object} response.Problem "Forbidden (not an administrator)"
+// @Failure 404 {object} response.Problem "User not found"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/admin/users/{id}/enable [put]
+// @Security BearerAuth
+func (h *adminHandler) EnableUser(c *gin.Context) {
+	h.setUserDisabled(c, false)
+}
+
+// setUserDisabled implements DisableUser and EnableUser.
+func (h *adminHandler) setUserDisabled(c *gin.Context, disabled bool) {
+	input, adminID, err := request.BindInputFromUriWithAuth[userIDInput](c)
+	if err != nil {
+		return
+	}
+
+	user, err := h.svc.SetUserDisabled(c, adminID, input.ID, disabled)
+	if err != nil {
+		if problems.IsUnexpected(err) {
+			logger.FromContext(c).Error().Err(err).Str("user_id", input.ID).Msg("failed to update user status")
+		}
+		problems.Error(c, err)
+		return
+	}
+
+	logger.FromContext(c).Info().Str("user_id", input.ID).Bool("disabled", disabled).Msg("user status updated")
+	c.JSON(http.StatusOK, user)
+}
+
+// ForcePasswordReset handles the HTTP request to force a user to change their password.
+// The user is signed out of all devices and cannot log in again until the password has
+// been changed.
+//
+// @Summary Force password reset
+// @Description Require a user to change their password before the next login and sign them out of all devices (admin only)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.Message "Password reset required"
+// @Failure 400 {object} response.Problem "Invalid request"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 403 {object} response.Problem "Forbidden (not an administrator)"
+// @Failure 404 {object} response.Problem "User not found"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/admin/users/{id}/password-reset [post]
+// @Security BearerAuth
+func (h *adminHandler) ForcePasswordReset(c *gin.Context) {
+	input, _, err := request.BindInputFromUriWithAuth[userIDInput](c)
+	if err != nil {
+		return
+	}
+
+	if err := h.svc.ForcePasswordReset(c, input.ID); err != nil {
+		if problems.IsUnexpected(err) {
+			logger.FromContext(c).Error().Err(err).Str("user_id", input.ID).Msg("failed to force password reset")
+		}
+		problems.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response.Message{
+		Message: "Password reset required",
+	})
+}
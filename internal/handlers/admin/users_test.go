@@ -0,0 +1,263 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/internal/services/admin"
+	"github.com/luongtruong20201/bookmark-management/internal/services/admin/mocks"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	mockAdminID = "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91"
+	mockUserID  = "550e8400-e29b-41d4-a716-446655440000"
+)
+
+func TestAdminHandler_ListUsers(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	users := []*model.User{
+		{Base: model.Base{ID: mockUserID}, Username: "johndoe", Email: "john.doe@example.com"},
+	}
+
+	testCases := []struct {
+		name           string
+		query          string
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.Service
+		expectedStatus int
+		verifyBody     func(t *testing.T, body map[string]any)
+	}{
+		{
+			name:  "success - search with pagination",
+			query: "?search=john&page=2&pageSize=5",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("ListUsers", ctx, "john", 5, 5).
+					Return(&admin.ListUsersResponse{Data: users, Total: 6}, nil).Once()
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			verifyBody: func(t *testing.T, body map[string]any) {
+				data, ok := body["data"].([]any)
+				assert.True(t, ok)
+				assert.Len(t, data, 1)
+				pagination := body["pagination"].(map[string]any)
+				assert.Equal(t, float64(6), pagination["total"])
+				assert.Equal(t, float64(2), pagination["page"])
+			},
+		},
+		{
+			name:  "error - invalid page size",
+			query: "?pageSize=1000",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				return mocks.NewService(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "error - internal error",
+			query: "",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("ListUsers", ctx, "", 0, 10).Return(nil, errors.New("database error")).Once()
+				return svcMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			verifyBody: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "Processing Error", body["message"])
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/admin/users"+tc.query, nil)
+			ctx.Set("claims", jwt.MapClaims{"sub": mockAdminID, "role": model.RoleAdmin})
+
+			handler := NewAdminHandler(tc.setupMockSvc(t, ctx))
+			handler.ListUsers(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.verifyBody != nil {
+				var body map[string]any
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				tc.verifyBody(t, body)
+			}
+		})
+	}
+}
+
+func TestAdminHandler_SetUserDisabled(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name            string
+		disabled        bool
+		userID          string
+		setupMockSvc    func(t *testing.T, ctx context.Context) *mocks.Service
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:     "success - disable user",
+			disabled: true,
+			userID:   mockUserID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("SetUserDisabled", ctx, mockAdminID, mockUserID, true).
+					Return(&model.User{Base: model.Base{ID: mockUserID}, Disabled: true}, nil).Once()
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:     "success - enable user",
+			disabled: false,
+			userID:   mockUserID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("SetUserDisabled", ctx, mockAdminID, mockUserID, false).
+					Return(&model.User{Base: model.Base{ID: mockUserID}}, nil).Once()
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:     "error - disable own account",
+			disabled: true,
+			userID:   mockAdminID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("SetUserDisabled", ctx, mockAdminID, mockAdminID, true).Return(nil, admin.ErrSelfDisable).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: admin.ErrSelfDisable.Error(),
+		},
+		{
+			name:     "error - user not found",
+			disabled: true,
+			userID:   mockUserID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("SetUserDisabled", ctx, mockAdminID, mockUserID, true).Return(nil, dbutils.ErrNotFoundType).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: "Resource not found",
+		},
+		{
+			name:     "error - internal error",
+			disabled: false,
+			userID:   mockUserID,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svcMock := mocks.NewService(t)
+				svcMock.On("SetUserDisabled", ctx, mockAdminID, mockUserID, false).Return(nil, errors.New("database error")).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedMessage: "Processing Error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/admin/users/"+tc.userID, nil)
+			ctx.Params = gin.Params{gin.Param{Key: "id", Value: tc.userID}}
+			ctx.Set("claims", jwt.MapClaims{"sub": mockAdminID, "role": model.RoleAdmin})
+
+			handler := NewAdminHandler(tc.setupMockSvc(t, ctx))
+			if tc.disabled {
+				handler.DisableUser(ctx)
+			} else {
+				handler.EnableUser(ctx)
+			}
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			var body map[string]any
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			if tc.expectedMessage != "" {
+				assert.Equal(t, tc.expectedMessage, body["message"])
+			} else {
+				assert.Equal(t, tc.userID, body["id"])
+				assert.Equal(t, tc.disabled, body["disabled"])
+			}
+		})
+	}
+}
+
+func TestAdminHandler_ForcePasswordReset(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name            string
+		svcErr          error
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:            "success",
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "Password reset required",
+		},
+		{
+			name:            "error - user not found",
+			svcErr:          dbutils.ErrNotFoundType,
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: "Resource not found",
+		},
+		{
+			name:            "error - internal error",
+			svcErr:          errors.New("database error"),
+			expectedStatus:  http.StatusInternalServerError,
+			expectedMessage: "Processing Error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/admin/users/"+mockUserID+"/password-reset", nil)
+			ctx.Params = gin.Params{gin.Param{Key: "id", Value: mockUserID}}
+			ctx.Set("claims", jwt.MapClaims{"sub": mockAdminID, "role": model.RoleAdmin})
+
+			svcMock := mocks.NewService(t)
+			svcMock.On("ForcePasswordReset", ctx, mockUserID).Return(tc.svcErr).Once()
+			handler := NewAdminHandler(svcMock)
+			handler.ForcePasswordReset(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			var body map[string]any
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedMessage, body["message"])
+		})
+	}
+}
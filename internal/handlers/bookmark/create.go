@@ -4,10 +4,10 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
 	"github.com/luongtruong20201/bookmark-management/pkg/request"
-	"github.com/luongtruong20201/bookmark-management/pkg/response"
-	"github.com/rs/zerolog/log"
 )
 
 // createBookmarkInput represents the request body for creating a bookmark.
@@ -35,9 +35,9 @@ type createBookmarkResponse struct {
 // @Produce json
 // @Param request body createBookmarkInput true "Bookmark create request"
 // @Success 200 {object} createBookmarkResponse "Create a bookmark successfully"
-// @Failure 400 {object} response.Message "Invalid request body or validation error"
-// @Failure 401 {object} response.Message "Unauthorized (missing/invalid token)"
-// @Failure 500 {object} response.Message "Internal server error"
+// @Failure 400 {object} response.Problem "Invalid request body or validation error"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 500 {object} response.Problem "Internal server error"
 // @Router /v1/bookmarks [post]
 // @Security BearerAuth
 func (h *bookmarkHandler) Create(c *gin.Context) {
@@ -48,8 +48,8 @@ func (h *bookmarkHandler) Create(c *gin.Context) {
 
 	res, err := h.svc.Create(c, body.Description, body.URL, userId)
 	if err != nil {
-		log.Error().Err(err).Str("uid", userId).Msg("failed to create bookmark")
-		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		logger.FromContext(c).Error().Err(err).Msg("failed to create bookmark")
+		problems.Error(c, err)
 		return
 	}
 
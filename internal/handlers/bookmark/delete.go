@@ -1,14 +1,13 @@
 package bookmark
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
 	"github.com/luongtruong20201/bookmark-management/pkg/request"
 	"github.com/luongtruong20201/bookmark-management/pkg/response"
-	"github.com/rs/zerolog/log"
 )
 
 type deleteBookmarkInput struct {
@@ -26,10 +25,10 @@ type deleteBookmarkInput struct {
 // @Produce json
 // @Param id path string true "Bookmark ID"
 // @Success 200 {object} response.Message "Successfully deleted bookmark"
-// @Failure 400 {object} response.Message "Invalid request or validation error"
-// @Failure 401 {object} response.Message "Unauthorized (missing/invalid token)"
-// @Failure 404 {object} response.Message "Bookmark not found"
-// @Failure 500 {object} response.Message "Internal server error"
+// @Failure 400 {object} response.Problem "Invalid request or validation error"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 404 {object} response.Problem "Bookmark not found"
+// @Failure 500 {object} response.Problem "Internal server error"
 // @Router /v1/bookmarks/{id} [delete]
 // @Security BearerAuth
 func (h *bookmarkHandler) DeleteBookmark(c *gin.Context) {
@@ -40,15 +39,10 @@ func (h *bookmarkHandler) DeleteBookmark(c *gin.Context) {
 
 	err = h.svc.Delete(c, input.ID, userId)
 	if err != nil {
-		if errors.Is(err, dbutils.ErrNotFoundType) {
-			c.JSON(http.StatusNotFound, &response.Message{
-				Message: "Bookmark not found",
-			})
-			return
+		if problems.IsUnexpected(err) {
+			logger.FromContext(c).Error().Err(err).Str("bookmark_id", input.ID).Msg("failed to delete bookmark")
 		}
-		log.Error().Err(err).Str("uid", userId).Str("bookmark_id", input.ID).Msg("failed to delete bookmark")
-
-		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		problems.Error(c, err)
 		return
 	}
 
@@ -95,7 +95,7 @@ func TestBookmarkHandler_DeleteBookmark(t *testing.T) {
 				}
 				err := json.Unmarshal(rec.Body.Bytes(), &resp)
 				assert.NoError(t, err)
-				assert.Equal(t, "Bookmark not found", resp.Message)
+				assert.Equal(t, "Resource not found", resp.Message)
 			},
 		},
 		{
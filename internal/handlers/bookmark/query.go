@@ -4,10 +4,11 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
 	"github.com/luongtruong20201/bookmark-management/pkg/request"
 	"github.com/luongtruong20201/bookmark-management/pkg/response"
-	"github.com/rs/zerolog/log"
 )
 
 // getBookmarksResponse represents the response structure for GetBookmarks endpoint.
@@ -27,10 +28,11 @@ type getBookmarksResponse struct {
 // @Produce json
 // @Param page query int false "Page number"
 // @Param pageSize query int false "Items per page"
+// @Param X-Read-Primary header bool false "Read from the primary database, to see a write just made"
 // @Success 200 {object} getBookmarksResponse "List of bookmarks with pagination"
-// @Failure 400 {object} response.Message "Invalid pagination parameters"
-// @Failure 401 {object} response.Message "Unauthorized (missing/invalid token)"
-// @Failure 500 {object} response.Message "Internal server error"
+// @Failure 400 {object} response.Problem "Invalid pagination parameters"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 500 {object} response.Problem "Internal server error"
 // @Router /v1/bookmarks [get]
 // @Security BearerAuth
 func (h *bookmarkHandler) GetBookmarks(c *gin.Context) {
@@ -44,8 +46,8 @@ func (h *bookmarkHandler) GetBookmarks(c *gin.Context) {
 
 	result, err := h.svc.GetBookmarks(c, userId, offset, limit)
 	if err != nil {
-		log.Error().Err(err).Str("uid", userId).Msg("failed to get bookmarks")
-		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		logger.FromContext(c).Error().Err(err).Msg("failed to get bookmarks")
+		problems.Error(c, err)
 		return
 	}
 
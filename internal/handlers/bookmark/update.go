@@ -1,14 +1,13 @@
 package bookmark
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
 	"github.com/luongtruong20201/bookmark-management/pkg/request"
 	"github.com/luongtruong20201/bookmark-management/pkg/response"
-	"github.com/rs/zerolog/log"
 )
 
 // updateBookmarkInput represents the request body for updating a bookmark.
@@ -31,10 +30,10 @@ type updateBookmarkInput struct {
 // @Param id path string true "Bookmark ID"
 // @Param request body updateBookmarkInput true "Bookmark update request"
 // @Success 200 {object} response.Message "Successfully updated bookmark"
-// @Failure 400 {object} response.Message "Invalid request body or validation error"
-// @Failure 401 {object} response.Message "Unauthorized (missing/invalid token)"
-// @Failure 404 {object} response.Message "Bookmark not found"
-// @Failure 500 {object} response.Message "Internal server error"
+// @Failure 400 {object} response.Problem "Invalid request body or validation error"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 404 {object} response.Problem "Bookmark not found"
+// @Failure 500 {object} response.Problem "Internal server error"
 // @Router /v1/bookmarks/{id} [put]
 // @Security BearerAuth
 func (h *bookmarkHandler) UpdateBookmark(c *gin.Context) {
@@ -44,23 +43,16 @@ func (h *bookmarkHandler) UpdateBookmark(c *gin.Context) {
 	}
 
 	if input.ID != c.Param("id") {
-		c.JSON(http.StatusBadRequest, &response.Message{
-			Message: "ID in path and body must match",
-		})
+		response.Abort(c, response.NewProblem(http.StatusBadRequest, response.CodeInvalidInput, "ID in path and body must match"))
 		return
 	}
 
 	_, err = h.svc.Update(c, input.ID, userId, input.Description, input.URL)
 	if err != nil {
-		if errors.Is(err, dbutils.ErrNotFoundType) {
-			c.JSON(http.StatusNotFound, &response.Message{
-				Message: "Bookmark not found",
-			})
-			return
+		if problems.IsUnexpected(err) {
+			logger.FromContext(c).Error().Err(err).Str("bookmark_id", input.ID).Msg("failed to update bookmark")
 		}
-		log.Error().Err(err).Str("uid", userId).Str("bookmark_id", input.ID).Msg("failed to update bookmark")
-
-		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		problems.Error(c, err)
 		return
 	}
 
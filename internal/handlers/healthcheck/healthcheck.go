@@ -11,6 +11,8 @@ import (
 // It provides methods to check the health status of the service.
 type Healthcheck interface {
 	Check(*gin.Context)
+	Live(*gin.Context)
+	Ready(*gin.Context)
 }
 
 // healthcheckHandler implements the Healthcheck interface and provides HTTP handlers
@@ -29,16 +31,57 @@ func NewHealthcheck(svc service.Healthcheck) Healthcheck {
 
 // Check handles the healthcheck endpoint request. It calls the healthcheck service
 // and returns a JSON response with the status message, service name, and instance ID.
+// The status is OK only when every dependency checked by the readiness probe is healthy.
+// While the service is shutting down it responds with 503 Service Unavailable, so load
+// balancers stop routing requests to the instance.
 // @Summary Health check
 // @Description Check the health status of the service
 // @Tags health
 // @Success 200 {object} map[string]string "Health status response"
+// @Failure 503 {object} map[string]string "Service shutting down"
 // @Router /health-check [get]
 func (h *healthcheckHandler) Check(c *gin.Context) {
 	message, serviceName, instanceId := h.healthcheckSvc.Check(c)
-	c.JSON(http.StatusOK, gin.H{
+	status := http.StatusOK
+	if message == service.StatusShuttingDown {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, gin.H{
 		"message":      message,
 		"service_name": serviceName,
 		"instance_id":  instanceId,
 	})
 }
+
+// Live handles the liveness probe. It responds with 200 OK as long as the process can
+// serve requests, without checking any dependency, so an unavailable dependency does not
+// get the instance restarted.
+// @Summary Liveness probe
+// @Description Report whether the process is up
+// @Tags health
+// @Produce json
+// @Success 200 {object} healthcheck.Report "Liveness report"
+// @Router /livez [get]
+func (h *healthcheckHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, h.healthcheckSvc.Live(c))
+}
+
+// Ready handles the readiness probe. It checks every dependency of the service and
+// responds with 200 OK when all of them are healthy or only optional ones, such as the
+// read replicas, are not, or 503 Service Unavailable with the result of each check
+// otherwise, and while the service is shutting down.
+// @Summary Readiness probe
+// @Description Check Postgres, Redis, the migration version and the JWT keys, and report whether the service can serve requests
+// @Tags health
+// @Produce json
+// @Success 200 {object} healthcheck.Report "Readiness report, with a DEGRADED status when an optional dependency is unhealthy"
+// @Failure 503 {object} healthcheck.Report "A dependency is unhealthy or the service is shutting down"
+// @Router /readyz [get]
+func (h *healthcheckHandler) Ready(c *gin.Context) {
+	report := h.healthcheckSvc.Ready(c)
+	status := http.StatusOK
+	if report.Status != service.StatusOK && report.Status != service.StatusDegraded {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
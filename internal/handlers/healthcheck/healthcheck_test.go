@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	service "github.com/luongtruong20201/bookmark-management/internal/services/healthcheck"
 	"github.com/luongtruong20201/bookmark-management/internal/services/healthcheck/mocks"
 	"github.com/stretchr/testify/assert"
 )
@@ -48,6 +49,19 @@ func TestHealthcheckHandler_Check(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedBody:   `{"instance_id":"instance_id","message":"NOT_OK","service_name":"bookmark_service"}`,
 		},
+		{
+			name: "shutting down",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/health-check", nil)
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Healthcheck {
+				mockSvc := mocks.NewHealthcheck(t)
+				mockSvc.On("Check", ctx).Return("SHUTTING_DOWN", "bookmark_service", "instance_id")
+				return mockSvc
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"instance_id":"instance_id","message":"SHUTTING_DOWN","service_name":"bookmark_service"}`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -67,3 +81,90 @@ func TestHealthcheckHandler_Check(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthcheckHandler_Live(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/livez", nil)
+	svc := mocks.NewHealthcheck(t)
+	svc.On("Live", ctx).Return(&service.Report{Status: "OK", ServiceName: "bookmark_service", InstanceId: "instance_id"})
+	handler := NewHealthcheck(svc)
+
+	handler.Live(ctx)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"status":"OK","service_name":"bookmark_service","instance_id":"instance_id"}`, rec.Body.String())
+}
+
+func TestHealthcheckHandler_Ready(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		report         *service.Report
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "ready",
+			report: &service.Report{
+				Status:      "OK",
+				ServiceName: "bookmark_service",
+				InstanceId:  "instance_id",
+				Checks:      []service.CheckResult{{Name: "redis", Status: "OK", Latency: "1ms"}},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"OK","service_name":"bookmark_service","instance_id":"instance_id","checks":[{"name":"redis","status":"OK","latency":"1ms"}]}`,
+		},
+		{
+			name: "optional dependency unhealthy",
+			report: &service.Report{
+				Status:      "DEGRADED",
+				ServiceName: "bookmark_service",
+				InstanceId:  "instance_id",
+				Checks:      []service.CheckResult{{Name: "postgres_replica_1", Status: "DEGRADED", Latency: "2s", Error: "unavailable"}},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"DEGRADED","service_name":"bookmark_service","instance_id":"instance_id","checks":[{"name":"postgres_replica_1","status":"DEGRADED","latency":"2s","error":"unavailable"}]}`,
+		},
+		{
+			name: "dependency unhealthy",
+			report: &service.Report{
+				Status:      "NOT_OK",
+				ServiceName: "bookmark_service",
+				InstanceId:  "instance_id",
+				Checks:      []service.CheckResult{{Name: "postgres", Status: "NOT_OK", Latency: "2s", Error: "unavailable"}},
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"status":"NOT_OK","service_name":"bookmark_service","instance_id":"instance_id","checks":[{"name":"postgres","status":"NOT_OK","latency":"2s","error":"unavailable"}]}`,
+		},
+		{
+			name:           "shutting down",
+			report:         &service.Report{Status: "SHUTTING_DOWN", ServiceName: "bookmark_service", InstanceId: "instance_id"},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"status":"SHUTTING_DOWN","service_name":"bookmark_service","instance_id":"instance_id"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			svc := mocks.NewHealthcheck(t)
+			svc.On("Ready", ctx).Return(tc.report)
+			handler := NewHealthcheck(svc)
+
+			handler.Ready(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package jwks
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/pkg/jwt"
+)
+
+// cacheControl lets clients cache the key set briefly, so a newly generated key is
+// picked up well before it is promoted to sign tokens.
+const cacheControl = "public, max-age=300"
+
+// JWKS defines the interface for the handler publishing the keys that verify the
+// tokens issued by the service.
+type JWKS interface {
+	GetJWKS(*gin.Context)
+}
+
+// jwksHandler implements the JWKS interface using the keys of the JWT validator.
+type jwksHandler struct {
+	validator jwt.JWTValidator
+}
+
+// NewJWKS creates a new JWKS handler with the provided JWT validator.
+func NewJWKS(validator jwt.JWTValidator) JWKS {
+	return &jwksHandler{
+		validator: validator,
+	}
+}
+
+// GetJWKS handles the request for the JSON Web Key Set. It returns the public keys
+// accepted for token verification, so other services can verify tokens on their own.
+// @Summary JSON Web Key Set
+// @Description List the public keys verifying the tokens issued by the service, identified by the kid token header
+// @Tags auth
+// @Produce json
+// @Success 200 {object} jwt.JWKS "JSON Web Key Set"
+// @Router /.well-known/jwks.json [get]
+func (h *jwksHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", cacheControl)
+	c.JSON(http.StatusOK, h.validator.JWKS())
+}
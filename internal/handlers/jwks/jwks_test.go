@@ -0,0 +1,65 @@
+package jwks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/pkg/jwt"
+	"github.com/luongtruong20201/bookmark-management/pkg/jwt/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWKSHandler_GetJWKS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupValidator func(*testing.T) *mocks.JWTValidator
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "success",
+			setupValidator: func(t *testing.T) *mocks.JWTValidator {
+				validator := mocks.NewJWTValidator(t)
+				validator.On("JWKS").Return(jwt.JWKS{Keys: []jwt.JWK{
+					{Kty: "RSA", Use: "sig", Alg: "RS256", Kid: "key-1", N: "n1", E: "AQAB"},
+					{Kty: "RSA", Use: "sig", Alg: "RS256", Kid: "key-2", N: "n2", E: "AQAB"},
+				}})
+				return validator
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"keys":[{"kty":"RSA","use":"sig","alg":"RS256","kid":"key-1","n":"n1","e":"AQAB"},{"kty":"RSA","use":"sig","alg":"RS256","kid":"key-2","n":"n2","e":"AQAB"}]}`,
+		},
+		{
+			name: "success - no keys",
+			setupValidator: func(t *testing.T) *mocks.JWTValidator {
+				validator := mocks.NewJWTValidator(t)
+				validator.On("JWKS").Return(jwt.JWKS{Keys: []jwt.JWK{}})
+				return validator
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"keys":[]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+			handler := NewJWKS(tc.setupValidator(t))
+
+			handler.GetJWKS(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedBody, rec.Body.String())
+			assert.Equal(t, cacheControl, rec.Header().Get("Cache-Control"))
+		})
+	}
+}
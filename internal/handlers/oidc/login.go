@@ -0,0 +1,101 @@
+package oidc
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
+	service "github.com/luongtruong20201/bookmark-management/internal/services/oidc"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
+)
+
+// callbackQuery represents the query parameters sent by the identity provider on redirect.
+type callbackQuery struct {
+	Code  string `form:"code" binding:"required"`
+	State string `form:"state" binding:"required"`
+}
+
+// callbackResponseBody represents the response body for a successful external login.
+type callbackResponseBody struct {
+	Token string `json:"token" example:"eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+// Login starts an OpenID Connect login with the provider named in the path and redirects
+// the user agent to the provider's authorization endpoint.
+// @Summary Start external login
+// @Description Redirect to the identity provider to sign in using the authorization code flow with PKCE
+// @Tags user
+// @Param provider path string true "Identity provider name"
+// @Success 302 "Redirect to the identity provider"
+// @Failure 404 {object} response.Problem "Identity provider not configured"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/users/oidc/{provider}/login [get]
+func (h *oidcHandler) Login(c *gin.Context) {
+	provider := c.Param("provider")
+
+	url, err := h.svc.AuthCodeURL(c, provider)
+	if err != nil {
+		if problems.IsUnexpected(err) {
+			logger.FromContext(c).Error().Err(err).Str("provider", provider).Msg("failed to start oidc login")
+		}
+		problems.Error(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, url)
+}
+
+// Callback completes an OpenID Connect login. It validates the state, exchanges the code for
+// a verified identity, links it to an account by verified email and returns a JWT token.
+// @Summary Complete external login
+// @Description Exchange the authorization code returned by the identity provider for a JWT token
+// @Tags user
+// @Produce json
+// @Param provider path string true "Identity provider name"
+// @Param code query string true "Authorization code"
+// @Param state query string true "State returned by the identity provider"
+// @Success 200 {object} callbackResponseBody "Successfully authenticated, returns JWT token"
+// @Failure 400 {object} response.Problem "Missing parameters, invalid state or login rejected by the provider"
+// @Failure 401 {object} response.Problem "Authentication with the identity provider failed"
+// @Failure 403 {object} response.Problem "Email not verified by the identity provider, account disabled or password reset required"
+// @Failure 404 {object} response.Problem "Identity provider not configured"
+// @Failure 409 {object} response.Problem "An account uses the email without having verified it"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/users/oidc/{provider}/callback [get]
+func (h *oidcHandler) Callback(c *gin.Context) {
+	provider := c.Param("provider")
+
+	if providerErr := c.Query("error"); providerErr != "" {
+		response.Abort(c, response.NewProblem(http.StatusBadRequest, response.CodeInvalidRequest, "login rejected by the identity provider").
+			WithDetails(providerErr))
+		return
+	}
+
+	query := &callbackQuery{}
+	if err := c.ShouldBindQuery(query); err != nil {
+		response.InputError(c, err)
+		return
+	}
+
+	token, err := h.svc.Callback(c, provider, query.State, query.Code, sessionService.Device{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrExchangeFailed):
+			logger.FromContext(c).Warn().Err(err).Str("provider", provider).Msg("oidc code exchange failed")
+		case problems.IsUnexpected(err):
+			logger.FromContext(c).Error().Err(err).Str("provider", provider).Msg("failed to complete oidc login")
+		}
+		problems.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &callbackResponseBody{
+		Token: token,
+	})
+}
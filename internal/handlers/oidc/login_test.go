@@ -0,0 +1,235 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	service "github.com/luongtruong20201/bookmark-management/internal/services/oidc"
+	"github.com/luongtruong20201/bookmark-management/internal/services/oidc/mocks"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
+	userService "github.com/luongtruong20201/bookmark-management/internal/services/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCHandler_Login(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	const mockAuthURL = "https://idp.example.com/authorize?state=abc"
+
+	testCases := []struct {
+		name             string
+		provider         string
+		setupMockSvc     func(t *testing.T, ctx context.Context) *mocks.OIDC
+		expectedStatus   int
+		expectedLocation string
+		expectedMessage  string
+	}{
+		{
+			name:     "success - redirect to provider",
+			provider: "company",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.OIDC {
+				svcMock := mocks.NewOIDC(t)
+				svcMock.On("AuthCodeURL", ctx, "company").Return(mockAuthURL, nil).Once()
+				return svcMock
+			},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: mockAuthURL,
+		},
+		{
+			name:     "error - provider not found",
+			provider: "unknown",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.OIDC {
+				svcMock := mocks.NewOIDC(t)
+				svcMock.On("AuthCodeURL", ctx, "unknown").Return("", service.ErrProviderNotFound).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: "identity provider not found",
+		},
+		{
+			name:     "error - internal error",
+			provider: "company",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.OIDC {
+				svcMock := mocks.NewOIDC(t)
+				svcMock.On("AuthCodeURL", ctx, "company").Return("", errors.New("redis down")).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedMessage: "Processing Error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v1/users/oidc/%s/login", tc.provider), nil)
+			ctx.Params = gin.Params{gin.Param{Key: "provider", Value: tc.provider}}
+
+			handler := NewOIDC(tc.setupMockSvc(t, ctx))
+			handler.Login(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedLocation != "" {
+				assert.Equal(t, tc.expectedLocation, rec.Header().Get("Location"))
+			}
+			if tc.expectedMessage != "" {
+				var body map[string]any
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tc.expectedMessage, body["message"])
+			}
+		})
+	}
+}
+
+func TestOIDCHandler_Callback(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	const mockToken = "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9.test"
+
+	device := sessionService.Device{UserAgent: "test-agent", IP: "192.0.2.1"}
+
+	testCases := []struct {
+		name            string
+		query           string
+		setupMockSvc    func(t *testing.T, ctx context.Context) *mocks.OIDC
+		expectedStatus  int
+		expectedToken   string
+		expectedMessage string
+	}{
+		{
+			name:  "success",
+			query: "code=code-1&state=state-1",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.OIDC {
+				svcMock := mocks.NewOIDC(t)
+				svcMock.On("Callback", ctx, "company", "state-1", "code-1", device).Return(mockToken, nil).Once()
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedToken:  mockToken,
+		},
+		{
+			name:  "error - provider returned an error",
+			query: "error=access_denied&state=state-1",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.OIDC {
+				return mocks.NewOIDC(t)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "login rejected by the identity provider",
+		},
+		{
+			name:  "error - missing code",
+			query: "state=state-1",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.OIDC {
+				return mocks.NewOIDC(t)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "Input error",
+		},
+		{
+			name:  "error - provider not found",
+			query: "code=code-1&state=state-1",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.OIDC {
+				svcMock := mocks.NewOIDC(t)
+				svcMock.On("Callback", ctx, "company", "state-1", "code-1", device).Return("", service.ErrProviderNotFound).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusNotFound,
+			expectedMessage: "identity provider not found",
+		},
+		{
+			name:  "error - invalid state",
+			query: "code=code-1&state=state-1",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.OIDC {
+				svcMock := mocks.NewOIDC(t)
+				svcMock.On("Callback", ctx, "company", "state-1", "code-1", device).Return("", service.ErrInvalidState).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: service.ErrInvalidState.Error(),
+		},
+		{
+			name:  "error - exchange failed",
+			query: "code=code-1&state=state-1",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.OIDC {
+				svcMock := mocks.NewOIDC(t)
+				svcMock.On("Callback", ctx, "company", "state-1", "code-1", device).
+					Return("", fmt.Errorf("%w: invalid_grant", service.ErrExchangeFailed)).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusUnauthorized,
+			expectedMessage: service.ErrExchangeFailed.Error(),
+		},
+		{
+			name:  "error - email not verified",
+			query: "code=code-1&state=state-1",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.OIDC {
+				svcMock := mocks.NewOIDC(t)
+				svcMock.On("Callback", ctx, "company", "state-1", "code-1", device).Return("", service.ErrEmailNotVerified).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusForbidden,
+			expectedMessage: service.ErrEmailNotVerified.Error(),
+		},
+		{
+			name:  "error - user disabled",
+			query: "code=code-1&state=state-1",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.OIDC {
+				svcMock := mocks.NewOIDC(t)
+				svcMock.On("Callback", ctx, "company", "state-1", "code-1", device).Return("", userService.ErrUserDisabled).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusForbidden,
+			expectedMessage: userService.ErrUserDisabled.Error(),
+		},
+		{
+			name:  "error - internal error",
+			query: "code=code-1&state=state-1",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.OIDC {
+				svcMock := mocks.NewOIDC(t)
+				svcMock.On("Callback", ctx, "company", "state-1", "code-1", device).Return("", errors.New("database error")).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedMessage: "Processing Error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/v1/users/oidc/company/callback?"+tc.query, nil)
+			ctx.Request.Header.Set("User-Agent", "test-agent")
+			ctx.Params = gin.Params{gin.Param{Key: "provider", Value: "company"}}
+
+			handler := NewOIDC(tc.setupMockSvc(t, ctx))
+			handler.Callback(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			var body map[string]any
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			if tc.expectedToken != "" {
+				assert.Equal(t, tc.expectedToken, body["token"])
+			}
+			if tc.expectedMessage != "" {
+				assert.Equal(t, tc.expectedMessage, body["message"])
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package oidc
+
+import (
+	"github.com/gin-gonic/gin"
+	service "github.com/luongtruong20201/bookmark-management/internal/services/oidc"
+)
+
+// OIDC defines the interface for OpenID Connect login handlers.
+// It provides methods to start a login with an external identity provider and to
+// complete it when the provider redirects back.
+type OIDC interface {
+	// Login redirects the user agent to the identity provider's authorization endpoint.
+	Login(c *gin.Context)
+	// Callback completes the login and returns a JWT token for the linked user.
+	Callback(c *gin.Context)
+}
+
+// oidcHandler implements the OIDC interface and wires OpenID Connect service calls
+// to HTTP requests/responses.
+type oidcHandler struct {
+	svc service.OIDC
+}
+
+// NewOIDC creates a new OpenID Connect login handler with the provided service.
+func NewOIDC(svc service.OIDC) OIDC {
+	return &oidcHandler{
+		svc: svc,
+	}
+}
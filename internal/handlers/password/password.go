@@ -1,47 +1,123 @@
 package password
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	service "github.com/luongtruong20201/bookmark-management/internal/services/password"
-	"github.com/rs/zerolog/log"
+	"github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
 )
 
+// entropyHeader carries the entropy estimate of plain text responses.
+const entropyHeader = "X-Password-Entropy"
+
+// genPassQuery represents the query parameters of the GenPass endpoint.
+type genPassQuery struct {
+	Mode             string `form:"mode" binding:"omitempty,oneof=password passphrase"`
+	Length           int    `form:"length" binding:"omitempty,min=8,max=128"`
+	Symbols          bool   `form:"symbols"`
+	ExcludeAmbiguous bool   `form:"exclude_ambiguous"`
+	RequireEachClass bool   `form:"require_each_class"`
+	Words            int    `form:"words" binding:"omitempty,min=3,max=20"`
+	Separator        string `form:"separator" binding:"omitempty,max=3"`
+	Format           string `form:"format" binding:"omitempty,oneof=text json"`
+}
+
+// genPassResponse represents the JSON response of the GenPass endpoint.
+type genPassResponse struct {
+	Password    string  `json:"password" example:"maple-otter-quartz-bloom-cedar-tango"`
+	Mode        string  `json:"mode" example:"passphrase"`
+	EntropyBits float64 `json:"entropy_bits" example:"62"`
+}
+
 // passwordHandler implements the Password interface and provides HTTP handlers
-// for password generation operations. It encapsulates the password service dependency
-// for business logic execution.
+// for password generation and strength checks. It encapsulates the password service and
+// password policy dependencies for business logic execution.
 type passwordHandler struct {
-	svc service.Password
+	svc    service.Password
+	policy passwordpolicy.Policy
 }
 
 // Password defines the interface for password handlers.
-// It provides methods to generate random passwords.
+// It provides methods to generate random passwords and to check the strength of a password.
 type Password interface {
 	GenPass(*gin.Context)
+	CheckStrength(*gin.Context)
 }
 
-// NewPassword creates a new password handler with the provided password service.
-func NewPassword(svc service.Password) Password {
+// NewPassword creates a new password handler with the provided password service and password
+// policy.
+func NewPassword(svc service.Password, policy passwordpolicy.Policy) Password {
 	return &passwordHandler{
-		svc: svc,
+		svc:    svc,
+		policy: policy,
 	}
 }
 
 // GenPass handles the password generation endpoint request. It generates a new password
-// using the password service and returns it as a plain text response.
+// or passphrase as configured by the query parameters and returns it as plain text, with
+// the entropy estimate in the X-Password-Entropy header, or as JSON when format=json is
+// given or the Accept header prefers JSON.
 // @Summary Generate password
-// @Description Generate a random alphanumeric password
+// @Description Generate a random password (10 letters and digits by default) or a diceware-style passphrase, with an entropy estimate
 // @Tags password
-// @Success 200 {string} string "Generated password"
-// @Failure 500 {string} string "Internal server error"
+// @Produce plain,json
+// @Param mode query string false "Generation mode" Enums(password, passphrase) default(password)
+// @Param length query int false "Password length" minimum(8) maximum(128) default(10)
+// @Param symbols query bool false "Include symbols"
+// @Param exclude_ambiguous query bool false "Exclude ambiguous characters such as l, 1, O and 0"
+// @Param require_each_class query bool false "Require at least one character of each selected class"
+// @Param words query int false "Passphrase word count" minimum(3) maximum(20) default(6)
+// @Param separator query string false "Passphrase word separator" default(-)
+// @Param format query string false "Response format" Enums(text, json)
+// @Success 200 {object} genPassResponse "Generated password"
+// @Failure 400 {object} response.Problem "Invalid options"
+// @Failure 500 {object} response.Problem "Internal server error"
 // @Router /gen-pass [get]
 func (h *passwordHandler) GenPass(c *gin.Context) {
-	pass, err := h.svc.GeneratePassword()
+	query := &genPassQuery{}
+	if err := c.ShouldBindQuery(query); err != nil {
+		response.InputError(c, err)
+		return
+	}
+
+	opts := service.Options{
+		Mode:             query.Mode,
+		Length:           query.Length,
+		Symbols:          query.Symbols,
+		ExcludeAmbiguous: query.ExcludeAmbiguous,
+		RequireEachClass: query.RequireEachClass,
+		Words:            query.Words,
+		Separator:        query.Separator,
+	}
+	pass, err := h.svc.GeneratePassword(opts)
 	if err != nil {
-		log.Error().Err(err).Msg("error when generating password")
-		c.String(http.StatusInternalServerError, "err")
+		if errors.Is(err, service.ErrInvalidOptions) {
+			response.Abort(c, response.NewProblem(http.StatusBadRequest, response.CodeInvalidInput, err.Error()))
+			return
+		}
+		logger.FromContext(c).Error().Err(err).Msg("error when generating password")
+		response.InternalError(c)
+		return
+	}
+
+	if query.Format == "json" || (query.Format == "" && c.NegotiateFormat(gin.MIMEPlain, gin.MIMEJSON) == gin.MIMEJSON) {
+		mode := opts.Mode
+		if mode == "" {
+			mode = service.ModePassword
+		}
+		c.JSON(http.StatusOK, genPassResponse{
+			Password:    pass.Value,
+			Mode:        mode,
+			EntropyBits: pass.Entropy,
+		})
+		return
 	}
 
-	c.String(http.StatusOK, pass)
+	c.Header(entropyHeader, strconv.FormatFloat(pass.Entropy, 'f', 1, 64))
+	c.String(http.StatusOK, pass.Value)
 }
@@ -2,11 +2,13 @@ package password
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	service "github.com/luongtruong20201/bookmark-management/internal/services/password"
 	"github.com/luongtruong20201/bookmark-management/internal/services/password/mocks"
 	"github.com/stretchr/testify/assert"
 )
@@ -16,11 +18,12 @@ func TestPasswordHandler_GenPass(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
-		name           string
-		setupRequest   func(*gin.Context)
-		setupMockSvc   func() *mocks.Password
-		expectedStatus int
-		expectedResp   string
+		name            string
+		setupRequest    func(*gin.Context)
+		setupMockSvc    func() *mocks.Password
+		expectedStatus  int
+		expectedResp    string
+		expectedEntropy string
 	}{
 		{
 			name: "success",
@@ -29,11 +32,80 @@ func TestPasswordHandler_GenPass(t *testing.T) {
 			},
 			setupMockSvc: func() *mocks.Password {
 				svcMock := mocks.NewPassword(t)
-				svcMock.On("GeneratePassword").Return("1234567890", nil)
+				svcMock.On("GeneratePassword", service.Options{}).Return(&service.Generated{Value: "1234567890", Entropy: 59.5}, nil)
+				return svcMock
+			},
+			expectedStatus:  http.StatusOK,
+			expectedResp:    "1234567890",
+			expectedEntropy: "59.5",
+		},
+		{
+			name: "success - password options",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/gen-pass?length=16&symbols=true&exclude_ambiguous=true&require_each_class=true", nil)
+			},
+			setupMockSvc: func() *mocks.Password {
+				svcMock := mocks.NewPassword(t)
+				svcMock.On("GeneratePassword", service.Options{Length: 16, Symbols: true, ExcludeAmbiguous: true, RequireEachClass: true}).
+					Return(&service.Generated{Value: "a#B2c$D4e%F6g&H8", Entropy: 101.2}, nil)
+				return svcMock
+			},
+			expectedStatus:  http.StatusOK,
+			expectedResp:    "a#B2c$D4e%F6g&H8",
+			expectedEntropy: "101.2",
+		},
+		{
+			name: "success - passphrase as JSON",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/gen-pass?mode=passphrase&words=4&separator=.&format=json", nil)
+			},
+			setupMockSvc: func() *mocks.Password {
+				svcMock := mocks.NewPassword(t)
+				svcMock.On("GeneratePassword", service.Options{Mode: service.ModePassphrase, Words: 4, Separator: "."}).
+					Return(&service.Generated{Value: "maple.otter.quartz.bloom", Entropy: 41.4}, nil)
 				return svcMock
 			},
 			expectedStatus: http.StatusOK,
-			expectedResp:   "1234567890",
+			expectedResp:   `{"password":"maple.otter.quartz.bloom","mode":"passphrase","entropy_bits":41.4}`,
+		},
+		{
+			name: "success - JSON from Accept header",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/gen-pass", nil)
+				ctx.Request.Header.Set("Accept", "application/json")
+			},
+			setupMockSvc: func() *mocks.Password {
+				svcMock := mocks.NewPassword(t)
+				svcMock.On("GeneratePassword", service.Options{}).Return(&service.Generated{Value: "1234567890", Entropy: 59.5}, nil)
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedResp:   `{"password":"1234567890","mode":"password","entropy_bits":59.5}`,
+		},
+		{
+			name: "error - length out of bounds",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/gen-pass?length=4", nil)
+			},
+			setupMockSvc: func() *mocks.Password {
+				return mocks.NewPassword(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `{"type":"about:blank","title":"Bad Request","status":400,"code":"invalid_input","message":"Input error","details":[{"field":"Length","rule":"min","message":"Length is invalid (min)"}]}`,
+		},
+		{
+			name: "error - invalid options",
+			setupRequest: func(ctx *gin.Context) {
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/gen-pass", nil)
+			},
+			setupMockSvc: func() *mocks.Password {
+				svcMock := mocks.NewPassword(t)
+				svcMock.On("GeneratePassword", service.Options{}).
+					Return(nil, fmt.Errorf("%w: length must be between 8 and 128", service.ErrInvalidOptions))
+				return svcMock
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedResp:   `{"type":"about:blank","title":"Bad Request","status":400,"code":"invalid_input","message":"invalid password options: length must be between 8 and 128"}`,
 		},
 		{
 			name: "internal server error",
@@ -42,11 +114,11 @@ func TestPasswordHandler_GenPass(t *testing.T) {
 			},
 			setupMockSvc: func() *mocks.Password {
 				svcMock := mocks.NewPassword(t)
-				svcMock.On("GeneratePassword").Return("", errors.New("failed"))
+				svcMock.On("GeneratePassword", service.Options{}).Return(nil, errors.New("failed"))
 				return svcMock
 			},
 			expectedStatus: http.StatusInternalServerError,
-			expectedResp:   "err",
+			expectedResp:   `{"type":"about:blank","title":"Internal Server Error","status":500,"code":"internal_error","message":"Processing Error"}`,
 		},
 	}
 
@@ -56,12 +128,13 @@ func TestPasswordHandler_GenPass(t *testing.T) {
 			gc, _ := gin.CreateTestContext(rec)
 			tc.setupRequest(gc)
 			mockSvc := tc.setupMockSvc()
-			testHandler := NewPassword(mockSvc)
+			testHandler := NewPassword(mockSvc, nil)
 
 			testHandler.GenPass(gc)
 
 			assert.Equal(t, tc.expectedStatus, rec.Code)
 			assert.Equal(t, tc.expectedResp, rec.Body.String())
+			assert.Equal(t, tc.expectedEntropy, rec.Header().Get(entropyHeader))
 		})
 	}
 }
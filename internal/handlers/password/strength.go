@@ -0,0 +1,57 @@
+package password
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
+	"github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/luongtruong20201/bookmark-management/pkg/request"
+)
+
+// checkStrengthRequestBody represents the request body of the CheckStrength endpoint.
+// Username and email are optional; when given, the password must not contain them.
+type checkStrengthRequestBody struct {
+	Password string `json:"password" binding:"required,max=128" example:"Blue-Otter-Canyon-42"`
+	Username string `json:"username" example:"johndoe"`
+	Email    string `json:"email" example:"john.doe@example.com"`
+}
+
+// checkStrengthResponse represents the response of the CheckStrength endpoint.
+type checkStrengthResponse struct {
+	Valid bool `json:"valid" example:"true"`
+	passwordpolicy.Result
+}
+
+// CheckStrength handles the password strength endpoint request. It evaluates the password
+// against the password policy applied at registration and password change, so that clients
+// can give live feedback before submitting it.
+// @Summary Check password strength
+// @Description Evaluate a password against the password policy: minimum length, strength score, username and email, and breached passwords
+// @Tags password
+// @Accept json
+// @Produce json
+// @Param request body checkStrengthRequestBody true "Password and optional account details"
+// @Success 200 {object} checkStrengthResponse "Password evaluation"
+// @Failure 400 {object} response.Problem "Invalid request body"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/password/strength [post]
+func (h *passwordHandler) CheckStrength(c *gin.Context) {
+	body, err := request.BindInputFromRequest[checkStrengthRequestBody](c)
+	if err != nil {
+		return
+	}
+
+	result, err := h.policy.Check(c, body.Password, body.Username, body.Email)
+	if err != nil {
+		logger.FromContext(c).Error().Err(err).Msg("error when checking password strength")
+		problems.Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, checkStrengthResponse{
+		Valid:  result.Valid(),
+		Result: *result,
+	})
+}
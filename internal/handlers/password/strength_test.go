@@ -0,0 +1,122 @@
+package password
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy"
+	"github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordHandler_CheckStrength(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	weakResult := &passwordpolicy.Result{
+		Score:       0,
+		EntropyBits: 1.6,
+		CrackTime:   "instant",
+		Violations: []passwordpolicy.Violation{
+			{Code: passwordpolicy.ViolationTooWeak, Message: "password is too easy to guess"},
+			{Code: passwordpolicy.ViolationBreached, Message: "password has appeared in a data breach"},
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		requestBody     any
+		setupMockPolicy func(t *testing.T, ctx context.Context) *mocks.Policy
+		expectedStatus  int
+		expectedValid   bool
+		expectedCodes   []string
+		expectedMessage string
+	}{
+		{
+			name:        "success - valid password",
+			requestBody: checkStrengthRequestBody{Password: "Blue-Otter-Canyon-42", Username: "johndoe"},
+			setupMockPolicy: func(t *testing.T, ctx context.Context) *mocks.Policy {
+				policyMock := mocks.NewPolicy(t)
+				policyMock.On("Check", ctx, "Blue-Otter-Canyon-42", "johndoe", "").
+					Return(&passwordpolicy.Result{Score: 4, Violations: []passwordpolicy.Violation{}}, nil).Once()
+				return policyMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedValid:  true,
+			expectedCodes:  []string{},
+		},
+		{
+			name:        "success - rejected password",
+			requestBody: checkStrengthRequestBody{Password: "password123", Email: "john.doe@example.com"},
+			setupMockPolicy: func(t *testing.T, ctx context.Context) *mocks.Policy {
+				policyMock := mocks.NewPolicy(t)
+				policyMock.On("Check", ctx, "password123", "", "john.doe@example.com").Return(weakResult, nil).Once()
+				return policyMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedValid:  false,
+			expectedCodes:  []string{passwordpolicy.ViolationTooWeak, passwordpolicy.ViolationBreached},
+		},
+		{
+			name:        "error - missing password",
+			requestBody: checkStrengthRequestBody{Username: "johndoe"},
+			setupMockPolicy: func(t *testing.T, ctx context.Context) *mocks.Policy {
+				return mocks.NewPolicy(t)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "Input error",
+		},
+		{
+			name:        "error - policy error",
+			requestBody: checkStrengthRequestBody{Password: "password123"},
+			setupMockPolicy: func(t *testing.T, ctx context.Context) *mocks.Policy {
+				policyMock := mocks.NewPolicy(t)
+				policyMock.On("Check", ctx, "password123", "", "").Return(nil, errors.New("read error")).Once()
+				return policyMock
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedMessage: "Processing Error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+
+			reqBody, err := json.Marshal(tc.requestBody)
+			assert.NoError(t, err)
+			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/password/strength", bytes.NewBuffer(reqBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			handler := NewPassword(nil, tc.setupMockPolicy(t, ctx))
+			handler.CheckStrength(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus != http.StatusOK {
+				var body map[string]any
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tc.expectedMessage, body["message"])
+				return
+			}
+
+			var body checkStrengthResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedValid, body.Valid)
+			codes := make([]string, 0, len(body.Violations))
+			for _, violation := range body.Violations {
+				codes = append(codes, violation.Code)
+			}
+			assert.Equal(t, tc.expectedCodes, codes)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Package problems maps the errors of the services and repositories to the problems
+// returned by the handlers. It lives with the handlers so that pkg/response does not
+// depend on the domain packages.
+package problems
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/export"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/session"
+	"github.com/luongtruong20201/bookmark-management/internal/services/account"
+	"github.com/luongtruong20201/bookmark-management/internal/services/admin"
+	"github.com/luongtruong20201/bookmark-management/internal/services/oidc"
+	"github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy"
+	"github.com/luongtruong20201/bookmark-management/internal/services/shorten"
+	"github.com/luongtruong20201/bookmark-management/internal/services/user"
+	"github.com/luongtruong20201/bookmark-management/internal/utils"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
+	pkgUtils "github.com/luongtruong20201/bookmark-management/pkg/utils"
+)
+
+// errorMappings maps the domain errors to the status, code and message of the problem
+// returned for them, most specific first. An empty message reports the text of the
+// domain error, which is written for users; the database errors get a generic message
+// instead, as their text is not.
+var errorMappings = []struct {
+	err     error
+	status  int
+	code    string
+	message string
+}{
+	{utils.ErrInvaidToken, http.StatusUnauthorized, response.CodeUnauthorized, "Invalid token"},
+	{utils.ErrEmptyUID, http.StatusUnauthorized, response.CodeUnauthorized, "Invalid token"},
+	{user.ErrClientErr, http.StatusBadRequest, response.CodeInvalidCredentials, ""},
+	{account.ErrInvalidPassword, http.StatusBadRequest, response.CodeInvalidCredentials, ""},
+	{account.ErrReauthenticationRequired, http.StatusUnauthorized, response.CodeUnauthorized, ""},
+	{user.ErrUserDisabled, http.StatusForbidden, response.CodeAccountDisabled, ""},
+	{user.ErrPasswordResetRequired, http.StatusForbidden, response.CodePasswordResetRequired, ""},
+	{user.ErrInvalidUsername, http.StatusBadRequest, response.CodeInvalidInput, ""},
+	{user.ErrEmailNotVerified, http.StatusConflict, response.CodeConflict, ""},
+	{passwordpolicy.ErrPasswordRejected, http.StatusBadRequest, response.CodePasswordRejected, ""},
+	{pkgUtils.ErrPasswordTooLong, http.StatusBadRequest, response.CodeInvalidInput, ""},
+	{admin.ErrSelfDisable, http.StatusBadRequest, response.CodeInvalidRequest, ""},
+	{account.ErrUnsupportedFormat, http.StatusBadRequest, response.CodeInvalidInput, ""},
+	{account.ErrExportNotReady, http.StatusConflict, response.CodeConflict, ""},
+	{account.ErrDeletionNotScheduled, http.StatusConflict, response.CodeConflict, ""},
+	{export.ErrJobNotFound, http.StatusNotFound, response.CodeNotFound, ""},
+	{export.ErrArchiveNotFound, http.StatusNotFound, response.CodeNotFound, ""},
+	{session.ErrSessionNotFound, http.StatusNotFound, response.CodeNotFound, "Session not found"},
+	{shorten.ErrCodeNotFound, http.StatusNotFound, response.CodeNotFound, "url not found"},
+	{oidc.ErrProviderNotFound, http.StatusNotFound, response.CodeNotFound, "identity provider not found"},
+	{oidc.ErrInvalidState, http.StatusBadRequest, response.CodeInvalidRequest, ""},
+	{oidc.ErrExchangeFailed, http.StatusUnauthorized, response.CodeUnauthorized, ""},
+	{oidc.ErrEmailNotVerified, http.StatusForbidden, response.CodeForbidden, ""},
+	{dbutils.ErrNotFoundType, http.StatusNotFound, response.CodeNotFound, "Resource not found"},
+	{dbutils.ErrDuplicationType, http.StatusConflict, response.CodeConflict, "Resource already exists"},
+}
+
+// Of returns the problem describing the error: the mapped problem for a domain
+// error, and a 500 problem hiding the cause for any other error. A rejected password
+// lists the broken rules in the details.
+func Of(err error) *response.Problem {
+	for _, m := range errorMappings {
+		if !errors.Is(err, m.err) {
+			continue
+		}
+		message := m.message
+		if message == "" {
+			message = m.err.Error()
+		}
+
+		problem := response.NewProblem(m.status, m.code, message)
+		var rejected *passwordpolicy.RejectedError
+		if errors.As(err, &rejected) {
+			problem.WithDetails(rejected.Violations)
+		}
+
+		return problem
+	}
+
+	return response.NewProblem(http.StatusInternalServerError, response.CodeInternal, "Processing Error")
+}
+
+// IsUnexpected reports whether the error maps to no domain error, so that it is returned
+// as a 500 problem. Handlers log such errors before calling Error.
+func IsUnexpected(err error) bool {
+	return Of(err).Status == http.StatusInternalServerError
+}
+
+// Error aborts the request with the problem describing the error; see Of.
+func Error(c *gin.Context, err error) {
+	response.Abort(c, Of(err))
+}
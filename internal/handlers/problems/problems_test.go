@@ -0,0 +1,113 @@
+package problems
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy"
+	"github.com/luongtruong20201/bookmark-management/internal/services/shorten"
+	"github.com/luongtruong20201/bookmark-management/internal/services/user"
+	"github.com/luongtruong20201/bookmark-management/internal/utils"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
+	pkgUtils "github.com/luongtruong20201/bookmark-management/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOf(t *testing.T) {
+	t.Parallel()
+
+	rejected := &passwordpolicy.RejectedError{Violations: []passwordpolicy.Violation{{Message: "too short"}}}
+
+	testCases := []struct {
+		name               string
+		err                error
+		expectedStatus     int
+		expectedCode       string
+		expectedMessage    string
+		expectedDetails    any
+		expectedUnexpected bool
+	}{
+		{
+			name:            "not found",
+			err:             fmt.Errorf("get bookmark: %w", dbutils.ErrNotFoundType),
+			expectedStatus:  http.StatusNotFound,
+			expectedCode:    response.CodeNotFound,
+			expectedMessage: "Resource not found",
+		},
+		{
+			name:            "duplicate",
+			err:             dbutils.ErrDuplicationType,
+			expectedStatus:  http.StatusConflict,
+			expectedCode:    response.CodeConflict,
+			expectedMessage: "Resource already exists",
+		},
+		{
+			name:            "short code not found",
+			err:             shorten.ErrCodeNotFound,
+			expectedStatus:  http.StatusNotFound,
+			expectedCode:    response.CodeNotFound,
+			expectedMessage: "url not found",
+		},
+		{
+			name:            "external login to an unverified email",
+			err:             user.ErrEmailNotVerified,
+			expectedStatus:  http.StatusConflict,
+			expectedCode:    response.CodeConflict,
+			expectedMessage: user.ErrEmailNotVerified.Error(),
+		},
+		{
+			name:            "invalid credentials",
+			err:             user.ErrClientErr,
+			expectedStatus:  http.StatusBadRequest,
+			expectedCode:    response.CodeInvalidCredentials,
+			expectedMessage: user.ErrClientErr.Error(),
+		},
+		{
+			name:            "invalid token",
+			err:             utils.ErrEmptyUID,
+			expectedStatus:  http.StatusUnauthorized,
+			expectedCode:    response.CodeUnauthorized,
+			expectedMessage: "Invalid token",
+		},
+		{
+			name:            "rejected password lists the violations",
+			err:             rejected,
+			expectedStatus:  http.StatusBadRequest,
+			expectedCode:    response.CodePasswordRejected,
+			expectedMessage: passwordpolicy.ErrPasswordRejected.Error(),
+			expectedDetails: rejected.Violations,
+		},
+		{
+			name:            "password too long to hash",
+			err:             pkgUtils.ErrPasswordTooLong,
+			expectedStatus:  http.StatusBadRequest,
+			expectedCode:    response.CodeInvalidInput,
+			expectedMessage: pkgUtils.ErrPasswordTooLong.Error(),
+		},
+		{
+			name:               "unexpected error hides the cause",
+			err:                errors.New("connection refused"),
+			expectedStatus:     http.StatusInternalServerError,
+			expectedCode:       response.CodeInternal,
+			expectedMessage:    "Processing Error",
+			expectedUnexpected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			problem := Of(tc.err)
+
+			assert.Equal(t, tc.expectedStatus, problem.Status)
+			assert.Equal(t, tc.expectedCode, problem.Code)
+			assert.Equal(t, tc.expectedMessage, problem.Message)
+			assert.Equal(t, tc.expectedDetails, problem.Details)
+			assert.Equal(t, tc.expectedUnexpected, IsUnexpected(tc.err))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package session
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
+)
+
+// sessionResponse represents a session of the authenticated user.
+type sessionResponse struct {
+	ID         string    `json:"id" example:"0b8f3c5e-2d4a-4e6b-9f1c-7a3d5e8b2c40"`
+	UserAgent  string    `json:"user_agent" example:"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5)"`
+	IP         string    `json:"ip" example:"203.0.113.7"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current" example:"true"`
+}
+
+// listSessionsResponse represents the response structure for ListSessions endpoint.
+type listSessionsResponse struct {
+	Data []*sessionResponse `json:"data"`
+}
+
+// sessionIDInput represents the session ID path parameter of RevokeSession.
+type sessionIDInput struct {
+	ID string `uri:"id" binding:"required"`
+}
+
+// Handler defines the HTTP handler interface for the session management endpoints.
+// All methods expect the caller to be authenticated.
+type Handler interface {
+	ListSessions(c *gin.Context)
+	RevokeSession(c *gin.Context)
+}
+
+// sessionHandler implements the Handler interface and wires session service calls
+// to HTTP requests/responses.
+type sessionHandler struct {
+	svc sessionService.Service
+}
+
+// NewSessionHandler creates a new session HTTP handler with the given service.
+func NewSessionHandler(svc sessionService.Service) Handler {
+	return &sessionHandler{
+		svc: svc,
+	}
+}
@@ -0,0 +1,92 @@
+package session
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
+	"github.com/luongtruong20201/bookmark-management/internal/utils"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/luongtruong20201/bookmark-management/pkg/request"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
+)
+
+// ListSessions handles the HTTP request to list the devices the authenticated user is
+// signed in on. The session of the calling token is flagged as current.
+//
+// @Summary List sessions
+// @Description List the active sessions of the authenticated user, most recently seen first
+// @Tags self
+// @Produce json
+// @Success 200 {object} listSessionsResponse "Active sessions"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/self/sessions [get]
+// @Security BearerAuth
+func (h *sessionHandler) ListSessions(c *gin.Context) {
+	claims, err := utils.GetJWTClaimsFromRequest(c)
+	if err != nil {
+		problems.Error(c, err)
+		return
+	}
+	userID, ok := claims["sub"].(string)
+	if !ok || userID == "" {
+		problems.Error(c, utils.ErrEmptyUID)
+		return
+	}
+	currentID, _ := claims["sid"].(string)
+
+	sessions, err := h.svc.List(c, userID)
+	if err != nil {
+		logger.FromContext(c).Error().Err(err).Msg("failed to list sessions")
+		problems.Error(c, err)
+		return
+	}
+
+	data := make([]*sessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		data = append(data, &sessionResponse{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			Current:    s.ID == currentID,
+		})
+	}
+
+	c.JSON(http.StatusOK, listSessionsResponse{Data: data})
+}
+
+// RevokeSession handles the HTTP request to sign the authenticated user out of one of
+// their sessions. Tokens issued with the session are rejected from then on, including
+// the calling token when it revokes its own session.
+//
+// @Summary Revoke session
+// @Description Sign out a device by revoking one of the authenticated user's sessions
+// @Tags self
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} response.Message "Session revoked"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 404 {object} response.Problem "Session not found"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/self/sessions/{id} [delete]
+// @Security BearerAuth
+func (h *sessionHandler) RevokeSession(c *gin.Context) {
+	input, userID, err := request.BindInputFromUriWithAuth[sessionIDInput](c)
+	if err != nil {
+		return
+	}
+
+	if err := h.svc.Revoke(c, userID, input.ID); err != nil {
+		if problems.IsUnexpected(err) {
+			logger.FromContext(c).Error().Err(err).Str("sid", input.ID).Msg("failed to revoke session")
+		}
+		problems.Error(c, err)
+		return
+	}
+
+	logger.FromContext(c).Info().Str("sid", input.ID).Msg("session revoked")
+	c.JSON(http.StatusOK, response.Message{Message: "Session revoked"})
+}
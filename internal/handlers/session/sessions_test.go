@@ -0,0 +1,211 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/session"
+	"github.com/luongtruong20201/bookmark-management/internal/services/session/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testUserID    = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+	testSessionID = "0b8f3c5e-2d4a-4e6b-9f1c-7a3d5e8b2c40"
+)
+
+// newTestContext creates a Gin context for the request, authenticated as testUserID on
+// testSessionID unless authenticated is false.
+func newTestContext(rec *httptest.ResponseRecorder, req *http.Request, authenticated bool) *gin.Context {
+	ctx, _ := gin.CreateTestContext(rec)
+	ctx.Request = req
+	if authenticated {
+		ctx.Set("claims", jwt.MapClaims{"sub": testUserID, "sid": testSessionID})
+	}
+	return ctx
+}
+
+func TestSessionHandler_ListSessions(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	seenAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		authenticated  bool
+		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.Service
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:          "success - current session is flagged",
+			authenticated: true,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("List", ctx, testUserID).Return([]*session.Session{
+					{ID: testSessionID, UserID: testUserID, UserAgent: "Mozilla/5.0", IP: "203.0.113.7", CreatedAt: seenAt, LastSeenAt: seenAt},
+					{ID: "other", UserID: testUserID, UserAgent: "curl/8.0", IP: "198.51.100.2", CreatedAt: seenAt, LastSeenAt: seenAt},
+				}, nil).Once()
+				return svc
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": []any{
+					map[string]any{
+						"id":           testSessionID,
+						"user_agent":   "Mozilla/5.0",
+						"ip":           "203.0.113.7",
+						"created_at":   "2025-03-01T10:00:00Z",
+						"last_seen_at": "2025-03-01T10:00:00Z",
+						"current":      true,
+					},
+					map[string]any{
+						"id":           "other",
+						"user_agent":   "curl/8.0",
+						"ip":           "198.51.100.2",
+						"created_at":   "2025-03-01T10:00:00Z",
+						"last_seen_at": "2025-03-01T10:00:00Z",
+						"current":      false,
+					},
+				},
+			},
+		},
+		{
+			name:          "success - no sessions",
+			authenticated: true,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("List", ctx, testUserID).Return([]*session.Session{}, nil).Once()
+				return svc
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"data": []any{},
+			},
+		},
+		{
+			name: "error - unauthenticated",
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				return mocks.NewService(t)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Unauthorized",
+				"status":  float64(http.StatusUnauthorized),
+				"code":    "unauthorized",
+				"message": "Invalid token",
+			},
+		},
+		{
+			name:          "error - internal error",
+			authenticated: true,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.Service {
+				svc := mocks.NewService(t)
+				svc.On("List", ctx, testUserID).Return(nil, errors.New("redis error")).Once()
+				return svc
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Internal Server Error",
+				"status":  float64(http.StatusInternalServerError),
+				"code":    "internal_error",
+				"message": "Processing Error",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/self/sessions", nil)
+			ctx := newTestContext(rec, req, tc.authenticated)
+			handler := NewSessionHandler(tc.setupMockSvc(t, ctx))
+
+			handler.ListSessions(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			var body map[string]any
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
+
+func TestSessionHandler_RevokeSession(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		svcErr         error
+		expectedStatus int
+		expectedBody   map[string]any
+	}{
+		{
+			name:           "success",
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]any{
+				"message": "Session revoked",
+			},
+		},
+		{
+			name:           "error - session not found",
+			svcErr:         session.ErrSessionNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Not Found",
+				"status":  float64(http.StatusNotFound),
+				"code":    "not_found",
+				"message": "Session not found",
+			},
+		},
+		{
+			name:           "error - internal error",
+			svcErr:         errors.New("redis error"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody: map[string]any{
+				"type":    "about:blank",
+				"title":   "Internal Server Error",
+				"status":  float64(http.StatusInternalServerError),
+				"code":    "internal_error",
+				"message": "Processing Error",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/v1/self/sessions/other", nil)
+			ctx := newTestContext(rec, req, true)
+			ctx.Params = gin.Params{gin.Param{Key: "id", Value: "other"}}
+			svc := mocks.NewService(t)
+			svc.On("Revoke", ctx, testUserID, "other").Return(tc.svcErr).Once()
+			handler := NewSessionHandler(svc)
+
+			handler.RevokeSession(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			var body map[string]any
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedBody, body)
+		})
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
+	"github.com/luongtruong20201/bookmark-management/internal/utils"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
 	"github.com/luongtruong20201/bookmark-management/pkg/request"
-	"github.com/rs/zerolog/log"
 )
 
 // ShortenURL handles the URL shortening endpoint request. It validates the input,
-// generates a short code for the URL, and returns the shortened URL code.
+// generates a short code for the URL, and returns the shortened URL code. When the request
+// carries a valid bearer token, the code is recorded as owned by the authenticated user.
 // @Summary Shorten URL
 // @Description Create a shortened URL with an optional expiration time (in seconds, max 604800)
 // @Tags url
@@ -17,8 +20,8 @@ import (
 // @Produce json
 // @Param request body urlShortenReq true "URL shortening request"
 // @Success 200 {object} urlShortenRes "Successfully shortened URL"
-// @Failure 400 {object} map[string]string "Invalid request body"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} response.Problem "Invalid request body"
+// @Failure 500 {object} response.Problem "Internal server error"
 // @Router /links/shorten [post]
 func (h *urlShortenHandler) ShortenURL(c *gin.Context) {
 	req, err := request.BindInputFromRequest[urlShortenReq](c)
@@ -26,12 +29,13 @@ func (h *urlShortenHandler) ShortenURL(c *gin.Context) {
 		return
 	}
 
-	code, err := h.svc.ShortenURL(c, req.Url, req.Exp)
+	// The route accepts anonymous requests, so a missing user ID just means no owner.
+	ownerID, _ := utils.GetUserIDFromRequest(c)
+
+	code, err := h.svc.ShortenURL(c, req.Url, req.Exp, ownerID)
 	if err != nil {
-		log.Error().Str("url", req.Url).Err(err).Msg("error when create shorten url")
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "internal server error",
-		})
+		logger.FromContext(c).Error().Str("url", req.Url).Err(err).Msg("error when create shorten url")
+		problems.Error(c, err)
 		return
 	}
 
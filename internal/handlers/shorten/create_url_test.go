@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/luongtruong20201/bookmark-management/internal/services/shorten/mocks"
 	"github.com/stretchr/testify/assert"
 )
@@ -44,7 +45,8 @@ func TestShortenURLHandler_ShortenURL(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedResp: map[string]any{
-				"message": "Input Error",
+				"code":    "invalid_input",
+				"message": "Input error",
 			},
 		},
 		{
@@ -61,12 +63,13 @@ func TestShortenURLHandler_ShortenURL(t *testing.T) {
 			},
 			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.ShortenURL {
 				svc := mocks.NewShortenURL(t)
-				svc.On("ShortenURL", ctx, "https://truonglq.com", 123).Return("", errors.New("failed"))
+				svc.On("ShortenURL", ctx, "https://truonglq.com", 123, "").Return("", errors.New("failed"))
 				return svc
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedResp: map[string]any{
-				"message": "internal server error",
+				"code":    "internal_error",
+				"message": "Processing Error",
 			},
 		},
 		{
@@ -83,7 +86,32 @@ func TestShortenURLHandler_ShortenURL(t *testing.T) {
 			},
 			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.ShortenURL {
 				svc := mocks.NewShortenURL(t)
-				svc.On("ShortenURL", ctx, "https://truonglq.com", 123).Return("1234567", nil).Once()
+				svc.On("ShortenURL", ctx, "https://truonglq.com", 123, "").Return("1234567", nil).Once()
+
+				return svc
+			},
+			expectedStatus: http.StatusOK,
+			expectedResp: map[string]any{
+				"message": "OK",
+				"code":    "1234567",
+			},
+		},
+		{
+			name: "success - authenticated owner",
+			setupRequest: func(c *gin.Context) {
+				body := map[string]any{
+					"url": "https://truonglq.com",
+					"exp": 123,
+				}
+				jsBody, _ := json.Marshal(body)
+				req := httptest.NewRequest(http.MethodPost, "/v1/links/shorten", bytes.NewReader(jsBody))
+				req.Header.Set("Content-Type", "application/json")
+				c.Request = req
+				c.Set("claims", jwt.MapClaims{"sub": "550e8400-e29b-41d4-a716-446655440000"})
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.ShortenURL {
+				svc := mocks.NewShortenURL(t)
+				svc.On("ShortenURL", ctx, "https://truonglq.com", 123, "550e8400-e29b-41d4-a716-446655440000").Return("1234567", nil).Once()
 
 				return svc
 			},
@@ -210,7 +238,7 @@ func TestShortenURLHandler_ShortenURL(t *testing.T) {
 			},
 			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.ShortenURL {
 				svc := mocks.NewShortenURL(t)
-				svc.On("ShortenURL", ctx, "https://truonglq.com", 604800).Return("maxexp01", nil).Once()
+				svc.On("ShortenURL", ctx, "https://truonglq.com", 604800, "").Return("maxexp01", nil).Once()
 
 				return svc
 			},
@@ -234,13 +262,14 @@ func TestShortenURLHandler_ShortenURL(t *testing.T) {
 			},
 			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.ShortenURL {
 				svc := mocks.NewShortenURL(t)
-				svc.On("ShortenURL", ctx, "https://truonglq.com", 123).Return("", errors.New("duplicate key")).Once()
+				svc.On("ShortenURL", ctx, "https://truonglq.com", 123, "").Return("", errors.New("duplicate key")).Once()
 
 				return svc
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedResp: map[string]any{
-				"message": "internal server error",
+				"code":    "internal_error",
+				"message": "Processing Error",
 			},
 		},
 		{
@@ -256,7 +285,8 @@ func TestShortenURLHandler_ShortenURL(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedResp: map[string]any{
-				"message": "Input Error",
+				"code":    "invalid_input",
+				"message": "Input error",
 			},
 		},
 		{
@@ -272,7 +302,8 @@ func TestShortenURLHandler_ShortenURL(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedResp: map[string]any{
-				"message": "Input Error",
+				"code":    "invalid_input",
+				"message": "Input error",
 			},
 		},
 	}
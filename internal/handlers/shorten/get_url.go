@@ -1,17 +1,18 @@
 package shorten
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	service "github.com/luongtruong20201/bookmark-management/internal/services/shorten"
-	"github.com/rs/zerolog/log"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/luongtruong20201/bookmark-management/pkg/metrics"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
 )
 
 // GetURL handles the request to retrieve the original URL from a short code.
 // It extracts the code from the URL parameter, validates it, and redirects to the original URL.
-// If the code is not found, it returns a 400 Bad Request with an error message.
+// If the code is not found, it returns a 404 Not Found problem.
 // If an internal error occurs, it returns a 500 Internal Server Error.
 // On success, it performs a 301 Permanent Redirect to the original URL.
 // @Summary Get original URL by code
@@ -20,34 +21,28 @@ import (
 // @Accept json
 // @Produce json
 // @Param code path string true "Short URL code"
+// @Param X-Read-Primary header bool false "Read from the primary database, to see a bookmark just created"
 // @Success 301 "Permanent redirect to original URL"
-// @Failure 400 {object} map[string]string "Code not found or invalid"
-// @Failure 500 {object} map[string]string "Internal server error"
+// @Failure 400 {object} response.Problem "Missing code"
+// @Failure 404 {object} response.Problem "Code not found"
+// @Failure 500 {object} response.Problem "Internal server error"
 // @Router /v1/links/{code} [get]
 func (s *urlShortenHandler) GetURL(c *gin.Context) {
 	code := c.Param("code")
 	if code == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"message": "unprocessable",
-		})
+		response.Abort(c, response.NewProblem(http.StatusBadRequest, response.CodeInvalidInput, "code is required"))
 		return
 	}
 
 	url, err := s.svc.GetURL(c, code)
 	if err != nil {
-		if errors.Is(err, service.ErrCodeNotFound) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "url not found",
-			})
-			return
+		if problems.IsUnexpected(err) {
+			logger.FromContext(c).Error().Str("code", code).Err(err).Msg("error when get original url from code")
 		}
-
-		log.Error().Str("code", code).Err(err).Msg("error when get original url from code")
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": "internal server error",
-		})
+		problems.Error(c, err)
 		return
 	}
 
+	metrics.RedirectServed()
 	c.Redirect(http.StatusMovedPermanently, url)
 }
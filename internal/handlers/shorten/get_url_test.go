@@ -38,7 +38,11 @@ func TestShortenURLHandler_GetURL(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedResp: map[string]any{
-				"message": "unprocessable",
+				"type":    "about:blank",
+				"title":   "Bad Request",
+				"status":  float64(http.StatusBadRequest),
+				"code":    "invalid_input",
+				"message": "code is required",
 			},
 		},
 		{
@@ -54,8 +58,12 @@ func TestShortenURLHandler_GetURL(t *testing.T) {
 
 				return svc
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusNotFound,
 			expectedResp: map[string]any{
+				"type":    "about:blank",
+				"title":   "Not Found",
+				"status":  float64(http.StatusNotFound),
+				"code":    "not_found",
 				"message": "url not found",
 			},
 		},
@@ -74,7 +82,11 @@ func TestShortenURLHandler_GetURL(t *testing.T) {
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedResp: map[string]any{
-				"message": "internal server error",
+				"type":    "about:blank",
+				"title":   "Internal Server Error",
+				"status":  float64(http.StatusInternalServerError),
+				"code":    "internal_error",
+				"message": "Processing Error",
 			},
 		},
 		{
@@ -120,8 +132,12 @@ func TestShortenURLHandler_GetURL(t *testing.T) {
 
 				return svc
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusNotFound,
 			expectedResp: map[string]any{
+				"type":    "about:blank",
+				"title":   "Not Found",
+				"status":  float64(http.StatusNotFound),
+				"code":    "not_found",
 				"message": "url not found",
 			},
 		},
@@ -138,8 +154,12 @@ func TestShortenURLHandler_GetURL(t *testing.T) {
 
 				return svc
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusNotFound,
 			expectedResp: map[string]any{
+				"type":    "about:blank",
+				"title":   "Not Found",
+				"status":  float64(http.StatusNotFound),
+				"code":    "not_found",
 				"message": "url not found",
 			},
 		},
@@ -156,8 +176,12 @@ func TestShortenURLHandler_GetURL(t *testing.T) {
 
 				return svc
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusNotFound,
 			expectedResp: map[string]any{
+				"type":    "about:blank",
+				"title":   "Not Found",
+				"status":  float64(http.StatusNotFound),
+				"code":    "not_found",
 				"message": "url not found",
 			},
 		},
@@ -4,8 +4,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
 	"github.com/luongtruong20201/bookmark-management/internal/utils"
-	"github.com/luongtruong20201/bookmark-management/pkg/response"
 )
 
 // GetProfile returns the profile information of the currently authenticated user.
@@ -16,19 +16,20 @@ import (
 // @Security BearerAuth
 // @Produce json
 // @Success 200 {object} model.User "User profile"
-// @Failure 401 {object} response.Message "Unauthorized (missing/invalid token)"
-// @Failure 500 {object} response.Message "Internal server error"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 404 {object} response.Problem "User not found"
+// @Failure 500 {object} response.Problem "Internal server error"
 // @Router /v1/self/info [get]
 func (u *user) GetProfile(c *gin.Context) {
 	userId, err := utils.GetUserIDFromRequest(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, response.Message{Message: "Invalid token"})
+		problems.Error(c, err)
 		return
 	}
 
 	res, err := u.svc.GetUserByID(c, userId)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+		problems.Error(c, err)
 		return
 	}
 
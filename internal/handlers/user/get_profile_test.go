@@ -109,7 +109,7 @@ func TestUserHandler_GetProfile(t *testing.T) {
 					Return(nil, dbutils.ErrNotFoundType).Once()
 				return svcMock
 			},
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusNotFound,
 			expectedBody:   nil,
 		},
 		{
@@ -196,7 +196,7 @@ func TestUserHandler_GetProfile(t *testing.T) {
 
 			tc.setupContext(ctx)
 			mockSvc := tc.setupMockSvc(t, ctx, mockUserID)
-			testHandler := NewUser(mockSvc)
+			testHandler := NewUser(mockSvc, nil)
 
 			testHandler.GetProfile(ctx)
 
@@ -227,6 +227,13 @@ func TestUserHandler_GetProfile(t *testing.T) {
 				message, ok := responseBody["message"].(string)
 				assert.True(t, ok)
 				assert.Equal(t, "Processing Error", message)
+			} else if tc.expectedStatus == http.StatusNotFound {
+				var responseBody map[string]interface{}
+				err := json.Unmarshal(rec.Body.Bytes(), &responseBody)
+				assert.NoError(t, err)
+
+				assert.Equal(t, "not_found", responseBody["code"])
+				assert.Equal(t, "Resource not found", responseBody["message"])
 			}
 		})
 	}
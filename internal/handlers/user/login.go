@@ -2,19 +2,26 @@ package user
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
 	service "github.com/luongtruong20201/bookmark-management/internal/services/user"
 	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/luongtruong20201/bookmark-management/pkg/metrics"
 	"github.com/luongtruong20201/bookmark-management/pkg/request"
 	"github.com/luongtruong20201/bookmark-management/pkg/response"
 )
 
 // loginRequestBody represents the request body for user login.
+// The username field accepts either the username or the email address of the account.
 type loginRequestBody struct {
 	Username string `json:"username" binding:"required" example:"johndoe"`
-	Password string `json:"password" binding:"required" example:"password123"`
+	Password string `json:"password" binding:"required,max=128" example:"password123"`
 }
 
 // loginResponseBody represents the response body for successful login.
@@ -24,15 +31,20 @@ type loginResponseBody struct {
 
 // Login handles the user login endpoint request. It validates the credentials,
 // authenticates the user, and returns a JWT token upon successful authentication.
+// Failed attempts are throttled per username and client IP; throttled requests are
+// rejected with 429 and a Retry-After header before the credentials are checked.
 // @Summary User login
-// @Description Authenticate user with username and password, returns JWT token
+// @Description Authenticate user with username or email address and password, returns JWT token. The identifier is matched regardless of letter case.
 // @Tags user
 // @Accept json
 // @Produce json
 // @Param request body loginRequestBody true "User login credentials"
 // @Success 200 {object} loginResponseBody "Successfully authenticated, returns JWT token"
-// @Failure 400 {object} response.Message "Invalid credentials or validation error"
-// @Failure 500 {object} response.Message "Internal server error"
+// @Failure 400 {object} response.Problem "Invalid credentials or validation error"
+// @Failure 403 {object} response.Problem "Account disabled or password reset required"
+// @Failure 429 {object} response.Problem "Too many failed login attempts"
+// @Header 429 {integer} Retry-After "Seconds to wait before trying again"
+// @Failure 500 {object} response.Problem "Internal server error"
 // @Router /v1/users/login [post]
 func (u *user) Login(c *gin.Context) {
 	body, err := request.BindInputFromRequest[loginRequestBody](c)
@@ -40,27 +52,73 @@ func (u *user) Login(c *gin.Context) {
 		return
 	}
 
-	token, err := u.svc.Login(c, body.Username, body.Password)
+	ip := c.ClientIP()
+	if !u.checkLoginLimits(c, ip, body.Username) {
+		return
+	}
+
+	token, err := u.svc.Login(c, body.Username, body.Password, sessionService.Device{
+		UserAgent: c.Request.UserAgent(),
+		IP:        ip,
+	})
 	if err != nil {
 		switch {
-		case errors.Is(err, service.ErrClientErr):
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error(),
-			})
-			return
-		case errors.Is(err, dbutils.ErrNotFoundType):
-			c.JSON(http.StatusBadRequest, response.Message{
-				Message: "invalid username or password",
-			})
-			return
-		case errors.Is(err, nil):
+		case errors.Is(err, service.ErrClientErr), errors.Is(err, dbutils.ErrNotFoundType):
+			// An unknown username is reported like a wrong password.
+			u.recordLoginFailure(c, ip, body.Username)
+			err = service.ErrClientErr
+		case errors.Is(err, service.ErrUserDisabled):
+			metrics.LoginFailed(metrics.LoginFailureDisabled)
+		case errors.Is(err, service.ErrPasswordResetRequired):
+			metrics.LoginFailed(metrics.LoginFailurePasswordReset)
 		default:
-			c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
-			return
+			logger.FromContext(c).Error().Err(err).Str("ip", ip).Msg("failed to log in")
 		}
+		problems.Error(c, err)
+		return
 	}
 
+	u.resetLoginLimits(c, ip, body.Username)
+
 	c.JSON(http.StatusOK, &loginResponseBody{
 		Token: token,
 	})
 }
+
+// checkLoginLimits reports whether the client may attempt to verify the password of the
+// username. Otherwise the request is aborted with 429 and a Retry-After header, or with the
+// error of the limiter.
+func (u *user) checkLoginLimits(c *gin.Context, ip, username string) bool {
+	retryAfter, err := u.limiter.Check(c, ip, username)
+	if err != nil {
+		logger.FromContext(c).Error().Err(err).Str("ip", ip).Msg("failed to check login limits")
+		problems.Error(c, err)
+		return false
+	}
+	if retryAfter > 0 {
+		metrics.LoginFailed(metrics.LoginFailureThrottled)
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		response.Abort(c, response.NewProblem(http.StatusTooManyRequests, response.CodeRateLimited, "too many failed login attempts, please try again later"))
+		return false
+	}
+
+	return true
+}
+
+// resetLoginLimits clears the failed attempts of the username once its password has been
+// verified. Errors are only logged because the request has succeeded.
+func (u *user) resetLoginLimits(c *gin.Context, ip, username string) {
+	if err := u.limiter.Reset(c, username); err != nil {
+		logger.FromContext(c).Error().Err(err).Str("ip", ip).Msg("failed to reset login limits")
+	}
+}
+
+// recordLoginFailure counts a failed login attempt towards the login limits and the
+// login failure metric. Errors are only logged because the
+// client must receive the invalid credentials response either way.
+func (u *user) recordLoginFailure(c *gin.Context, ip, username string) {
+	metrics.LoginFailed(metrics.LoginFailureInvalidCredentials)
+	if err := u.limiter.RecordFailure(c, ip, username); err != nil {
+		logger.FromContext(c).Error().Err(err).Str("ip", ip).Msg("failed to record login failure")
+	}
+}
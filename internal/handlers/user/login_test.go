@@ -7,13 +7,18 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	limiterMocks "github.com/luongtruong20201/bookmark-management/internal/services/loginlimit/mocks"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
 	service "github.com/luongtruong20201/bookmark-management/internal/services/user"
 	"github.com/luongtruong20201/bookmark-management/internal/services/user/mocks"
 	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestUserHandler_Login(t *testing.T) {
@@ -21,6 +26,8 @@ func TestUserHandler_Login(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 
+	device := sessionService.Device{UserAgent: "test-agent", IP: "192.0.2.1"}
+
 	const mockToken = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJzdWIiOiI1NTBlODQwMC1lMjliLTQxZDQtYTcxNi00NDY2NTU0NDAwMDAiLCJpYXQiOjE2MDAwMDAwMDAsImV4cCI6MTYwMDA4NjQwMH0.test"
 
 	var (
@@ -29,11 +36,14 @@ func TestUserHandler_Login(t *testing.T) {
 	)
 
 	testCases := []struct {
-		name           string
-		requestBody    interface{}
-		setupMockSvc   func(t *testing.T, ctx context.Context) *mocks.User
+		name         string
+		requestBody  interface{}
+		setupMockSvc func(t *testing.T, ctx context.Context) *mocks.User
+		// setupLimiter overrides the default limiter, which allows every attempt.
+		setupLimiter   func(t *testing.T, ctx context.Context) *limiterMocks.Limiter
 		expectedStatus int
 		expectedBody   interface{}
+		expectedHeader map[string]string
 	}{
 		{
 			name: "success - valid credentials",
@@ -43,15 +53,139 @@ func TestUserHandler_Login(t *testing.T) {
 			},
 			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
 				svcMock := mocks.NewUser(t)
-				svcMock.On("Login", ctx, "johndoe", "password123").
+				svcMock.On("Login", ctx, "johndoe", "password123", device).
+					Return(mockToken, nil).Once()
+				return svcMock
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: loginResponseBody{
+				Token: mockToken,
+			},
+		},
+		{
+			name: "success - failed attempts are reset",
+			requestBody: loginRequestBody{
+				Username: "johndoe",
+				Password: "password123",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("Login", ctx, "johndoe", "password123", device).
 					Return(mockToken, nil).Once()
 				return svcMock
 			},
+			setupLimiter: func(t *testing.T, ctx context.Context) *limiterMocks.Limiter {
+				limiterMock := limiterMocks.NewLimiter(t)
+				limiterMock.On("Check", ctx, "192.0.2.1", "johndoe").Return(time.Duration(0), nil).Once()
+				limiterMock.On("Reset", ctx, "johndoe").Return(nil).Once()
+				return limiterMock
+			},
 			expectedStatus: http.StatusOK,
 			expectedBody: loginResponseBody{
 				Token: mockToken,
 			},
 		},
+		{
+			name: "error - unknown user records a failed attempt",
+			requestBody: loginRequestBody{
+				Username: "johndoe",
+				Password: "wrongpassword",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("Login", ctx, "johndoe", "wrongpassword", device).
+					Return("", dbutils.ErrNotFoundType).Once()
+				return svcMock
+			},
+			setupLimiter: func(t *testing.T, ctx context.Context) *limiterMocks.Limiter {
+				limiterMock := limiterMocks.NewLimiter(t)
+				limiterMock.On("Check", ctx, "192.0.2.1", "johndoe").Return(time.Duration(0), nil).Once()
+				limiterMock.On("RecordFailure", ctx, "192.0.2.1", "johndoe").Return(errors.New("redis error")).Once()
+				return limiterMock
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name: "error - password too long",
+			requestBody: loginRequestBody{
+				Username: "johndoe",
+				Password: strings.Repeat("a", 129),
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				return mocks.NewUser(t)
+			},
+			setupLimiter: func(t *testing.T, ctx context.Context) *limiterMocks.Limiter {
+				return limiterMocks.NewLimiter(t)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name: "error - too many attempts",
+			requestBody: loginRequestBody{
+				Username: "johndoe",
+				Password: "password123",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				return mocks.NewUser(t)
+			},
+			setupLimiter: func(t *testing.T, ctx context.Context) *limiterMocks.Limiter {
+				limiterMock := limiterMocks.NewLimiter(t)
+				limiterMock.On("Check", ctx, "192.0.2.1", "johndoe").Return(1500*time.Millisecond, nil).Once()
+				return limiterMock
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedBody:   nil,
+			expectedHeader: map[string]string{"Retry-After": "2"},
+		},
+		{
+			name: "error - limiter fails",
+			requestBody: loginRequestBody{
+				Username: "johndoe",
+				Password: "password123",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				return mocks.NewUser(t)
+			},
+			setupLimiter: func(t *testing.T, ctx context.Context) *limiterMocks.Limiter {
+				limiterMock := limiterMocks.NewLimiter(t)
+				limiterMock.On("Check", ctx, "192.0.2.1", "johndoe").Return(time.Duration(0), errors.New("redis error")).Once()
+				return limiterMock
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   nil,
+		},
+		{
+			name: "error - user disabled",
+			requestBody: loginRequestBody{
+				Username: "johndoe",
+				Password: "password123",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("Login", ctx, "johndoe", "password123", device).
+					Return("", service.ErrUserDisabled).Once()
+				return svcMock
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   nil,
+		},
+		{
+			name: "error - password reset required",
+			requestBody: loginRequestBody{
+				Username: "johndoe",
+				Password: "password123",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("Login", ctx, "johndoe", "password123", device).
+					Return("", service.ErrPasswordResetRequired).Once()
+				return svcMock
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   nil,
+		},
 		{
 			name: "invalid request body - missing username",
 			requestBody: loginRequestBody{
@@ -94,7 +228,7 @@ func TestUserHandler_Login(t *testing.T) {
 			},
 			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
 				svcMock := mocks.NewUser(t)
-				svcMock.On("Login", ctx, "johndoe", "wrongpassword").
+				svcMock.On("Login", ctx, "johndoe", "wrongpassword", device).
 					Return("", service.ErrClientErr).Once()
 				return svcMock
 			},
@@ -109,7 +243,7 @@ func TestUserHandler_Login(t *testing.T) {
 			},
 			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
 				svcMock := mocks.NewUser(t)
-				svcMock.On("Login", ctx, "nonexistent", "password123").
+				svcMock.On("Login", ctx, "nonexistent", "password123", device).
 					Return("", dbutils.ErrNotFoundType).Once()
 				return svcMock
 			},
@@ -124,7 +258,7 @@ func TestUserHandler_Login(t *testing.T) {
 			},
 			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
 				svcMock := mocks.NewUser(t)
-				svcMock.On("Login", ctx, "johndoe", "password123").
+				svcMock.On("Login", ctx, "johndoe", "password123", device).
 					Return("", testErrDatabase).Once()
 				return svcMock
 			},
@@ -139,7 +273,7 @@ func TestUserHandler_Login(t *testing.T) {
 			},
 			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
 				svcMock := mocks.NewUser(t)
-				svcMock.On("Login", ctx, "johndoe", "password123").
+				svcMock.On("Login", ctx, "johndoe", "password123", device).
 					Return("", testErrJWT).Once()
 				return svcMock
 			},
@@ -199,12 +333,25 @@ func TestUserHandler_Login(t *testing.T) {
 
 			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/login", bytes.NewBuffer(reqBody))
 			ctx.Request.Header.Set("Content-Type", "application/json")
+			ctx.Request.Header.Set("User-Agent", "test-agent")
 			mockSvc := tc.setupMockSvc(t, ctx)
-			testHandler := NewUser(mockSvc)
+			var mockLimiter *limiterMocks.Limiter
+			if tc.setupLimiter != nil {
+				mockLimiter = tc.setupLimiter(t, ctx)
+			} else {
+				mockLimiter = limiterMocks.NewLimiter(t)
+				mockLimiter.On("Check", ctx, "192.0.2.1", mock.Anything).Return(time.Duration(0), nil).Maybe()
+				mockLimiter.On("RecordFailure", ctx, "192.0.2.1", mock.Anything).Return(nil).Maybe()
+				mockLimiter.On("Reset", ctx, mock.Anything).Return(nil).Maybe()
+			}
+			testHandler := NewUser(mockSvc, mockLimiter)
 
 			testHandler.Login(ctx)
 
 			assert.Equal(t, tc.expectedStatus, rec.Code)
+			for key, value := range tc.expectedHeader {
+				assert.Equal(t, value, rec.Header().Get(key))
+			}
 
 			if tc.expectedBody != nil {
 				var responseBody loginResponseBody
@@ -219,9 +366,8 @@ func TestUserHandler_Login(t *testing.T) {
 				assert.NoError(t, err)
 
 				if tc.name == "error - invalid password" {
-					errorMsg, ok := responseBody["error"].(string)
-					assert.True(t, ok)
-					assert.Contains(t, errorMsg, "invalid username or password")
+					assert.Equal(t, "invalid_credentials", responseBody["code"])
+					assert.Equal(t, "invalid username or password", responseBody["message"])
 				} else if tc.name == "error - user not found" {
 					message, ok := responseBody["message"].(string)
 					assert.True(t, ok)
@@ -0,0 +1,67 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
+	service "github.com/luongtruong20201/bookmark-management/internal/services/user"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/luongtruong20201/bookmark-management/pkg/request"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
+)
+
+// changePasswordRequestBody represents the request body for changing a password.
+type changePasswordRequestBody struct {
+	Username        string `json:"username" binding:"required" example:"johndoe"`
+	CurrentPassword string `json:"current_password" binding:"required,max=128" example:"password123"`
+	NewPassword     string `json:"new_password" binding:"required,max=128" example:"Blue-Otter-Canyon-42"`
+}
+
+// ChangePassword handles the password change endpoint request. It verifies the current
+// credentials and stores the new password. Because it does not require a token, users whose
+// password reset was forced by an administrator can use it before logging in again.
+// As it verifies a password, it is throttled together with Login: failed attempts count
+// towards the limits of the username and client IP.
+// @Summary Change password
+// @Description Change the password of an account using its current credentials; also clears a forced password reset
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body changePasswordRequestBody true "Current credentials and new password"
+// @Success 200 {object} response.Message "Password changed"
+// @Failure 400 {object} response.Problem "Invalid credentials, validation error or password rejected by the password policy"
+// @Failure 403 {object} response.Problem "Account disabled"
+// @Failure 429 {object} response.Problem "Too many failed login attempts"
+// @Header 429 {integer} Retry-After "Seconds to wait before trying again"
+// @Failure 500 {object} response.Problem "Internal server error"
+// @Router /v1/users/password [put]
+func (u *user) ChangePassword(c *gin.Context) {
+	body, err := request.BindInputFromRequest[changePasswordRequestBody](c)
+	if err != nil {
+		return
+	}
+
+	ip := c.ClientIP()
+	if !u.checkLoginLimits(c, ip, body.Username) {
+		return
+	}
+
+	err = u.svc.ChangePassword(c, body.Username, body.CurrentPassword, body.NewPassword)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrClientErr):
+			u.recordLoginFailure(c, ip, body.Username)
+		case problems.IsUnexpected(err):
+			logger.FromContext(c).Error().Err(err).Msg("error when changing password")
+		}
+		problems.Error(c, err)
+		return
+	}
+	u.resetLoginLimits(c, ip, body.Username)
+
+	c.JSON(http.StatusOK, response.Message{
+		Message: "Password changed successfully",
+	})
+}
@@ -0,0 +1,182 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	limiterMocks "github.com/luongtruong20201/bookmark-management/internal/services/loginlimit/mocks"
+	"github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy"
+	service "github.com/luongtruong20201/bookmark-management/internal/services/user"
+	"github.com/luongtruong20201/bookmark-management/internal/services/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserHandler_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	validBody := changePasswordRequestBody{
+		Username:        "johndoe",
+		CurrentPassword: "password123",
+		NewPassword:     "newPassword456",
+	}
+
+	testCases := []struct {
+		name         string
+		requestBody  changePasswordRequestBody
+		setupMockSvc func(t *testing.T, ctx context.Context) *mocks.User
+		// setupLimiter overrides the default limiter, which allows every attempt.
+		setupLimiter    func(t *testing.T, ctx context.Context) *limiterMocks.Limiter
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:        "success",
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("ChangePassword", ctx, "johndoe", "password123", "newPassword456").Return(nil).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "Password changed successfully",
+		},
+		{
+			name:        "success - failed attempts are reset",
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("ChangePassword", ctx, "johndoe", "password123", "newPassword456").Return(nil).Once()
+				return svcMock
+			},
+			setupLimiter: func(t *testing.T, ctx context.Context) *limiterMocks.Limiter {
+				limiterMock := limiterMocks.NewLimiter(t)
+				limiterMock.On("Check", ctx, "192.0.2.1", "johndoe").Return(time.Duration(0), nil).Once()
+				limiterMock.On("Reset", ctx, "johndoe").Return(nil).Once()
+				return limiterMock
+			},
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "Password changed successfully",
+		},
+		{
+			name:        "error - too many attempts",
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				return mocks.NewUser(t)
+			},
+			setupLimiter: func(t *testing.T, ctx context.Context) *limiterMocks.Limiter {
+				limiterMock := limiterMocks.NewLimiter(t)
+				limiterMock.On("Check", ctx, "192.0.2.1", "johndoe").Return(time.Minute, nil).Once()
+				return limiterMock
+			},
+			expectedStatus:  http.StatusTooManyRequests,
+			expectedMessage: "too many failed login attempts, please try again later",
+		},
+		{
+			name: "error - missing new password",
+			requestBody: changePasswordRequestBody{
+				Username:        "johndoe",
+				CurrentPassword: "password123",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				return mocks.NewUser(t)
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "Input error",
+		},
+		{
+			name:        "error - new password rejected by policy",
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("ChangePassword", ctx, "johndoe", "password123", "newPassword456").
+					Return(&passwordpolicy.RejectedError{Violations: []passwordpolicy.Violation{
+						{Code: passwordpolicy.ViolationTooWeak, Message: "password is too easy to guess"},
+					}}).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "password does not meet the password policy",
+		},
+		{
+			name:        "error - invalid credentials records a failed attempt",
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("ChangePassword", ctx, "johndoe", "password123", "newPassword456").Return(service.ErrClientErr).Once()
+				return svcMock
+			},
+			setupLimiter: func(t *testing.T, ctx context.Context) *limiterMocks.Limiter {
+				limiterMock := limiterMocks.NewLimiter(t)
+				limiterMock.On("Check", ctx, "192.0.2.1", "johndoe").Return(time.Duration(0), nil).Once()
+				limiterMock.On("RecordFailure", ctx, "192.0.2.1", "johndoe").Return(nil).Once()
+				return limiterMock
+			},
+			expectedStatus:  http.StatusBadRequest,
+			expectedMessage: "invalid username or password",
+		},
+		{
+			name:        "error - user disabled",
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("ChangePassword", ctx, "johndoe", "password123", "newPassword456").Return(service.ErrUserDisabled).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusForbidden,
+			expectedMessage: "user account is disabled",
+		},
+		{
+			name:        "error - internal error",
+			requestBody: validBody,
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("ChangePassword", ctx, "johndoe", "password123", "newPassword456").Return(errors.New("database error")).Once()
+				return svcMock
+			},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedMessage: "Processing Error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(rec)
+
+			reqBody, err := json.Marshal(tc.requestBody)
+			assert.NoError(t, err)
+			ctx.Request = httptest.NewRequest(http.MethodPut, "/v1/users/password", bytes.NewBuffer(reqBody))
+			ctx.Request.Header.Set("Content-Type", "application/json")
+
+			mockSvc := tc.setupMockSvc(t, ctx)
+			var mockLimiter *limiterMocks.Limiter
+			if tc.setupLimiter != nil {
+				mockLimiter = tc.setupLimiter(t, ctx)
+			} else {
+				mockLimiter = limiterMocks.NewLimiter(t)
+				mockLimiter.On("Check", ctx, "192.0.2.1", mock.Anything).Return(time.Duration(0), nil).Maybe()
+				mockLimiter.On("Reset", ctx, mock.Anything).Return(nil).Maybe()
+			}
+			handler := NewUser(mockSvc, mockLimiter)
+			handler.ChangePassword(ctx)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			var body map[string]any
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedMessage, body["message"])
+		})
+	}
+}
@@ -7,10 +7,12 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy"
 	"github.com/luongtruong20201/bookmark-management/internal/services/user/mocks"
 	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
 	"github.com/stretchr/testify/assert"
@@ -92,7 +94,37 @@ func TestUserHandler_RegisterUser(t *testing.T) {
 			expectedBody:   nil,
 		},
 		{
-			name: "invalid request body - password too short",
+			name: "invalid request body - username containing @",
+			requestBody: createUserInputBody{
+				Username:    "jane.doe@example.com",
+				Password:    "password123",
+				DisplayName: "John Doe",
+				Email:       "john.doe@example.com",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				return svcMock
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name: "invalid request body - password too long",
+			requestBody: createUserInputBody{
+				Username:    "johndoe",
+				Password:    strings.Repeat("a", 129),
+				DisplayName: "John Doe",
+				Email:       "john.doe@example.com",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				return svcMock
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   nil,
+		},
+		{
+			name: "password rejected by policy",
 			requestBody: createUserInputBody{
 				Username:    "johndoe",
 				Password:    "12345",
@@ -101,6 +133,10 @@ func TestUserHandler_RegisterUser(t *testing.T) {
 			},
 			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
 				svcMock := mocks.NewUser(t)
+				svcMock.On("CreateUser", ctx, "johndoe", "12345", "John Doe", "john.doe@example.com").
+					Return(nil, &passwordpolicy.RejectedError{Violations: []passwordpolicy.Violation{
+						{Code: passwordpolicy.ViolationTooShort, Message: "password must be at least 8 characters long"},
+					}}).Once()
 				return svcMock
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -147,8 +183,42 @@ func TestUserHandler_RegisterUser(t *testing.T) {
 					Return(nil, dbutils.ErrDuplicationType).Once()
 				return svcMock
 			},
-			expectedStatus: http.StatusBadRequest,
-			expectedBody:   nil,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "username or email already taken",
+		},
+		{
+			name: "duplicate username",
+			requestBody: createUserInputBody{
+				Username:    "johndoe",
+				Password:    "password123",
+				DisplayName: "John Doe",
+				Email:       "john.doe@example.com",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("CreateUser", ctx, "johndoe", "password123", "John Doe", "john.doe@example.com").
+					Return(nil, &dbutils.ConstraintError{Kind: dbutils.ErrDuplicationType, Constraint: "uni_users_username_lower"}).Once()
+				return svcMock
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "username already taken",
+		},
+		{
+			name: "duplicate email",
+			requestBody: createUserInputBody{
+				Username:    "johndoe",
+				Password:    "password123",
+				DisplayName: "John Doe",
+				Email:       "john.doe@example.com",
+			},
+			setupMockSvc: func(t *testing.T, ctx context.Context) *mocks.User {
+				svcMock := mocks.NewUser(t)
+				svcMock.On("CreateUser", ctx, "johndoe", "password123", "John Doe", "john.doe@example.com").
+					Return(nil, &dbutils.ConstraintError{Kind: dbutils.ErrDuplicationType, Table: "users", Column: "email"}).Once()
+				return svcMock
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "email already taken",
 		},
 		{
 			name: "invalid request body - missing display name",
@@ -181,7 +251,7 @@ func TestUserHandler_RegisterUser(t *testing.T) {
 			expectedBody:   nil,
 		},
 		{
-			name: "invalid request body - password exactly 6 characters (boundary)",
+			name: "success - password length is checked by the service",
 			requestBody: createUserInputBody{
 				Username:    "johndoe",
 				Password:    "123456",
@@ -262,13 +332,13 @@ func TestUserHandler_RegisterUser(t *testing.T) {
 			ctx.Request = httptest.NewRequest(http.MethodPost, "/v1/users/register", bytes.NewBuffer(reqBody))
 			ctx.Request.Header.Set("Content-Type", "application/json")
 			mockSvc := tc.setupMockSvc(t, ctx)
-			testHandler := NewUser(mockSvc)
+			testHandler := NewUser(mockSvc, nil)
 
 			testHandler.RegisterUser(ctx)
 
 			assert.Equal(t, tc.expectedStatus, rec.Code)
 
-			if tc.expectedBody != nil {
+			if tc.expectedStatus == http.StatusOK && tc.expectedBody != nil {
 				var responseBody map[string]interface{}
 				err := json.Unmarshal(rec.Body.Bytes(), &responseBody)
 				assert.NoError(t, err)
@@ -283,15 +353,24 @@ func TestUserHandler_RegisterUser(t *testing.T) {
 				assert.Equal(t, expectedUser.Username, data["username"])
 				assert.Equal(t, expectedUser.DisplayName, data["display_name"])
 				assert.Equal(t, expectedUser.Email, data["email"])
+			} else if tc.expectedStatus == http.StatusConflict {
+				var responseBody map[string]interface{}
+				err := json.Unmarshal(rec.Body.Bytes(), &responseBody)
+				assert.NoError(t, err)
+
+				assert.Equal(t, "conflict", responseBody["code"])
+				assert.Equal(t, tc.expectedBody, responseBody["message"])
 			} else if tc.expectedStatus == http.StatusBadRequest {
 				var responseBody map[string]interface{}
 				err := json.Unmarshal(rec.Body.Bytes(), &responseBody)
 				assert.NoError(t, err)
 
-				if tc.name == "duplicate username or email" {
-					message, ok := responseBody["message"].(string)
-					assert.True(t, ok)
-					assert.Equal(t, "username or email already taken", message)
+				if tc.name == "password rejected by policy" {
+					assert.Equal(t, "password does not meet the password policy", responseBody["message"])
+					assert.Equal(t, []any{map[string]any{
+						"code":    "too_short",
+						"message": "password must be at least 8 characters long",
+					}}, responseBody["details"])
 				} else {
 					_, hasMessage := responseBody["message"]
 					assert.True(t, hasMessage, "Bad request should have message field")
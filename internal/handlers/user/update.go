@@ -5,11 +5,12 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/handlers/problems"
 	"github.com/luongtruong20201/bookmark-management/internal/utils"
 	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
 	"github.com/luongtruong20201/bookmark-management/pkg/request"
 	"github.com/luongtruong20201/bookmark-management/pkg/response"
-	"github.com/rs/zerolog/log"
 )
 
 // updateProfileRequestBody represents the request body for updating user profile.
@@ -28,14 +29,15 @@ type updateProfileRequestBody struct {
 // @Produce json
 // @Param request body updateProfileRequestBody true "User profile update request"
 // @Success 200 {object} model.User "Updated user profile"
-// @Failure 400 {object} response.Message "Invalid request body or validation error"
-// @Failure 401 {object} response.Message "Unauthorized (missing/invalid token)"
-// @Failure 500 {object} response.Message "Internal server error"
+// @Failure 400 {object} response.Problem "Invalid request body or validation error"
+// @Failure 401 {object} response.Problem "Unauthorized (missing/invalid token)"
+// @Failure 409 {object} response.Problem "Username or email already taken"
+// @Failure 500 {object} response.Problem "Internal server error"
 // @Router /v1/self/info [put]
 func (u *user) UpdateProfile(c *gin.Context) {
 	userId, err := utils.GetUserIDFromRequest(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, response.Message{Message: "Invalid token"})
+		problems.Error(c, err)
 		return
 	}
 
@@ -48,15 +50,13 @@ func (u *user) UpdateProfile(c *gin.Context) {
 	if err != nil {
 		switch {
 		case errors.Is(err, dbutils.ErrDuplicationType):
-			c.JSON(http.StatusBadRequest, response.Message{
-				Message: "username or email already taken",
-			})
-			return
-		default:
-			log.Error().Err(err).Msg("error when updating user profile")
-			c.JSON(http.StatusInternalServerError, response.InternalErrResponse)
+			response.Abort(c, duplicateIdentityProblem(err))
 			return
+		case problems.IsUnexpected(err):
+			logger.FromContext(c).Error().Err(err).Msg("error when updating user profile")
 		}
+		problems.Error(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, res)
@@ -117,7 +117,7 @@ func TestUserHandler_UpdateProfile(t *testing.T) {
 					Return(nil, dbutils.ErrDuplicationType).Once()
 				return svcMock
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusConflict,
 		},
 		{
 			name: "error - service returns internal error",
@@ -189,7 +189,7 @@ func TestUserHandler_UpdateProfile(t *testing.T) {
 
 			tc.setupContext(ctx)
 			mockSvc := tc.setupMockSvc(t, ctx, mockUserID)
-			testHandler := NewUser(mockSvc)
+			testHandler := NewUser(mockSvc, nil)
 
 			testHandler.UpdateProfile(ctx)
 
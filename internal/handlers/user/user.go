@@ -1,8 +1,14 @@
 package user
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/services/loginlimit"
 	service "github.com/luongtruong20201/bookmark-management/internal/services/user"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
 )
 
 // User defines the interface for user handlers.
@@ -23,17 +29,51 @@ type User interface {
 	// UpdateProfile updates the profile information (display name and email) of the currently authenticated user.
 	// The user ID is extracted from the JWT token claims in the request context.
 	UpdateProfile(c *gin.Context)
+	// ChangePassword replaces the password of a user after verifying the current one.
+	// It is also used to complete a password reset forced by an administrator.
+	ChangePassword(c *gin.Context)
 }
 
 // user implements the User interface and provides HTTP handlers for user operations.
-// It encapsulates the user service dependency for business logic execution.
+// It encapsulates the user service dependency for business logic execution
+// and the limiter that throttles failed login attempts.
 type user struct {
-	svc service.User
+	svc     service.User
+	limiter loginlimit.Limiter
 }
 
-// NewUser creates a new user handler with the provided user service.
-func NewUser(svc service.User) User {
+// NewUser creates a new user handler with the provided user service and login limiter.
+func NewUser(svc service.User, limiter loginlimit.Limiter) User {
 	return &user{
-		svc: svc,
+		svc:     svc,
+		limiter: limiter,
+	}
+}
+
+// identityConstraints maps the unique constraints and columns of the users table to the
+// identity they guard, so a duplicate can be reported for the identity that is taken.
+var identityConstraints = map[string]string{
+	"uni_users_username_lower": "username",
+	"username":                 "username",
+	"uni_users_email_lower":    "email",
+	"email":                    "email",
+}
+
+// duplicateIdentityProblem returns the 409 problem reporting which identity of a user,
+// the username or the email, is already taken by another account.
+func duplicateIdentityProblem(err error) *response.Problem {
+	message := "username or email already taken"
+
+	var constraintErr *dbutils.ConstraintError
+	if errors.As(err, &constraintErr) {
+		identity, ok := identityConstraints[constraintErr.Constraint]
+		if !ok {
+			identity, ok = identityConstraints[constraintErr.Column]
+		}
+		if ok {
+			message = identity + " already taken"
+		}
 	}
+
+	return response.NewProblem(http.StatusConflict, response.CodeConflict, message)
 }
@@ -3,7 +3,14 @@ package infrastructure
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/luongtruong20201/bookmark-management/internal/api"
+	"github.com/luongtruong20201/bookmark-management/internal/api/middlewares"
+	"github.com/luongtruong20201/bookmark-management/internal/services/account"
+	"github.com/luongtruong20201/bookmark-management/internal/services/healthcheck"
+	"github.com/luongtruong20201/bookmark-management/internal/services/loginlimit"
+	"github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy"
+	"github.com/luongtruong20201/bookmark-management/internal/services/session"
 	"github.com/luongtruong20201/bookmark-management/pkg/common"
+	"github.com/luongtruong20201/bookmark-management/pkg/utils"
 )
 
 func CreateAPIConfig() *api.Config {
@@ -12,19 +19,77 @@ func CreateAPIConfig() *api.Config {
 	return cfg
 }
 
+func CreateLoginLimitConfig() *loginlimit.Config {
+	cfg, err := loginlimit.NewConfig()
+	common.HandleError(err)
+	return cfg
+}
+
+func CreateRateLimitConfig() *middlewares.RateLimitConfig {
+	cfg, err := middlewares.NewRateLimitConfig()
+	common.HandleError(err)
+	return cfg
+}
+
+func CreateAccountConfig() *account.Config {
+	cfg, err := account.NewConfig()
+	common.HandleError(err)
+	return cfg
+}
+
+func CreateSessionConfig() *session.Config {
+	cfg, err := session.NewConfig()
+	common.HandleError(err)
+	return cfg
+}
+
+func CreatePasswordPolicyConfig() *passwordpolicy.Config {
+	cfg, err := passwordpolicy.NewConfig()
+	common.HandleError(err)
+	return cfg
+}
+
+func CreatePasswordHashConfig() *utils.HashConfig {
+	cfg, err := utils.NewHashConfig()
+	common.HandleError(err)
+	return cfg
+}
+
+func CreateHealthCheckConfig() *healthcheck.Config {
+	cfg, err := healthcheck.NewConfig()
+	common.HandleError(err)
+	return cfg
+}
+
 func CreateAPI() api.Engine {
 	cfg := CreateAPIConfig()
 	redis := CreateRedis()
 	jwtGennerator, jwtValidator := CreateJWTProvider()
 	db := CreateSqlDBAndMigrate()
+	oidcProviders := CreateOIDCProviders()
+	loginLimit := CreateLoginLimitConfig()
+	rateLimit := CreateRateLimitConfig()
+	accountCfg := CreateAccountConfig()
+	sessionCfg := CreateSessionConfig()
+	passwordPolicyCfg := CreatePasswordPolicyConfig()
+	passwordHashCfg := CreatePasswordHashConfig()
+	healthCheckCfg := CreateHealthCheckConfig()
 	engine := gin.New()
 
 	return api.New(&api.EngineOpts{
-		Engine:       engine,
-		Cfg:          cfg,
-		Redis:        redis,
-		DB:           db,
-		JWTGenerator: jwtGennerator,
-		JWTValidator: jwtValidator,
+		Engine:         engine,
+		Cfg:            cfg,
+		Redis:          redis,
+		DB:             db,
+		JWTGenerator:   jwtGennerator,
+		JWTValidator:   jwtValidator,
+		OIDCProviders:  oidcProviders,
+		LoginLimit:     loginLimit,
+		RateLimit:      rateLimit,
+		Account:        accountCfg,
+		Session:        sessionCfg,
+		PasswordPolicy: passwordPolicyCfg,
+		PasswordHash:   passwordHashCfg,
+		HealthCheck:    healthCheckCfg,
 	})
 }
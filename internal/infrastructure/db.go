@@ -1,17 +1,36 @@
 package infrastructure
 
 import (
+	"context"
+
 	"github.com/luongtruong20201/bookmark-management/pkg/common"
 	sqldb "github.com/luongtruong20201/bookmark-management/pkg/sql"
 	"gorm.io/gorm"
 )
 
-func CreateSqlDBAndMigrate() *gorm.DB {
+func CreateSqlDB() *gorm.DB {
 	db, err := sqldb.NewClient("")
 	common.HandleError(err)
 
-	err = MigrateSQLDB(db, "file://./migrations", "up", 0)
+	return db
+}
+
+// CreateSqlDBAndMigrate connects to the database and applies the pending migrations,
+// unless DB_AUTO_MIGRATE disables it because the migrate command runs them separately.
+// It refuses to start when the schema is ahead of the migrations of the binary, as a
+// rolled back service would run against a schema it does not know.
+func CreateSqlDBAndMigrate() *gorm.DB {
+	db := CreateSqlDB()
+
+	cfg := CreateMigrationConfig()
+	migrator, err := CreateMigrator(context.Background(), db, cfg.Path)
 	common.HandleError(err)
+	defer migrator.Close()
+
+	common.HandleError(migrator.CheckVersion())
+	if cfg.AutoMigrate {
+		common.HandleError(migrator.Up())
+	}
 
 	return db
 }
@@ -5,12 +5,14 @@ import (
 	"github.com/luongtruong20201/bookmark-management/pkg/jwt"
 )
 
+// CreateJWTProvider creates the JWT generator and validator from the signing and
+// verification keys configured in the environment, see jwt.Config.
 func CreateJWTProvider() (jwt.JWTGenerator, jwt.JWTValidator) {
-	jwtGenerator, err := jwt.NewJWTGenerator("./private.pem")
+	cfg, err := jwt.NewConfig()
 	common.HandleError(err)
 
-	jwtValidator, err := jwt.NewJWTValidator("./public.pem")
+	keySet, err := jwt.LoadKeySet(cfg)
 	common.HandleError(err)
 
-	return jwtGenerator, jwtValidator
+	return jwt.NewKeySetGenerator(keySet, cfg), jwt.NewKeySetValidator(keySet, cfg)
 }
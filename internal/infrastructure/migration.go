@@ -1,54 +1,80 @@
 package infrastructure
 
 import (
+	"context"
+	"database/sql"
 	"errors"
-	"fmt"
 
-	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-	_ "github.com/lib/pq"
+	"github.com/luongtruong20201/bookmark-management/migrations"
+	"github.com/luongtruong20201/bookmark-management/pkg/common"
+	"github.com/luongtruong20201/bookmark-management/pkg/migration"
 	"gorm.io/gorm"
 )
 
-func MigrateSQLDB(db *gorm.DB, migrationPath string, mode string, steps int) error {
+// CreateMigrationConfig loads the migration settings from the environment, see
+// migration.Config.
+func CreateMigrationConfig() *migration.Config {
+	cfg, err := migration.NewConfig()
+	common.HandleError(err)
+	return cfg
+}
+
+// CreateMigrator creates a Migrator running the migrations found at the source URL on the
+// database, or the migrations embedded in the binary when the URL is empty. It holds a
+// single connection of the pool; closing the Migrator releases the connection and leaves
+// the pool open. The statement timeout of the pool is disabled on that connection, as a
+// migration may run longer, and restored before the connection returns to the pool.
+func CreateMigrator(ctx context.Context, db *gorm.DB, sourceURL string) (migration.Migrator, error) {
 	sqlDB, err := db.DB()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	postgresDriver, err := postgres.WithInstance(sqlDB, &postgres.Config{})
+	src, err := migration.OpenSource(sourceURL, migrations.FS)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	m, err := migrate.NewWithDatabaseInstance(migrationPath, db.Name(), postgresDriver)
+	conn, err := sqlDB.Conn(ctx)
 	if err != nil {
-		return err
+		src.Close()
+		return nil, err
 	}
 
-	return migrateSchema(m, mode, steps)
-}
-
-func migrateSchema(m *migrate.Migrate, mode string, steps int) error {
-	var migrationErr error
-
-	switch mode {
-	case "up":
-		migrationErr = m.Up()
-	case "steps":
-		if steps == 0 {
-			return errors.New("[Database migration] Steps must not be 0. Please use a positive number to migrate up, a negative number to migrate down.")
-		}
-		migrationErr = m.Steps(steps)
-	default:
-		return errors.New("[Database migration] Invalid mode. Please use 'up' or 'steps'.")
+	if _, err := conn.ExecContext(ctx, "SET statement_timeout = 0"); err != nil {
+		src.Close()
+		conn.Close()
+		return nil, err
 	}
 
-	if migrationErr != nil && !errors.Is(migrationErr, migrate.ErrNoChange) {
-		return fmt.Errorf("[Database migration] Error: %s", migrationErr.Error())
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		src.Close()
+		resetStatementTimeout(conn)
+		conn.Close()
+		return nil, err
 	}
 
-	return nil
+	return migration.New(src, &connDriver{Driver: driver, conn: conn})
+}
+
+// connDriver restores the statement timeout of the connection held by a database driver
+// before closing it.
+type connDriver struct {
+	database.Driver
+	conn *sql.Conn
+}
+
+// Close restores the statement timeout of the connection, then releases it.
+func (d *connDriver) Close() error {
+	return errors.Join(resetStatementTimeout(d.conn), d.Driver.Close())
+}
 
+// resetStatementTimeout restores the statement timeout of the connection to the value of
+// the pool settings.
+func resetStatementTimeout(conn *sql.Conn) error {
+	_, err := conn.ExecContext(context.Background(), "RESET statement_timeout")
+	return err
 }
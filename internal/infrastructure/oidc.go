@@ -0,0 +1,15 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/luongtruong20201/bookmark-management/pkg/common"
+	"github.com/luongtruong20201/bookmark-management/pkg/oidc"
+)
+
+func CreateOIDCProviders() map[string]oidc.Provider {
+	providers, err := oidc.NewProviders(context.Background())
+	common.HandleError(err)
+
+	return providers
+}
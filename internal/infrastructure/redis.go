@@ -6,7 +6,7 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-func CreateRedis() *redis.Client {
+func CreateRedis() redis.UniversalClient {
 	redis, err := redisPkg.NewClient("")
 	common.HandleError(err)
 
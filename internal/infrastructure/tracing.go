@@ -0,0 +1,20 @@
+package infrastructure
+
+import (
+	"context"
+
+	"github.com/luongtruong20201/bookmark-management/pkg/common"
+	"github.com/luongtruong20201/bookmark-management/pkg/tracing"
+)
+
+// CreateTracing installs the global tracer provider with the exporter configured in the
+// environment, see tracing.Config. The returned function flushes the pending spans.
+func CreateTracing(ctx context.Context) func(context.Context) error {
+	cfg, err := tracing.NewConfig()
+	common.HandleError(err)
+
+	shutdown, err := tracing.Setup(ctx, cfg)
+	common.HandleError(err)
+
+	return shutdown
+}
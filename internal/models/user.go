@@ -2,20 +2,39 @@
 // It defines the structure of domain objects and their database mappings.
 package model
 
+import "time"
+
+const (
+	// RoleUser is the default role granted to every registered account.
+	RoleUser = "user"
+	// RoleAdmin grants access to the administration endpoints.
+	RoleAdmin = "admin"
+)
+
 // User represents a user entity in the system.
 // It contains user identification, authentication, and profile information.
 // The struct is mapped to the "users" table in the database using GORM tags.
 //
 // Fields:
 //   - ID: Unique identifier (UUID) for the user, automatically generated if not provided
-//   - Username: Unique username for login and identification
+//   - Username: Username for login and identification, unique regardless of letter case
 //   - Password: Bcrypt-hashed password (excluded from JSON responses for security)
 //   - DisplayName: User's display name shown in the application
-//   - Email: Unique email address for the user account
+//   - Email: Email address of the user account, also accepted for login and unique regardless of letter case
+//   - EmailVerified: Whether the email address has been verified, which an external identity provider requires to sign in to the account
+//   - Role: Authorization role of the user (RoleUser or RoleAdmin), carried in the JWT claims
+//   - Disabled: Whether the account has been disabled by an administrator
+//   - PasswordResetRequired: Whether the user must change the password before logging in again
+//   - DeletionScheduledAt: When the account will be purged, set while a deletion request is in its grace period
 type User struct {
 	Base
-	Username    string `gorm:"unique;column:username" json:"username"`
-	Password    string `gorm:"column:password" json:"-"`
-	DisplayName string `gorm:"column:display_name" json:"display_name"`
-	Email       string `gorm:"column:email;unique" json:"email"`
+	Username              string     `gorm:"column:username;uniqueIndex:uni_users_username_lower,expression:lower(username)" json:"username"`
+	Password              string     `gorm:"column:password" json:"-"`
+	DisplayName           string     `gorm:"column:display_name" json:"display_name"`
+	Email                 string     `gorm:"column:email;uniqueIndex:uni_users_email_lower,expression:lower(email)" json:"email"`
+	EmailVerified         bool       `gorm:"column:email_verified;default:false" json:"email_verified"`
+	Role                  string     `gorm:"column:role;default:user" json:"role"`
+	Disabled              bool       `gorm:"column:disabled;default:false" json:"disabled"`
+	PasswordResetRequired bool       `gorm:"column:password_reset_required;default:false" json:"password_reset_required"`
+	DeletionScheduledAt   *time.Time `gorm:"column:deletion_scheduled_at" json:"deletion_scheduled_at,omitempty"`
 }
@@ -14,7 +14,9 @@ import (
 type Repository interface {
 	CreateBookmark(ctx context.Context, bookmark *model.Bookmark) (*model.Bookmark, error)
 	GetBookmarks(ctx context.Context, userID string, offset, limit int) ([]*model.Bookmark, error)
+	GetAllBookmarks(ctx context.Context, userID string) ([]*model.Bookmark, error)
 	CountBookmarks(ctx context.Context, userID string) (int64, error)
+	CountAllBookmarks(ctx context.Context) (int64, error)
 	UpdateBookmark(ctx context.Context, bookmarkID, userID string, updates *model.Bookmark) (*model.Bookmark, error)
 	DeleteBookmark(ctx context.Context, bookmarkID, userID string) error
 	GetBookmarkByCode(ctx context.Context, code string) (*model.Bookmark, error)
@@ -2,7 +2,6 @@ package bookmark
 
 import (
 	"context"
-	"strings"
 	"testing"
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
@@ -103,13 +102,7 @@ func TestRepository_CreateBookmark(t *testing.T) {
 			res, err := repo.CreateBookmark(ctx, tc.inputBookmark)
 
 			if tc.expectedError != nil {
-				assert.Error(t, err)
-
-				if err != tc.expectedError {
-					errStr := strings.ToLower(err.Error())
-					assert.True(t, strings.Contains(errStr, "unique constraint") || err == dbutils.ErrDuplicationType,
-						"expected duplicate constraint error, got: %v", err)
-				}
+				assert.ErrorIs(t, err, tc.expectedError)
 				assert.Nil(t, res)
 			} else {
 				assert.NoError(t, err)
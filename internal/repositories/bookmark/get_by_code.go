@@ -5,14 +5,20 @@ import (
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
 	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	sqldb "github.com/luongtruong20201/bookmark-management/pkg/sql"
+	"gorm.io/gorm"
 )
 
 // GetBookmarkByCode retrieves a bookmark by its code from the database.
 // It returns the bookmark if found, or dbutils.ErrNotFoundType if the code does not exist.
 // Database errors are wrapped using dbutils.CatchDBErr for normalized error handling.
+// It reads from a replica unless the context forces the primary, see sqldb.Read.
 func (r *repository) GetBookmarkByCode(ctx context.Context, code string) (*model.Bookmark, error) {
 	var bookmark model.Bookmark
-	if err := r.db.WithContext(ctx).Where("code = ?", code).First(&bookmark).Error; err != nil {
+	err := sqldb.Read(ctx, r.db, func(tx *gorm.DB) error {
+		return tx.Where("code = ?", code).First(&bookmark).Error
+	})
+	if err != nil {
 		return nil, dbutils.CatchDBErr(err)
 	}
 
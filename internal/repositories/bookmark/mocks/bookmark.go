@@ -14,6 +14,34 @@ type Repository struct {
 	mock.Mock
 }
 
+// CountAllBookmarks provides a mock function with given fields: ctx
+func (_m *Repository) CountAllBookmarks(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountAllBookmarks")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountBookmarks provides a mock function with given fields: ctx, userID
 func (_m *Repository) CountBookmarks(ctx context.Context, userID string) (int64, error) {
 	ret := _m.Called(ctx, userID)
@@ -90,6 +118,36 @@ func (_m *Repository) DeleteBookmark(ctx context.Context, bookmarkID string, use
 	return r0
 }
 
+// GetAllBookmarks provides a mock function with given fields: ctx, userID
+func (_m *Repository) GetAllBookmarks(ctx context.Context, userID string) ([]*model.Bookmark, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllBookmarks")
+	}
+
+	var r0 []*model.Bookmark
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.Bookmark, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.Bookmark); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Bookmark)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBookmarkByCode provides a mock function with given fields: ctx, code
 func (_m *Repository) GetBookmarkByCode(ctx context.Context, code string) (*model.Bookmark, error) {
 	ret := _m.Called(ctx, code)
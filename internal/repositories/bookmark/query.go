@@ -4,11 +4,14 @@ import (
 	"context"
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	sqldb "github.com/luongtruong20201/bookmark-management/pkg/sql"
+	"gorm.io/gorm"
 )
 
 // GetBookmarks retrieves bookmarks for a specific user with pagination support.
 // It queries the database for bookmarks filtered by userID, ordered by creation date (ascending),
-// and applies offset and limit for pagination.
+// and applies offset and limit for pagination. It reads from a replica unless the context
+// forces the primary, see sqldb.Read.
 //
 // Parameters:
 //   - ctx: Context for database operation cancellation and timeout
@@ -20,12 +23,36 @@ import (
 //   - []*model.Bookmark: A slice of bookmarks for the user, or nil if an error occurs
 //   - error: A database error if the query fails
 func (r *repository) GetBookmarks(ctx context.Context, userID string, offset, limit int) ([]*model.Bookmark, error) {
+	bookmarks := make([]*model.Bookmark, 0)
+	err := sqldb.Read(ctx, r.db, func(tx *gorm.DB) error {
+		return tx.Where("user_id = ?", userID).
+			Order("created_at ASC").
+			Offset(offset).
+			Limit(limit).
+			Find(&bookmarks).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bookmarks, nil
+}
+
+// GetAllBookmarks retrieves every bookmark of a specific user, ordered by creation date
+// (ascending). It is meant for exports, where the full list is needed at once.
+//
+// Parameters:
+//   - ctx: Context for database operation cancellation and timeout
+//   - userID: The unique identifier of the user whose bookmarks to retrieve
+//
+// Returns:
+//   - []*model.Bookmark: All bookmarks of the user, or nil if an error occurs
+//   - error: A database error if the query fails
+func (r *repository) GetAllBookmarks(ctx context.Context, userID string) ([]*model.Bookmark, error) {
 	bookmarks := make([]*model.Bookmark, 0)
 	if err := r.db.WithContext(ctx).
 		Where("user_id = ?", userID).
 		Order("created_at ASC").
-		Offset(offset).
-		Limit(limit).
 		Find(&bookmarks).Error; err != nil {
 		return nil, err
 	}
@@ -34,7 +61,8 @@ func (r *repository) GetBookmarks(ctx context.Context, userID string, offset, li
 }
 
 // CountBookmarks counts the total number of bookmarks for a specific user.
-// It queries the database to get the total count of bookmarks filtered by userID.
+// It queries the database to get the total count of bookmarks filtered by userID, on a
+// replica unless the context forces the primary.
 //
 // Parameters:
 //   - ctx: Context for database operation cancellation and timeout
@@ -44,10 +72,31 @@ func (r *repository) GetBookmarks(ctx context.Context, userID string, offset, li
 //   - int64: The total number of bookmarks for the user, or 0 if an error occurs
 //   - error: A database error if the count query fails
 func (r *repository) CountBookmarks(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	err := sqldb.Read(ctx, r.db, func(tx *gorm.DB) error {
+		return tx.Model(&model.Bookmark{}).
+			Where("user_id = ?", userID).
+			Count(&count).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// CountAllBookmarks counts the bookmarks of all users in the system.
+//
+// Parameters:
+//   - ctx: Context for database operation cancellation and timeout
+//
+// Returns:
+//   - int64: The total number of bookmarks, or 0 if an error occurs
+//   - error: A database error if the count query fails
+func (r *repository) CountAllBookmarks(ctx context.Context) (int64, error) {
 	var count int64
 	if err := r.db.WithContext(ctx).
 		Model(&model.Bookmark{}).
-		Where("user_id = ?", userID).
 		Count(&count).Error; err != nil {
 		return 0, err
 	}
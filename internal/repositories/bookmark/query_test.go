@@ -7,6 +7,7 @@ import (
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
 	"github.com/luongtruong20201/bookmark-management/internal/test/fixture"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestRepository_GetBookmarks(t *testing.T) {
@@ -184,3 +185,116 @@ func TestRepository_GetBookmarks(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_CountAllBookmarks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupDB       func(t *testing.T) *gorm.DB
+		expectedCount int64
+		expectError   bool
+	}{
+		{
+			name: "success - count seeded bookmarks of all users",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			expectedCount: 8,
+		},
+		{
+			name: "error - db error",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+				sqlDB, _ := db.DB()
+				_ = sqlDB.Close()
+				return db
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := NewBookmark(tc.setupDB(t))
+
+			count, err := repo.CountAllBookmarks(ctx)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Zero(t, count)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
+
+func TestRepository_GetAllBookmarks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupDB       func(t *testing.T) *gorm.DB
+		userID        string
+		expectedCodes []string
+		expectError   bool
+	}{
+		{
+			name: "success - all bookmarks of the user",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			userID:        "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91",
+			expectedCodes: []string{"abc12345", "def56789"},
+		},
+		{
+			name: "success - user without bookmarks",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+			},
+			userID:        "7a9f2d41-5b4c-4f3e-9d21-3e8c6a5b4f72",
+			expectedCodes: []string{},
+		},
+		{
+			name: "error - db error",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
+				sqlDB, _ := db.DB()
+				_ = sqlDB.Close()
+				return db
+			},
+			userID:      "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := NewBookmark(tc.setupDB(t))
+
+			bookmarks, err := repo.GetAllBookmarks(ctx, tc.userID)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, bookmarks)
+				return
+			}
+
+			assert.NoError(t, err)
+			codes := make([]string, 0, len(bookmarks))
+			for _, bookmark := range bookmarks {
+				codes = append(codes, bookmark.Code)
+			}
+			assert.ElementsMatch(t, tc.expectedCodes, codes)
+		})
+	}
+}
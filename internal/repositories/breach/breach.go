@@ -0,0 +1,40 @@
+// Package breach looks passwords up in an offline copy of a breached password corpus,
+// such as the Pwned Passwords range files.
+package breach
+
+import (
+	"context"
+)
+
+const (
+	// prefixLen is the number of hex characters of the SHA-1 hash naming a range file.
+	prefixLen = 5
+	// rangeFileExt is the extension of the range files of a breached password directory.
+	rangeFileExt = ".txt"
+)
+
+// Storage defines the interface for checking passwords against a breached password list.
+//
+//go:generate mockery --name Storage --filename storage.go
+type Storage interface {
+	// Count returns the number of times the password appears in the breached password list,
+	// or zero if it does not appear in it.
+	Count(ctx context.Context, password string) (int64, error)
+}
+
+// storage implements the Storage interface on a directory of k-anonymity range files.
+// The SHA-1 hash of a password, in upper case hex, is split after its first five characters:
+// the prefix names the range file "<PREFIX>.txt", which lists the remaining suffixes of
+// breached hashes as "SUFFIX:COUNT" lines. Only the range file of the password is read, so
+// the list can be kept on disk in full without loading it into memory.
+type storage struct {
+	dir string
+}
+
+// NewStorage creates a new breached password storage reading the range files of dir.
+// When dir is empty, no password is considered breached.
+func NewStorage(dir string) Storage {
+	return &storage{
+		dir: dir,
+	}
+}
@@ -0,0 +1,46 @@
+package breach
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Count hashes the password, reads the range file of its prefix and returns the count of
+// the line matching its suffix. A missing range file means no breached hash has the prefix.
+func (s *storage) Count(ctx context.Context, password string) (int64, error) {
+	if s.dir == "" {
+		return 0, nil
+	}
+
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:prefixLen], hash[prefixLen:]
+
+	file, err := os.Open(filepath.Join(s.dir, prefix+rangeFileExt))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineSuffix, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(lineSuffix, suffix) {
+			continue
+		}
+
+		return strconv.ParseInt(count, 10, 64)
+	}
+
+	return 0, scanner.Err()
+}
@@ -0,0 +1,54 @@
+package breach
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage_Count(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		dir           string
+		password      string
+		expectedCount int64
+	}{
+		{
+			name:          "breached password",
+			dir:           "testdata/range",
+			password:      "password",
+			expectedCount: 9545824,
+		},
+		{
+			name:          "suffix not in range file",
+			dir:           "testdata/range",
+			password:      "P@ssw0rd",
+			expectedCount: 0,
+		},
+		{
+			name:          "no range file for prefix",
+			dir:           "testdata/range",
+			password:      "correct horse battery staple",
+			expectedCount: 0,
+		},
+		{
+			name:          "list disabled",
+			dir:           "",
+			password:      "password",
+			expectedCount: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			count, err := NewStorage(tc.dir).Count(t.Context(), tc.password)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedCount, count)
+		})
+	}
+}
@@ -12,7 +12,7 @@ import (
 // It uses Redis hash operations (HSET/HGET) to store cache entries within
 // cache groups, allowing efficient bulk invalidation by deleting the entire group.
 type redisCache struct {
-	c *redis.Client
+	c redis.UniversalClient
 }
 
 // NewRedisCache creates a new Redis cache implementation with the provided Redis client.
@@ -22,7 +22,7 @@ type redisCache struct {
 //
 // Returns:
 //   - DB: An implementation of the cache DB interface backed by Redis
-func NewRedisCache(c *redis.Client) DB {
+func NewRedisCache(c redis.UniversalClient) DB {
 	return &redisCache{
 		c: c,
 	}
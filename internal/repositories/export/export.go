@@ -0,0 +1,69 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// jobKeyFormat is the Redis key holding the latest export job of a user.
+	jobKeyFormat = "export_job_%s"
+	// archiveKeyFormat is the Redis key holding the archive produced by the latest export job.
+	archiveKeyFormat = "export_archive_%s"
+)
+
+// Job statuses reported while an export is being produced.
+const (
+	StatusPending   = "pending"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+var (
+	// ErrJobNotFound is returned when the user has not requested an export or it has expired.
+	ErrJobNotFound = errors.New("export job not found")
+	// ErrArchiveNotFound is returned when no archive is stored for the user.
+	ErrArchiveNotFound = errors.New("export archive not found")
+)
+
+// Job describes an asynchronous export of a user's data. Only the latest job of each
+// user is kept.
+type Job struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	Format      string     `json:"format"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// Storage defines the interface for storing export jobs and the archives they produce.
+//
+//go:generate mockery --name Storage --filename storage.go
+type Storage interface {
+	// SaveJob stores the job as the latest export of the user for the given duration.
+	SaveJob(ctx context.Context, userID string, job *Job, ttl time.Duration) error
+	// GetJob returns the latest export job of the user, or ErrJobNotFound.
+	GetJob(ctx context.Context, userID string) (*Job, error)
+	// SaveArchive stores the archive of the user's latest export for the given duration.
+	SaveArchive(ctx context.Context, userID string, data []byte, ttl time.Duration) error
+	// GetArchive returns the archive of the user's latest export, or ErrArchiveNotFound.
+	GetArchive(ctx context.Context, userID string) ([]byte, error)
+	// Delete removes the user's export job and archive.
+	Delete(ctx context.Context, userID string) error
+}
+
+// storage implements the Storage interface using Redis keys with a TTL.
+type storage struct {
+	client redis.UniversalClient
+}
+
+// NewStorage creates a new export storage with the provided Redis client.
+func NewStorage(client redis.UniversalClient) Storage {
+	return &storage{
+		client: client,
+	}
+}
@@ -0,0 +1,69 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SaveJob serializes the job and stores it under the user's job key.
+func (s *storage) SaveJob(ctx context.Context, userID string, job *Job, ttl time.Duration) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Set(ctx, fmt.Sprintf(jobKeyFormat, userID), data, ttl).Err()
+}
+
+// GetJob reads the user's job key. Returns ErrJobNotFound if the key does not exist.
+func (s *storage) GetJob(ctx context.Context, userID string) (*Job, error) {
+	data, err := s.client.Get(ctx, fmt.Sprintf(jobKeyFormat, userID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+
+	job := &Job{}
+	if err := json.Unmarshal(data, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// SaveArchive stores the archive bytes under the user's archive key.
+func (s *storage) SaveArchive(ctx context.Context, userID string, data []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, fmt.Sprintf(archiveKeyFormat, userID), data, ttl).Err()
+}
+
+// GetArchive reads the user's archive key. Returns ErrArchiveNotFound if the key does not exist.
+func (s *storage) GetArchive(ctx context.Context, userID string) ([]byte, error) {
+	data, err := s.client.Get(ctx, fmt.Sprintf(archiveKeyFormat, userID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrArchiveNotFound
+		}
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Delete removes both the job and the archive keys of the user, with a DEL each as the
+// keys may live in different slots of a Redis Cluster.
+func (s *storage) Delete(ctx context.Context, userID string) error {
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, fmt.Sprintf(jobKeyFormat, userID))
+		pipe.Del(ctx, fmt.Sprintf(archiveKeyFormat, userID))
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,154 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+const testUserID = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+
+func TestStorage_Job(t *testing.T) {
+	t.Parallel()
+
+	completedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	job := &Job{
+		ID:          "job-1",
+		Status:      StatusCompleted,
+		Format:      "zip",
+		CreatedAt:   time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC),
+		CompletedAt: &completedAt,
+	}
+
+	testCases := []struct {
+		name          string
+		setupRedis    func(t *testing.T, ctx context.Context) *redis.Client
+		expectedJob   *Job
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = NewStorage(client).SaveJob(ctx, testUserID, job, time.Hour)
+				return client
+			},
+			expectedJob: job,
+		},
+		{
+			name: "fail - job not found",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			expectedError: ErrJobNotFound,
+		},
+		{
+			name: "fail - invalid data",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				client.Set(ctx, "export_job_"+testUserID, "not-json", time.Hour)
+				return client
+			},
+			expectedError: &json.SyntaxError{},
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			repo := NewStorage(tc.setupRedis(t, ctx))
+
+			res, err := repo.GetJob(ctx, testUserID)
+			if tc.expectedError != nil {
+				assert.Nil(t, res)
+				assert.IsType(t, tc.expectedError, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedJob.ID, res.ID)
+			assert.Equal(t, tc.expectedJob.Status, res.Status)
+			assert.Equal(t, tc.expectedJob.Format, res.Format)
+			assert.True(t, tc.expectedJob.CreatedAt.Equal(res.CreatedAt))
+			assert.True(t, tc.expectedJob.CompletedAt.Equal(*res.CompletedAt))
+		})
+	}
+}
+
+func TestStorage_Archive(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupRedis     func(t *testing.T, ctx context.Context) *redis.Client
+		expectedResult []byte
+		expectedError  error
+	}{
+		{
+			name: "success",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = NewStorage(client).SaveArchive(ctx, testUserID, []byte("archive"), time.Hour)
+				return client
+			},
+			expectedResult: []byte("archive"),
+		},
+		{
+			name: "fail - archive not found",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			expectedError: ErrArchiveNotFound,
+		},
+		{
+			name: "fail - deleted with the job",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewStorage(client)
+				_ = repo.SaveJob(ctx, testUserID, &Job{ID: "job-1", Status: StatusCompleted}, time.Hour)
+				_ = repo.SaveArchive(ctx, testUserID, []byte("archive"), time.Hour)
+				_ = repo.Delete(ctx, testUserID)
+				return client
+			},
+			expectedError: ErrArchiveNotFound,
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			repo := NewStorage(tc.setupRedis(t, ctx))
+
+			res, err := repo.GetArchive(ctx, testUserID)
+			assert.Equal(t, tc.expectedResult, res)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
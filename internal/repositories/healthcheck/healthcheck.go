@@ -2,82 +2,80 @@ package healthcheck
 
 import (
 	"context"
-
-	"github.com/redis/go-redis/v9"
+	"sync"
+	"time"
 )
 
-// HealthCheck defines the interface for health check operations.
-// It provides methods to verify the connectivity and health status of external dependencies,
-// primarily used for checking Redis connection health.
+// Checker checks the health of a single dependency of the service, such as a database
+// or a cache.
+//
+// Implementations should be lightweight and fast, as they are called every time the
+// readiness endpoint is probed, and must return when the context is cancelled.
 //
-// Implementations of this interface should be lightweight and fast, as they are typically
-// called frequently by health check endpoints to monitor service availability.
+//go:generate mockery --name Checker --filename checker.go
+type Checker interface {
+	// Name identifies the dependency in health reports, e.g. "postgres" or "redis".
+	Name() string
+	// Check returns nil if the dependency is healthy, or an error describing why it is not.
+	Check(context.Context) error
+}
+
+// Registration is a checker registered with the time it is allowed to take. The failure
+// of an optional checker degrades the service without making it unready.
+type Registration struct {
+	Checker  Checker
+	Timeout  time.Duration
+	Optional bool
+}
+
+// HealthCheck defines the interface for the registry of health checkers.
+// Each dependency registers a checker when the service is wired, and the healthcheck
+// service runs every registered checker to decide whether the service is ready.
 //
 //go:generate mockery --name HealthCheck --filename health_check.go
 type HealthCheck interface {
-	// Ping checks the connectivity to the underlying data store (e.g., Redis).
-	// It sends a PING command to verify that the connection is alive and responsive.
-	//
-	// Parameters:
-	//   - ctx: Context for controlling the request lifecycle. Can be used for cancellation
-	//          and timeout control. If the context is cancelled, the operation should
-	//          return immediately with an appropriate error.
-	//
-	// Returns:
-	//   - error: Returns nil if the ping operation succeeds, indicating the connection
-	//            is healthy. Returns a non-nil error if:
-	//            - The connection is closed or unavailable (e.g., redis.ErrClosed)
-	//            - A network timeout occurs
-	//            - The context is cancelled or times out
-	//            - Any other connection-related error occurs
-	//
-	// Example usage:
-	//   ctx := context.Background()
-	//   if err := healthCheck.Ping(ctx); err != nil {
-	//       log.Printf("Health check failed: %v", err)
-	//   }
-	Ping(context.Context) error
+	// Register adds a checker that must complete within timeout; with a zero timeout
+	// the checker is only bounded by the caller's context.
+	Register(checker Checker, timeout time.Duration)
+	// RegisterOptional adds a checker like Register, for a dependency the service can
+	// serve requests without, such as a read replica.
+	RegisterOptional(checker Checker, timeout time.Duration)
+	// Checkers returns the registered checkers in registration order.
+	Checkers() []Registration
 }
 
-// NewHealthCheck creates a new HealthCheck repository instance that uses Redis
-// as the underlying health check mechanism.
-//
-// This function initializes a redisHealthCheck implementation that wraps a Redis client.
-// The returned HealthCheck instance can be used to verify Redis connectivity by sending
-// PING commands through the provided Redis client.
-//
-// Parameters:
-//   - redis: A pointer to a configured Redis client. The client should be properly
-//     initialized and connected to a Redis server. Must not be nil.
-//
-// Returns:
-//   - HealthCheck: A new instance of HealthCheck that uses the provided Redis client
-//     for health check operations. The implementation is thread-safe and
-//     can be used concurrently from multiple goroutines.
-//
-// Example usage:
-//
-//	redisClient := redis.NewClient(&redis.Options{
-//	    Addr: "localhost:6379",
-//	})
-//	healthCheck := NewHealthCheck(redisClient)
-//	err := healthCheck.Ping(context.Background())
-func NewHealthCheck(redis *redis.Client) HealthCheck {
-	return &redisHealthCheck{
-		redis: redis,
-	}
+// NewHealthCheck creates an empty registry of health checkers. The registry is safe
+// for concurrent use.
+func NewHealthCheck() HealthCheck {
+	return &registry{}
 }
 
-// redisHealthCheck is the concrete implementation of HealthCheck interface
-// that uses Redis for health check operations.
-//
-// This struct wraps a Redis client and implements the HealthCheck interface
-// by delegating ping operations to the underlying Redis connection. It provides
-// a simple way to check if the Redis connection is alive and responsive.
-//
-// Fields:
-//   - redis: A pointer to the Redis client used for health check operations.
-//     This client is used to send PING commands to verify connectivity.
-type redisHealthCheck struct {
-	redis *redis.Client
+// registry is the concrete implementation of the HealthCheck interface.
+type registry struct {
+	mu       sync.RWMutex
+	checkers []Registration
+}
+
+// Register adds a checker to the registry.
+func (r *registry) Register(checker Checker, timeout time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checkers = append(r.checkers, Registration{Checker: checker, Timeout: timeout})
+}
+
+// RegisterOptional adds an optional checker to the registry.
+func (r *registry) RegisterOptional(checker Checker, timeout time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checkers = append(r.checkers, Registration{Checker: checker, Timeout: timeout, Optional: true})
+}
+
+// Checkers returns a copy of the registered checkers.
+func (r *registry) Checkers() []Registration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Registration(nil), r.checkers...)
 }
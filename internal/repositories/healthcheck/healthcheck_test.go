@@ -0,0 +1,32 @@
+package healthcheck
+
+import (
+	"testing"
+	"time"
+
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	sqldb "github.com/luongtruong20201/bookmark-management/pkg/sql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthCheck_Register(t *testing.T) {
+	t.Parallel()
+
+	first := NewRedisChecker(redisPkg.InitMockRedis(t))
+	second := NewPostgresChecker(sqldb.InitMockDB(t))
+	replica, err := sqldb.InitMockDB(t).DB()
+	assert.NoError(t, err)
+	third := NewPostgresReplicaChecker(1, replica)
+	registry := NewHealthCheck()
+	assert.Empty(t, registry.Checkers())
+
+	registry.Register(first, time.Second)
+	registry.Register(second, 0)
+	registry.RegisterOptional(third, time.Second)
+
+	assert.Equal(t, []Registration{
+		{Checker: first, Timeout: time.Second},
+		{Checker: second},
+		{Checker: third, Timeout: time.Second, Optional: true},
+	}, registry.Checkers())
+}
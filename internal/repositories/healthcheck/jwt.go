@@ -0,0 +1,43 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang-jwt/jwt/v5"
+	jwtPkg "github.com/luongtruong20201/bookmark-management/pkg/jwt"
+)
+
+// ErrNoVerificationKeys is returned when the validator holds no key to verify tokens.
+var ErrNoVerificationKeys = errors.New("no token verification key loaded")
+
+// NewJWTKeysChecker creates a checker that verifies the keys used to sign and verify
+// access tokens are loaded: the generator must be able to sign a token and the
+// validator must hold at least one public key.
+func NewJWTKeysChecker(generator jwtPkg.JWTGenerator, validator jwtPkg.JWTValidator) Checker {
+	return &jwtKeysChecker{
+		generator: generator,
+		validator: validator,
+	}
+}
+
+// jwtKeysChecker checks the token signing and verification keys.
+type jwtKeysChecker struct {
+	generator jwtPkg.JWTGenerator
+	validator jwtPkg.JWTValidator
+}
+
+// Name returns "jwt_keys".
+func (j *jwtKeysChecker) Name() string {
+	return "jwt_keys"
+}
+
+// Check counts the verification keys and signs a probe token, which is discarded.
+func (j *jwtKeysChecker) Check(_ context.Context) error {
+	if len(j.validator.JWKS().Keys) == 0 {
+		return ErrNoVerificationKeys
+	}
+	_, err := j.generator.GenerateToken(jwt.MapClaims{"sub": "readiness-probe"})
+
+	return err
+}
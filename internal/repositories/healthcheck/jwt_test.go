@@ -0,0 +1,76 @@
+package healthcheck
+
+import (
+	"errors"
+	"testing"
+
+	jwtPkg "github.com/luongtruong20201/bookmark-management/pkg/jwt"
+	"github.com/luongtruong20201/bookmark-management/pkg/jwt/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestJWTKeysChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	errSign := errors.New("sign error")
+
+	testCases := []struct {
+		name           string
+		setupGenerator func(t *testing.T) *mocks.JWTGenerator
+		setupValidator func(t *testing.T) *mocks.JWTValidator
+		expectedErr    error
+	}{
+		{
+			name: "success",
+			setupGenerator: func(t *testing.T) *mocks.JWTGenerator {
+				generator := mocks.NewJWTGenerator(t)
+				generator.On("GenerateToken", mock.Anything).Return("token", nil)
+				return generator
+			},
+			setupValidator: func(t *testing.T) *mocks.JWTValidator {
+				validator := mocks.NewJWTValidator(t)
+				validator.On("JWKS").Return(jwtPkg.JWKS{Keys: []jwtPkg.JWK{{Kid: "kid"}}})
+				return validator
+			},
+		},
+		{
+			name: "no verification key",
+			setupGenerator: func(t *testing.T) *mocks.JWTGenerator {
+				return mocks.NewJWTGenerator(t)
+			},
+			setupValidator: func(t *testing.T) *mocks.JWTValidator {
+				validator := mocks.NewJWTValidator(t)
+				validator.On("JWKS").Return(jwtPkg.JWKS{})
+				return validator
+			},
+			expectedErr: ErrNoVerificationKeys,
+		},
+		{
+			name: "signing fails",
+			setupGenerator: func(t *testing.T) *mocks.JWTGenerator {
+				generator := mocks.NewJWTGenerator(t)
+				generator.On("GenerateToken", mock.Anything).Return("", errSign)
+				return generator
+			},
+			setupValidator: func(t *testing.T) *mocks.JWTValidator {
+				validator := mocks.NewJWTValidator(t)
+				validator.On("JWKS").Return(jwtPkg.JWKS{Keys: []jwtPkg.JWK{{Kid: "kid"}}})
+				return validator
+			},
+			expectedErr: errSign,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := NewJWTKeysChecker(tc.setupGenerator(t), tc.setupValidator(t))
+
+			err := checker.Check(t.Context())
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, "jwt_keys", checker.Name())
+		})
+	}
+}
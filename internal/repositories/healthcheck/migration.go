@@ -0,0 +1,72 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNoMigrations is returned when no migration has been applied to the database.
+	ErrNoMigrations = errors.New("no migration applied")
+	// ErrMigrationDirty is returned when the last migration failed half way and the
+	// schema must be fixed by hand.
+	ErrMigrationDirty = errors.New("migration is dirty")
+	// ErrMigrationBehind is returned when the schema is older than the version the
+	// service expects.
+	ErrMigrationBehind = errors.New("migration version is behind")
+)
+
+// migrationTable is the table where golang-migrate records the schema version.
+const migrationTable = "schema_migrations"
+
+// NewMigrationChecker creates a checker that reads the schema version recorded by the
+// migrations. The schema is healthy when a migration has been applied, the last one
+// completed, and its version is at least minVersion; a zero minVersion accepts any version.
+func NewMigrationChecker(db *gorm.DB, minVersion uint) Checker {
+	return &migrationChecker{
+		db:         db,
+		minVersion: minVersion,
+	}
+}
+
+// migrationChecker checks the schema version of the database.
+type migrationChecker struct {
+	db         *gorm.DB
+	minVersion uint
+}
+
+// migrationVersion is a row of the migration table.
+type migrationVersion struct {
+	Version uint
+	Dirty   bool
+}
+
+// Name returns "migrations".
+func (m *migrationChecker) Name() string {
+	return "migrations"
+}
+
+// Check reads the schema version and reports whether the schema is usable.
+func (m *migrationChecker) Check(ctx context.Context) error {
+	var versions []migrationVersion
+	err := m.db.WithContext(ctx).Table(migrationTable).Select("version", "dirty").Limit(1).Find(&versions).Error
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return ErrNoMigrations
+	}
+
+	current := versions[0]
+	if current.Dirty {
+		return fmt.Errorf("%w: version %d", ErrMigrationDirty, current.Version)
+	}
+	if current.Version < m.minVersion {
+		return fmt.Errorf("%w: version %d, expected at least %d", ErrMigrationBehind, current.Version, m.minVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package healthcheck
+
+import (
+	"testing"
+
+	sqldb "github.com/luongtruong20201/bookmark-management/pkg/sql"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestMigrationChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	withVersion := func(version uint, dirty bool) func(t *testing.T) *gorm.DB {
+		return func(t *testing.T) *gorm.DB {
+			db := sqldb.InitMockDB(t)
+			assert.NoError(t, db.Exec("CREATE TABLE schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)").Error)
+			assert.NoError(t, db.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)", version, dirty).Error)
+
+			return db
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		minVersion  uint
+		setupDB     func(t *testing.T) *gorm.DB
+		expectedErr error
+	}{
+		{
+			name:       "success",
+			minVersion: 5,
+			setupDB:    withVersion(5, false),
+		},
+		{
+			name:    "any version",
+			setupDB: withVersion(1, false),
+		},
+		{
+			name: "no migration applied",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := sqldb.InitMockDB(t)
+				assert.NoError(t, db.Exec("CREATE TABLE schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)").Error)
+
+				return db
+			},
+			expectedErr: ErrNoMigrations,
+		},
+		{
+			name:        "dirty",
+			setupDB:     withVersion(5, true),
+			expectedErr: ErrMigrationDirty,
+		},
+		{
+			name:        "behind",
+			minVersion:  6,
+			setupDB:     withVersion(5, false),
+			expectedErr: ErrMigrationBehind,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := NewMigrationChecker(tc.setupDB(t), tc.minVersion)
+
+			err := checker.Check(t.Context())
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, "migrations", checker.Name())
+		})
+	}
+
+	t.Run("missing table", func(t *testing.T) {
+		t.Parallel()
+
+		checker := NewMigrationChecker(sqldb.InitMockDB(t), 0)
+
+		assert.Error(t, checker.Check(t.Context()))
+	})
+}
@@ -3,8 +3,9 @@
 package mocks
 
 import (
-	context "context"
+	time "time"
 
+	healthcheck "github.com/luongtruong20201/bookmark-management/internal/repositories/healthcheck"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -13,24 +14,36 @@ type HealthCheck struct {
 	mock.Mock
 }
 
-// Ping provides a mock function with given fields: _a0
-func (_m *HealthCheck) Ping(_a0 context.Context) error {
-	ret := _m.Called(_a0)
+// Checkers provides a mock function with no fields
+func (_m *HealthCheck) Checkers() []healthcheck.Registration {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for Ping")
+		panic("no return value specified for Checkers")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = rf(_a0)
+	var r0 []healthcheck.Registration
+	if rf, ok := ret.Get(0).(func() []healthcheck.Registration); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]healthcheck.Registration)
+		}
 	}
 
 	return r0
 }
 
+// Register provides a mock function with given fields: checker, timeout
+func (_m *HealthCheck) Register(checker healthcheck.Checker, timeout time.Duration) {
+	_m.Called(checker, timeout)
+}
+
+// RegisterOptional provides a mock function with given fields: checker, timeout
+func (_m *HealthCheck) RegisterOptional(checker healthcheck.Checker, timeout time.Duration) {
+	_m.Called(checker, timeout)
+}
+
 // NewHealthCheck creates a new instance of HealthCheck. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewHealthCheck(t interface {
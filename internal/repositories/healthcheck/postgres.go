@@ -0,0 +1,63 @@
+package healthcheck
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// NewPostgresChecker creates a checker that pings the database behind the GORM
+// connection to verify that it is reachable.
+func NewPostgresChecker(db *gorm.DB) Checker {
+	return &postgresChecker{
+		db: db,
+	}
+}
+
+// postgresChecker checks the connectivity to the database.
+type postgresChecker struct {
+	db *gorm.DB
+}
+
+// Name returns "postgres".
+func (p *postgresChecker) Name() string {
+	return "postgres"
+}
+
+// Check pings the database, opening a connection if none is idle in the pool.
+func (p *postgresChecker) Check(ctx context.Context) error {
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.PingContext(ctx)
+}
+
+// NewPostgresReplicaChecker creates a checker that pings the read replica behind the
+// connection pool. It is named "postgres_replica_<n>", n being the position of the replica
+// in the configuration, starting at 1.
+func NewPostgresReplicaChecker(n int, db *sql.DB) Checker {
+	return &replicaChecker{
+		name: fmt.Sprintf("postgres_replica_%d", n),
+		db:   db,
+	}
+}
+
+// replicaChecker checks the connectivity to a read replica.
+type replicaChecker struct {
+	name string
+	db   *sql.DB
+}
+
+// Name returns "postgres_replica_<n>".
+func (r *replicaChecker) Name() string {
+	return r.name
+}
+
+// Check pings the replica, opening a connection if none is idle in the pool.
+func (r *replicaChecker) Check(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
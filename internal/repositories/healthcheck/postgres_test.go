@@ -0,0 +1,95 @@
+package healthcheck
+
+import (
+	"testing"
+
+	sqldb "github.com/luongtruong20201/bookmark-management/pkg/sql"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestPostgresChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		setupDB     func(t *testing.T) *gorm.DB
+		expectedErr string
+	}{
+		{
+			name: "success",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return sqldb.InitMockDB(t)
+			},
+		},
+		{
+			name: "closed database",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := sqldb.InitMockDB(t)
+				sqlDB, err := db.DB()
+				assert.NoError(t, err)
+				_ = sqlDB.Close()
+
+				return db
+			},
+			expectedErr: "sql: database is closed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			checker := NewPostgresChecker(tc.setupDB(t))
+
+			err := checker.Check(t.Context())
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedErr)
+			}
+			assert.Equal(t, "postgres", checker.Name())
+		})
+	}
+}
+
+func TestPostgresReplicaChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		closed      bool
+		expectedErr string
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:        "closed replica",
+			closed:      true,
+			expectedErr: "sql: database is closed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			replica, err := sqldb.InitMockDB(t).DB()
+			assert.NoError(t, err)
+			if tc.closed {
+				_ = replica.Close()
+			}
+
+			checker := NewPostgresReplicaChecker(2, replica)
+
+			err = checker.Check(t.Context())
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedErr)
+			}
+			assert.Equal(t, "postgres_replica_2", checker.Name())
+		})
+	}
+}
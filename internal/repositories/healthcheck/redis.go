@@ -0,0 +1,31 @@
+package healthcheck
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NewRedisChecker creates a checker that sends a PING command to verify that the
+// Redis server is reachable and responsive.
+func NewRedisChecker(redis redis.UniversalClient) Checker {
+	return &redisChecker{
+		redis: redis,
+	}
+}
+
+// redisChecker checks the connectivity to Redis.
+type redisChecker struct {
+	redis redis.UniversalClient
+}
+
+// Name returns "redis".
+func (r *redisChecker) Name() string {
+	return "redis"
+}
+
+// Check sends a PING command to the Redis server. It returns redis.ErrClosed if the
+// client is closed, or the network or context error that interrupted the command.
+func (r *redisChecker) Check(ctx context.Context) error {
+	return r.redis.Ping(ctx).Err()
+}
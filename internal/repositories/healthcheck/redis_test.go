@@ -8,7 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestHealthCheck_Ping(t *testing.T) {
+func TestRedisChecker_Check(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
@@ -41,12 +41,12 @@ func TestHealthCheck_Ping(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			redis := tc.setupRedis(t)
-			healthCheck := NewHealthCheck(redis)
+			checker := NewRedisChecker(tc.setupRedis(t))
 			ctx := t.Context()
 
-			err := healthCheck.Ping(ctx)
-			assert.Equal(t, err, tc.expectedError)
+			err := checker.Check(ctx)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, "redis", checker.Name())
 		})
 	}
 }
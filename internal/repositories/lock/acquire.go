@@ -0,0 +1,32 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// releaseScript deletes the lock key only if it still holds the token of the owner, so
+// an owner whose lock has expired cannot release the lock taken since by another one.
+//
+// KEYS[1]: lock key
+// ARGV: owner token
+// Returns: the number of deleted keys
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Acquire stores the token under the lock key unless the key already exists.
+func (l *locker) Acquire(ctx context.Context, name, token string, ttl time.Duration) (bool, error) {
+	return l.client.SetNX(ctx, fmt.Sprintf(lockKeyFormat, name), token, ttl).Result()
+}
+
+// Release deletes the lock key if it still holds the token.
+func (l *locker) Release(ctx context.Context, name, token string) error {
+	return releaseScript.Run(ctx, l.client, []string{fmt.Sprintf(lockKeyFormat, name)}, token).Err()
+}
@@ -0,0 +1,128 @@
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocker_Acquire(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupRedis    func(t *testing.T, ctx context.Context) *redis.Client
+		expected      bool
+		expectedToken string
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			expected:      true,
+			expectedToken: "owner-1",
+		},
+		{
+			name: "success - held by another owner",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				client.Set(ctx, "lock_purge", "owner-2", time.Minute)
+				return client
+			},
+			expectedToken: "owner-2",
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			client := tc.setupRedis(t, ctx)
+			repo := NewLocker(client)
+
+			acquired, err := repo.Acquire(ctx, "purge", "owner-1", time.Minute)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expected, acquired)
+			if tc.expectedError == nil {
+				assert.Equal(t, tc.expectedToken, client.Get(ctx, "lock_purge").Val())
+				assert.Equal(t, time.Minute, client.PTTL(ctx, "lock_purge").Val())
+			}
+		})
+	}
+}
+
+func TestLocker_Release(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupRedis    func(t *testing.T, ctx context.Context) *redis.Client
+		expectedToken string
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				client.Set(ctx, "lock_purge", "owner-1", time.Minute)
+				return client
+			},
+		},
+		{
+			name: "success - not held",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+		},
+		{
+			name: "success - held by another owner is kept",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				client.Set(ctx, "lock_purge", "owner-2", time.Minute)
+				return client
+			},
+			expectedToken: "owner-2",
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			client := tc.setupRedis(t, ctx)
+			repo := NewLocker(client)
+
+			err := repo.Release(ctx, "purge", "owner-1")
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.Equal(t, tc.expectedToken, client.Get(ctx, "lock_purge").Val())
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// Package lock provides locks held in Redis, so that a periodic task running on every
+// replica of the service is performed by a single replica at a time.
+package lock
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// lockKeyFormat is the Redis key holding the token of the owner of a lock.
+const lockKeyFormat = "lock_%s"
+
+// Locker defines the interface for taking and releasing named locks.
+//
+//go:generate mockery --name Locker --filename locker.go
+type Locker interface {
+	// Acquire takes the named lock for ttl on behalf of the owner identified by token.
+	// It returns false when another owner holds the lock.
+	Acquire(ctx context.Context, name, token string, ttl time.Duration) (bool, error)
+	// Release frees the named lock if it is still held by the owner identified by token.
+	Release(ctx context.Context, name, token string) error
+}
+
+// locker implements the Locker interface with a Redis key per lock, which expires
+// on its own if its owner stops without releasing it.
+type locker struct {
+	client redis.UniversalClient
+}
+
+// NewLocker creates a new Redis locker with the provided Redis client.
+func NewLocker(client redis.UniversalClient) Locker {
+	return &locker{
+		client: client,
+	}
+}
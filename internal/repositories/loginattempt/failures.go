@@ -0,0 +1,77 @@
+package loginattempt
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// AddFailure adds the attempt to the subject's sorted set, prunes entries older than the
+// window and refreshes the key expiry so that idle subjects are eventually forgotten.
+// All commands run in a single transaction.
+func (s *storage) AddFailure(ctx context.Context, subject string, at time.Time, window time.Duration) (int64, error) {
+	key := fmt.Sprintf(failuresKeyFormat, subject)
+
+	var card *redis.IntCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(at.UnixMilli()), Member: uuid.NewString()})
+		pipe.ZRemRangeByScore(ctx, key, "-inf", windowStart(at, window))
+		card = pipe.ZCard(ctx, key)
+		pipe.PExpire(ctx, key, window)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return card.Val(), nil
+}
+
+// GetFailures prunes entries older than the window, then returns the number of remaining
+// entries and the score of the newest one.
+func (s *storage) GetFailures(ctx context.Context, subject string, at time.Time, window time.Duration) (int64, time.Time, error) {
+	key := fmt.Sprintf(failuresKeyFormat, subject)
+
+	var (
+		card   *redis.IntCmd
+		latest *redis.ZSliceCmd
+	)
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, key, "-inf", windowStart(at, window))
+		card = pipe.ZCard(ctx, key)
+		latest = pipe.ZRangeWithScores(ctx, key, -1, -1)
+		return nil
+	})
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	if len(latest.Val()) == 0 {
+		return 0, time.Time{}, nil
+	}
+
+	return card.Val(), time.UnixMilli(int64(latest.Val()[0].Score)), nil
+}
+
+// ClearFailures deletes the sorted sets of the given subjects. Each key is deleted by its
+// own command, as the keys of the subjects may hash to different Redis Cluster slots.
+func (s *storage) ClearFailures(ctx context.Context, subjects ...string) error {
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, subject := range subjects {
+			pipe.Del(ctx, fmt.Sprintf(failuresKeyFormat, subject))
+		}
+		return nil
+	})
+
+	return err
+}
+
+// windowStart returns the exclusive lower bound of the window as a sorted set score.
+// Entries scored at or before it fall outside the window.
+func windowStart(at time.Time, window time.Duration) string {
+	return strconv.FormatInt(at.Add(-window).UnixMilli(), 10)
+}
@@ -0,0 +1,250 @@
+package loginattempt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage_AddFailure(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	testCases := []struct {
+		name          string
+		setupRedis    func(t *testing.T, ctx context.Context) *redis.Client
+		expectedCount int64
+		expectedError error
+	}{
+		{
+			name: "success - first failure",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "success - failures within the window are counted",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewStorage(client)
+				_, _ = repo.AddFailure(ctx, "user:johndoe", now.Add(-2*time.Minute), 10*time.Minute)
+				_, _ = repo.AddFailure(ctx, "user:johndoe", now.Add(-time.Minute), 10*time.Minute)
+				return client
+			},
+			expectedCount: 3,
+		},
+		{
+			name: "success - failures outside the window are pruned",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewStorage(client)
+				_, _ = repo.AddFailure(ctx, "user:johndoe", now.Add(-time.Hour), 10*time.Minute)
+				_, _ = repo.AddFailure(ctx, "user:johndoe", now.Add(-time.Minute), 10*time.Minute)
+				return client
+			},
+			expectedCount: 2,
+		},
+		{
+			name: "success - subjects are counted separately",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewStorage(client)
+				_, _ = repo.AddFailure(ctx, "ip:10.0.0.1", now.Add(-time.Minute), 10*time.Minute)
+				return client
+			},
+			expectedCount: 1,
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedCount: 0,
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			repo := NewStorage(tc.setupRedis(t, ctx))
+
+			count, err := repo.AddFailure(ctx, "user:johndoe", now, 10*time.Minute)
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestStorage_GetFailures(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	testCases := []struct {
+		name           string
+		setupRedis     func(t *testing.T, ctx context.Context) *redis.Client
+		expectedCount  int64
+		expectedLatest time.Time
+		expectedError  error
+	}{
+		{
+			name: "success - no failures",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+		},
+		{
+			name: "success - count and latest failure",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewStorage(client)
+				_, _ = repo.AddFailure(ctx, "user:johndoe", now.Add(-time.Hour), 10*time.Minute)
+				_, _ = repo.AddFailure(ctx, "user:johndoe", now.Add(-3*time.Minute), 10*time.Minute)
+				_, _ = repo.AddFailure(ctx, "user:johndoe", now.Add(-time.Minute), 10*time.Minute)
+				return client
+			},
+			expectedCount:  2,
+			expectedLatest: time.UnixMilli(now.Add(-time.Minute).UnixMilli()),
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			repo := NewStorage(tc.setupRedis(t, ctx))
+
+			count, latest, err := repo.GetFailures(ctx, "user:johndoe", now, 10*time.Minute)
+			assert.Equal(t, tc.expectedCount, count)
+			assert.True(t, tc.expectedLatest.Equal(latest))
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestStorage_ClearFailures(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	testCases := []struct {
+		name          string
+		setupRedis    func(t *testing.T, ctx context.Context) redis.UniversalClient
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewStorage(client)
+				_, _ = repo.AddFailure(ctx, "user:johndoe", now, 10*time.Minute)
+				_, _ = repo.AddFailure(ctx, "ip:10.0.0.1", now, 10*time.Minute)
+				return client
+			},
+		},
+		{
+			name: "success - cluster",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{miniredis.RunT(t).Addr()}})
+				t.Cleanup(func() { _ = client.Close() })
+				client.AddHook(crossSlotHook{})
+				repo := NewStorage(client)
+				_, _ = repo.AddFailure(ctx, "user:johndoe", now, 10*time.Minute)
+				_, _ = repo.AddFailure(ctx, "ip:10.0.0.1", now, 10*time.Minute)
+				return client
+			},
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			client := tc.setupRedis(t, ctx)
+			repo := NewStorage(client)
+
+			err := repo.ClearFailures(ctx, "user:johndoe", "ip:10.0.0.1")
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError != nil {
+				return
+			}
+
+			for _, subject := range []string{"user:johndoe", "ip:10.0.0.1"} {
+				count, _, err := repo.GetFailures(ctx, subject, now, 10*time.Minute)
+				assert.NoError(t, err)
+				assert.Zero(t, count)
+			}
+		})
+	}
+}
+
+// crossSlotHook rejects the multi-key DEL commands with the CROSSSLOT error of Redis
+// Cluster, which the single node of miniredis does not report.
+type crossSlotHook struct{}
+
+func (crossSlotHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (crossSlotHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := checkCrossSlot(cmd); err != nil {
+			return err
+		}
+		return next(ctx, cmd)
+	}
+}
+
+func (crossSlotHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			if err := checkCrossSlot(cmd); err != nil {
+				return err
+			}
+		}
+		return next(ctx, cmds)
+	}
+}
+
+// checkCrossSlot fails the command when it is a DEL of several keys.
+func checkCrossSlot(cmd redis.Cmder) error {
+	if cmd.Name() != "del" || len(cmd.Args()) <= 2 {
+		return nil
+	}
+	err := errors.New("CROSSSLOT Keys in request don't hash to the same slot")
+	cmd.SetErr(err)
+	return err
+}
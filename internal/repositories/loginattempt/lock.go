@@ -0,0 +1,26 @@
+package loginattempt
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Lock stores the subject's lock key with the lockout duration as TTL.
+func (s *storage) Lock(ctx context.Context, subject string, duration time.Duration) error {
+	return s.client.Set(ctx, fmt.Sprintf(lockKeyFormat, subject), 1, duration).Err()
+}
+
+// GetLockTTL returns the TTL of the subject's lock key. Redis reports a negative TTL for
+// missing keys, which is returned as zero.
+func (s *storage) GetLockTTL(ctx context.Context, subject string) (time.Duration, error) {
+	ttl, err := s.client.PTTL(ctx, fmt.Sprintf(lockKeyFormat, subject)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
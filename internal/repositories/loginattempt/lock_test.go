@@ -0,0 +1,108 @@
+package loginattempt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage_Lock(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupRedis    func(t *testing.T) *redis.Client
+		duration      time.Duration
+		expectedTTL   time.Duration
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupRedis: func(t *testing.T) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			duration:    15 * time.Minute,
+			expectedTTL: 15 * time.Minute,
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			duration:      15 * time.Minute,
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			client := tc.setupRedis(t)
+			repo := NewStorage(client)
+
+			err := repo.Lock(ctx, "user:johndoe", tc.duration)
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.Equal(t, tc.expectedTTL, client.PTTL(ctx, "login_lock_user:johndoe").Val())
+			}
+		})
+	}
+}
+
+func TestStorage_GetLockTTL(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupRedis    func(t *testing.T, ctx context.Context) *redis.Client
+		expectedTTL   time.Duration
+		expectedError error
+	}{
+		{
+			name: "success - locked",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = NewStorage(client).Lock(ctx, "user:johndoe", time.Minute)
+				return client
+			},
+			expectedTTL: time.Minute,
+		},
+		{
+			name: "success - not locked",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			expectedTTL: 0,
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			repo := NewStorage(tc.setupRedis(t, ctx))
+
+			ttl, err := repo.GetLockTTL(ctx, "user:johndoe")
+			assert.Equal(t, tc.expectedTTL, ttl)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
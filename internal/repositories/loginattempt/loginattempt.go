@@ -0,0 +1,48 @@
+package loginattempt
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// failuresKeyFormat is the Redis sorted set holding the failed login attempts of a subject.
+	// Members are unique attempt IDs scored by the attempt time in milliseconds.
+	failuresKeyFormat = "login_failures_%s"
+	// lockKeyFormat is the Redis key whose presence locks a subject out of logging in.
+	lockKeyFormat = "login_lock_%s"
+)
+
+// Storage defines the interface for tracking failed login attempts and lockouts.
+// Subjects are opaque strings such as "user:johndoe" or "ip:10.0.0.1"; failures are
+// counted over a sliding window ending at the given time.
+//
+//go:generate mockery --name Storage --filename storage.go
+type Storage interface {
+	// AddFailure records a failed attempt for the subject at the given time and returns the
+	// number of failures within the window ending at that time, including the new one.
+	AddFailure(ctx context.Context, subject string, at time.Time, window time.Duration) (int64, error)
+	// GetFailures returns the number of failures within the window ending at the given time
+	// and the time of the most recent one. The time is zero when there are no failures.
+	GetFailures(ctx context.Context, subject string, at time.Time, window time.Duration) (int64, time.Time, error)
+	// ClearFailures removes every recorded failure of the given subjects.
+	ClearFailures(ctx context.Context, subjects ...string) error
+	// Lock prevents the subject from logging in for the given duration.
+	Lock(ctx context.Context, subject string, duration time.Duration) error
+	// GetLockTTL returns the remaining lockout time of the subject, or zero if it is not locked.
+	GetLockTTL(ctx context.Context, subject string) (time.Duration, error)
+}
+
+// storage implements the Storage interface using Redis sorted sets and expiring keys.
+type storage struct {
+	client redis.UniversalClient
+}
+
+// NewStorage creates a new login attempt storage with the provided Redis client.
+func NewStorage(client redis.UniversalClient) Storage {
+	return &storage{
+		client: client,
+	}
+}
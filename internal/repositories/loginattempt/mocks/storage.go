@@ -0,0 +1,164 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Storage is an autogenerated mock type for the Storage type
+type Storage struct {
+	mock.Mock
+}
+
+// AddFailure provides a mock function with given fields: ctx, subject, at, window
+func (_m *Storage) AddFailure(ctx context.Context, subject string, at time.Time, window time.Duration) (int64, error) {
+	ret := _m.Called(ctx, subject, at, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddFailure")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Duration) (int64, error)); ok {
+		return rf(ctx, subject, at, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Duration) int64); ok {
+		r0 = rf(ctx, subject, at, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, subject, at, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClearFailures provides a mock function with given fields: ctx, subjects
+func (_m *Storage) ClearFailures(ctx context.Context, subjects ...string) error {
+	_va := make([]interface{}, len(subjects))
+	for _i := range subjects {
+		_va[_i] = subjects[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearFailures")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = rf(ctx, subjects...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetFailures provides a mock function with given fields: ctx, subject, at, window
+func (_m *Storage) GetFailures(ctx context.Context, subject string, at time.Time, window time.Duration) (int64, time.Time, error) {
+	ret := _m.Called(ctx, subject, at, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFailures")
+	}
+
+	var r0 int64
+	var r1 time.Time
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Duration) (int64, time.Time, error)); ok {
+		return rf(ctx, subject, at, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Duration) int64); ok {
+		r0 = rf(ctx, subject, at, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Duration) time.Time); ok {
+		r1 = rf(ctx, subject, at, window)
+	} else {
+		r1 = ret.Get(1).(time.Time)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Time, time.Duration) error); ok {
+		r2 = rf(ctx, subject, at, window)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetLockTTL provides a mock function with given fields: ctx, subject
+func (_m *Storage) GetLockTTL(ctx context.Context, subject string) (time.Duration, error) {
+	ret := _m.Called(ctx, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLockTTL")
+	}
+
+	var r0 time.Duration
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Duration, error)); ok {
+		return rf(ctx, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Duration); ok {
+		r0 = rf(ctx, subject)
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lock provides a mock function with given fields: ctx, subject, duration
+func (_m *Storage) Lock(ctx context.Context, subject string, duration time.Duration) error {
+	ret := _m.Called(ctx, subject, duration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, subject, duration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStorage creates a new instance of Storage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Storage {
+	mock := &Storage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
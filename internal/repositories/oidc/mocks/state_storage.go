@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	oidc "github.com/luongtruong20201/bookmark-management/internal/repositories/oidc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StateStorage is an autogenerated mock type for the StateStorage type
+type StateStorage struct {
+	mock.Mock
+}
+
+// PopState provides a mock function with given fields: ctx, state
+func (_m *StateStorage) PopState(ctx context.Context, state string) (*oidc.AuthState, error) {
+	ret := _m.Called(ctx, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PopState")
+	}
+
+	var r0 *oidc.AuthState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*oidc.AuthState, error)); ok {
+		return rf(ctx, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *oidc.AuthState); ok {
+		r0 = rf(ctx, state)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oidc.AuthState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveState provides a mock function with given fields: ctx, state, authState
+func (_m *StateStorage) SaveState(ctx context.Context, state string, authState *oidc.AuthState) error {
+	ret := _m.Called(ctx, state, authState)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *oidc.AuthState) error); ok {
+		r0 = rf(ctx, state, authState)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStateStorage creates a new instance of StateStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStateStorage(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StateStorage {
+	mock := &StateStorage{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
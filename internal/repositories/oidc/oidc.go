@@ -0,0 +1,51 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// stateKeyFormat is the Redis key used to store a pending login by its state value.
+	stateKeyFormat = "oidc_state_%s"
+	// stateExpireTime bounds how long a user can take to sign in at the identity provider.
+	stateExpireTime = 10 * time.Minute
+)
+
+// ErrStateNotFound is returned when a state does not exist, has expired or was already used.
+var ErrStateNotFound = errors.New("oidc state not found")
+
+// AuthState holds the data of a pending OpenID Connect login that must survive the
+// round trip to the identity provider.
+type AuthState struct {
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+// StateStorage defines the interface for storing pending OpenID Connect logins.
+// Each state can be consumed only once.
+//
+//go:generate mockery --name StateStorage --filename state_storage.go
+type StateStorage interface {
+	// SaveState stores the pending login under the given state value.
+	SaveState(ctx context.Context, state string, authState *AuthState) error
+	// PopState retrieves and deletes the pending login stored under the given state value.
+	// It returns ErrStateNotFound if the state is unknown or expired.
+	PopState(ctx context.Context, state string) (*AuthState, error)
+}
+
+// stateStorage implements the StateStorage interface using Redis keys with a TTL.
+type stateStorage struct {
+	client redis.UniversalClient
+}
+
+// NewStateStorage creates a new OpenID Connect state storage with the provided Redis client.
+func NewStateStorage(client redis.UniversalClient) StateStorage {
+	return &stateStorage{
+		client: client,
+	}
+}
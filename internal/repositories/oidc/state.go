@@ -0,0 +1,39 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// SaveState serializes the pending login and stores it in Redis with stateExpireTime as TTL.
+func (s *stateStorage) SaveState(ctx context.Context, state string, authState *AuthState) error {
+	data, err := json.Marshal(authState)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Set(ctx, fmt.Sprintf(stateKeyFormat, state), data, stateExpireTime).Err()
+}
+
+// PopState atomically reads and deletes the pending login stored under the state value,
+// so that a state cannot be replayed. Returns ErrStateNotFound if the key does not exist.
+func (s *stateStorage) PopState(ctx context.Context, state string) (*AuthState, error) {
+	data, err := s.client.GetDel(ctx, fmt.Sprintf(stateKeyFormat, state)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrStateNotFound
+		}
+		return nil, err
+	}
+
+	authState := &AuthState{}
+	if err := json.Unmarshal(data, authState); err != nil {
+		return nil, err
+	}
+
+	return authState, nil
+}
@@ -0,0 +1,149 @@
+package oidc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateStorage_SaveState(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupMock     func(t *testing.T) *redis.Client
+		state         string
+		authState     *AuthState
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupMock: func(t *testing.T) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			state: "state-1",
+			authState: &AuthState{
+				Provider:     "company",
+				Nonce:        "nonce-1",
+				CodeVerifier: "verifier-1",
+			},
+			expectedError: nil,
+		},
+		{
+			name: "lost connection",
+			setupMock: func(t *testing.T) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			state:         "state-1",
+			authState:     &AuthState{Provider: "company"},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			client := tc.setupMock(t)
+			storage := NewStateStorage(client)
+
+			err := storage.SaveState(ctx, tc.state, tc.authState)
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError != nil {
+				return
+			}
+
+			ttl, err := client.TTL(ctx, "oidc_state_"+tc.state).Result()
+			assert.NoError(t, err)
+			assert.Greater(t, ttl, time.Duration(0))
+			assert.LessOrEqual(t, ttl, stateExpireTime)
+		})
+	}
+}
+
+func TestStateStorage_PopState(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupMock      func(t *testing.T, ctx context.Context) *redis.Client
+		state          string
+		expectedOutput *AuthState
+		expectedError  error
+	}{
+		{
+			name: "success",
+			setupMock: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				client.Set(ctx, "oidc_state_state-1", `{"provider":"company","nonce":"nonce-1","code_verifier":"verifier-1"}`, 0)
+				return client
+			},
+			state: "state-1",
+			expectedOutput: &AuthState{
+				Provider:     "company",
+				Nonce:        "nonce-1",
+				CodeVerifier: "verifier-1",
+			},
+		},
+		{
+			name: "state not found",
+			setupMock: func(t *testing.T, ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			state:         "state-1",
+			expectedError: ErrStateNotFound,
+		},
+		{
+			name: "malformed data",
+			setupMock: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				client.Set(ctx, "oidc_state_state-1", "not-json", 0)
+				return client
+			},
+			state: "state-1",
+		},
+		{
+			name: "lost connection",
+			setupMock: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			state:         "state-1",
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			client := tc.setupMock(t, ctx)
+			storage := NewStateStorage(client)
+
+			result, err := storage.PopState(ctx, tc.state)
+			if tc.expectedOutput == nil {
+				assert.Error(t, err)
+				if tc.expectedError != nil {
+					assert.ErrorIs(t, err, tc.expectedError)
+				}
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedOutput, result)
+
+			_, err = storage.PopState(ctx, tc.state)
+			assert.ErrorIs(t, err, ErrStateNotFound, "state must not be usable twice")
+		})
+	}
+}
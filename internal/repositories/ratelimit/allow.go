@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// gcraScript implements GCRA on a single key storing the theoretical arrival time in
+// milliseconds. Every request moves the TAT forward by one emission interval
+// (period / rate); a request is rejected when that would put the TAT further than
+// burst intervals ahead of now. Rejected requests do not change the stored TAT.
+//
+// KEYS[1]: subject key
+// ARGV: burst, rate, period (ms), now (ms)
+// Returns: {allowed (0|1), remaining, retry after (ms), reset after (ms)}
+var gcraScript = redis.NewScript(`
+local burst = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local period = tonumber(ARGV[3])
+local now = tonumber(ARGV[4])
+
+local emission = period / rate
+local tat = tonumber(redis.call("GET", KEYS[1]) or now)
+if tat < now then
+	tat = now
+end
+
+local new_tat = tat + emission
+local diff = now - (new_tat - emission * burst)
+if diff < 0 then
+	return {0, 0, math.ceil(-diff), math.ceil(tat - now)}
+end
+
+local reset_after = math.ceil(new_tat - now)
+redis.call("SET", KEYS[1], new_tat, "PX", reset_after)
+return {1, math.floor(diff / emission), 0, reset_after}
+`)
+
+// Allow runs the GCRA script for the subject and converts its reply into a Result.
+func (l *limiter) Allow(ctx context.Context, subject string, limit Limit, at time.Time) (*Result, error) {
+	values, err := gcraScript.Run(ctx, l.client,
+		[]string{fmt.Sprintf(keyFormat, subject)},
+		limit.Burst, limit.Rate, limit.Period.Milliseconds(), at.UnixMilli(),
+	).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Allowed:    values[0] == 1,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+		ResetAfter: time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
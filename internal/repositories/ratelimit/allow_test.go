@@ -0,0 +1,131 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	now := time.UnixMilli(time.Now().UnixMilli())
+	// limit allows 10 requests per minute (one every 6s) with bursts of 3.
+	limit := Limit{Rate: 10, Period: time.Minute, Burst: 3}
+
+	testCases := []struct {
+		name           string
+		setupRedis     func(t *testing.T, ctx context.Context) *redis.Client
+		at             time.Time
+		expectedResult *Result
+		expectedError  error
+	}{
+		{
+			name: "allowed - first request",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			at: now,
+			expectedResult: &Result{
+				Allowed:    true,
+				Remaining:  2,
+				ResetAfter: 6 * time.Second,
+			},
+		},
+		{
+			name: "allowed - last request of the burst",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewLimiter(client)
+				_, _ = repo.Allow(ctx, "ip:10.0.0.1", limit, now)
+				_, _ = repo.Allow(ctx, "ip:10.0.0.1", limit, now)
+				return client
+			},
+			at: now,
+			expectedResult: &Result{
+				Allowed:    true,
+				Remaining:  0,
+				ResetAfter: 18 * time.Second,
+			},
+		},
+		{
+			name: "rejected - burst exhausted",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewLimiter(client)
+				for range 3 {
+					_, _ = repo.Allow(ctx, "ip:10.0.0.1", limit, now)
+				}
+				return client
+			},
+			at: now.Add(time.Second),
+			expectedResult: &Result{
+				Allowed:    false,
+				Remaining:  0,
+				RetryAfter: 5 * time.Second,
+				ResetAfter: 17 * time.Second,
+			},
+		},
+		{
+			name: "allowed - allowance refills over time",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewLimiter(client)
+				for range 3 {
+					_, _ = repo.Allow(ctx, "ip:10.0.0.1", limit, now)
+				}
+				return client
+			},
+			at: now.Add(6 * time.Second),
+			expectedResult: &Result{
+				Allowed:    true,
+				Remaining:  0,
+				ResetAfter: 18 * time.Second,
+			},
+		},
+		{
+			name: "allowed - subjects are limited separately",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewLimiter(client)
+				for range 3 {
+					_, _ = repo.Allow(ctx, "ip:10.0.0.2", limit, now)
+				}
+				return client
+			},
+			at: now,
+			expectedResult: &Result{
+				Allowed:    true,
+				Remaining:  2,
+				ResetAfter: 6 * time.Second,
+			},
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			at:            now,
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			repo := NewLimiter(tc.setupRedis(t, ctx))
+
+			result, err := repo.Allow(ctx, "ip:10.0.0.1", limit, tc.at)
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	ratelimit "github.com/luongtruong20201/bookmark-management/internal/repositories/ratelimit"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Limiter is an autogenerated mock type for the Limiter type
+type Limiter struct {
+	mock.Mock
+}
+
+// Allow provides a mock function with given fields: ctx, subject, limit, at
+func (_m *Limiter) Allow(ctx context.Context, subject string, limit ratelimit.Limit, at time.Time) (*ratelimit.Result, error) {
+	ret := _m.Called(ctx, subject, limit, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Allow")
+	}
+
+	var r0 *ratelimit.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ratelimit.Limit, time.Time) (*ratelimit.Result, error)); ok {
+		return rf(ctx, subject, limit, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ratelimit.Limit, time.Time) *ratelimit.Result); ok {
+		r0 = rf(ctx, subject, limit, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ratelimit.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ratelimit.Limit, time.Time) error); ok {
+		r1 = rf(ctx, subject, limit, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewLimiter creates a new instance of Limiter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLimiter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Limiter {
+	mock := &Limiter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
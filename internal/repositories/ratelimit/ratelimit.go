@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyFormat is the Redis key holding the theoretical arrival time (TAT) of a rate limited subject.
+const keyFormat = "rate_limit_%s"
+
+// Limit describes a rate: Rate requests per Period, with bursts of up to Burst requests.
+type Limit struct {
+	Rate   int
+	Period time.Duration
+	Burst  int
+}
+
+// Result is the outcome of a rate limit check.
+type Result struct {
+	// Allowed reports whether the request may proceed.
+	Allowed bool
+	// Remaining is the number of requests that could still be made right now.
+	Remaining int
+	// RetryAfter is how long to wait before the next request is allowed. It is zero when allowed.
+	RetryAfter time.Duration
+	// ResetAfter is how long until the subject is back to its full burst.
+	ResetAfter time.Duration
+}
+
+// Limiter defines the interface for checking requests against a rate limit.
+//
+//go:generate mockery --name Limiter --filename limiter.go
+type Limiter interface {
+	// Allow consumes one request from the subject's allowance at the given time and
+	// reports whether it is within the limit.
+	Allow(ctx context.Context, subject string, limit Limit, at time.Time) (*Result, error)
+}
+
+// limiter implements the Limiter interface with the generic cell rate algorithm (GCRA)
+// evaluated atomically by a Redis script.
+type limiter struct {
+	client redis.UniversalClient
+}
+
+// NewLimiter creates a new Redis rate limiter with the provided Redis client.
+func NewLimiter(client redis.UniversalClient) Limiter {
+	return &limiter{
+		client: client,
+	}
+}
@@ -0,0 +1,33 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Create adds the session ID to the user's set of sessions, then serializes the session
+// under its key. The two keys may live in different hash slots of a Redis Cluster, so
+// they are not written in a transaction; adding the ID first ensures DeleteByUser finds
+// every stored session. The set expires together with the newest session.
+func (s *storage) Create(ctx context.Context, session *Session, ttl time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	userKey := fmt.Sprintf(userSessionsKeyFormat, session.UserID)
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, userKey, session.ID)
+		pipe.Expire(ctx, userKey, ttl)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.client.Set(ctx, fmt.Sprintf(sessionKeyFormat, session.ID), data, ttl).Err()
+}
@@ -0,0 +1,44 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Delete deletes the session key, then removes the ID from the user's set of sessions.
+// The two keys may live in different hash slots of a Redis Cluster, so the set is only
+// updated once the session is gone; an ID left behind is dropped by ListByUser.
+func (s *storage) Delete(ctx context.Context, userID, sessionID string) error {
+	if err := s.client.Del(ctx, fmt.Sprintf(sessionKeyFormat, sessionID)).Err(); err != nil {
+		return err
+	}
+
+	return s.client.SRem(ctx, fmt.Sprintf(userSessionsKeyFormat, userID), sessionID).Err()
+}
+
+// DeleteByUser reads the user's set of session IDs and deletes every session key in a
+// single pipeline, which a Redis Cluster splits by node. The set is deleted only once
+// all the sessions are gone, so a failed call can be retried.
+func (s *storage) DeleteByUser(ctx context.Context, userID string) error {
+	userKey := fmt.Sprintf(userSessionsKeyFormat, userID)
+	ids, err := s.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return err
+	}
+
+	if len(ids) > 0 {
+		_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, id := range ids {
+				pipe.Del(ctx, fmt.Sprintf(sessionKeyFormat, id))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.client.Del(ctx, userKey).Err()
+}
@@ -0,0 +1,146 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage_Delete(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupRedis    func(t *testing.T, ctx context.Context) redis.UniversalClient
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewStorage(client)
+				_ = repo.Create(ctx, testSession, time.Hour)
+				_ = repo.Create(ctx, &Session{ID: "session-2", UserID: testUserID}, time.Hour)
+				return client
+			},
+		},
+		{
+			name: "success - redis cluster",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := newClusterClient(t)
+				repo := NewStorage(client)
+				_ = repo.Create(ctx, testSession, time.Hour)
+				_ = repo.Create(ctx, &Session{ID: "session-2", UserID: testUserID}, time.Hour)
+				return client
+			},
+		},
+		{
+			name: "success - session already gone",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				return redisPkg.InitMockRedis(t)
+			},
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			client := tc.setupRedis(t, ctx)
+			repo := NewStorage(client)
+
+			err := repo.Delete(ctx, testUserID, "session-1")
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError != nil {
+				return
+			}
+
+			assert.Zero(t, client.Exists(ctx, "session_session-1").Val())
+			assert.False(t, client.SIsMember(ctx, "user_sessions_"+testUserID, "session-1").Val())
+		})
+	}
+}
+
+func TestStorage_DeleteByUser(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupRedis    func(t *testing.T, ctx context.Context) redis.UniversalClient
+		otherSessions int64
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewStorage(client)
+				_ = repo.Create(ctx, testSession, time.Hour)
+				_ = repo.Create(ctx, &Session{ID: "session-2", UserID: testUserID}, time.Hour)
+				_ = repo.Create(ctx, &Session{ID: "session-3", UserID: "another-user"}, time.Hour)
+				return client
+			},
+			otherSessions: 1,
+		},
+		{
+			name: "success - redis cluster",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := newClusterClient(t)
+				repo := NewStorage(client)
+				_ = repo.Create(ctx, testSession, time.Hour)
+				_ = repo.Create(ctx, &Session{ID: "session-2", UserID: testUserID}, time.Hour)
+				_ = repo.Create(ctx, &Session{ID: "session-3", UserID: "another-user"}, time.Hour)
+				return client
+			},
+			otherSessions: 1,
+		},
+		{
+			name: "success - no session",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				return redisPkg.InitMockRedis(t)
+			},
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			client := tc.setupRedis(t, ctx)
+			repo := NewStorage(client)
+
+			err := repo.DeleteByUser(ctx, testUserID)
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError != nil {
+				return
+			}
+
+			assert.Zero(t, client.Exists(ctx, "session_session-1", "session_session-2", "user_sessions_"+testUserID).Val())
+			assert.Equal(t, tc.otherSessions, client.Exists(ctx, "session_session-3").Val())
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Get reads the session key. Returns ErrSessionNotFound if the key does not exist.
+func (s *storage) Get(ctx context.Context, sessionID string) (*Session, error) {
+	data, err := s.client.Get(ctx, fmt.Sprintf(sessionKeyFormat, sessionID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+
+	session := &Session{}
+	if err := json.Unmarshal(data, session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// ListByUser reads the user's set of session IDs and resolves them in a single pipeline
+// of GETs, which a Redis Cluster splits by node, unlike an MGET of keys in different
+// slots. IDs whose session has expired are dropped from the set.
+func (s *storage) ListByUser(ctx context.Context, userID string) ([]*Session, error) {
+	userKey := fmt.Sprintf(userSessionsKeyFormat, userID)
+	ids, err := s.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*Session, 0, len(ids))
+	if len(ids) == 0 {
+		return sessions, nil
+	}
+
+	cmds, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range ids {
+			pipe.Get(ctx, fmt.Sprintf(sessionKeyFormat, id))
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	expired := make([]any, 0)
+	for i, id := range ids {
+		data, err := cmds[i].(*redis.StringCmd).Result()
+		if errors.Is(err, redis.Nil) {
+			expired = append(expired, id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		session := &Session{}
+		if err := json.Unmarshal([]byte(data), session); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	if len(expired) > 0 {
+		if err := s.client.SRem(ctx, userKey, expired...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,172 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+const testUserID = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+
+var testSession = &Session{
+	ID:         "session-1",
+	UserID:     testUserID,
+	UserAgent:  "Mozilla/5.0",
+	IP:         "203.0.113.7",
+	CreatedAt:  time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC),
+	LastSeenAt: time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC),
+}
+
+// newClusterClient returns a Redis Cluster client of a single node, which rejects the
+// transactions of keys in different hash slots like a real cluster.
+func newClusterClient(t *testing.T) redis.UniversalClient {
+	client := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{miniredis.RunT(t).Addr()}})
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestStorage_Get(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupRedis     func(t *testing.T, ctx context.Context) redis.UniversalClient
+		expectedResult *Session
+		expectedError  error
+	}{
+		{
+			name: "success",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := redisPkg.InitMockRedis(t)
+				_ = NewStorage(client).Create(ctx, testSession, time.Hour)
+				return client
+			},
+			expectedResult: testSession,
+		},
+		{
+			name: "success - redis cluster",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := newClusterClient(t)
+				assert.NoError(t, NewStorage(client).Create(ctx, testSession, time.Hour))
+				assert.True(t, client.SIsMember(ctx, "user_sessions_"+testUserID, "session-1").Val())
+				return client
+			},
+			expectedResult: testSession,
+		},
+		{
+			name: "fail - session not found",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				return redisPkg.InitMockRedis(t)
+			},
+			expectedError: ErrSessionNotFound,
+		},
+		{
+			name: "fail - invalid data",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := redisPkg.InitMockRedis(t)
+				client.Set(ctx, "session_session-1", "not-json", time.Hour)
+				return client
+			},
+			expectedError: &json.SyntaxError{},
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			repo := NewStorage(tc.setupRedis(t, ctx))
+
+			res, err := repo.Get(ctx, "session-1")
+			if tc.expectedError != nil {
+				assert.Nil(t, res)
+				assert.IsType(t, tc.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, res)
+		})
+	}
+}
+
+func TestStorage_ListByUser(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupRedis     func(t *testing.T, ctx context.Context) *redis.Client
+		expectedResult []*Session
+		expectedError  error
+	}{
+		{
+			name: "success - active sessions",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewStorage(client)
+				_ = repo.Create(ctx, testSession, time.Hour)
+				_ = repo.Create(ctx, &Session{ID: "another-session", UserID: "another-user"}, time.Hour)
+				return client
+			},
+			expectedResult: []*Session{testSession},
+		},
+		{
+			name: "success - expired sessions are dropped",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = NewStorage(client).Create(ctx, testSession, time.Hour)
+				client.SAdd(ctx, "user_sessions_"+testUserID, "expired")
+				return client
+			},
+			expectedResult: []*Session{testSession},
+		},
+		{
+			name: "success - no sessions",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			expectedResult: []*Session{},
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			client := tc.setupRedis(t, ctx)
+			repo := NewStorage(client)
+
+			res, err := repo.ListByUser(ctx, testUserID)
+			assert.Equal(t, tc.expectedResult, res)
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.EqualValues(t, len(tc.expectedResult), client.SCard(ctx, "user_sessions_"+testUserID).Val())
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// sessionKeyFormat is the Redis key holding a session by its ID.
+	sessionKeyFormat = "session_%s"
+	// userSessionsKeyFormat is the set of session IDs opened by a user.
+	userSessionsKeyFormat = "user_sessions_%s"
+)
+
+// ErrSessionNotFound is returned when a session does not exist, has expired or was revoked.
+var ErrSessionNotFound = errors.New("session not found")
+
+// Session describes a login of a user from a device. It lives as long as the token
+// issued with it.
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+// Storage defines the interface for storing the sessions of logged-in users.
+//
+//go:generate mockery --name Storage --filename storage.go
+type Storage interface {
+	// Create stores a new session for the given duration and adds it to the sessions of its user.
+	Create(ctx context.Context, session *Session, ttl time.Duration) error
+	// Get returns the session with the given ID, or ErrSessionNotFound.
+	Get(ctx context.Context, sessionID string) (*Session, error)
+	// ListByUser returns the sessions of the user that have not expired or been revoked.
+	ListByUser(ctx context.Context, userID string) ([]*Session, error)
+	// UpdateLastSeen records the time the session was last used without extending its lifetime.
+	// Returns ErrSessionNotFound if the session no longer exists.
+	UpdateLastSeen(ctx context.Context, session *Session, lastSeenAt time.Time) error
+	// Delete revokes the session of the user.
+	Delete(ctx context.Context, userID, sessionID string) error
+	// DeleteByUser revokes every session of the user.
+	DeleteByUser(ctx context.Context, userID string) error
+}
+
+// storage implements the Storage interface using a Redis key per session and a set
+// of session IDs per user.
+type storage struct {
+	client redis.UniversalClient
+}
+
+// NewStorage creates a new session storage with the provided Redis client.
+func NewStorage(client redis.UniversalClient) Storage {
+	return &storage{
+		client: client,
+	}
+}
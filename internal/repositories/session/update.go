@@ -0,0 +1,36 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// UpdateLastSeen rewrites the session with the new last-seen time, keeping the remaining
+// lifetime of the key. The write is skipped if the session has been revoked in the meantime.
+func (s *storage) UpdateLastSeen(ctx context.Context, session *Session, lastSeenAt time.Time) error {
+	updated := *session
+	updated.LastSeenAt = lastSeenAt
+	data, err := json.Marshal(&updated)
+	if err != nil {
+		return err
+	}
+
+	err = s.client.SetArgs(ctx, fmt.Sprintf(sessionKeyFormat, session.ID), data, redis.SetArgs{
+		Mode:    "XX",
+		KeepTTL: true,
+	}).Err()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+
+	session.LastSeenAt = lastSeenAt
+	return nil
+}
@@ -0,0 +1,72 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorage_UpdateLastSeen(t *testing.T) {
+	t.Parallel()
+
+	lastSeenAt := testSession.LastSeenAt.Add(10 * time.Minute)
+
+	testCases := []struct {
+		name          string
+		setupRedis    func(t *testing.T, ctx context.Context) *redis.Client
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = NewStorage(client).Create(ctx, testSession, time.Hour)
+				return client
+			},
+		},
+		{
+			name: "fail - session revoked",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			expectedError: ErrSessionNotFound,
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			client := tc.setupRedis(t, ctx)
+			repo := NewStorage(client)
+			session := *testSession
+
+			err := repo.UpdateLastSeen(ctx, &session, lastSeenAt)
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError != nil {
+				assert.Zero(t, client.Exists(ctx, "session_session-1").Val())
+				return
+			}
+
+			assert.Equal(t, lastSeenAt, session.LastSeenAt)
+			stored, _ := repo.Get(ctx, "session-1")
+			assert.Equal(t, lastSeenAt, stored.LastSeenAt)
+			assert.Equal(t, testSession.CreatedAt, stored.CreatedAt)
+			assert.Positive(t, client.TTL(ctx, "session_session-1").Val())
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package url
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// Count returns the number of shortened links that have not expired yet.
+// Expired entries are pruned from the link index before counting.
+func (s *urlStorage) Count(ctx context.Context) (int64, error) {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	if err := s.client.ZRemRangeByScore(ctx, linkIndexKey, "-inf", now).Err(); err != nil {
+		return 0, err
+	}
+
+	return s.client.ZCard(ctx, linkIndexKey).Result()
+}
@@ -0,0 +1,80 @@
+package url
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURLStorage_Count(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupRedis     func(t *testing.T, ctx context.Context) *redis.Client
+		expectedResult int64
+		expectedError  error
+	}{
+		{
+			name: "success - count stored links",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewURLStorage(client)
+				_, _ = repo.StoreIfNotExists(ctx, "1234567", "https://truonglq.com", 0)
+				_, _ = repo.StoreIfNotExists(ctx, "7654321", "https://example.com", 60)
+				_, _ = repo.StoreIfNotExists(ctx, "1234567", "https://duplicate.com", 0)
+
+				return client
+			},
+			expectedResult: 2,
+		},
+		{
+			name: "success - expired links are pruned",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				client.ZAdd(ctx, linkIndexKey,
+					redis.Z{Score: float64(time.Now().Add(-time.Minute).Unix()), Member: "expired"},
+					redis.Z{Score: float64(time.Now().Add(time.Hour).Unix()), Member: "active"},
+				)
+
+				return client
+			},
+			expectedResult: 1,
+		},
+		{
+			name: "success - no links",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			expectedResult: 0,
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+
+				return client
+			},
+			expectedResult: 0,
+			expectedError:  redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			repo := NewURLStorage(tc.setupRedis(t, ctx))
+
+			count, err := repo.Count(ctx)
+			assert.Equal(t, tc.expectedResult, count)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
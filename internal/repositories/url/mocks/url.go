@@ -13,6 +13,70 @@ type URLStorage struct {
 	mock.Mock
 }
 
+// AddOwnedLink provides a mock function with given fields: ctx, userID, code
+func (_m *URLStorage) AddOwnedLink(ctx context.Context, userID string, code string) error {
+	ret := _m.Called(ctx, userID, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOwnedLink")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Count provides a mock function with given fields: _a0
+func (_m *URLStorage) Count(_a0 context.Context) (int64, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Count")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteOwnedLinks provides a mock function with given fields: ctx, userID
+func (_m *URLStorage) DeleteOwnedLinks(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOwnedLinks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: _a0, _a1
 func (_m *URLStorage) Get(_a0 context.Context, _a1 string) (string, error) {
 	ret := _m.Called(_a0, _a1)
@@ -41,6 +105,36 @@ func (_m *URLStorage) Get(_a0 context.Context, _a1 string) (string, error) {
 	return r0, r1
 }
 
+// GetOwnedLinks provides a mock function with given fields: ctx, userID
+func (_m *URLStorage) GetOwnedLinks(ctx context.Context, userID string) (map[string]string, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOwnedLinks")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]string, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // StoreIfNotExists provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *URLStorage) StoreIfNotExists(_a0 context.Context, _a1 string, _a2 string, _a3 int) (bool, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
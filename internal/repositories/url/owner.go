@@ -0,0 +1,89 @@
+package url
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AddOwnedLink adds the code to the user's set of shortened links.
+func (s *urlStorage) AddOwnedLink(ctx context.Context, userID, code string) error {
+	return s.client.SAdd(ctx, fmt.Sprintf(ownedLinksKeyFormat, userID), code).Err()
+}
+
+// GetOwnedLinks reads the user's set of codes and resolves them in a single pipeline of
+// GETs, which a Redis Cluster splits by node, unlike an MGET of keys in different slots.
+// Codes whose URL has expired are dropped from the set.
+func (s *urlStorage) GetOwnedLinks(ctx context.Context, userID string) (map[string]string, error) {
+	key := fmt.Sprintf(ownedLinksKeyFormat, userID)
+	codes, err := s.client.SMembers(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	links := make(map[string]string, len(codes))
+	if len(codes) == 0 {
+		return links, nil
+	}
+
+	cmds, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, code := range codes {
+			pipe.Get(ctx, code)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	expired := make([]any, 0)
+	for i, code := range codes {
+		url, err := cmds[i].(*redis.StringCmd).Result()
+		if errors.Is(err, redis.Nil) {
+			expired = append(expired, code)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		links[code] = url
+	}
+	if len(expired) > 0 {
+		if err := s.client.SRem(ctx, key, expired...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return links, nil
+}
+
+// DeleteOwnedLinks deletes the user's codes and removes them from the link index in a
+// single pipeline, which a Redis Cluster splits by node, as the keys live in different
+// hash slots. The ownership set is deleted only once the codes are gone, so a failed call
+// can be retried.
+func (s *urlStorage) DeleteOwnedLinks(ctx context.Context, userID string) error {
+	key := fmt.Sprintf(ownedLinksKeyFormat, userID)
+	codes, err := s.client.SMembers(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	if len(codes) > 0 {
+		_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			members := make([]any, 0, len(codes))
+			for _, code := range codes {
+				members = append(members, code)
+				pipe.Del(ctx, code)
+			}
+			pipe.ZRem(ctx, linkIndexKey, members...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.client.Del(ctx, key).Err()
+}
@@ -0,0 +1,209 @@
+package url
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+const testOwnerID = "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91"
+
+// newClusterClient returns a Redis Cluster client of a single node, which rejects the
+// transactions of keys in different hash slots like a real cluster.
+func newClusterClient(t *testing.T) redis.UniversalClient {
+	client := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{miniredis.RunT(t).Addr()}})
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestURLStorage_AddOwnedLink(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupRedis    func(t *testing.T) *redis.Client
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupRedis: func(t *testing.T) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			client := tc.setupRedis(t)
+			repo := NewURLStorage(client)
+
+			err := repo.AddOwnedLink(ctx, testOwnerID, "1234567")
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				members := client.SMembers(ctx, "user_short_links_"+testOwnerID).Val()
+				assert.Equal(t, []string{"1234567"}, members)
+			}
+		})
+	}
+}
+
+func TestURLStorage_GetOwnedLinks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupRedis     func(t *testing.T, ctx context.Context) *redis.Client
+		expectedResult map[string]string
+		expectedError  error
+	}{
+		{
+			name: "success - owned links",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewURLStorage(client)
+				_, _ = repo.StoreIfNotExists(ctx, "1234567", "https://truonglq.com", 0)
+				_, _ = repo.StoreIfNotExists(ctx, "7654321", "https://example.com", 0)
+				_ = repo.AddOwnedLink(ctx, testOwnerID, "1234567")
+				_ = repo.AddOwnedLink(ctx, testOwnerID, "7654321")
+				_ = repo.AddOwnedLink(ctx, "another-user", "7654321")
+				return client
+			},
+			expectedResult: map[string]string{
+				"1234567": "https://truonglq.com",
+				"7654321": "https://example.com",
+			},
+		},
+		{
+			name: "success - expired links are dropped",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewURLStorage(client)
+				_, _ = repo.StoreIfNotExists(ctx, "1234567", "https://truonglq.com", 0)
+				_ = repo.AddOwnedLink(ctx, testOwnerID, "1234567")
+				_ = repo.AddOwnedLink(ctx, testOwnerID, "expired")
+				return client
+			},
+			expectedResult: map[string]string{
+				"1234567": "https://truonglq.com",
+			},
+		},
+		{
+			name: "success - no links",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				return redisPkg.InitMockRedis(t)
+			},
+			expectedResult: map[string]string{},
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) *redis.Client {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			client := tc.setupRedis(t, ctx)
+			repo := NewURLStorage(client)
+
+			links, err := repo.GetOwnedLinks(ctx, testOwnerID)
+			assert.Equal(t, tc.expectedResult, links)
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.EqualValues(t, len(tc.expectedResult), client.SCard(ctx, "user_short_links_"+testOwnerID).Val())
+			}
+		})
+	}
+}
+
+func TestURLStorage_DeleteOwnedLinks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupRedis    func(t *testing.T, ctx context.Context) redis.UniversalClient
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := redisPkg.InitMockRedis(t)
+				repo := NewURLStorage(client)
+				_, _ = repo.StoreIfNotExists(ctx, "1234567", "https://truonglq.com", 0)
+				_, _ = repo.StoreIfNotExists(ctx, "7654321", "https://example.com", 0)
+				_ = repo.AddOwnedLink(ctx, testOwnerID, "1234567")
+				return client
+			},
+		},
+		{
+			name: "success - redis cluster",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := newClusterClient(t)
+				repo := NewURLStorage(client)
+				_, _ = repo.StoreIfNotExists(ctx, "1234567", "https://truonglq.com", 0)
+				_, _ = repo.StoreIfNotExists(ctx, "7654321", "https://example.com", 0)
+				_ = repo.AddOwnedLink(ctx, testOwnerID, "1234567")
+				return client
+			},
+		},
+		{
+			name: "success - no links",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				return redisPkg.InitMockRedis(t)
+			},
+		},
+		{
+			name: "fail - redis connection",
+			setupRedis: func(t *testing.T, ctx context.Context) redis.UniversalClient {
+				client := redisPkg.InitMockRedis(t)
+				_ = client.Close()
+				return client
+			},
+			expectedError: redis.ErrClosed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			client := tc.setupRedis(t, ctx)
+			repo := NewURLStorage(client)
+
+			err := repo.DeleteOwnedLinks(ctx, testOwnerID)
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError != nil {
+				return
+			}
+
+			assert.Zero(t, client.Exists(ctx, "1234567", "user_short_links_"+testOwnerID).Val())
+			assert.Zero(t, client.ZScore(ctx, linkIndexKey, "1234567").Val())
+			count, _ := repo.Count(ctx)
+			assert.LessOrEqual(t, count, int64(1))
+		})
+	}
+}
@@ -3,16 +3,31 @@ package url
 import (
 	"context"
 	"time"
+
+	"github.com/redis/go-redis/v9"
 )
 
 // StoreIfNotExists stores a URL with the given code if the code does not already exist.
 // It returns true if the code was successfully stored, false if it already exists, and an error if storage fails.
 // The expire parameter specifies the expiration time in seconds (0 means default expiration).
+// Stored codes are also recorded in the link index so they can be counted by Count.
 func (s *urlStorage) StoreIfNotExists(ctx context.Context, code, url string, expire int) (bool, error) {
 	duration := expireTime
 	if expire > 0 {
 		duration = time.Duration(expire) * time.Second
 	}
 
-	return s.client.SetNX(ctx, code, url, duration).Result()
+	ok, err := s.client.SetNX(ctx, code, url, duration).Result()
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	if err := s.client.ZAdd(ctx, linkIndexKey, redis.Z{
+		Score:  float64(time.Now().Add(duration).Unix()),
+		Member: code,
+	}).Err(); err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
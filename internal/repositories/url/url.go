@@ -10,6 +10,12 @@ import (
 const (
 	// expireTime is the default expiration time for stored URLs.
 	expireTime = 24 * time.Hour
+
+	// linkIndexKey is the sorted set indexing stored codes by their expiration timestamp.
+	linkIndexKey = "short_link_index"
+
+	// ownedLinksKeyFormat is the set of codes shortened by an authenticated user.
+	ownedLinksKeyFormat = "user_short_links_%s"
 )
 
 // URLStorage defines the interface for URL storage repositories.
@@ -23,6 +29,15 @@ type URLStorage interface {
 	// It returns the URL string if found, or redis.Nil error if the key does not exist.
 	// Any other error indicates a storage operation failure.
 	Get(context.Context, string) (string, error)
+	// Count returns the number of stored URLs that have not expired yet.
+	Count(context.Context) (int64, error)
+	// AddOwnedLink records that the code was shortened by the given user.
+	AddOwnedLink(ctx context.Context, userID, code string) error
+	// GetOwnedLinks returns the codes shortened by the given user that have not expired yet,
+	// mapped to their URLs.
+	GetOwnedLinks(ctx context.Context, userID string) (map[string]string, error)
+	// DeleteOwnedLinks removes every URL shortened by the given user along with the ownership record.
+	DeleteOwnedLinks(ctx context.Context, userID string) error
 	// Exists(context.Context, string) (bool, error)
 }
 
@@ -30,11 +45,11 @@ type URLStorage interface {
 // for shortened URL mappings. It uses a Redis client to store and retrieve URL data
 // with configurable expiration times.
 type urlStorage struct {
-	client *redis.Client
+	client redis.UniversalClient
 }
 
 // NewURLStorage creates a new URL storage repository instance with the provided Redis client.
-func NewURLStorage(client *redis.Client) URLStorage {
+func NewURLStorage(client redis.UniversalClient) URLStorage {
 	return &urlStorage{
 		client: client,
 	}
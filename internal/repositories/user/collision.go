@@ -0,0 +1,67 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+)
+
+// identityFields are the user columns that must be unique regardless of letter case.
+var identityFields = []string{"username", "email"}
+
+// IdentityCollision describes several users sharing the same username or email address
+// once letter case is ignored. Such users prevent the lowercased unique indexes from being
+// created and must be resolved by hand.
+type IdentityCollision struct {
+	// Field is the colliding column, either "username" or "email".
+	Field string
+	// Value is the lowercased value shared by the users.
+	Value string
+	// Users are the colliding users, oldest first.
+	Users []*model.User
+}
+
+// FindIdentityCollisions groups the users by their lowercased username and email address
+// and returns every group holding more than one user.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//
+// Returns:
+//   - []*IdentityCollision: The collisions, usernames first; empty when there are none
+//   - error: A database error if a query fails
+func (u *user) FindIdentityCollisions(ctx context.Context) ([]*IdentityCollision, error) {
+	collisions := make([]*IdentityCollision, 0)
+	for _, field := range identityFields {
+		lowered := fmt.Sprintf("LOWER(%s)", field)
+
+		values := make([]string, 0)
+		if err := u.db.WithContext(ctx).Model(&model.User{}).
+			Select(lowered).
+			Group(lowered).
+			Having("COUNT(*) > 1").
+			Order(lowered).
+			Pluck(lowered, &values).Error; err != nil {
+			return nil, err
+		}
+
+		for _, value := range values {
+			users := make([]*model.User, 0)
+			if err := u.db.WithContext(ctx).
+				Where(fmt.Sprintf("%s = ?", lowered), value).
+				Order("created_at ASC").
+				Find(&users).Error; err != nil {
+				return nil, err
+			}
+
+			collisions = append(collisions, &IdentityCollision{
+				Field: field,
+				Value: value,
+				Users: users,
+			})
+		}
+	}
+
+	return collisions, nil
+}
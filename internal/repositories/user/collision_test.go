@@ -0,0 +1,86 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/internal/test/fixture"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestUser_FindIdentityCollisions(t *testing.T) {
+	t.Parallel()
+
+	// withoutIdentityIndexes recreates a database predating the lowercased unique indexes,
+	// where identities that differ only by case could still be stored.
+	withoutIdentityIndexes := func(t *testing.T) *gorm.DB {
+		db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+		db.Exec("DROP INDEX uni_users_username_lower")
+		db.Exec("DROP INDEX uni_users_email_lower")
+		return db
+	}
+
+	testCases := []struct {
+		name           string
+		setupDB        func(t *testing.T) *gorm.DB
+		expectedResult map[string][]string
+	}{
+		{
+			name: "success - no collisions",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			expectedResult: map[string][]string{},
+		},
+		{
+			name: "success - username and email collisions",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := withoutIdentityIndexes(t)
+				db.Create(&model.User{
+					Base:        model.Base{ID: "0c1f5d8e-2b7a-4e19-9c3d-5a6b7c8d9e01", CreatedAt: time.Now()},
+					Username:    "AN.NGUYEN",
+					DisplayName: "An Upper",
+					Email:       "an.upper@example.com",
+					Password:    "P@ssw0rd",
+				})
+				db.Create(&model.User{
+					Base:        model.Base{ID: "0c1f5d8e-2b7a-4e19-9c3d-5a6b7c8d9e02", CreatedAt: time.Now()},
+					Username:    "binh.other",
+					DisplayName: "Binh Other",
+					Email:       "Binh.Tran@Example.com",
+					Password:    "P@ssw0rd",
+				})
+				return db
+			},
+			expectedResult: map[string][]string{
+				"username:an.nguyen":          {"9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91", "0c1f5d8e-2b7a-4e19-9c3d-5a6b7c8d9e01"},
+				"email:binh.tran@example.com": {"2f6c9d14-9e42-4c31-9e6a-0f8c4b2a7c55", "0c1f5d8e-2b7a-4e19-9c3d-5a6b7c8d9e02"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := NewUser(tc.setupDB(t))
+
+			collisions, err := repo.FindIdentityCollisions(ctx)
+			assert.NoError(t, err)
+
+			result := make(map[string][]string, len(collisions))
+			for _, collision := range collisions {
+				ids := make([]string, 0, len(collision.Users))
+				for _, user := range collision.Users {
+					ids = append(ids, user.ID)
+				}
+				result[collision.Field+":"+collision.Value] = ids
+			}
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
@@ -2,7 +2,6 @@ package user
 
 import (
 	"context"
-	"strings"
 	"testing"
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
@@ -16,12 +15,13 @@ func TestUser_CreateUser(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
-		name           string
-		setupDB        func(t *testing.T) *gorm.DB
-		inputUser      *model.User
-		expectedError  error
-		expectedOutput *model.User
-		verifyFunc     func(t *testing.T, db *gorm.DB, user *model.User)
+		name               string
+		setupDB            func(t *testing.T) *gorm.DB
+		inputUser          *model.User
+		expectedError      error
+		expectedConstraint string
+		expectedOutput     *model.User
+		verifyFunc         func(t *testing.T, db *gorm.DB, user *model.User)
 	}{
 		{
 			name: "success - create new user",
@@ -94,9 +94,42 @@ func TestUser_CreateUser(t *testing.T) {
 				Password:    "P@ssw0rd1",
 				Email:       "duplicate@example.com",
 			},
-			expectedError:  dbutils.ErrDuplicationType,
-			expectedOutput: nil,
-			verifyFunc:     nil,
+			expectedError:      dbutils.ErrDuplicationType,
+			expectedConstraint: "uni_users_username_lower",
+			expectedOutput:     nil,
+			verifyFunc:         nil,
+		},
+		{
+			name: "error - username differs only by case",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			inputUser: &model.User{
+				DisplayName: "Duplicate User",
+				Username:    "An.Nguyen",
+				Password:    "P@ssw0rd1",
+				Email:       "duplicate@example.com",
+			},
+			expectedError:      dbutils.ErrDuplicationType,
+			expectedConstraint: "uni_users_username_lower",
+			expectedOutput:     nil,
+			verifyFunc:         nil,
+		},
+		{
+			name: "error - email differs only by case",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			inputUser: &model.User{
+				DisplayName: "Duplicate User",
+				Username:    "duplicate.user",
+				Password:    "P@ssw0rd1",
+				Email:       "AN.NGUYEN@example.com",
+			},
+			expectedError:      dbutils.ErrDuplicationType,
+			expectedConstraint: "uni_users_email_lower",
+			expectedOutput:     nil,
+			verifyFunc:         nil,
 		},
 	}
 
@@ -111,13 +144,10 @@ func TestUser_CreateUser(t *testing.T) {
 			res, err := repo.CreateUser(ctx, tc.inputUser)
 
 			if tc.expectedError != nil {
-				assert.Error(t, err)
-
-				if err != tc.expectedError {
-
-					errStr := strings.ToLower(err.Error())
-					assert.True(t, strings.Contains(errStr, "unique constraint") || err == dbutils.ErrDuplicationType,
-						"expected duplicate constraint error, got: %v", err)
+				assert.ErrorIs(t, err, tc.expectedError)
+				var constraintErr *dbutils.ConstraintError
+				if assert.ErrorAs(t, err, &constraintErr) {
+					assert.Equal(t, tc.expectedConstraint, constraintErr.Constraint)
 				}
 				assert.Nil(t, res)
 			} else {
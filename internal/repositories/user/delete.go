@@ -0,0 +1,73 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"gorm.io/gorm"
+)
+
+// ScheduleDeletion sets the time at which the account of the user identified by their ID
+// will be purged. Passing nil cancels a scheduled deletion.
+// It returns ErrNotFoundType if the user does not exist.
+func (u *user) ScheduleDeletion(ctx context.Context, id string, at *time.Time) error {
+	tx := u.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", id).Update("deletion_scheduled_at", at)
+	if tx.Error != nil {
+		return dbutils.CatchDBErr(tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
+
+// ListUsersDueForDeletion retrieves up to limit users whose scheduled deletion time is at
+// or before the given time, oldest schedule first.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - before: Users scheduled for deletion at or before this time are returned
+//   - limit: The maximum number of records to return
+//
+// Returns:
+//   - []*model.User: A slice of users due for deletion, or nil if an error occurs
+//   - error: A database error if the query fails
+func (u *user) ListUsersDueForDeletion(ctx context.Context, before time.Time, limit int) ([]*model.User, error) {
+	users := make([]*model.User, 0)
+	if err := u.db.WithContext(ctx).
+		Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", before).
+		Order("deletion_scheduled_at ASC").
+		Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// DeleteUserDueForDeletion permanently removes the user identified by their ID if their
+// deletion is still scheduled at or before the given time. The bookmarks of the user are
+// removed by the ON DELETE CASCADE foreign key of the bookmarks table. The cleanup removing
+// the data kept out of the database runs once the row is deleted, in the same transaction:
+// the row stays locked until it completes, so a concurrent cancellation waits, and the
+// deletion is rolled back if it fails.
+// It returns ErrNotFoundType if the user does not exist or their deletion is no longer due,
+// in which case the cleanup does not run.
+func (u *user) DeleteUserDueForDeletion(ctx context.Context, id string, before time.Time, cleanup func(ctx context.Context) error) error {
+	return dbutils.Transaction(ctx, u.db, func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", id, before).
+			Delete(&model.User{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return dbutils.ErrNotFoundType
+		}
+
+		return cleanup(ctx)
+	})
+}
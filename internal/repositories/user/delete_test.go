@@ -0,0 +1,296 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/internal/test/fixture"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestUser_ScheduleDeletion(t *testing.T) {
+	t.Parallel()
+
+	scheduledAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		setupDB       func(t *testing.T) *gorm.DB
+		id            string
+		at            *time.Time
+		expectedError error
+		expectError   bool
+		expectedAt    *time.Time
+	}{
+		{
+			name: "success - schedule deletion",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			id:         "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91",
+			at:         &scheduledAt,
+			expectedAt: &scheduledAt,
+		},
+		{
+			name: "success - cancel deletion",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				db.Model(&model.User{}).Where("id = ?", "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91").
+					Update("deletion_scheduled_at", scheduledAt)
+				return db
+			},
+			id: "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91",
+		},
+		{
+			name: "error - user not found",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			id:            "00000000-0000-0000-0000-000000000000",
+			at:            &scheduledAt,
+			expectedError: dbutils.ErrNotFoundType,
+		},
+		{
+			name: "error - db error",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				_ = db.Migrator().DropTable(&model.User{})
+				return db
+			},
+			id:          "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91",
+			at:          &scheduledAt,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := tc.setupDB(t)
+			repo := NewUser(db)
+
+			err := repo.ScheduleDeletion(ctx, tc.id, tc.at)
+			switch {
+			case tc.expectedError != nil:
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			case tc.expectError:
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			user := &model.User{}
+			assert.NoError(t, db.Where("id = ?", tc.id).First(user).Error)
+			if tc.expectedAt == nil {
+				assert.Nil(t, user.DeletionScheduledAt)
+			} else {
+				assert.True(t, tc.expectedAt.Equal(*user.DeletionScheduledAt))
+			}
+		})
+	}
+}
+
+func TestUser_ListUsersDueForDeletion(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name        string
+		setupDB     func(t *testing.T) *gorm.DB
+		limit       int
+		expectedIDs []string
+		expectError bool
+	}{
+		{
+			name: "success - only overdue users, oldest first",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				schedules := map[string]time.Time{
+					"9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91": now.Add(-time.Hour),
+					"2f6c9d14-9e42-4c31-9e6a-0f8c4b2a7c55": now.Add(-48 * time.Hour),
+					"e3c2a8f1-1d3b-4c62-8e54-6b7f9a2d1c90": now.Add(time.Hour),
+				}
+				for id, at := range schedules {
+					db.Model(&model.User{}).Where("id = ?", id).Update("deletion_scheduled_at", at)
+				}
+				return db
+			},
+			limit: 10,
+			expectedIDs: []string{
+				"2f6c9d14-9e42-4c31-9e6a-0f8c4b2a7c55",
+				"9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91",
+			},
+		},
+		{
+			name: "success - limit applied",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				db.Model(&model.User{}).Where("id = ?", "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91").
+					Update("deletion_scheduled_at", now.Add(-2*time.Hour))
+				db.Model(&model.User{}).Where("id = ?", "2f6c9d14-9e42-4c31-9e6a-0f8c4b2a7c55").
+					Update("deletion_scheduled_at", now.Add(-time.Hour))
+				return db
+			},
+			limit:       1,
+			expectedIDs: []string{"9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91"},
+		},
+		{
+			name: "success - nothing scheduled",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			limit:       10,
+			expectedIDs: []string{},
+		},
+		{
+			name: "error - db error",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				_ = db.Migrator().DropTable(&model.User{})
+				return db
+			},
+			limit:       10,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := NewUser(tc.setupDB(t))
+
+			users, err := repo.ListUsersDueForDeletion(ctx, now, tc.limit)
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, users)
+				return
+			}
+			assert.NoError(t, err)
+
+			ids := make([]string, 0, len(users))
+			for _, user := range users {
+				ids = append(ids, user.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestUser_DeleteUserDueForDeletion(t *testing.T) {
+	t.Parallel()
+
+	const userID = "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91"
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	testErrCleanup := errors.New("cleanup error")
+
+	// scheduledAt returns a fixture database where the deletion of the user is scheduled at
+	// the given time, or not scheduled when nil.
+	scheduledAt := func(at *time.Time) func(t *testing.T) *gorm.DB {
+		return func(t *testing.T) *gorm.DB {
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			assert.NoError(t, db.Model(&model.User{}).Where("id = ?", userID).Update("deletion_scheduled_at", at).Error)
+			return db
+		}
+	}
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	testCases := []struct {
+		name            string
+		setupDB         func(t *testing.T) *gorm.DB
+		id              string
+		cleanupErr      error
+		expectedCleanup bool
+		expectedDeleted bool
+		expectedError   error
+		expectError     bool
+	}{
+		{
+			name:            "success",
+			setupDB:         scheduledAt(&past),
+			id:              userID,
+			expectedCleanup: true,
+			expectedDeleted: true,
+		},
+		{
+			name:          "error - deletion cancelled",
+			setupDB:       scheduledAt(nil),
+			id:            userID,
+			expectedError: dbutils.ErrNotFoundType,
+		},
+		{
+			name:          "error - deletion not due yet",
+			setupDB:       scheduledAt(&future),
+			id:            userID,
+			expectedError: dbutils.ErrNotFoundType,
+		},
+		{
+			name:          "error - user not found",
+			setupDB:       scheduledAt(&past),
+			id:            "00000000-0000-0000-0000-000000000000",
+			expectedError: dbutils.ErrNotFoundType,
+		},
+		{
+			name:            "error - cleanup fails and the deletion is rolled back",
+			setupDB:         scheduledAt(&past),
+			id:              userID,
+			cleanupErr:      testErrCleanup,
+			expectedCleanup: true,
+			expectedError:   testErrCleanup,
+		},
+		{
+			name: "error - db error",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				_ = db.Migrator().DropTable(&model.User{})
+				return db
+			},
+			id:          userID,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := tc.setupDB(t)
+			repo := NewUser(db)
+
+			cleanedUp := false
+			err := repo.DeleteUserDueForDeletion(ctx, tc.id, now, func(context.Context) error {
+				cleanedUp = true
+				return tc.cleanupErr
+			})
+			assert.Equal(t, tc.expectedCleanup, cleanedUp)
+			switch {
+			case tc.expectedError != nil:
+				assert.ErrorIs(t, err, tc.expectedError)
+			case tc.expectError:
+				assert.Error(t, err)
+				return
+			default:
+				assert.NoError(t, err)
+			}
+
+			var count int64
+			assert.NoError(t, db.Model(&model.User{}).Where("id = ?", userID).Count(&count).Error)
+			if tc.expectedDeleted {
+				assert.Zero(t, count)
+			} else {
+				assert.Equal(t, int64(1), count)
+			}
+		})
+	}
+}
@@ -3,13 +3,15 @@ package user
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
 	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"gorm.io/gorm/clause"
 )
 
 // GetUserByUsername retrieves a user from the database by their unique username.
-// This is a convenience method that delegates to GetUserByField with the "username" field.
+// Usernames are unique regardless of letter case, so the match ignores case.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and timeout
@@ -19,7 +21,47 @@ import (
 //   - *model.User: User information if found
 //   - error: Returns ErrNotFoundType if user doesn't exist, or other database errors
 func (u *user) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
-	return u.GetUserByField(ctx, "username", username)
+	return u.getUserByFoldedField(ctx, "username", username)
+}
+
+// GetUserByEmail retrieves a user from the database by their unique email address.
+// Email addresses are unique regardless of letter case, so the match ignores case.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - email: Email address to search for
+//
+// Returns:
+//   - *model.User: User information if found
+//   - error: Returns ErrNotFoundType if user doesn't exist, or other database errors
+func (u *user) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	return u.getUserByFoldedField(ctx, "email", email)
+}
+
+// GetUserByLogin retrieves the user whose username or email address matches the given
+// login identifier, ignoring case. When the identifier is the username of one account and
+// the email address of another, the email match wins: usernames cannot contain '@', but
+// accounts registered before that rule must not shadow the email login of another account.
+// Take is used rather than First, whose primary key ordering would replace this precedence.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - login: Username or email address to search for
+//
+// Returns:
+//   - *model.User: User information if found
+//   - error: Returns ErrNotFoundType if no user matches, or other database errors
+func (u *user) GetUserByLogin(ctx context.Context, login string) (*model.User, error) {
+	login = strings.ToLower(login)
+
+	user := &model.User{}
+	if err := u.db.WithContext(ctx).
+		Where("LOWER(username) = ? OR LOWER(email) = ?", login, login).
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "CASE WHEN LOWER(email) = ? THEN 0 ELSE 1 END", Vars: []any{login}}}).
+		Take(user).Error; err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+	return user, nil
 }
 
 // GetUserByID retrieves a user from the database by their unique identifier (UUID).
@@ -58,3 +100,13 @@ func (u *user) GetUserByField(ctx context.Context, field string, value string) (
 	}
 	return user, nil
 }
+
+// getUserByFoldedField retrieves a user whose value in the given column matches the value
+// regardless of case. The lowercased comparison is served by the lowercased unique indexes.
+func (u *user) getUserByFoldedField(ctx context.Context, field string, value string) (*model.User, error) {
+	user := &model.User{}
+	if err := u.db.WithContext(ctx).Where(fmt.Sprintf("LOWER(%s) = ?", field), strings.ToLower(value)).First(user).Error; err != nil {
+		return nil, dbutils.CatchDBErr(err)
+	}
+	return user, nil
+}
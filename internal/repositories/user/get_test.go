@@ -57,6 +57,23 @@ func TestUser_GetUserByUsername(t *testing.T) {
 				Email:       "binh.tran@example.com",
 			},
 		},
+		{
+			name: "success - username matches regardless of case",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			username:      "AN.Nguyen",
+			expectedError: nil,
+			expectedOutput: &model.User{
+				Base: model.Base{
+					ID: "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91",
+				},
+				DisplayName: "Nguyen Van An",
+				Username:    "an.nguyen",
+				Password:    "P@ssw0rd1",
+				Email:       "an.nguyen@example.com",
+			},
+		},
 		{
 			name: "error - user not found",
 			setupDB: func(t *testing.T) *gorm.DB {
@@ -104,6 +121,157 @@ func TestUser_GetUserByUsername(t *testing.T) {
 	}
 }
 
+func TestUser_GetUserByEmail(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		setupDB        func(t *testing.T) *gorm.DB
+		email          string
+		expectedError  error
+		expectedOutput *model.User
+	}{
+		{
+			name: "success - get existing user",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			email:         "an.nguyen@example.com",
+			expectedError: nil,
+			expectedOutput: &model.User{
+				Base: model.Base{
+					ID: "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91",
+				},
+				DisplayName: "Nguyen Van An",
+				Username:    "an.nguyen",
+				Password:    "P@ssw0rd1",
+				Email:       "an.nguyen@example.com",
+			},
+		},
+		{
+			name: "success - email matches regardless of case",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			email:         "An.Nguyen@Example.com",
+			expectedError: nil,
+			expectedOutput: &model.User{
+				Base: model.Base{
+					ID: "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91",
+				},
+				DisplayName: "Nguyen Van An",
+				Username:    "an.nguyen",
+				Password:    "P@ssw0rd1",
+				Email:       "an.nguyen@example.com",
+			},
+		},
+		{
+			name: "error - user not found",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			email:          "nonexistent@example.com",
+			expectedError:  dbutils.ErrNotFoundType,
+			expectedOutput: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := tc.setupDB(t)
+			repo := NewUser(db)
+
+			res, err := repo.GetUserByEmail(ctx, tc.email)
+
+			if tc.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.expectedError, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, res)
+				assert.Equal(t, tc.expectedOutput.ID, res.ID)
+				assert.Equal(t, tc.expectedOutput.Username, res.Username)
+				assert.Equal(t, tc.expectedOutput.Email, res.Email)
+				assert.Equal(t, tc.expectedOutput.DisplayName, res.DisplayName)
+				assert.True(t, utils.VerifyPassword(tc.expectedOutput.Password, res.Password))
+			}
+		})
+	}
+}
+
+func TestUser_GetUserByLogin(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupDB       func(t *testing.T) *gorm.DB
+		login         string
+		expectedError error
+		expectedID    string
+	}{
+		{
+			name: "success - username",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			login:      "binh.tran",
+			expectedID: "2f6c9d14-9e42-4c31-9e6a-0f8c4b2a7c55",
+		},
+		{
+			name: "success - email regardless of case",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			login:      "Binh.Tran@Example.com",
+			expectedID: "2f6c9d14-9e42-4c31-9e6a-0f8c4b2a7c55",
+		},
+		{
+			name: "success - email wins over another user's username",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				db.Create(&model.User{
+					Base:        model.Base{ID: "0c1f5d8e-2b7a-4e19-9c3d-5a6b7c8d9e01"},
+					Username:    "an.nguyen@example.com",
+					DisplayName: "Email Like Username",
+					Email:       "email.like@example.com",
+					Password:    "P@ssw0rd",
+				})
+				return db
+			},
+			login:      "an.nguyen@example.com",
+			expectedID: "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91",
+		},
+		{
+			name: "error - user not found",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			login:         "nobody@example.com",
+			expectedError: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := NewUser(tc.setupDB(t))
+
+			res, err := repo.GetUserByLogin(ctx, tc.login)
+
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.Equal(t, tc.expectedID, res.ID)
+			}
+		})
+	}
+}
+
 func TestUser_GetUserByID(t *testing.T) {
 	t.Parallel()
 
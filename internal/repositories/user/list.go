@@ -0,0 +1,70 @@
+package user
+
+import (
+	"context"
+	"strings"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"gorm.io/gorm"
+)
+
+// ListUsers retrieves users with pagination support, optionally filtered by a search term.
+// The search is case-insensitive and matches against username, email and display name.
+// Users are ordered by creation date (ascending).
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - search: Optional search term; an empty string returns all users
+//   - offset: The number of records to skip (for pagination)
+//   - limit: The maximum number of records to return
+//
+// Returns:
+//   - []*model.User: A slice of matching users, or nil if an error occurs
+//   - error: A database error if the query fails
+func (u *user) ListUsers(ctx context.Context, search string, offset, limit int) ([]*model.User, error) {
+	users := make([]*model.User, 0)
+	if err := u.searchUsers(ctx, search).
+		Order("created_at ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// CountUsers counts the users matching the optional search term.
+// It applies the same filter as ListUsers so the result can be used for pagination.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - search: Optional search term; an empty string counts all users
+//
+// Returns:
+//   - int64: The number of matching users, or 0 if an error occurs
+//   - error: A database error if the count query fails
+func (u *user) CountUsers(ctx context.Context, search string) (int64, error) {
+	var count int64
+	if err := u.searchUsers(ctx, search).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// searchUsers builds the base query shared by ListUsers and CountUsers.
+func (u *user) searchUsers(ctx context.Context, search string) *gorm.DB {
+	query := u.db.WithContext(ctx).Model(&model.User{})
+
+	search = strings.TrimSpace(search)
+	if search == "" {
+		return query
+	}
+
+	pattern := "%" + strings.ToLower(search) + "%"
+	return query.Where(
+		"LOWER(username) LIKE ? OR LOWER(email) LIKE ? OR LOWER(display_name) LIKE ?",
+		pattern, pattern, pattern,
+	)
+}
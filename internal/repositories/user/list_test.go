@@ -0,0 +1,109 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/luongtruong20201/bookmark-management/internal/test/fixture"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestUser_ListUsers(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name              string
+		setupDB           func(t *testing.T) *gorm.DB
+		search            string
+		offset            int
+		limit             int
+		expectedUsernames []string
+		expectedCount     int64
+		expectError       bool
+	}{
+		{
+			name: "success - first page without search",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			offset:            0,
+			limit:             3,
+			expectedUsernames: []string{"an.nguyen", "binh.tran", "huy.le"},
+			expectedCount:     11,
+		},
+		{
+			name: "success - search is case insensitive across fields",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			search:            "NGUYEN",
+			offset:            0,
+			limit:             10,
+			expectedUsernames: []string{"an.nguyen", "mai.nguyen"},
+			expectedCount:     2,
+		},
+		{
+			name: "success - search by display name",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			search:            "john doe",
+			offset:            0,
+			limit:             10,
+			expectedUsernames: []string{"johndoe"},
+			expectedCount:     1,
+		},
+		{
+			name: "success - no match",
+			setupDB: func(t *testing.T) *gorm.DB {
+				return fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			},
+			search:            "nobody",
+			offset:            0,
+			limit:             10,
+			expectedUsernames: []string{},
+			expectedCount:     0,
+		},
+		{
+			name: "error - db error",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				sqlDB, _ := db.DB()
+				_ = sqlDB.Close()
+				return db
+			},
+			limit:       10,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repo := NewUser(tc.setupDB(t))
+
+			users, err := repo.ListUsers(ctx, tc.search, tc.offset, tc.limit)
+			count, countErr := repo.CountUsers(ctx, tc.search)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Error(t, countErr)
+				assert.Nil(t, users)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NoError(t, countErr)
+			assert.Equal(t, tc.expectedCount, count)
+
+			usernames := make([]string, 0, len(users))
+			for _, u := range users {
+				usernames = append(usernames, u.Username)
+			}
+			assert.ElementsMatch(t, tc.expectedUsernames, usernames)
+		})
+	}
+}
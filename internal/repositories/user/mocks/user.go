@@ -4,8 +4,10 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	user "github.com/luongtruong20201/bookmark-management/internal/repositories/user"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,6 +16,34 @@ type User struct {
 	mock.Mock
 }
 
+// CountUsers provides a mock function with given fields: ctx, search
+func (_m *User) CountUsers(ctx context.Context, search string) (int64, error) {
+	ret := _m.Called(ctx, search)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUsers")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, search)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, search)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, search)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateUser provides a mock function with given fields: _a0, _a1
 func (_m *User) CreateUser(_a0 context.Context, _a1 *model.User) (*model.User, error) {
 	ret := _m.Called(_a0, _a1)
@@ -44,6 +74,84 @@ func (_m *User) CreateUser(_a0 context.Context, _a1 *model.User) (*model.User, e
 	return r0, r1
 }
 
+// DeleteUserDueForDeletion provides a mock function with given fields: ctx, id, before, cleanup
+func (_m *User) DeleteUserDueForDeletion(ctx context.Context, id string, before time.Time, cleanup func(context.Context) error) error {
+	ret := _m.Called(ctx, id, before, cleanup)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserDueForDeletion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, func(context.Context) error) error); ok {
+		r0 = rf(ctx, id, before, cleanup)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindIdentityCollisions provides a mock function with given fields: ctx
+func (_m *User) FindIdentityCollisions(ctx context.Context) ([]*user.IdentityCollision, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIdentityCollisions")
+	}
+
+	var r0 []*user.IdentityCollision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*user.IdentityCollision, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*user.IdentityCollision); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*user.IdentityCollision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByEmail provides a mock function with given fields: ctx, email
+func (_m *User) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByField provides a mock function with given fields: ctx, field, value
 func (_m *User) GetUserByField(ctx context.Context, field string, value string) (*model.User, error) {
 	ret := _m.Called(ctx, field, value)
@@ -104,6 +212,36 @@ func (_m *User) GetUserByID(ctx context.Context, id string) (*model.User, error)
 	return r0, r1
 }
 
+// GetUserByLogin provides a mock function with given fields: ctx, login
+func (_m *User) GetUserByLogin(ctx context.Context, login string) (*model.User, error) {
+	ret := _m.Called(ctx, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByLogin")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.User, error)); ok {
+		return rf(ctx, login)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.User); ok {
+		r0 = rf(ctx, login)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, login)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserByUsername provides a mock function with given fields: _a0, _a1
 func (_m *User) GetUserByUsername(_a0 context.Context, _a1 string) (*model.User, error) {
 	ret := _m.Called(_a0, _a1)
@@ -134,6 +272,138 @@ func (_m *User) GetUserByUsername(_a0 context.Context, _a1 string) (*model.User,
 	return r0, r1
 }
 
+// ListUsers provides a mock function with given fields: ctx, search, offset, limit
+func (_m *User) ListUsers(ctx context.Context, search string, offset int, limit int) ([]*model.User, error) {
+	ret := _m.Called(ctx, search, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []*model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*model.User, error)); ok {
+		return rf(ctx, search, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*model.User); ok {
+		r0 = rf(ctx, search, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, search, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsersDueForDeletion provides a mock function with given fields: ctx, before, limit
+func (_m *User) ListUsersDueForDeletion(ctx context.Context, before time.Time, limit int) ([]*model.User, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsersDueForDeletion")
+	}
+
+	var r0 []*model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]*model.User, error)); ok {
+		return rf(ctx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*model.User); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequirePasswordReset provides a mock function with given fields: ctx, id
+func (_m *User) RequirePasswordReset(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequirePasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScheduleDeletion provides a mock function with given fields: ctx, id, at
+func (_m *User) ScheduleDeletion(ctx context.Context, id string, at *time.Time) error {
+	ret := _m.Called(ctx, id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleDeletion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time) error); ok {
+		r0 = rf(ctx, id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePassword provides a mock function with given fields: ctx, id, hashedPassword
+func (_m *User) UpdatePassword(ctx context.Context, id string, hashedPassword string) error {
+	ret := _m.Called(ctx, id, hashedPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, hashedPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePasswordHash provides a mock function with given fields: ctx, id, hashedPassword
+func (_m *User) UpdatePasswordHash(ctx context.Context, id string, hashedPassword string) error {
+	ret := _m.Called(ctx, id, hashedPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePasswordHash")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, hashedPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUserProfile provides a mock function with given fields: ctx, id, displayName, email
 func (_m *User) UpdateUserProfile(ctx context.Context, id string, displayName string, email string) (*model.User, error) {
 	ret := _m.Called(ctx, id, displayName, email)
@@ -164,6 +434,36 @@ func (_m *User) UpdateUserProfile(ctx context.Context, id string, displayName st
 	return r0, r1
 }
 
+// UpdateUserStatus provides a mock function with given fields: ctx, id, disabled
+func (_m *User) UpdateUserStatus(ctx context.Context, id string, disabled bool) (*model.User, error) {
+	ret := _m.Called(ctx, id, disabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserStatus")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*model.User, error)); ok {
+		return rf(ctx, id, disabled)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *model.User); ok {
+		r0 = rf(ctx, id, disabled)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, id, disabled)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewUser creates a new instance of User. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUser(t interface {
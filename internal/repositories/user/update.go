@@ -5,17 +5,44 @@ import (
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
 	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"gorm.io/gorm"
 )
 
 // UpdateUserProfile updates the display name and email of a user identified by their ID.
+// Changing the email, beyond its letter case, marks it as unverified.
+// The update and the read of the updated user run in a transaction, retried if the
+// database aborts it on a conflict.
 // It returns the updated user or an error if the user does not exist or the update fails.
 func (u *user) UpdateUserProfile(ctx context.Context, id, displayName, email string) (*model.User, error) {
 	updates := map[string]interface{}{
-		"display_name": displayName,
-		"email":        email,
+		"display_name":   displayName,
+		"email":          email,
+		"email_verified": gorm.Expr("email_verified AND LOWER(email) = LOWER(?)", email),
 	}
 
-	tx := u.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", id).Updates(updates)
+	res := &model.User{}
+	err := dbutils.Transaction(ctx, u.db, func(tx *gorm.DB) error {
+		result := tx.Model(&model.User{}).Where("id = ?", id).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return dbutils.ErrNotFoundType
+		}
+
+		return tx.Where("id = ?", id).First(res).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateUserStatus enables or disables the account of the user identified by their ID.
+// It returns the updated user or ErrNotFoundType if the user does not exist.
+func (u *user) UpdateUserStatus(ctx context.Context, id string, disabled bool) (*model.User, error) {
+	tx := u.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", id).Update("disabled", disabled)
 	if tx.Error != nil {
 		return nil, dbutils.CatchDBErr(tx.Error)
 	}
@@ -26,3 +53,53 @@ func (u *user) UpdateUserProfile(ctx context.Context, id, displayName, email str
 
 	return u.GetUserByID(ctx, id)
 }
+
+// RequirePasswordReset flags the user identified by their ID so that they must change
+// their password before they can log in again. It returns ErrNotFoundType if the user does not exist.
+func (u *user) RequirePasswordReset(ctx context.Context, id string) error {
+	tx := u.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", id).Update("password_reset_required", true)
+	if tx.Error != nil {
+		return dbutils.CatchDBErr(tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
+
+// UpdatePassword replaces the password hash of the user identified by their ID and clears
+// any pending password reset requirement. It returns ErrNotFoundType if the user does not exist.
+func (u *user) UpdatePassword(ctx context.Context, id, hashedPassword string) error {
+	updates := map[string]interface{}{
+		"password":                hashedPassword,
+		"password_reset_required": false,
+	}
+
+	tx := u.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", id).Updates(updates)
+	if tx.Error != nil {
+		return dbutils.CatchDBErr(tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
+
+// UpdatePasswordHash replaces the password hash of the user identified by their ID, leaving
+// the password reset requirement as is. It returns ErrNotFoundType if the user does not exist.
+func (u *user) UpdatePasswordHash(ctx context.Context, id, hashedPassword string) error {
+	tx := u.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", id).Update("password", hashedPassword)
+	if tx.Error != nil {
+		return dbutils.CatchDBErr(tx.Error)
+	}
+
+	if tx.RowsAffected == 0 {
+		return dbutils.ErrNotFoundType
+	}
+
+	return nil
+}
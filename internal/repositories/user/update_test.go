@@ -45,6 +45,38 @@ func TestUser_UpdateUserProfile(t *testing.T) {
 				assert.Equal(t, email, toCheckUser.Email)
 			},
 		},
+		{
+			name: "success - new email no longer verified",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				assert.NoError(t, db.Model(&model.User{}).Where("id = ?", "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91").
+					Update("email_verified", true).Error)
+				return db
+			},
+			id:          "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91",
+			displayName: "Nguyen Van An",
+			email:       "updated.email@example.com",
+			verifyFunc: func(t *testing.T, db *gorm.DB, id, displayName, email string, got *model.User) {
+				assert.Equal(t, email, got.Email)
+				assert.False(t, got.EmailVerified)
+			},
+		},
+		{
+			name: "success - email letter case change stays verified",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+				assert.NoError(t, db.Model(&model.User{}).Where("id = ?", "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91").
+					Update("email_verified", true).Error)
+				return db
+			},
+			id:          "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91",
+			displayName: "Nguyen Van An",
+			email:       "An.Nguyen@example.com",
+			verifyFunc: func(t *testing.T, db *gorm.DB, id, displayName, email string, got *model.User) {
+				assert.Equal(t, email, got.Email)
+				assert.True(t, got.EmailVerified)
+			},
+		},
 		{
 			name: "error - user not found",
 			setupDB: func(t *testing.T) *gorm.DB {
@@ -99,3 +131,184 @@ func TestUser_UpdateUserProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_UpdateUserStatus(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		id            string
+		disabled      bool
+		expectedError error
+	}{
+		{
+			name:     "success - disable user",
+			id:       "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91",
+			disabled: true,
+		},
+		{
+			name:     "success - enable disabled user",
+			id:       fixture.DisabledUserID,
+			disabled: false,
+		},
+		{
+			name:          "error - user not found",
+			id:            "00000000-0000-0000-0000-000000000000",
+			disabled:      true,
+			expectedError: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := fixture.NewFixture(t, &fixture.UserAdminTestDB{})
+			repo := NewUser(db)
+
+			got, err := repo.UpdateUserStatus(ctx, tc.id, tc.disabled)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.disabled, got.Disabled)
+
+			toCheckUser := &model.User{}
+			assert.NoError(t, db.Where("id = ?", tc.id).First(toCheckUser).Error)
+			assert.Equal(t, tc.disabled, toCheckUser.Disabled)
+		})
+	}
+}
+
+func TestUser_RequirePasswordReset(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		id            string
+		expectedError error
+	}{
+		{
+			name: "success - flag user",
+			id:   "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91",
+		},
+		{
+			name:          "error - user not found",
+			id:            "00000000-0000-0000-0000-000000000000",
+			expectedError: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			repo := NewUser(db)
+
+			err := repo.RequirePasswordReset(ctx, tc.id)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			toCheckUser := &model.User{}
+			assert.NoError(t, db.Where("id = ?", tc.id).First(toCheckUser).Error)
+			assert.True(t, toCheckUser.PasswordResetRequired)
+		})
+	}
+}
+
+func TestUser_UpdatePassword(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		id            string
+		expectedError error
+	}{
+		{
+			name: "success - update password and clear reset flag",
+			id:   fixture.ResetRequiredUserID,
+		},
+		{
+			name:          "error - user not found",
+			id:            "00000000-0000-0000-0000-000000000000",
+			expectedError: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := fixture.NewFixture(t, &fixture.UserAdminTestDB{})
+			repo := NewUser(db)
+
+			err := repo.UpdatePassword(ctx, tc.id, "new-hash")
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			toCheckUser := &model.User{}
+			assert.NoError(t, db.Where("id = ?", tc.id).First(toCheckUser).Error)
+			assert.Equal(t, "new-hash", toCheckUser.Password)
+			assert.False(t, toCheckUser.PasswordResetRequired)
+		})
+	}
+}
+
+func TestUser_UpdatePasswordHash(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		id            string
+		expectedError error
+	}{
+		{
+			name: "success - update hash and keep reset flag",
+			id:   fixture.ResetRequiredUserID,
+		},
+		{
+			name:          "error - user not found",
+			id:            "00000000-0000-0000-0000-000000000000",
+			expectedError: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			db := fixture.NewFixture(t, &fixture.UserAdminTestDB{})
+			repo := NewUser(db)
+
+			err := repo.UpdatePasswordHash(ctx, tc.id, "new-hash")
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			toCheckUser := &model.User{}
+			assert.NoError(t, db.Where("id = ?", tc.id).First(toCheckUser).Error)
+			assert.Equal(t, "new-hash", toCheckUser.Password)
+			assert.True(t, toCheckUser.PasswordResetRequired)
+		})
+	}
+}
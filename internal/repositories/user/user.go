@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"time"
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
 	"gorm.io/gorm"
@@ -17,10 +18,18 @@ type User interface {
 	// The user ID will be automatically generated as a UUID if not provided.
 	CreateUser(context.Context, *model.User) (*model.User, error)
 
-	// GetUserByUsername retrieves a user by their unique username.
+	// GetUserByUsername retrieves a user by their unique username, ignoring case.
 	// Returns the user or an error if not found.
 	GetUserByUsername(context.Context, string) (*model.User, error)
 
+	// GetUserByEmail retrieves a user by their unique email address, ignoring case.
+	// Returns the user or an error if not found.
+	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
+
+	// GetUserByLogin retrieves a user by username or email address, ignoring case.
+	// Returns the user or an error if not found.
+	GetUserByLogin(ctx context.Context, login string) (*model.User, error)
+
 	// GetUserByID retrieves a user by their unique identifier (UUID).
 	// Returns the user or an error if not found.
 	GetUserByID(ctx context.Context, id string) (*model.User, error)
@@ -33,6 +42,42 @@ type User interface {
 	// UpdateUserProfile updates the display name and email of a user identified by ID.
 	// Returns the updated user or an error if the user is not found or the update fails.
 	UpdateUserProfile(ctx context.Context, id, displayName, email string) (*model.User, error)
+
+	// ListUsers retrieves a page of users, optionally filtered by a case-insensitive search
+	// term matched against username, email and display name.
+	ListUsers(ctx context.Context, search string, offset, limit int) ([]*model.User, error)
+
+	// CountUsers counts the users matching the optional search term used by ListUsers.
+	CountUsers(ctx context.Context, search string) (int64, error)
+
+	// UpdateUserStatus enables or disables the account of a user identified by ID.
+	// Returns the updated user or an error if the user is not found or the update fails.
+	UpdateUserStatus(ctx context.Context, id string, disabled bool) (*model.User, error)
+
+	// RequirePasswordReset forces the user identified by ID to change their password before logging in again.
+	RequirePasswordReset(ctx context.Context, id string) error
+
+	// UpdatePassword stores a new password hash for the user identified by ID and clears
+	// any pending password reset requirement.
+	UpdatePassword(ctx context.Context, id, hashedPassword string) error
+
+	// UpdatePasswordHash replaces the password hash of the user identified by ID without
+	// touching the password reset requirement, to upgrade the hash of an unchanged password.
+	UpdatePasswordHash(ctx context.Context, id, hashedPassword string) error
+
+	// ScheduleDeletion sets or, with a nil time, clears the purge time of the user identified by ID.
+	ScheduleDeletion(ctx context.Context, id string, at *time.Time) error
+
+	// ListUsersDueForDeletion retrieves up to limit users whose scheduled purge time has passed.
+	ListUsersDueForDeletion(ctx context.Context, before time.Time, limit int) ([]*model.User, error)
+
+	// DeleteUserDueForDeletion permanently removes the user identified by ID together with
+	// their bookmarks if their deletion is still due, running cleanup before committing.
+	DeleteUserDueForDeletion(ctx context.Context, id string, before time.Time, cleanup func(ctx context.Context) error) error
+
+	// FindIdentityCollisions reports the usernames and email addresses shared by several
+	// users once letter case is ignored.
+	FindIdentityCollisions(ctx context.Context) ([]*IdentityCollision, error)
 }
 
 // user implements the User interface and provides database operations for user entities.
@@ -0,0 +1,122 @@
+// Package account lets users take their data with them and close their account.
+// Exports run as background jobs that the user polls, and deletions are purged once
+// their grace period has ended.
+package account
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	bookmarkRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/bookmark"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/cache"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/export"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/lock"
+	urlRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/url"
+	userRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/user"
+	"github.com/luongtruong20201/bookmark-management/pkg/utils"
+)
+
+// Export archive formats.
+const (
+	FormatJSON = "json"
+	FormatZIP  = "zip"
+)
+
+var (
+	// ErrUnsupportedFormat is returned when an export is requested in an unknown format.
+	ErrUnsupportedFormat = errors.New("unsupported export format")
+	// ErrExportNotReady is returned when the archive of an export that has not completed
+	// is downloaded.
+	ErrExportNotReady = errors.New("export is not ready")
+	// ErrInvalidPassword is returned when the password confirming a deletion is wrong.
+	ErrInvalidPassword = errors.New("invalid password")
+	// ErrReauthenticationRequired is returned when a deletion is confirmed neither by the
+	// password nor by a recent login.
+	ErrReauthenticationRequired = errors.New("confirm your password or log in again to delete the account")
+	// ErrDeletionNotScheduled is returned when cancelling a deletion that was never requested.
+	ErrDeletionNotScheduled = errors.New("account deletion is not scheduled")
+)
+
+// Service defines the interface for account data export and deletion.
+//
+//go:generate mockery --name Service --filename service.go
+type Service interface {
+	// RequestExport starts a background export of the user's data in the given format and
+	// returns the pending job. A job that is still pending is returned instead of starting
+	// another one.
+	RequestExport(ctx context.Context, userID, format string) (*export.Job, error)
+	// GetExport returns the latest export job of the user, or export.ErrJobNotFound.
+	GetExport(ctx context.Context, userID string) (*export.Job, error)
+	// DownloadExport returns the latest export job of the user together with its archive.
+	// It returns ErrExportNotReady while the job has not completed.
+	DownloadExport(ctx context.Context, userID string) (*export.Job, []byte, error)
+	// RequestDeletion schedules the deletion of the user's account and returns the time at
+	// which the account will be purged. The deletion is confirmed by the password or, when
+	// the password is empty, by a login at authenticatedAt within ReauthWindow.
+	RequestDeletion(ctx context.Context, userID, password string, authenticatedAt time.Time) (time.Time, error)
+	// CancelDeletion cancels a scheduled deletion during its grace period.
+	CancelDeletion(ctx context.Context, userID string) error
+	// PurgeDueAccounts permanently deletes the accounts whose grace period has ended and
+	// returns how many were purged. A single replica purges at a time; the others purge nothing.
+	PurgeDueAccounts(ctx context.Context) (int, error)
+	// RunPurger runs PurgeDueAccounts every PurgeInterval until the context is cancelled.
+	RunPurger(ctx context.Context)
+}
+
+// service implements the Service interface.
+type service struct {
+	userRepo      userRepo.User
+	bookmarkRepo  bookmarkRepo.Repository
+	urlStorage    urlRepo.URLStorage
+	exportStorage export.Storage
+	cache         cache.DB
+	locker        lock.Locker
+	hasher        utils.Hasher
+	cfg           *Config
+	now           func() time.Time
+	// goFn runs export jobs in the background. Tests replace it to run jobs synchronously.
+	goFn func(func())
+}
+
+// NewService creates a new account service with the provided repositories, the locker
+// guarding the purge, password hasher and configuration.
+func NewService(
+	userRepo userRepo.User,
+	bookmarkRepo bookmarkRepo.Repository,
+	urlStorage urlRepo.URLStorage,
+	exportStorage export.Storage,
+	cache cache.DB,
+	locker lock.Locker,
+	hasher utils.Hasher,
+	cfg *Config,
+) Service {
+	return &service{
+		userRepo:      userRepo,
+		bookmarkRepo:  bookmarkRepo,
+		urlStorage:    urlStorage,
+		exportStorage: exportStorage,
+		cache:         cache,
+		locker:        locker,
+		hasher:        hasher,
+		cfg:           cfg,
+		now:           time.Now,
+		goFn:          func(f func()) { go f() },
+	}
+}
+
+// Archive is the content of an export. The JSON format contains it as a single document;
+// the ZIP format stores each field in its own file.
+type Archive struct {
+	ExportedAt time.Time         `json:"exported_at"`
+	Profile    *model.User       `json:"profile"`
+	Bookmarks  []*model.Bookmark `json:"bookmarks"`
+	ShortLinks []*ShortLink      `json:"short_links"`
+}
+
+// ShortLink is a short link created by the user while signed in.
+type ShortLink struct {
+	Code string `json:"code"`
+	URL  string `json:"url"`
+}
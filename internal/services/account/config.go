@@ -0,0 +1,38 @@
+package account
+
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Config holds the account export and deletion settings loaded from environment variables.
+//
+// A deleted account stays in its DeletionGracePeriod, during which the user can cancel the
+// deletion, and is purged by the first purge pass after it ends. Purge passes run every
+// PurgeInterval and delete at most PurgeBatchSize accounts each. A pass holds the purge lock
+// for at most PurgeLockTTL, so the lock of a replica stopped mid-pass expires on its own.
+// Export jobs and their archives are kept for ExportTTL.
+//
+// A deletion requested without the password is accepted when the token was issued less
+// than ReauthWindow ago, so users without a password, such as those signing in through an
+// identity provider, confirm it by logging in again.
+type Config struct {
+	DeletionGracePeriod time.Duration `default:"720h" envconfig:"ACCOUNT_DELETION_GRACE_PERIOD"`
+	PurgeInterval       time.Duration `default:"1h" envconfig:"ACCOUNT_PURGE_INTERVAL"`
+	PurgeBatchSize      int           `default:"100" envconfig:"ACCOUNT_PURGE_BATCH_SIZE"`
+	PurgeLockTTL        time.Duration `default:"10m" envconfig:"ACCOUNT_PURGE_LOCK_TTL"`
+	ExportTTL           time.Duration `default:"24h" envconfig:"ACCOUNT_EXPORT_TTL"`
+	ReauthWindow        time.Duration `default:"5m" envconfig:"ACCOUNT_REAUTH_WINDOW"`
+}
+
+// NewConfig creates a new configuration instance by reading environment variables.
+// Unset variables fall back to the defaults declared on Config.
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,59 @@
+package account
+
+import (
+	"context"
+	"time"
+)
+
+// RequestDeletion confirms the deletion with the password of the user, or with a recent
+// login when the password is empty, and schedules the purge of the account at the end of
+// the grace period. Requesting the deletion again keeps the original schedule.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - userID: ID of the user deleting their account
+//   - password: Plain text password of the user, or empty to rely on a recent login
+//   - authenticatedAt: When the user last logged in, that is the issue time of the token
+//
+// Returns:
+//   - time.Time: When the account will be purged
+//   - error: ErrInvalidPassword if the password is wrong, ErrReauthenticationRequired if the
+//     password is empty and the login is older than ReauthWindow, dbutils.ErrNotFoundType if
+//     the user does not exist, or an error if the update fails
+func (s *service) RequestDeletion(ctx context.Context, userID, password string, authenticatedAt time.Time) (time.Time, error) {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if password != "" {
+		if !s.hasher.VerifyPassword(password, user.Password) {
+			return time.Time{}, ErrInvalidPassword
+		}
+	} else if s.now().Sub(authenticatedAt) > s.cfg.ReauthWindow {
+		return time.Time{}, ErrReauthenticationRequired
+	}
+	if user.DeletionScheduledAt != nil {
+		return *user.DeletionScheduledAt, nil
+	}
+
+	at := s.now().Add(s.cfg.DeletionGracePeriod).UTC()
+	if err := s.userRepo.ScheduleDeletion(ctx, userID, &at); err != nil {
+		return time.Time{}, err
+	}
+
+	return at, nil
+}
+
+// CancelDeletion clears the deletion schedule of the user. It returns ErrDeletionNotScheduled
+// if no deletion is pending.
+func (s *service) CancelDeletion(ctx context.Context, userID string) error {
+	user, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.DeletionScheduledAt == nil {
+		return ErrDeletionNotScheduled
+	}
+
+	return s.userRepo.ScheduleDeletion(ctx, userID, nil)
+}
@@ -0,0 +1,214 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	mockUserRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/user/mocks"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	mockUtils "github.com/luongtruong20201/bookmark-management/pkg/utils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_RequestDeletion(t *testing.T) {
+	t.Parallel()
+
+	const hashedPassword = "$2a$10$hash"
+
+	expectedAt := testNow.Add(testConfig.DeletionGracePeriod)
+	scheduledAt := testNow.Add(time.Hour)
+	testErr := errors.New("database error")
+
+	testCases := []struct {
+		name           string
+		password       string
+		authenticated  time.Time
+		setupRepo      func(t *testing.T, ctx context.Context) *mockUserRepo.User
+		setupHasher    func(t *testing.T) *mockUtils.Hasher
+		expectedResult time.Time
+		expectedError  error
+	}{
+		{
+			name:     "success",
+			password: "P@ssw0rd",
+			setupRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repo := mockUserRepo.NewUser(t)
+				repo.On("GetUserByID", ctx, testUserID).Return(&model.User{Base: model.Base{ID: testUserID}, Password: hashedPassword}, nil).Once()
+				repo.On("ScheduleDeletion", ctx, testUserID, mock.MatchedBy(func(at *time.Time) bool {
+					return at != nil && at.Equal(expectedAt)
+				})).Return(nil).Once()
+				return repo
+			},
+			setupHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasher := mockUtils.NewHasher(t)
+				hasher.On("VerifyPassword", "P@ssw0rd", hashedPassword).Return(true).Once()
+				return hasher
+			},
+			expectedResult: expectedAt,
+		},
+		{
+			name:     "success - already scheduled keeps the schedule",
+			password: "P@ssw0rd",
+			setupRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repo := mockUserRepo.NewUser(t)
+				repo.On("GetUserByID", ctx, testUserID).Return(&model.User{Password: hashedPassword, DeletionScheduledAt: &scheduledAt}, nil).Once()
+				return repo
+			},
+			setupHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasher := mockUtils.NewHasher(t)
+				hasher.On("VerifyPassword", "P@ssw0rd", hashedPassword).Return(true).Once()
+				return hasher
+			},
+			expectedResult: scheduledAt,
+		},
+		{
+			name:     "error - wrong password",
+			password: "P@ssw0rd",
+			setupRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repo := mockUserRepo.NewUser(t)
+				repo.On("GetUserByID", ctx, testUserID).Return(&model.User{Password: hashedPassword}, nil).Once()
+				return repo
+			},
+			setupHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasher := mockUtils.NewHasher(t)
+				hasher.On("VerifyPassword", "P@ssw0rd", hashedPassword).Return(false).Once()
+				return hasher
+			},
+			expectedError: ErrInvalidPassword,
+		},
+		{
+			name:          "success - recent login confirms without the password",
+			authenticated: testNow.Add(-time.Minute),
+			setupRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repo := mockUserRepo.NewUser(t)
+				repo.On("GetUserByID", ctx, testUserID).Return(&model.User{Base: model.Base{ID: testUserID}, Password: hashedPassword}, nil).Once()
+				repo.On("ScheduleDeletion", ctx, testUserID, mock.MatchedBy(func(at *time.Time) bool {
+					return at != nil && at.Equal(expectedAt)
+				})).Return(nil).Once()
+				return repo
+			},
+			setupHasher: func(t *testing.T) *mockUtils.Hasher {
+				return mockUtils.NewHasher(t)
+			},
+			expectedResult: expectedAt,
+		},
+		{
+			name:          "error - login too old to confirm without the password",
+			authenticated: testNow.Add(-time.Hour),
+			setupRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repo := mockUserRepo.NewUser(t)
+				repo.On("GetUserByID", ctx, testUserID).Return(&model.User{Password: hashedPassword}, nil).Once()
+				return repo
+			},
+			setupHasher: func(t *testing.T) *mockUtils.Hasher {
+				return mockUtils.NewHasher(t)
+			},
+			expectedError: ErrReauthenticationRequired,
+		},
+		{
+			name:     "error - user not found",
+			password: "P@ssw0rd",
+			setupRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repo := mockUserRepo.NewUser(t)
+				repo.On("GetUserByID", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType).Once()
+				return repo
+			},
+			setupHasher: func(t *testing.T) *mockUtils.Hasher {
+				return mockUtils.NewHasher(t)
+			},
+			expectedError: dbutils.ErrNotFoundType,
+		},
+		{
+			name:     "error - schedule fails",
+			password: "P@ssw0rd",
+			setupRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repo := mockUserRepo.NewUser(t)
+				repo.On("GetUserByID", ctx, testUserID).Return(&model.User{Password: hashedPassword}, nil).Once()
+				repo.On("ScheduleDeletion", ctx, testUserID, mock.Anything).Return(testErr).Once()
+				return repo
+			},
+			setupHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasher := mockUtils.NewHasher(t)
+				hasher.On("VerifyPassword", "P@ssw0rd", hashedPassword).Return(true).Once()
+				return hasher
+			},
+			expectedError: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			svc := &service{
+				userRepo: tc.setupRepo(t, ctx),
+				hasher:   tc.setupHasher(t),
+				cfg:      testConfig,
+				now:      func() time.Time { return testNow },
+			}
+
+			at, err := svc.RequestDeletion(ctx, testUserID, tc.password, tc.authenticated)
+
+			assert.Equal(t, tc.expectedError, err)
+			assert.True(t, tc.expectedResult.Equal(at))
+		})
+	}
+}
+
+func TestService_CancelDeletion(t *testing.T) {
+	t.Parallel()
+
+	scheduledAt := testNow.Add(time.Hour)
+
+	testCases := []struct {
+		name          string
+		setupRepo     func(t *testing.T, ctx context.Context) *mockUserRepo.User
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repo := mockUserRepo.NewUser(t)
+				repo.On("GetUserByID", ctx, testUserID).Return(&model.User{DeletionScheduledAt: &scheduledAt}, nil).Once()
+				repo.On("ScheduleDeletion", ctx, testUserID, (*time.Time)(nil)).Return(nil).Once()
+				return repo
+			},
+		},
+		{
+			name: "error - not scheduled",
+			setupRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repo := mockUserRepo.NewUser(t)
+				repo.On("GetUserByID", ctx, testUserID).Return(&model.User{}, nil).Once()
+				return repo
+			},
+			expectedError: ErrDeletionNotScheduled,
+		},
+		{
+			name: "error - user not found",
+			setupRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repo := mockUserRepo.NewUser(t)
+				repo.On("GetUserByID", ctx, testUserID).Return(nil, dbutils.ErrNotFoundType).Once()
+				return repo
+			},
+			expectedError: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			svc := &service{userRepo: tc.setupRepo(t, ctx), cfg: testConfig}
+
+			err := svc.CancelDeletion(ctx, testUserID)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/export"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+)
+
+// RequestExport validates the format, stores a pending job and builds the archive in the
+// background. The background work is detached from the request context so that it
+// outlives the request.
+func (s *service) RequestExport(ctx context.Context, userID, format string) (*export.Job, error) {
+	if format != FormatJSON && format != FormatZIP {
+		return nil, ErrUnsupportedFormat
+	}
+
+	current, err := s.exportStorage.GetJob(ctx, userID)
+	switch {
+	case err == nil:
+		if current.Status == export.StatusPending {
+			return current, nil
+		}
+	case !errors.Is(err, export.ErrJobNotFound):
+		return nil, err
+	}
+
+	job := &export.Job{
+		ID:        uuid.NewString(),
+		Status:    export.StatusPending,
+		Format:    format,
+		CreatedAt: s.now(),
+	}
+	if err := s.exportStorage.SaveJob(ctx, userID, job, s.cfg.ExportTTL); err != nil {
+		return nil, err
+	}
+
+	bgCtx := context.WithoutCancel(ctx)
+	pending := *job
+	s.goFn(func() {
+		s.runExport(bgCtx, userID, &pending)
+	})
+
+	return job, nil
+}
+
+// GetExport returns the latest export job of the user.
+func (s *service) GetExport(ctx context.Context, userID string) (*export.Job, error) {
+	return s.exportStorage.GetJob(ctx, userID)
+}
+
+// DownloadExport returns the archive of the user's latest export once it has completed.
+func (s *service) DownloadExport(ctx context.Context, userID string) (*export.Job, []byte, error) {
+	job, err := s.exportStorage.GetJob(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if job.Status != export.StatusCompleted {
+		return job, nil, ErrExportNotReady
+	}
+
+	data, err := s.exportStorage.GetArchive(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return job, data, nil
+}
+
+// runExport builds the archive of the job and records the outcome on the job. Failures are
+// logged and reported through the job status, since nobody waits for the result.
+func (s *service) runExport(ctx context.Context, userID string, job *export.Job) {
+	data, err := s.buildArchive(ctx, userID, job.Format)
+	if err == nil {
+		err = s.exportStorage.SaveArchive(ctx, userID, data, s.cfg.ExportTTL)
+	}
+
+	completedAt := s.now()
+	job.CompletedAt = &completedAt
+	job.Status = export.StatusCompleted
+	if err != nil {
+		logger.FromContext(ctx).Error().Err(err).Str("job", job.ID).Msg("failed to export user data")
+		job.Status = export.StatusFailed
+		job.Error = "export failed"
+	}
+
+	if err := s.exportStorage.SaveJob(ctx, userID, job, s.cfg.ExportTTL); err != nil {
+		logger.FromContext(ctx).Error().Err(err).Str("job", job.ID).Msg("failed to save export job")
+	}
+}
+
+// buildArchive collects the profile, bookmarks and owned short links of the user and
+// encodes them in the requested format.
+func (s *service) buildArchive(ctx context.Context, userID, format string) ([]byte, error) {
+	profile, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarks, err := s.bookmarkRepo.GetAllBookmarks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.urlStorage.GetOwnedLinks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	shortLinks := make([]*ShortLink, 0, len(links))
+	for code, url := range links {
+		shortLinks = append(shortLinks, &ShortLink{Code: code, URL: url})
+	}
+	sort.Slice(shortLinks, func(i, j int) bool {
+		return shortLinks[i].Code < shortLinks[j].Code
+	})
+
+	archive := &Archive{
+		ExportedAt: s.now(),
+		Profile:    profile,
+		Bookmarks:  bookmarks,
+		ShortLinks: shortLinks,
+	}
+
+	if format == FormatJSON {
+		return json.MarshalIndent(archive, "", "  ")
+	}
+
+	return zipArchive(archive)
+}
+
+// zipArchive writes each part of the archive to its own JSON file inside a ZIP file.
+func zipArchive(archive *Archive) ([]byte, error) {
+	files := []struct {
+		name    string
+		content any
+	}{
+		{name: "profile.json", content: archive.Profile},
+		{name: "bookmarks.json", content: archive.Bookmarks},
+		{name: "short_links.json", content: archive.ShortLinks},
+	}
+
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for _, f := range files {
+		data, err := json.MarshalIndent(f.content, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		fw, err := w.CreateHeader(&zip.FileHeader{
+			Name:     f.name,
+			Method:   zip.Deflate,
+			Modified: archive.ExportedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fw.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
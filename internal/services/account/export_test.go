@@ -0,0 +1,313 @@
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	mockBookmarkRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/bookmark/mocks"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/export"
+	mockExport "github.com/luongtruong20201/bookmark-management/internal/repositories/export/mocks"
+	mockURLStorage "github.com/luongtruong20201/bookmark-management/internal/repositories/url/mocks"
+	mockUserRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserID = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+
+var (
+	testNow    = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	testConfig = &Config{
+		DeletionGracePeriod: 720 * time.Hour,
+		PurgeInterval:       time.Hour,
+		PurgeBatchSize:      10,
+		PurgeLockTTL:        10 * time.Minute,
+		ExportTTL:           24 * time.Hour,
+		ReauthWindow:        5 * time.Minute,
+	}
+	testProfile = &model.User{
+		Base:        model.Base{ID: testUserID},
+		Username:    "truonglq",
+		DisplayName: "Truong",
+		Email:       "truonglq@example.com",
+		Password:    "$2a$10$hash",
+	}
+	testBookmarks = []*model.Bookmark{
+		{Base: model.Base{ID: "b1"}, Description: "Google", URL: "https://google.com", Code: "abcd1234", UserID: testUserID},
+	}
+	testLinks = map[string]string{
+		"zzz9999": "https://example.com",
+		"aaa1111": "https://truonglq.com",
+	}
+)
+
+// setupExportData expects the lookups made while building an archive.
+func setupExportData(t *testing.T) (*mockUserRepo.User, *mockBookmarkRepo.Repository, *mockURLStorage.URLStorage) {
+	userRepo := mockUserRepo.NewUser(t)
+	userRepo.On("GetUserByID", mock.Anything, testUserID).Return(testProfile, nil).Once()
+	bookmarkRepo := mockBookmarkRepo.NewRepository(t)
+	bookmarkRepo.On("GetAllBookmarks", mock.Anything, testUserID).Return(testBookmarks, nil).Once()
+	urlStorage := mockURLStorage.NewURLStorage(t)
+	urlStorage.On("GetOwnedLinks", mock.Anything, testUserID).Return(testLinks, nil).Once()
+
+	return userRepo, bookmarkRepo, urlStorage
+}
+
+func TestService_RequestExport(t *testing.T) {
+	t.Parallel()
+
+	testErr := errors.New("redis error")
+
+	testCases := []struct {
+		name           string
+		format         string
+		setupMocks     func(t *testing.T, ctx context.Context) *service
+		expectedStatus string
+		expectedError  error
+	}{
+		{
+			name:   "success - job completes in the background",
+			format: FormatJSON,
+			setupMocks: func(t *testing.T, ctx context.Context) *service {
+				userRepo, bookmarkRepo, urlStorage := setupExportData(t)
+				storage := mockExport.NewStorage(t)
+				storage.On("GetJob", ctx, testUserID).Return(nil, export.ErrJobNotFound).Once()
+				storage.On("SaveJob", ctx, testUserID, mock.MatchedBy(func(job *export.Job) bool {
+					return job.Status == export.StatusPending && job.Format == FormatJSON
+				}), testConfig.ExportTTL).Return(nil).Once()
+				storage.On("SaveArchive", mock.Anything, testUserID, mock.Anything, testConfig.ExportTTL).Return(nil).Once()
+				storage.On("SaveJob", mock.Anything, testUserID, mock.MatchedBy(func(job *export.Job) bool {
+					return job.Status == export.StatusCompleted && job.CompletedAt != nil
+				}), testConfig.ExportTTL).Return(nil).Once()
+
+				return &service{userRepo: userRepo, bookmarkRepo: bookmarkRepo, urlStorage: urlStorage, exportStorage: storage}
+			},
+			expectedStatus: export.StatusPending,
+		},
+		{
+			name:   "success - failed export is reported on the job",
+			format: FormatZIP,
+			setupMocks: func(t *testing.T, ctx context.Context) *service {
+				userRepo := mockUserRepo.NewUser(t)
+				userRepo.On("GetUserByID", mock.Anything, testUserID).Return(nil, errors.New("db error")).Once()
+				storage := mockExport.NewStorage(t)
+				storage.On("GetJob", ctx, testUserID).Return(&export.Job{ID: "old", Status: export.StatusCompleted}, nil).Once()
+				storage.On("SaveJob", ctx, testUserID, mock.MatchedBy(func(job *export.Job) bool {
+					return job.Status == export.StatusPending && job.ID != "old"
+				}), testConfig.ExportTTL).Return(nil).Once()
+				storage.On("SaveJob", mock.Anything, testUserID, mock.MatchedBy(func(job *export.Job) bool {
+					return job.Status == export.StatusFailed && job.Error != ""
+				}), testConfig.ExportTTL).Return(nil).Once()
+
+				return &service{userRepo: userRepo, exportStorage: storage}
+			},
+			expectedStatus: export.StatusPending,
+		},
+		{
+			name:   "success - pending job is reused",
+			format: FormatJSON,
+			setupMocks: func(t *testing.T, ctx context.Context) *service {
+				storage := mockExport.NewStorage(t)
+				storage.On("GetJob", ctx, testUserID).Return(&export.Job{ID: "job-1", Status: export.StatusPending}, nil).Once()
+
+				return &service{exportStorage: storage}
+			},
+			expectedStatus: export.StatusPending,
+		},
+		{
+			name:   "error - unsupported format",
+			format: "xml",
+			setupMocks: func(t *testing.T, ctx context.Context) *service {
+				return &service{}
+			},
+			expectedError: ErrUnsupportedFormat,
+		},
+		{
+			name:   "error - job lookup fails",
+			format: FormatJSON,
+			setupMocks: func(t *testing.T, ctx context.Context) *service {
+				storage := mockExport.NewStorage(t)
+				storage.On("GetJob", ctx, testUserID).Return(nil, testErr).Once()
+
+				return &service{exportStorage: storage}
+			},
+			expectedError: testErr,
+		},
+		{
+			name:   "error - job cannot be saved",
+			format: FormatJSON,
+			setupMocks: func(t *testing.T, ctx context.Context) *service {
+				storage := mockExport.NewStorage(t)
+				storage.On("GetJob", ctx, testUserID).Return(nil, export.ErrJobNotFound).Once()
+				storage.On("SaveJob", ctx, testUserID, mock.Anything, testConfig.ExportTTL).Return(testErr).Once()
+
+				return &service{exportStorage: storage}
+			},
+			expectedError: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			svc := tc.setupMocks(t, ctx)
+			svc.cfg = testConfig
+			svc.now = func() time.Time { return testNow }
+			svc.goFn = func(f func()) { f() }
+
+			job, err := svc.RequestExport(ctx, testUserID, tc.format)
+
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.Equal(t, tc.expectedStatus, job.Status)
+			}
+		})
+	}
+}
+
+func TestService_DownloadExport(t *testing.T) {
+	t.Parallel()
+
+	testErr := errors.New("redis error")
+
+	testCases := []struct {
+		name          string
+		setupStorage  func(t *testing.T, ctx context.Context) *mockExport.Storage
+		expectedData  []byte
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockExport.Storage {
+				storage := mockExport.NewStorage(t)
+				storage.On("GetJob", ctx, testUserID).Return(&export.Job{ID: "job-1", Status: export.StatusCompleted, Format: FormatJSON}, nil).Once()
+				storage.On("GetArchive", ctx, testUserID).Return([]byte("{}"), nil).Once()
+				return storage
+			},
+			expectedData: []byte("{}"),
+		},
+		{
+			name: "error - export still pending",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockExport.Storage {
+				storage := mockExport.NewStorage(t)
+				storage.On("GetJob", ctx, testUserID).Return(&export.Job{ID: "job-1", Status: export.StatusPending}, nil).Once()
+				return storage
+			},
+			expectedError: ErrExportNotReady,
+		},
+		{
+			name: "error - no export",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockExport.Storage {
+				storage := mockExport.NewStorage(t)
+				storage.On("GetJob", ctx, testUserID).Return(nil, export.ErrJobNotFound).Once()
+				return storage
+			},
+			expectedError: export.ErrJobNotFound,
+		},
+		{
+			name: "error - archive lookup fails",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockExport.Storage {
+				storage := mockExport.NewStorage(t)
+				storage.On("GetJob", ctx, testUserID).Return(&export.Job{ID: "job-1", Status: export.StatusCompleted}, nil).Once()
+				storage.On("GetArchive", ctx, testUserID).Return(nil, testErr).Once()
+				return storage
+			},
+			expectedError: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			svc := &service{exportStorage: tc.setupStorage(t, ctx), cfg: testConfig}
+
+			_, data, err := svc.DownloadExport(ctx, testUserID)
+
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedData, data)
+		})
+	}
+}
+
+func TestService_buildArchive(t *testing.T) {
+	t.Parallel()
+
+	expectedLinks := []*ShortLink{
+		{Code: "aaa1111", URL: "https://truonglq.com"},
+		{Code: "zzz9999", URL: "https://example.com"},
+	}
+
+	testCases := []struct {
+		name   string
+		format string
+		verify func(t *testing.T, data []byte)
+	}{
+		{
+			name:   "success - json",
+			format: FormatJSON,
+			verify: func(t *testing.T, data []byte) {
+				archive := &Archive{}
+				assert.NoError(t, json.Unmarshal(data, archive))
+				assert.Equal(t, "truonglq", archive.Profile.Username)
+				assert.Empty(t, archive.Profile.Password)
+				assert.Len(t, archive.Bookmarks, 1)
+				assert.Equal(t, expectedLinks, archive.ShortLinks)
+				assert.NotContains(t, string(data), testProfile.Password)
+			},
+		},
+		{
+			name:   "success - zip",
+			format: FormatZIP,
+			verify: func(t *testing.T, data []byte) {
+				r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+				assert.NoError(t, err)
+
+				files := map[string][]byte{}
+				for _, f := range r.File {
+					rc, err := f.Open()
+					assert.NoError(t, err)
+					content, _ := io.ReadAll(rc)
+					_ = rc.Close()
+					files[f.Name] = content
+				}
+				assert.Len(t, files, 3)
+
+				var links []*ShortLink
+				assert.NoError(t, json.Unmarshal(files["short_links.json"], &links))
+				assert.Equal(t, expectedLinks, links)
+				assert.Contains(t, string(files["profile.json"]), "truonglq@example.com")
+				assert.Contains(t, string(files["bookmarks.json"]), "https://google.com")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			userRepo, bookmarkRepo, urlStorage := setupExportData(t)
+			svc := &service{
+				userRepo:     userRepo,
+				bookmarkRepo: bookmarkRepo,
+				urlStorage:   urlStorage,
+				now:          func() time.Time { return testNow },
+			}
+
+			data, err := svc.buildArchive(t.Context(), testUserID, tc.format)
+
+			assert.NoError(t, err)
+			tc.verify(t, data)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	export "github.com/luongtruong20201/bookmark-management/internal/repositories/export"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// CancelDeletion provides a mock function with given fields: ctx, userID
+func (_m *Service) CancelDeletion(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelDeletion")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DownloadExport provides a mock function with given fields: ctx, userID
+func (_m *Service) DownloadExport(ctx context.Context, userID string) (*export.Job, []byte, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadExport")
+	}
+
+	var r0 *export.Job
+	var r1 []byte
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*export.Job, []byte, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *export.Job); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*export.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) []byte); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetExport provides a mock function with given fields: ctx, userID
+func (_m *Service) GetExport(ctx context.Context, userID string) (*export.Job, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExport")
+	}
+
+	var r0 *export.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*export.Job, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *export.Job); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*export.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDueAccounts provides a mock function with given fields: ctx
+func (_m *Service) PurgeDueAccounts(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDueAccounts")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestDeletion provides a mock function with given fields: ctx, userID, password, authenticatedAt
+func (_m *Service) RequestDeletion(ctx context.Context, userID string, password string, authenticatedAt time.Time) (time.Time, error) {
+	ret := _m.Called(ctx, userID, password, authenticatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestDeletion")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (time.Time, error)); ok {
+		return rf(ctx, userID, password, authenticatedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) time.Time); ok {
+		r0 = rf(ctx, userID, password, authenticatedAt)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, userID, password, authenticatedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestExport provides a mock function with given fields: ctx, userID, format
+func (_m *Service) RequestExport(ctx context.Context, userID string, format string) (*export.Job, error) {
+	ret := _m.Called(ctx, userID, format)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestExport")
+	}
+
+	var r0 *export.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*export.Job, error)); ok {
+		return rf(ctx, userID, format)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *export.Job); ok {
+		r0 = rf(ctx, userID, format)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*export.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, format)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RunPurger provides a mock function with given fields: ctx
+func (_m *Service) RunPurger(ctx context.Context) {
+	_m.Called(ctx)
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,99 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	bookmarkService "github.com/luongtruong20201/bookmark-management/internal/services/bookmark"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+)
+
+// purgeLockName names the lock held by the replica running a purge pass.
+const purgeLockName = "account_purge"
+
+// PurgeDueAccounts deletes up to PurgeBatchSize accounts whose grace period has ended.
+// An account that fails to purge is logged and retried by the next pass; one whose
+// deletion has been cancelled since it was listed is kept. The pass runs
+// under the purge lock, so replicas do not race to purge the same accounts; when another
+// replica holds it, nothing is purged.
+func (s *service) PurgeDueAccounts(ctx context.Context) (int, error) {
+	token := uuid.NewString()
+	acquired, err := s.locker.Acquire(ctx, purgeLockName, token, s.cfg.PurgeLockTTL)
+	if err != nil {
+		return 0, err
+	}
+	if !acquired {
+		logger.FromContext(ctx).Debug().Msg("account purge running on another replica")
+		return 0, nil
+	}
+	defer func() {
+		if err := s.locker.Release(context.WithoutCancel(ctx), purgeLockName, token); err != nil {
+			logger.FromContext(ctx).Warn().Err(err).Msg("failed to release the account purge lock")
+		}
+	}()
+
+	now := s.now()
+	users, err := s.userRepo.ListUsersDueForDeletion(ctx, now, s.cfg.PurgeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, user := range users {
+		err := s.purgeUser(ctx, user.ID, now)
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			logger.FromContext(ctx).Info().Str("uid", user.ID).Msg("account deletion cancelled before the purge")
+			continue
+		}
+		if err != nil {
+			logger.FromContext(ctx).Error().Err(err).Str("uid", user.ID).Msg("failed to purge account")
+			continue
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// RunPurger runs a purge pass immediately and then every PurgeInterval until the context
+// is cancelled.
+func (s *service) RunPurger(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := s.PurgeDueAccounts(ctx)
+		if err != nil {
+			logger.FromContext(ctx).Error().Err(err).Msg("failed to purge deleted accounts")
+		} else if purged > 0 {
+			logger.FromContext(ctx).Info().Int("count", purged).Msg("purged deleted accounts")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeUser deletes the user row, whose foreign key cascades to the bookmarks, if the
+// deletion is still due at the given time, and removes the Redis data of the user before
+// the deletion commits. A failed purge is rolled back and keeps the account listed as due,
+// so it is retried. It returns ErrNotFoundType if the deletion has been cancelled, leaving
+// the Redis data untouched.
+func (s *service) purgeUser(ctx context.Context, userID string, now time.Time) error {
+	return s.userRepo.DeleteUserDueForDeletion(ctx, userID, now, func(ctx context.Context) error {
+		if err := s.urlStorage.DeleteOwnedLinks(ctx, userID); err != nil {
+			return err
+		}
+		if err := s.cache.DeleteCacheData(ctx, bookmarkService.CacheGroupKey(userID)); err != nil {
+			return err
+		}
+
+		return s.exportStorage.Delete(ctx, userID)
+	})
+}
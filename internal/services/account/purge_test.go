@@ -0,0 +1,154 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	mockCache "github.com/luongtruong20201/bookmark-management/internal/repositories/cache/mocks"
+	mockExport "github.com/luongtruong20201/bookmark-management/internal/repositories/export/mocks"
+	mockLock "github.com/luongtruong20201/bookmark-management/internal/repositories/lock/mocks"
+	mockURLStorage "github.com/luongtruong20201/bookmark-management/internal/repositories/url/mocks"
+	mockUserRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/user/mocks"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_PurgeDueAccounts(t *testing.T) {
+	t.Parallel()
+
+	const otherUserID = "7a9f2d41-5b4c-4f3e-9d21-3e8c6a5b4f72"
+
+	testErr := errors.New("redis error")
+	dueUsers := []*model.User{
+		{Base: model.Base{ID: testUserID}},
+		{Base: model.Base{ID: otherUserID}},
+	}
+
+	// acquiredLocker returns a locker granting the purge lock to the pass and expecting
+	// the pass to release it.
+	acquiredLocker := func(t *testing.T, ctx context.Context) *mockLock.Locker {
+		locker := mockLock.NewLocker(t)
+		locker.On("Acquire", ctx, "account_purge", mock.AnythingOfType("string"), testConfig.PurgeLockTTL).Return(true, nil).Once()
+		locker.On("Release", mock.Anything, "account_purge", mock.AnythingOfType("string")).Return(nil).Once()
+		return locker
+	}
+
+	// runCleanup deletes the due account by running the cleanup of the purge, as the
+	// repository does before committing the deletion.
+	runCleanup := func(ctx context.Context, _ string, _ time.Time, cleanup func(context.Context) error) error {
+		return cleanup(ctx)
+	}
+
+	testCases := []struct {
+		name           string
+		setupMocks     func(t *testing.T, ctx context.Context) *service
+		expectedResult int
+		expectedError  error
+	}{
+		{
+			name: "success - every due account is purged",
+			setupMocks: func(t *testing.T, ctx context.Context) *service {
+				userRepo := mockUserRepo.NewUser(t)
+				userRepo.On("ListUsersDueForDeletion", ctx, testNow, testConfig.PurgeBatchSize).Return(dueUsers, nil).Once()
+				urlStorage := mockURLStorage.NewURLStorage(t)
+				cacheDB := mockCache.NewDB(t)
+				storage := mockExport.NewStorage(t)
+				for _, id := range []string{testUserID, otherUserID} {
+					urlStorage.On("DeleteOwnedLinks", ctx, id).Return(nil).Once()
+					cacheDB.On("DeleteCacheData", ctx, "get_bookmarks_"+id).Return(nil).Once()
+					storage.On("Delete", ctx, id).Return(nil).Once()
+					userRepo.On("DeleteUserDueForDeletion", ctx, id, testNow, mock.Anything).Return(runCleanup).Once()
+				}
+
+				return &service{userRepo: userRepo, urlStorage: urlStorage, cache: cacheDB, exportStorage: storage, locker: acquiredLocker(t, ctx)}
+			},
+			expectedResult: 2,
+		},
+		{
+			name: "success - failed account is kept for the next pass",
+			setupMocks: func(t *testing.T, ctx context.Context) *service {
+				userRepo := mockUserRepo.NewUser(t)
+				userRepo.On("ListUsersDueForDeletion", ctx, testNow, testConfig.PurgeBatchSize).Return(dueUsers, nil).Once()
+				userRepo.On("DeleteUserDueForDeletion", ctx, testUserID, testNow, mock.Anything).Return(runCleanup).Once()
+				userRepo.On("DeleteUserDueForDeletion", ctx, otherUserID, testNow, mock.Anything).Return(runCleanup).Once()
+				urlStorage := mockURLStorage.NewURLStorage(t)
+				urlStorage.On("DeleteOwnedLinks", ctx, testUserID).Return(testErr).Once()
+				urlStorage.On("DeleteOwnedLinks", ctx, otherUserID).Return(nil).Once()
+				cacheDB := mockCache.NewDB(t)
+				cacheDB.On("DeleteCacheData", ctx, "get_bookmarks_"+otherUserID).Return(nil).Once()
+				storage := mockExport.NewStorage(t)
+				storage.On("Delete", ctx, otherUserID).Return(nil).Once()
+
+				return &service{userRepo: userRepo, urlStorage: urlStorage, cache: cacheDB, exportStorage: storage, locker: acquiredLocker(t, ctx)}
+			},
+			expectedResult: 1,
+		},
+		{
+			name: "success - cancelled deletion keeps the account and its data",
+			setupMocks: func(t *testing.T, ctx context.Context) *service {
+				userRepo := mockUserRepo.NewUser(t)
+				userRepo.On("ListUsersDueForDeletion", ctx, testNow, testConfig.PurgeBatchSize).Return(dueUsers, nil).Once()
+				userRepo.On("DeleteUserDueForDeletion", ctx, testUserID, testNow, mock.Anything).Return(dbutils.ErrNotFoundType).Once()
+				userRepo.On("DeleteUserDueForDeletion", ctx, otherUserID, testNow, mock.Anything).Return(runCleanup).Once()
+				urlStorage := mockURLStorage.NewURLStorage(t)
+				urlStorage.On("DeleteOwnedLinks", ctx, otherUserID).Return(nil).Once()
+				cacheDB := mockCache.NewDB(t)
+				cacheDB.On("DeleteCacheData", ctx, "get_bookmarks_"+otherUserID).Return(nil).Once()
+				storage := mockExport.NewStorage(t)
+				storage.On("Delete", ctx, otherUserID).Return(nil).Once()
+
+				return &service{userRepo: userRepo, urlStorage: urlStorage, cache: cacheDB, exportStorage: storage, locker: acquiredLocker(t, ctx)}
+			},
+			expectedResult: 1,
+		},
+		{
+			name: "error - listing due accounts fails",
+			setupMocks: func(t *testing.T, ctx context.Context) *service {
+				userRepo := mockUserRepo.NewUser(t)
+				userRepo.On("ListUsersDueForDeletion", ctx, testNow, testConfig.PurgeBatchSize).Return(nil, testErr).Once()
+
+				return &service{userRepo: userRepo, locker: acquiredLocker(t, ctx)}
+			},
+			expectedError: testErr,
+		},
+		{
+			name: "success - purge running on another replica",
+			setupMocks: func(t *testing.T, ctx context.Context) *service {
+				locker := mockLock.NewLocker(t)
+				locker.On("Acquire", ctx, "account_purge", mock.AnythingOfType("string"), testConfig.PurgeLockTTL).Return(false, nil).Once()
+
+				return &service{userRepo: mockUserRepo.NewUser(t), locker: locker}
+			},
+		},
+		{
+			name: "error - acquiring the lock fails",
+			setupMocks: func(t *testing.T, ctx context.Context) *service {
+				locker := mockLock.NewLocker(t)
+				locker.On("Acquire", ctx, "account_purge", mock.AnythingOfType("string"), testConfig.PurgeLockTTL).Return(false, testErr).Once()
+
+				return &service{userRepo: mockUserRepo.NewUser(t), locker: locker}
+			},
+			expectedError: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			svc := tc.setupMocks(t, ctx)
+			svc.cfg = testConfig
+			svc.now = func() time.Time { return testNow }
+
+			purged, err := svc.PurgeDueAccounts(ctx)
+
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedResult, purged)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Package admin implements the business logic behind the administration endpoints:
+// user management and system-wide statistics.
+package admin
+
+import (
+	"context"
+	"errors"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	bookmarkRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/bookmark"
+	urlRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/url"
+	userRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/user"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
+)
+
+// ErrSelfDisable is returned when an administrator tries to disable their own account.
+var ErrSelfDisable = errors.New("administrators cannot disable their own account")
+
+// Service defines the interface for administration operations.
+//
+//go:generate mockery --name Service --filename admin.go
+type Service interface {
+	// ListUsers returns a page of users matching the optional search term together with the total count.
+	ListUsers(ctx context.Context, search string, offset, limit int) (*ListUsersResponse, error)
+	// SetUserDisabled disables or re-enables the account of a user on behalf of an administrator.
+	SetUserDisabled(ctx context.Context, adminID, userID string, disabled bool) (*model.User, error)
+	// ForcePasswordReset requires the user to change their password before logging in again
+	// and signs them out of all devices.
+	ForcePasswordReset(ctx context.Context, userID string) error
+	// GetStats returns system-wide counts of users, bookmarks and shortened links.
+	GetStats(ctx context.Context) (*Stats, error)
+}
+
+// adminSvc is the concrete implementation of the Service interface.
+// It reads and updates data owned by the user, bookmark and URL repositories, and revokes
+// the sessions of the users through the session service.
+type adminSvc struct {
+	userRepo     userRepo.User
+	bookmarkRepo bookmarkRepo.Repository
+	urlStorage   urlRepo.URLStorage
+	sessions     sessionService.Service
+}
+
+// NewAdmin constructs a new administration service from the user and bookmark
+// repositories, the shortened URL storage and the session service.
+func NewAdmin(
+	userRepo userRepo.User,
+	bookmarkRepo bookmarkRepo.Repository,
+	urlStorage urlRepo.URLStorage,
+	sessions sessionService.Service,
+) Service {
+	return &adminSvc{
+		userRepo:     userRepo,
+		bookmarkRepo: bookmarkRepo,
+		urlStorage:   urlStorage,
+		sessions:     sessions,
+	}
+}
@@ -0,0 +1,138 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	admin "github.com/luongtruong20201/bookmark-management/internal/services/admin"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// ForcePasswordReset provides a mock function with given fields: ctx, userID
+func (_m *Service) ForcePasswordReset(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForcePasswordReset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetStats provides a mock function with given fields: ctx
+func (_m *Service) GetStats(ctx context.Context) (*admin.Stats, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStats")
+	}
+
+	var r0 *admin.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*admin.Stats, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *admin.Stats); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsers provides a mock function with given fields: ctx, search, offset, limit
+func (_m *Service) ListUsers(ctx context.Context, search string, offset int, limit int) (*admin.ListUsersResponse, error) {
+	ret := _m.Called(ctx, search, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 *admin.ListUsersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (*admin.ListUsersResponse, error)); ok {
+		return rf(ctx, search, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) *admin.ListUsersResponse); ok {
+		r0 = rf(ctx, search, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*admin.ListUsersResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, search, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetUserDisabled provides a mock function with given fields: ctx, adminID, userID, disabled
+func (_m *Service) SetUserDisabled(ctx context.Context, adminID string, userID string, disabled bool) (*model.User, error) {
+	ret := _m.Called(ctx, adminID, userID, disabled)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserDisabled")
+	}
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) (*model.User, error)); ok {
+		return rf(ctx, adminID, userID, disabled)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) *model.User); ok {
+		r0 = rf(ctx, adminID, userID, disabled)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool) error); ok {
+		r1 = rf(ctx, adminID, userID, disabled)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
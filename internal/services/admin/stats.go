@@ -0,0 +1,42 @@
+package admin
+
+import "context"
+
+// Stats represents system-wide counters exposed to administrators.
+type Stats struct {
+	Users     int64 `json:"users" example:"120"`
+	Bookmarks int64 `json:"bookmarks" example:"3400"`
+	Links     int64 `json:"links" example:"560"`
+}
+
+// GetStats counts the users and bookmarks stored in the database and the shortened
+// links that have not expired yet.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//
+// Returns:
+//   - *Stats: The system-wide counters, or nil if an error occurs
+//   - error: An error if any of the counts fails
+func (s *adminSvc) GetStats(ctx context.Context) (*Stats, error) {
+	users, err := s.userRepo.CountUsers(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarks, err := s.bookmarkRepo.CountAllBookmarks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.urlStorage.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stats{
+		Users:     users,
+		Bookmarks: bookmarks,
+		Links:     links,
+	}, nil
+}
@@ -0,0 +1,116 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mockBookmarkRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/bookmark/mocks"
+	mockURLRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/url/mocks"
+	mockSessionSvc "github.com/luongtruong20201/bookmark-management/internal/services/session/mocks"
+	mockUserRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/user/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminService_GetStats(t *testing.T) {
+	t.Parallel()
+
+	var (
+		testErrDatabase = errors.New("database error")
+		testErrRedis    = errors.New("redis error")
+	)
+
+	testCases := []struct {
+		name                  string
+		setupMockUserRepo     func(t *testing.T, ctx context.Context) *mockUserRepo.User
+		setupMockBookmarkRepo func(t *testing.T, ctx context.Context) *mockBookmarkRepo.Repository
+		setupMockURLStorage   func(t *testing.T, ctx context.Context) *mockURLRepo.URLStorage
+		expectedStats         *Stats
+		expectedError         error
+	}{
+		{
+			name: "success",
+			setupMockUserRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repoMock := mockUserRepo.NewUser(t)
+				repoMock.On("CountUsers", ctx, "").Return(int64(11), nil).Once()
+				return repoMock
+			},
+			setupMockBookmarkRepo: func(t *testing.T, ctx context.Context) *mockBookmarkRepo.Repository {
+				repoMock := mockBookmarkRepo.NewRepository(t)
+				repoMock.On("CountAllBookmarks", ctx).Return(int64(8), nil).Once()
+				return repoMock
+			},
+			setupMockURLStorage: func(t *testing.T, ctx context.Context) *mockURLRepo.URLStorage {
+				storageMock := mockURLRepo.NewURLStorage(t)
+				storageMock.On("Count", ctx).Return(int64(3), nil).Once()
+				return storageMock
+			},
+			expectedStats: &Stats{Users: 11, Bookmarks: 8, Links: 3},
+		},
+		{
+			name: "error - count users fails",
+			setupMockUserRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repoMock := mockUserRepo.NewUser(t)
+				repoMock.On("CountUsers", ctx, "").Return(int64(0), testErrDatabase).Once()
+				return repoMock
+			},
+			setupMockBookmarkRepo: func(t *testing.T, ctx context.Context) *mockBookmarkRepo.Repository {
+				return mockBookmarkRepo.NewRepository(t)
+			},
+			setupMockURLStorage: func(t *testing.T, ctx context.Context) *mockURLRepo.URLStorage {
+				return mockURLRepo.NewURLStorage(t)
+			},
+			expectedError: testErrDatabase,
+		},
+		{
+			name: "error - count bookmarks fails",
+			setupMockUserRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repoMock := mockUserRepo.NewUser(t)
+				repoMock.On("CountUsers", ctx, "").Return(int64(11), nil).Once()
+				return repoMock
+			},
+			setupMockBookmarkRepo: func(t *testing.T, ctx context.Context) *mockBookmarkRepo.Repository {
+				repoMock := mockBookmarkRepo.NewRepository(t)
+				repoMock.On("CountAllBookmarks", ctx).Return(int64(0), testErrDatabase).Once()
+				return repoMock
+			},
+			setupMockURLStorage: func(t *testing.T, ctx context.Context) *mockURLRepo.URLStorage {
+				return mockURLRepo.NewURLStorage(t)
+			},
+			expectedError: testErrDatabase,
+		},
+		{
+			name: "error - count links fails",
+			setupMockUserRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repoMock := mockUserRepo.NewUser(t)
+				repoMock.On("CountUsers", ctx, "").Return(int64(11), nil).Once()
+				return repoMock
+			},
+			setupMockBookmarkRepo: func(t *testing.T, ctx context.Context) *mockBookmarkRepo.Repository {
+				repoMock := mockBookmarkRepo.NewRepository(t)
+				repoMock.On("CountAllBookmarks", ctx).Return(int64(8), nil).Once()
+				return repoMock
+			},
+			setupMockURLStorage: func(t *testing.T, ctx context.Context) *mockURLRepo.URLStorage {
+				storageMock := mockURLRepo.NewURLStorage(t)
+				storageMock.On("Count", ctx).Return(int64(0), testErrRedis).Once()
+				return storageMock
+			},
+			expectedError: testErrRedis,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			svc := NewAdmin(tc.setupMockUserRepo(t, ctx), tc.setupMockBookmarkRepo(t, ctx), tc.setupMockURLStorage(t, ctx), mockSessionSvc.NewService(t))
+
+			stats, err := svc.GetStats(ctx)
+
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedStats, stats)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package admin
+
+import (
+	"context"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+)
+
+// ListUsersResponse represents the response structure for ListUsers service method.
+type ListUsersResponse struct {
+	Data  []*model.User `json:"data"`
+	Total int64         `json:"total"`
+}
+
+// ListUsers retrieves a page of users, optionally filtered by a case-insensitive search
+// term matched against username, email and display name, and counts all matching users.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - search: Optional search term; an empty string lists all users
+//   - offset: The number of records to skip (for pagination)
+//   - limit: The maximum number of records to return
+//
+// Returns:
+//   - *ListUsersResponse: The matching users and their total count, or nil if an error occurs
+//   - error: An error if the repository operation fails
+func (s *adminSvc) ListUsers(ctx context.Context, search string, offset, limit int) (*ListUsersResponse, error) {
+	users, err := s.userRepo.ListUsers(ctx, search, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.userRepo.CountUsers(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListUsersResponse{
+		Data:  users,
+		Total: total,
+	}, nil
+}
+
+// SetUserDisabled disables or re-enables the account identified by userID. Disabled users
+// can no longer log in and their existing tokens are rejected by the authentication middleware.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - adminID: ID of the administrator performing the action
+//   - userID: ID of the user whose account is updated
+//   - disabled: true to disable the account, false to enable it
+//
+// Returns:
+//   - *model.User: The updated user
+//   - error: ErrSelfDisable if an administrator disables their own account,
+//     ErrNotFoundType if the user does not exist, or other repository errors
+func (s *adminSvc) SetUserDisabled(ctx context.Context, adminID, userID string, disabled bool) (*model.User, error) {
+	if disabled && adminID == userID {
+		return nil, ErrSelfDisable
+	}
+
+	return s.userRepo.UpdateUserStatus(ctx, userID, disabled)
+}
+
+// ForcePasswordReset flags the account identified by userID so that logins are refused
+// until the user has changed their password, then revokes all of the user's sessions so
+// the tokens already issued stop working too.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - userID: ID of the user who must change their password
+//
+// Returns:
+//   - error: ErrNotFoundType if the user does not exist, or other repository or session
+//     storage errors
+func (s *adminSvc) ForcePasswordReset(ctx context.Context, userID string) error {
+	if err := s.userRepo.RequirePasswordReset(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.sessions.RevokeAll(ctx, userID)
+}
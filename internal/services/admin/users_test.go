@@ -0,0 +1,201 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	mockBookmarkRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/bookmark/mocks"
+	mockURLRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/url/mocks"
+	mockUserRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/user/mocks"
+	mockSessionSvc "github.com/luongtruong20201/bookmark-management/internal/services/session/mocks"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	mockAdminID = "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91"
+	mockUserID  = "550e8400-e29b-41d4-a716-446655440000"
+)
+
+func TestAdminService_ListUsers(t *testing.T) {
+	t.Parallel()
+
+	testErrDatabase := errors.New("database error")
+	users := []*model.User{
+		{Base: model.Base{ID: mockUserID}, Username: "johndoe"},
+	}
+
+	testCases := []struct {
+		name             string
+		setupMockRepo    func(t *testing.T, ctx context.Context) *mockUserRepo.User
+		expectedResponse *ListUsersResponse
+		expectedError    error
+	}{
+		{
+			name: "success",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repoMock := mockUserRepo.NewUser(t)
+				repoMock.On("ListUsers", ctx, "john", 0, 10).Return(users, nil).Once()
+				repoMock.On("CountUsers", ctx, "john").Return(int64(1), nil).Once()
+				return repoMock
+			},
+			expectedResponse: &ListUsersResponse{Data: users, Total: 1},
+		},
+		{
+			name: "error - list fails",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repoMock := mockUserRepo.NewUser(t)
+				repoMock.On("ListUsers", ctx, "john", 0, 10).Return(nil, testErrDatabase).Once()
+				return repoMock
+			},
+			expectedError: testErrDatabase,
+		},
+		{
+			name: "error - count fails",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repoMock := mockUserRepo.NewUser(t)
+				repoMock.On("ListUsers", ctx, "john", 0, 10).Return(users, nil).Once()
+				repoMock.On("CountUsers", ctx, "john").Return(int64(0), testErrDatabase).Once()
+				return repoMock
+			},
+			expectedError: testErrDatabase,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			svc := NewAdmin(tc.setupMockRepo(t, ctx), mockBookmarkRepo.NewRepository(t), mockURLRepo.NewURLStorage(t), mockSessionSvc.NewService(t))
+
+			res, err := svc.ListUsers(ctx, "john", 0, 10)
+
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedResponse, res)
+		})
+	}
+}
+
+func TestAdminService_SetUserDisabled(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		userID        string
+		disabled      bool
+		setupMockRepo func(t *testing.T, ctx context.Context) *mockUserRepo.User
+		expectedUser  *model.User
+		expectedError error
+	}{
+		{
+			name:     "success - disable user",
+			userID:   mockUserID,
+			disabled: true,
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repoMock := mockUserRepo.NewUser(t)
+				repoMock.On("UpdateUserStatus", ctx, mockUserID, true).
+					Return(&model.User{Base: model.Base{ID: mockUserID}, Disabled: true}, nil).Once()
+				return repoMock
+			},
+			expectedUser: &model.User{Base: model.Base{ID: mockUserID}, Disabled: true},
+		},
+		{
+			name:     "success - admin enables own account",
+			userID:   mockAdminID,
+			disabled: false,
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repoMock := mockUserRepo.NewUser(t)
+				repoMock.On("UpdateUserStatus", ctx, mockAdminID, false).
+					Return(&model.User{Base: model.Base{ID: mockAdminID}}, nil).Once()
+				return repoMock
+			},
+			expectedUser: &model.User{Base: model.Base{ID: mockAdminID}},
+		},
+		{
+			name:     "error - admin disables own account",
+			userID:   mockAdminID,
+			disabled: true,
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				return mockUserRepo.NewUser(t)
+			},
+			expectedError: ErrSelfDisable,
+		},
+		{
+			name:     "error - user not found",
+			userID:   mockUserID,
+			disabled: true,
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockUserRepo.User {
+				repoMock := mockUserRepo.NewUser(t)
+				repoMock.On("UpdateUserStatus", ctx, mockUserID, true).Return(nil, dbutils.ErrNotFoundType).Once()
+				return repoMock
+			},
+			expectedError: dbutils.ErrNotFoundType,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			svc := NewAdmin(tc.setupMockRepo(t, ctx), mockBookmarkRepo.NewRepository(t), mockURLRepo.NewURLStorage(t), mockSessionSvc.NewService(t))
+
+			user, err := svc.SetUserDisabled(ctx, mockAdminID, tc.userID, tc.disabled)
+
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedUser, user)
+		})
+	}
+}
+
+func TestAdminService_ForcePasswordReset(t *testing.T) {
+	t.Parallel()
+
+	testErr := errors.New("redis error")
+
+	testCases := []struct {
+		name          string
+		repoErr       error
+		revokeErr     error
+		expectRevoke  bool
+		expectedError error
+	}{
+		{
+			name:         "success",
+			expectRevoke: true,
+		},
+		{
+			name:          "error - user not found",
+			repoErr:       dbutils.ErrNotFoundType,
+			expectedError: dbutils.ErrNotFoundType,
+		},
+		{
+			name:          "error - revoking the sessions fails",
+			revokeErr:     testErr,
+			expectRevoke:  true,
+			expectedError: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repoMock := mockUserRepo.NewUser(t)
+			repoMock.On("RequirePasswordReset", ctx, mockUserID).Return(tc.repoErr).Once()
+			sessionsMock := mockSessionSvc.NewService(t)
+			if tc.expectRevoke {
+				sessionsMock.On("RevokeAll", ctx, mockUserID).Return(tc.revokeErr).Once()
+			}
+			svc := NewAdmin(repoMock, mockBookmarkRepo.NewRepository(t), mockURLRepo.NewURLStorage(t), sessionsMock)
+
+			err := svc.ForcePasswordReset(ctx, mockUserID)
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -8,7 +8,9 @@ import (
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
 	"github.com/luongtruong20201/bookmark-management/internal/repositories/cache"
-	"github.com/rs/zerolog/log"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/luongtruong20201/bookmark-management/pkg/metrics"
+	sqldb "github.com/luongtruong20201/bookmark-management/pkg/sql"
 )
 
 // bookmarkCache is a caching decorator around the bookmark Service.
@@ -21,6 +23,10 @@ import (
 // - group key: `get_bookmarks_<userID>`
 // - item key:  `<offset>_<limit>`
 //
+// Writes also mark the user as recently written for readYourWritesWindow, during which
+// the lists are read from the primary database, so that a replica lagging behind the
+// write cannot refill the cache with a stale list.
+//
 // Notes:
 // - Cache failures are non-fatal: on cache miss/unmarshal error it falls back to
 //   the underlying service; on cache set/delete errors it logs and continues.
@@ -38,6 +44,16 @@ const (
 	getBookmarksCacheKeyFormat   = "%d_%d"
 	// getBookmarksCacheDuration is the TTL for cached bookmark list responses.
 	getBookmarksCacheDuration    = time.Hour
+	// bookmarksCacheName identifies the bookmark list cache in the cache metrics.
+	bookmarksCacheName = "bookmarks"
+	// recentWriteCacheGroupFormat is the cache group marking a user whose bookmarks were
+	// recently written.
+	recentWriteCacheGroupFormat = "bookmarks_written_%s"
+	// recentWriteCacheKey is the cache item of the recent write mark.
+	recentWriteCacheKey = "written"
+	// readYourWritesWindow bounds the replication lag of the read replicas: the lists of
+	// a user are read from the primary database for that long after a write.
+	readYourWritesWindow = 10 * time.Second
 )
 
 // NewBookmarkCache creates a new bookmark cache service that wraps the provided
@@ -50,24 +66,48 @@ func NewBookmarkCache(s Service, cache cache.DB) *bookmarkCache {
 	}
 }
 
+// CacheGroupKey returns the cache group holding the cached bookmark lists of a user.
+// Deleting the group invalidates every cached page for that user.
+func CacheGroupKey(userID string) string {
+	return fmt.Sprintf(getBookmarksCacheGroupFormat, userID)
+}
+
 // getCacheGroupKey generates a cache group key for a user's bookmarks
 func (c *bookmarkCache) getCacheGroupKey(userID string) string {
-	return fmt.Sprintf(getBookmarksCacheGroupFormat, userID)
+	return CacheGroupKey(userID)
 }
 
-// invalidateUserCache deletes the cache for a user's bookmarks.
-// Errors are logged but do not prevent the operation from continuing.
+// invalidateUserCache deletes the cache for a user's bookmarks and marks the user as
+// recently written. Errors are logged but do not prevent the operation from continuing.
 func (c *bookmarkCache) invalidateUserCache(ctx context.Context, userID string) {
 	cacheGroupKey := c.getCacheGroupKey(userID)
 	if err := c.cache.DeleteCacheData(ctx, cacheGroupKey); err != nil {
-		log.Warn().Err(err).Str("userID", userID).Msg("failed to invalidate user cache")
+		logger.FromContext(ctx).Warn().Err(err).Str("userID", userID).Msg("failed to invalidate user cache")
 	}
+
+	recentWriteGroupKey := fmt.Sprintf(recentWriteCacheGroupFormat, userID)
+	if err := c.cache.SetCacheData(ctx, recentWriteGroupKey, recentWriteCacheKey, []byte("1"), readYourWritesWindow); err != nil {
+		logger.FromContext(ctx).Warn().Err(err).Str("userID", userID).Msg("failed to mark user bookmarks as written")
+	}
+}
+
+// readContext returns the context of the reads refilling the cache of a user, forcing the
+// primary database while the user is marked as recently written.
+func (c *bookmarkCache) readContext(ctx context.Context, userID string) context.Context {
+	recentWriteGroupKey := fmt.Sprintf(recentWriteCacheGroupFormat, userID)
+	if data, err := c.cache.GetCacheData(ctx, recentWriteGroupKey, recentWriteCacheKey); err == nil && len(data) > 0 {
+		return sqldb.WithPrimary(ctx)
+	}
+
+	return ctx
 }
 
 // GetBookmarks retrieves bookmarks for a user with pagination support.
 // It first attempts to retrieve data from cache using the cache group key
 // and pagination-specific cache key. If cache miss occurs or unmarshal fails,
-// it fetches from the underlying service and caches the result.
+// it fetches from the underlying service and caches the result, from the primary
+// database after a recent write. Each lookup is recorded as a cache hit or miss in the
+// service metrics.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and timeout
@@ -86,12 +126,14 @@ func (s *bookmarkCache) GetBookmarks(ctx context.Context, userID string, offset,
 	if err == nil && len(cacheData) > 0 {
 		result := &GetBookmarksResponse{}
 		if err := json.Unmarshal(cacheData, result); err == nil {
+			metrics.CacheHit(bookmarksCacheName)
 			return result, nil
 		}
-		log.Warn().Err(err).Msg("failed to unmarshal cached data, fetching from service")
+		logger.FromContext(ctx).Warn().Err(err).Msg("failed to unmarshal cached data, fetching from service")
 	}
+	metrics.CacheMiss(bookmarksCacheName)
 
-	result, err := s.Service.GetBookmarks(ctx, userID, offset, limit)
+	result, err := s.Service.GetBookmarks(s.readContext(ctx, userID), userID, offset, limit)
 	if err != nil {
 		return result, err
 	}
@@ -99,10 +141,10 @@ func (s *bookmarkCache) GetBookmarks(ctx context.Context, userID string, offset,
 	resultBytes, err := json.Marshal(result)
 	if err == nil {
 		if err := s.cache.SetCacheData(ctx, cacheGroupKey, cacheKey, resultBytes, getBookmarksCacheDuration); err != nil {
-			log.Error().Err(err).Msg("failed to cache data")
+			logger.FromContext(ctx).Error().Err(err).Msg("failed to cache data")
 		}
 	} else {
-		log.Error().Err(err).Msg("failed to marshal result for caching")
+		logger.FromContext(ctx).Error().Err(err).Msg("failed to marshal result for caching")
 	}
 
 	return result, nil
@@ -12,7 +12,9 @@ import (
 	bookmark "github.com/luongtruong20201/bookmark-management/internal/services/bookmark"
 	cacheMocks "github.com/luongtruong20201/bookmark-management/internal/repositories/cache/mocks"
 	serviceMocks "github.com/luongtruong20201/bookmark-management/internal/services/bookmark/mocks"
+	sqldb "github.com/luongtruong20201/bookmark-management/pkg/sql"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNewBookmarkCache(t *testing.T) {
@@ -30,9 +32,10 @@ func TestBookmarkCache_GetBookmarks(t *testing.T) {
 	t.Parallel()
 
 	var (
-		testErrService = errors.New("service error")
-		testErrCache   = errors.New("cache error")
-		mockUserID     = "550e8400-e29b-41d4-a716-446655440000"
+		testErrService      = errors.New("service error")
+		testErrCache        = errors.New("cache error")
+		mockUserID          = "550e8400-e29b-41d4-a716-446655440000"
+		recentWriteGroupKey = fmt.Sprintf("bookmarks_written_%s", mockUserID)
 	)
 
 	testCases := []struct {
@@ -93,6 +96,7 @@ func TestBookmarkCache_GetBookmarks(t *testing.T) {
 			setupCache: func(t *testing.T, ctx context.Context, cacheGroupKey, cacheKey string, shouldHit bool, shouldUnmarshalFail bool, shouldSetFail bool, shouldMarshalFail bool) *cacheMocks.DB {
 				cache := cacheMocks.NewDB(t)
 				cache.On("GetCacheData", ctx, cacheGroupKey, cacheKey).Return([]byte(nil), testErrCache).Once()
+				cache.On("GetCacheData", ctx, recentWriteGroupKey, "written").Return([]byte(nil), testErrCache).Once()
 				response := &bookmark.GetBookmarksResponse{
 					Data: []*models.Bookmark{
 						{
@@ -148,6 +152,7 @@ func TestBookmarkCache_GetBookmarks(t *testing.T) {
 			setupCache: func(t *testing.T, ctx context.Context, cacheGroupKey, cacheKey string, shouldHit bool, shouldUnmarshalFail bool, shouldSetFail bool, shouldMarshalFail bool) *cacheMocks.DB {
 				cache := cacheMocks.NewDB(t)
 				cache.On("GetCacheData", ctx, cacheGroupKey, cacheKey).Return([]byte{}, nil).Once()
+				cache.On("GetCacheData", ctx, recentWriteGroupKey, "written").Return([]byte(nil), testErrCache).Once()
 				response := &bookmark.GetBookmarksResponse{
 					Data: []*models.Bookmark{
 						{
@@ -202,6 +207,7 @@ func TestBookmarkCache_GetBookmarks(t *testing.T) {
 			setupCache: func(t *testing.T, ctx context.Context, cacheGroupKey, cacheKey string, shouldHit bool, shouldUnmarshalFail bool, shouldSetFail bool, shouldMarshalFail bool) *cacheMocks.DB {
 				cache := cacheMocks.NewDB(t)
 				cache.On("GetCacheData", ctx, cacheGroupKey, cacheKey).Return([]byte("invalid json"), nil).Once()
+				cache.On("GetCacheData", ctx, recentWriteGroupKey, "written").Return([]byte(nil), testErrCache).Once()
 				response := &bookmark.GetBookmarksResponse{
 					Data: []*models.Bookmark{
 						{
@@ -256,6 +262,7 @@ func TestBookmarkCache_GetBookmarks(t *testing.T) {
 			setupCache: func(t *testing.T, ctx context.Context, cacheGroupKey, cacheKey string, shouldHit bool, shouldUnmarshalFail bool, shouldSetFail bool, shouldMarshalFail bool) *cacheMocks.DB {
 				cache := cacheMocks.NewDB(t)
 				cache.On("GetCacheData", ctx, cacheGroupKey, cacheKey).Return([]byte(nil), testErrCache).Once()
+				cache.On("GetCacheData", ctx, recentWriteGroupKey, "written").Return([]byte(nil), testErrCache).Once()
 				return cache
 			},
 			setupService: func(t *testing.T, ctx context.Context, userID string, offset, limit int) *serviceMocks.Service {
@@ -276,6 +283,7 @@ func TestBookmarkCache_GetBookmarks(t *testing.T) {
 			setupCache: func(t *testing.T, ctx context.Context, cacheGroupKey, cacheKey string, shouldHit bool, shouldUnmarshalFail bool, shouldSetFail bool, shouldMarshalFail bool) *cacheMocks.DB {
 				cache := cacheMocks.NewDB(t)
 				cache.On("GetCacheData", ctx, cacheGroupKey, cacheKey).Return([]byte(nil), testErrCache).Once()
+				cache.On("GetCacheData", ctx, recentWriteGroupKey, "written").Return([]byte(nil), testErrCache).Once()
 				response := &bookmark.GetBookmarksResponse{
 					Data: []*models.Bookmark{
 						{
@@ -322,6 +330,30 @@ func TestBookmarkCache_GetBookmarks(t *testing.T) {
 				assert.Equal(t, "Twitter", resp.Data[0].Description)
 			},
 		},
+		{
+			name:   "success - recent write, list read from the primary",
+			userID: mockUserID,
+			offset: 0,
+			limit:  10,
+			setupCache: func(t *testing.T, ctx context.Context, cacheGroupKey, cacheKey string, shouldHit bool, shouldUnmarshalFail bool, shouldSetFail bool, shouldMarshalFail bool) *cacheMocks.DB {
+				cache := cacheMocks.NewDB(t)
+				cache.On("GetCacheData", ctx, cacheGroupKey, cacheKey).Return([]byte(nil), testErrCache).Once()
+				cache.On("GetCacheData", ctx, recentWriteGroupKey, "written").Return([]byte("1"), nil).Once()
+				data, _ := json.Marshal(&bookmark.GetBookmarksResponse{Data: []*models.Bookmark{}, Total: 0})
+				cache.On("SetCacheData", ctx, cacheGroupKey, cacheKey, data, time.Hour).Return(nil).Once()
+				return cache
+			},
+			setupService: func(t *testing.T, ctx context.Context, userID string, offset, limit int) *serviceMocks.Service {
+				service := serviceMocks.NewService(t)
+				primary := mock.MatchedBy(func(ctx context.Context) bool { return sqldb.PrimaryForced(ctx) })
+				response := &bookmark.GetBookmarksResponse{Data: []*models.Bookmark{}, Total: 0}
+				service.On("GetBookmarks", primary, userID, offset, limit).Return(response, nil).Once()
+				return service
+			},
+			expectedError: nil,
+			expectedCount: 0,
+			expectedTotal: 0,
+		},
 		{
 			name:   "success - with different offset and limit",
 			userID: mockUserID,
@@ -330,6 +362,7 @@ func TestBookmarkCache_GetBookmarks(t *testing.T) {
 			setupCache: func(t *testing.T, ctx context.Context, cacheGroupKey, cacheKey string, shouldHit bool, shouldUnmarshalFail bool, shouldSetFail bool, shouldMarshalFail bool) *cacheMocks.DB {
 				cache := cacheMocks.NewDB(t)
 				cache.On("GetCacheData", ctx, cacheGroupKey, cacheKey).Return([]byte(nil), testErrCache).Once()
+				cache.On("GetCacheData", ctx, recentWriteGroupKey, "written").Return([]byte(nil), testErrCache).Once()
 				response := &bookmark.GetBookmarksResponse{
 					Data:  []*models.Bookmark{},
 					Total: 15,
@@ -504,6 +537,7 @@ func TestBookmarkCache_Create(t *testing.T) {
 			ctx := context.Background()
 			service := tc.setupService(t, ctx, tc.description, tc.url, tc.userID)
 			cache := tc.setupCache(t, ctx, tc.userID, false)
+			cache.On("SetCacheData", ctx, fmt.Sprintf("bookmarks_written_%s", tc.userID), "written", []byte("1"), 10*time.Second).Return(nil).Once()
 
 			cacheService := bookmark.NewBookmarkCache(service, cache)
 
@@ -643,6 +677,7 @@ func TestBookmarkCache_Update(t *testing.T) {
 			ctx := context.Background()
 			service := tc.setupService(t, ctx, tc.bookmarkID, tc.userID, tc.description, tc.url)
 			cache := tc.setupCache(t, ctx, tc.userID, false)
+			cache.On("SetCacheData", ctx, fmt.Sprintf("bookmarks_written_%s", tc.userID), "written", []byte("1"), 10*time.Second).Return(nil).Once()
 
 			cacheService := bookmark.NewBookmarkCache(service, cache)
 
@@ -744,6 +779,7 @@ func TestBookmarkCache_Delete(t *testing.T) {
 			ctx := context.Background()
 			service := tc.setupService(t, ctx, tc.bookmarkID, tc.userID)
 			cache := tc.setupCache(t, ctx, tc.userID, false)
+			cache.On("SetCacheData", ctx, fmt.Sprintf("bookmarks_written_%s", tc.userID), "written", []byte("1"), 10*time.Second).Return(nil).Once()
 
 			cacheService := bookmark.NewBookmarkCache(service, cache)
 
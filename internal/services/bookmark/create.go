@@ -4,6 +4,7 @@ import (
 	"context"
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/pkg/metrics"
 )
 
 const (
@@ -33,6 +34,7 @@ func (s bookmarkSvc) Create(ctx context.Context, description, url, userId string
 	if err != nil {
 		return nil, err
 	}
+	metrics.BookmarkCreated()
 
 	return bookmark, nil
 }
@@ -0,0 +1,82 @@
+package bookmark
+
+import (
+	"context"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedService is a tracing decorator around the bookmark Service. Every call is
+// recorded as a span named after the decorated component and the method, e.g.
+// "bookmarkCache.GetBookmarks", so the time spent in the cache and in the service
+// can be told apart.
+type tracedService struct {
+	Service
+	component string
+}
+
+// NewTracedService creates a bookmark service recording a span for each call to s,
+// named after the given component.
+func NewTracedService(s Service, component string) Service {
+	return &tracedService{
+		Service:   s,
+		component: component,
+	}
+}
+
+// Create records a span around the creation of a bookmark.
+func (s *tracedService) Create(ctx context.Context, description, url, userId string) (*model.Bookmark, error) {
+	ctx, span := tracing.Start(ctx, s.component+".Create", attribute.String("user.id", userId))
+	bookmark, err := s.Service.Create(ctx, description, url, userId)
+	tracing.End(span, err)
+
+	return bookmark, err
+}
+
+// GetBookmarks records a span around the listing of the bookmarks of a user.
+func (s *tracedService) GetBookmarks(ctx context.Context, userID string, offset, limit int) (*GetBookmarksResponse, error) {
+	ctx, span := tracing.Start(ctx, s.component+".GetBookmarks",
+		attribute.String("user.id", userID),
+		attribute.Int("offset", offset),
+		attribute.Int("limit", limit),
+	)
+	result, err := s.Service.GetBookmarks(ctx, userID, offset, limit)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+// CountBookmarks records a span around the count of the bookmarks of a user.
+func (s *tracedService) CountBookmarks(ctx context.Context, userID string) (int64, error) {
+	ctx, span := tracing.Start(ctx, s.component+".CountBookmarks", attribute.String("user.id", userID))
+	count, err := s.Service.CountBookmarks(ctx, userID)
+	tracing.End(span, err)
+
+	return count, err
+}
+
+// Update records a span around the update of a bookmark.
+func (s *tracedService) Update(ctx context.Context, bookmarkID, userID, description, url string) (*model.Bookmark, error) {
+	ctx, span := tracing.Start(ctx, s.component+".Update",
+		attribute.String("bookmark.id", bookmarkID),
+		attribute.String("user.id", userID),
+	)
+	bookmark, err := s.Service.Update(ctx, bookmarkID, userID, description, url)
+	tracing.End(span, err)
+
+	return bookmark, err
+}
+
+// Delete records a span around the deletion of a bookmark.
+func (s *tracedService) Delete(ctx context.Context, bookmarkID, userID string) error {
+	ctx, span := tracing.Start(ctx, s.component+".Delete",
+		attribute.String("bookmark.id", bookmarkID),
+		attribute.String("user.id", userID),
+	)
+	err := s.Service.Delete(ctx, bookmarkID, userID)
+	tracing.End(span, err)
+
+	return err
+}
@@ -0,0 +1,115 @@
+package bookmark_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	models "github.com/luongtruong20201/bookmark-management/internal/models"
+	bookmark "github.com/luongtruong20201/bookmark-management/internal/services/bookmark"
+	serviceMocks "github.com/luongtruong20201/bookmark-management/internal/services/bookmark/mocks"
+	"github.com/luongtruong20201/bookmark-management/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestTracedService(t *testing.T) {
+	t.Parallel()
+
+	testErr := errors.New("service error")
+
+	testCases := []struct {
+		name         string
+		setupMock    func(svc *serviceMocks.Service)
+		call         func(ctx context.Context, svc bookmark.Service) error
+		expectedName string
+		expectedAttr attribute.KeyValue
+		expectedErr  error
+	}{
+		{
+			name: "create",
+			setupMock: func(svc *serviceMocks.Service) {
+				svc.On("Create", mock.Anything, "description", "https://example.com", "user-1").Return(&models.Bookmark{}, nil)
+			},
+			call: func(ctx context.Context, svc bookmark.Service) error {
+				_, err := svc.Create(ctx, "description", "https://example.com", "user-1")
+				return err
+			},
+			expectedName: "bookmarkSvc.Create",
+			expectedAttr: attribute.String("user.id", "user-1"),
+		},
+		{
+			name: "get bookmarks",
+			setupMock: func(svc *serviceMocks.Service) {
+				svc.On("GetBookmarks", mock.Anything, "user-1", 10, 20).Return(&bookmark.GetBookmarksResponse{}, nil)
+			},
+			call: func(ctx context.Context, svc bookmark.Service) error {
+				_, err := svc.GetBookmarks(ctx, "user-1", 10, 20)
+				return err
+			},
+			expectedName: "bookmarkSvc.GetBookmarks",
+			expectedAttr: attribute.Int("limit", 20),
+		},
+		{
+			name: "count bookmarks",
+			setupMock: func(svc *serviceMocks.Service) {
+				svc.On("CountBookmarks", mock.Anything, "user-1").Return(int64(3), nil)
+			},
+			call: func(ctx context.Context, svc bookmark.Service) error {
+				_, err := svc.CountBookmarks(ctx, "user-1")
+				return err
+			},
+			expectedName: "bookmarkSvc.CountBookmarks",
+			expectedAttr: attribute.String("user.id", "user-1"),
+		},
+		{
+			name: "update fails",
+			setupMock: func(svc *serviceMocks.Service) {
+				svc.On("Update", mock.Anything, "bookmark-1", "user-1", "description", "https://example.com").Return(nil, testErr)
+			},
+			call: func(ctx context.Context, svc bookmark.Service) error {
+				_, err := svc.Update(ctx, "bookmark-1", "user-1", "description", "https://example.com")
+				return err
+			},
+			expectedName: "bookmarkSvc.Update",
+			expectedAttr: attribute.String("bookmark.id", "bookmark-1"),
+			expectedErr:  testErr,
+		},
+		{
+			name: "delete",
+			setupMock: func(svc *serviceMocks.Service) {
+				svc.On("Delete", mock.Anything, "bookmark-1", "user-1").Return(nil)
+			},
+			call: func(ctx context.Context, svc bookmark.Service) error {
+				return svc.Delete(ctx, "bookmark-1", "user-1")
+			},
+			expectedName: "bookmarkSvc.Delete",
+			expectedAttr: attribute.String("bookmark.id", "bookmark-1"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, spans := tracing.InitMockTracer(t)
+			svc := serviceMocks.NewService(t)
+			tc.setupMock(svc)
+
+			err := tc.call(ctx, bookmark.NewTracedService(svc, "bookmarkSvc"))
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			recorded := spans()
+			assert.Len(t, recorded, 1)
+			assert.Equal(t, tc.expectedName, recorded[0].Name)
+			assert.Contains(t, recorded[0].Attributes, tc.expectedAttr)
+			if tc.expectedErr != nil {
+				assert.Equal(t, codes.Error, recorded[0].Status.Code)
+			} else {
+				assert.Equal(t, codes.Unset, recorded[0].Status.Code)
+			}
+		})
+	}
+}
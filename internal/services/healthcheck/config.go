@@ -0,0 +1,29 @@
+package healthcheck
+
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Config holds the readiness check settings loaded from environment variables.
+//
+// Each dependency has its own timeout, so a slow dependency is reported as failing
+// without delaying the report of the others.
+type Config struct {
+	PostgresTimeout  time.Duration `default:"2s" envconfig:"HEALTH_POSTGRES_TIMEOUT"`
+	RedisTimeout     time.Duration `default:"1s" envconfig:"HEALTH_REDIS_TIMEOUT"`
+	MigrationTimeout time.Duration `default:"2s" envconfig:"HEALTH_MIGRATION_TIMEOUT"`
+	JWTKeysTimeout   time.Duration `default:"1s" envconfig:"HEALTH_JWT_KEYS_TIMEOUT"`
+}
+
+// NewConfig creates a new configuration instance by reading environment variables.
+// Unset variables fall back to the defaults declared on Config.
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
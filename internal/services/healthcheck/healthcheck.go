@@ -2,46 +2,217 @@ package healthcheck
 
 import (
 	"context"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	repository "github.com/luongtruong20201/bookmark-management/internal/repositories/healthcheck"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
 )
 
+// Health statuses reported by the service and by each dependency check.
+const (
+	StatusOK           = "OK"
+	StatusDegraded     = "DEGRADED"
+	StatusNotOK        = "NOT_OK"
+	StatusShuttingDown = "SHUTTING_DOWN"
+)
+
+// Causes reported for a failed check instead of its error, which may name hosts,
+// addresses or connection settings of the dependency. The error is logged.
+const (
+	causeTimeout           = "timeout"
+	causeConnectionRefused = "connection refused"
+	causeUnavailable       = "unavailable"
+)
+
+// reportedErrors are the checker errors whose messages describe the state of the
+// dependency without naming its settings; they are reported as the cause of a failure.
+var reportedErrors = []error{
+	repository.ErrNoMigrations,
+	repository.ErrMigrationDirty,
+	repository.ErrMigrationBehind,
+	repository.ErrNoVerificationKeys,
+}
+
+// CheckResult is the outcome of the check of a single dependency.
+type CheckResult struct {
+	Name    string `json:"name" example:"postgres"`
+	Status  string `json:"status" example:"OK"`
+	Latency string `json:"latency" example:"1.2ms"`
+	Error   string `json:"error,omitempty" example:"timeout"`
+}
+
+// Report is the health of the service, with the result of each dependency check for
+// readiness reports.
+type Report struct {
+	Status      string        `json:"status" example:"OK"`
+	ServiceName string        `json:"service_name" example:"bookmark-api"`
+	InstanceId  string        `json:"instance_id" example:"instance-1"`
+	Checks      []CheckResult `json:"checks,omitempty"`
+}
+
 // Healthcheck defines the interface for healthcheck services.
 // It provides methods to check the health status of the service.
 //
 //go:generate mockery --name Healthcheck --filename health_check_service.go
 type Healthcheck interface {
+	// Check returns the readiness status, service name and instance ID.
 	Check(context.Context) (string, string, string)
+	// Live reports whether the process is up; it does not check any dependency.
+	Live(context.Context) *Report
+	// Ready runs every registered dependency check in parallel and reports whether the
+	// service can serve requests, StatusDegraded meaning it can without an optional one.
+	Ready(context.Context) *Report
+	// Shutdown marks the service as shutting down; readiness reports StatusShuttingDown from then on.
+	Shutdown()
 }
 
 // healthcheckService implements the Healthcheck interface and provides business logic
-// for health check operations. It checks the health status of external dependencies
-// (such as Redis) and returns service metadata including service name and instance ID.
+// for health check operations. It runs the checkers registered for the external
+// dependencies (such as Postgres and Redis) and returns service metadata including
+// service name and instance ID.
 type healthcheckService struct {
-	serviceName string
-	instanceId  string
-	healthCheck repository.HealthCheck
+	serviceName  string
+	instanceId   string
+	healthCheck  repository.HealthCheck
+	shuttingDown *atomic.Bool
 }
 
 // NewHealthcheck creates a new healthcheck service instance with the provided
-// service name and instance ID.
+// service name, instance ID and registry of dependency checkers.
 func NewHealthcheck(
 	serviceName string,
 	instanceId string,
 	healthCheck repository.HealthCheck,
 ) Healthcheck {
 	return &healthcheckService{
-		serviceName: serviceName,
-		instanceId:  instanceId,
-		healthCheck: healthCheck,
+		serviceName:  serviceName,
+		instanceId:   instanceId,
+		healthCheck:  healthCheck,
+		shuttingDown: &atomic.Bool{},
 	}
 }
 
-// Check performs a health check and returns the status message, service name, and instance ID.
+// Check performs a readiness check and returns the status message, service name, and instance ID.
 func (s healthcheckService) Check(ctx context.Context) (string, string, string) {
-	if err := s.healthCheck.Ping(ctx); err != nil {
-		return "NOT_OK", s.serviceName, s.instanceId
+	return s.Ready(ctx).Status, s.serviceName, s.instanceId
+}
+
+// Live reports the service as up. It stays OK while shutting down, so the process is
+// not restarted while it drains requests.
+func (s healthcheckService) Live(_ context.Context) *Report {
+	return s.report(StatusOK, nil)
+}
+
+// Ready runs the registered checkers in parallel, each bounded by its own timeout, and
+// reports StatusOK only if all of them pass, StatusDegraded if only optional checkers
+// fail, and StatusNotOK otherwise. Once the service is shutting down, dependencies are
+// no longer checked.
+func (s healthcheckService) Ready(ctx context.Context) *Report {
+	if s.shuttingDown.Load() {
+		return s.report(StatusShuttingDown, nil)
+	}
+
+	checkers := s.healthCheck.Checkers()
+	results := make([]CheckResult, len(checkers))
+	var wg sync.WaitGroup
+	for i, registration := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runCheck(ctx, registration)
+		}()
+	}
+	wg.Wait()
+
+	status := StatusOK
+	for _, result := range results {
+		switch {
+		case result.Status == StatusNotOK:
+			status = StatusNotOK
+		case result.Status == StatusDegraded && status == StatusOK:
+			status = StatusDegraded
+		}
+	}
+
+	return s.report(status, results)
+}
+
+// Shutdown marks the service as shutting down.
+func (s healthcheckService) Shutdown() {
+	s.shuttingDown.Store(true)
+}
+
+// report builds a report with the service metadata.
+func (s healthcheckService) report(status string, checks []CheckResult) *Report {
+	return &Report{
+		Status:      status,
+		ServiceName: s.serviceName,
+		InstanceId:  s.instanceId,
+		Checks:      checks,
+	}
+}
+
+// runCheck runs a single checker within its timeout. The result is reported when the
+// timeout expires even if the checker ignores its context and is still running. A failure
+// is logged with the checker name and reported with its sanitized cause, with
+// StatusDegraded for an optional checker.
+func runCheck(ctx context.Context, registration repository.Registration) CheckResult {
+	if registration.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, registration.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- registration.Checker.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{
+		Name:    registration.Checker.Name(),
+		Status:  StatusOK,
+		Latency: time.Since(start).String(),
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error().Err(err).Str("checker", result.Name).Msg("readiness check failed")
+		result.Status = StatusNotOK
+		if registration.Optional {
+			result.Status = StatusDegraded
+		}
+		result.Error = failureCause(err)
+	}
+
+	return result
+}
+
+// failureCause returns the cause reported for the error of a failed check: a timeout, a
+// refused connection, one of the reportedErrors, or causeUnavailable for any other error.
+func failureCause(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return causeTimeout
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return causeConnectionRefused
+	}
+	for _, reported := range reportedErrors {
+		if errors.Is(err, reported) {
+			return reported.Error()
+		}
 	}
 
-	return "OK", s.serviceName, s.instanceId
+	return causeUnavailable
 }
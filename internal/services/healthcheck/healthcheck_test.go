@@ -1,12 +1,21 @@
 package healthcheck
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
 	"testing"
+	"time"
 
 	repository "github.com/luongtruong20201/bookmark-management/internal/repositories/healthcheck"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/healthcheck/mocks"
 	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestHealthCheckService_Check(t *testing.T) {
@@ -18,6 +27,7 @@ func TestHealthCheckService_Check(t *testing.T) {
 		instanceID      string
 		expectedMsg     string
 		expectedSvcName string
+		shutdown        bool
 		setupRedis      func(t *testing.T) *redis.Client
 	}{
 		{
@@ -41,6 +51,17 @@ func TestHealthCheckService_Check(t *testing.T) {
 				return redis
 			},
 		},
+		{
+			name:        "shutting down",
+			serviceName: "bookmark_service",
+			instanceID:  "instance_id",
+			expectedMsg: "SHUTTING_DOWN",
+			shutdown:    true,
+			setupRedis: func(t *testing.T) *redis.Client {
+				redis := redisPkg.InitMockRedis(t)
+				return redis
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -49,8 +70,12 @@ func TestHealthCheckService_Check(t *testing.T) {
 
 			ctx := t.Context()
 			redis := tc.setupRedis(t)
-			repository := repository.NewHealthCheck(redis)
-			testSvc := NewHealthcheck(tc.serviceName, tc.instanceID, repository)
+			registry := repository.NewHealthCheck()
+			registry.Register(repository.NewRedisChecker(redis), time.Second)
+			testSvc := NewHealthcheck(tc.serviceName, tc.instanceID, registry)
+			if tc.shutdown {
+				testSvc.Shutdown()
+			}
 			message, serviceName, instanceId := testSvc.Check(ctx)
 
 			assert.Equal(t, tc.instanceID, instanceId)
@@ -59,3 +84,183 @@ func TestHealthCheckService_Check(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthCheckService_Ready(t *testing.T) {
+	t.Parallel()
+
+	newChecker := func(t *testing.T, name string, check func(context.Context) error) *mocks.Checker {
+		checker := mocks.NewChecker(t)
+		checker.On("Name").Return(name)
+		checker.On("Check", mock.Anything).Return(check)
+		return checker
+	}
+	healthy := func(context.Context) error { return nil }
+	hanging := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	testCases := []struct {
+		name           string
+		setupRegistry  func(t *testing.T) repository.HealthCheck
+		shutdown       bool
+		expectedStatus string
+		expectedChecks []CheckResult
+	}{
+		{
+			name: "all checks pass",
+			setupRegistry: func(t *testing.T) repository.HealthCheck {
+				registry := repository.NewHealthCheck()
+				registry.Register(newChecker(t, "postgres", healthy), time.Second)
+				registry.Register(newChecker(t, "redis", healthy), time.Second)
+				return registry
+			},
+			expectedStatus: StatusOK,
+			expectedChecks: []CheckResult{
+				{Name: "postgres", Status: StatusOK},
+				{Name: "redis", Status: StatusOK},
+			},
+		},
+		{
+			name: "failing check hides the error",
+			setupRegistry: func(t *testing.T) repository.HealthCheck {
+				registry := repository.NewHealthCheck()
+				registry.Register(newChecker(t, "postgres", func(context.Context) error {
+					return errors.New("dial tcp db.internal:5432: connect: connection refused")
+				}), time.Second)
+				registry.Register(newChecker(t, "redis", healthy), time.Second)
+				return registry
+			},
+			expectedStatus: StatusNotOK,
+			expectedChecks: []CheckResult{
+				{Name: "postgres", Status: StatusNotOK, Error: causeUnavailable},
+				{Name: "redis", Status: StatusOK},
+			},
+		},
+		{
+			name: "refused connection is reported",
+			setupRegistry: func(t *testing.T) repository.HealthCheck {
+				registry := repository.NewHealthCheck()
+				registry.Register(newChecker(t, "postgres", func(context.Context) error {
+					return &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+				}), time.Second)
+				return registry
+			},
+			expectedStatus: StatusNotOK,
+			expectedChecks: []CheckResult{
+				{Name: "postgres", Status: StatusNotOK, Error: causeConnectionRefused},
+			},
+		},
+		{
+			name: "schema behind is reported without its versions",
+			setupRegistry: func(t *testing.T) repository.HealthCheck {
+				registry := repository.NewHealthCheck()
+				registry.Register(newChecker(t, "migrations", func(context.Context) error {
+					return fmt.Errorf("%w: version 5, expected at least 6", repository.ErrMigrationBehind)
+				}), time.Second)
+				return registry
+			},
+			expectedStatus: StatusNotOK,
+			expectedChecks: []CheckResult{
+				{Name: "migrations", Status: StatusNotOK, Error: "migration version is behind"},
+			},
+		},
+		{
+			name: "check times out",
+			setupRegistry: func(t *testing.T) repository.HealthCheck {
+				registry := repository.NewHealthCheck()
+				registry.Register(newChecker(t, "postgres", hanging), 50*time.Millisecond)
+				registry.Register(newChecker(t, "redis", healthy), time.Second)
+				return registry
+			},
+			expectedStatus: StatusNotOK,
+			expectedChecks: []CheckResult{
+				{Name: "postgres", Status: StatusNotOK, Error: causeTimeout},
+				{Name: "redis", Status: StatusOK},
+			},
+		},
+		{
+			name: "failing optional check degrades the service",
+			setupRegistry: func(t *testing.T) repository.HealthCheck {
+				registry := repository.NewHealthCheck()
+				registry.Register(newChecker(t, "postgres", healthy), time.Second)
+				registry.RegisterOptional(newChecker(t, "postgres_replica_1", func(context.Context) error {
+					return errors.New("dial tcp replica.internal:5432: connect: connection refused")
+				}), time.Second)
+				return registry
+			},
+			expectedStatus: StatusDegraded,
+			expectedChecks: []CheckResult{
+				{Name: "postgres", Status: StatusOK},
+				{Name: "postgres_replica_1", Status: StatusDegraded, Error: causeUnavailable},
+			},
+		},
+		{
+			name: "failing check wins over a degraded one",
+			setupRegistry: func(t *testing.T) repository.HealthCheck {
+				registry := repository.NewHealthCheck()
+				registry.RegisterOptional(newChecker(t, "postgres_replica_1", hanging), 50*time.Millisecond)
+				registry.Register(newChecker(t, "redis", hanging), 50*time.Millisecond)
+				return registry
+			},
+			expectedStatus: StatusNotOK,
+			expectedChecks: []CheckResult{
+				{Name: "postgres_replica_1", Status: StatusDegraded, Error: causeTimeout},
+				{Name: "redis", Status: StatusNotOK, Error: causeTimeout},
+			},
+		},
+		{
+			name: "no checkers",
+			setupRegistry: func(t *testing.T) repository.HealthCheck {
+				return repository.NewHealthCheck()
+			},
+			expectedStatus: StatusOK,
+			expectedChecks: []CheckResult{},
+		},
+		{
+			name: "shutting down",
+			setupRegistry: func(t *testing.T) repository.HealthCheck {
+				registry := repository.NewHealthCheck()
+				registry.Register(mocks.NewChecker(t), time.Second)
+				return registry
+			},
+			shutdown:       true,
+			expectedStatus: StatusShuttingDown,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			testSvc := NewHealthcheck("bookmark_service", "instance_id", tc.setupRegistry(t))
+			if tc.shutdown {
+				testSvc.Shutdown()
+			}
+
+			report := testSvc.Ready(t.Context())
+
+			assert.Equal(t, tc.expectedStatus, report.Status)
+			assert.Equal(t, "bookmark_service", report.ServiceName)
+			assert.Equal(t, "instance_id", report.InstanceId)
+			for i := range report.Checks {
+				assert.NotEmpty(t, report.Checks[i].Latency)
+				report.Checks[i].Latency = ""
+			}
+			assert.Equal(t, tc.expectedChecks, report.Checks)
+		})
+	}
+}
+
+func TestHealthCheckService_Live(t *testing.T) {
+	t.Parallel()
+
+	testSvc := NewHealthcheck("bookmark_service", "instance_id", repository.NewHealthCheck())
+	testSvc.Shutdown()
+
+	assert.Equal(t, &Report{
+		Status:      StatusOK,
+		ServiceName: "bookmark_service",
+		InstanceId:  "instance_id",
+	}, testSvc.Live(t.Context()))
+}
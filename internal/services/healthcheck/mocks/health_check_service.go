@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 
+	healthcheck "github.com/luongtruong20201/bookmark-management/internal/services/healthcheck"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -48,6 +49,51 @@ func (_m *Healthcheck) Check(_a0 context.Context) (string, string, string) {
 	return r0, r1, r2
 }
 
+// Live provides a mock function with given fields: _a0
+func (_m *Healthcheck) Live(_a0 context.Context) *healthcheck.Report {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Live")
+	}
+
+	var r0 *healthcheck.Report
+	if rf, ok := ret.Get(0).(func(context.Context) *healthcheck.Report); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*healthcheck.Report)
+		}
+	}
+
+	return r0
+}
+
+// Ready provides a mock function with given fields: _a0
+func (_m *Healthcheck) Ready(_a0 context.Context) *healthcheck.Report {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ready")
+	}
+
+	var r0 *healthcheck.Report
+	if rf, ok := ret.Get(0).(func(context.Context) *healthcheck.Report); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*healthcheck.Report)
+		}
+	}
+
+	return r0
+}
+
+// Shutdown provides a mock function with no fields
+func (_m *Healthcheck) Shutdown() {
+	_m.Called()
+}
+
 // NewHealthcheck creates a new instance of Healthcheck. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewHealthcheck(t interface {
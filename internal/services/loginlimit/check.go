@@ -0,0 +1,52 @@
+package loginlimit
+
+import (
+	"context"
+	"time"
+)
+
+// Check returns the remaining lockout time of the account or IP, whichever is longer.
+// If neither is locked, it applies the progressive delay of the account: the time since
+// its latest failure must be at least delayFor(failures).
+func (l *limiter) Check(ctx context.Context, ip, username string) (time.Duration, error) {
+	account, err := l.accountSubject(ctx, username)
+	if err != nil {
+		return 0, err
+	}
+
+	var retryAfter time.Duration
+	for _, subject := range []string{account, ipSubject(ip)} {
+		ttl, err := l.storage.GetLockTTL(ctx, subject)
+		if err != nil {
+			return 0, err
+		}
+		retryAfter = max(retryAfter, ttl)
+	}
+	if retryAfter > 0 {
+		return retryAfter, nil
+	}
+
+	now := l.now()
+	failures, latest, err := l.storage.GetFailures(ctx, account, now, l.cfg.Window)
+	if err != nil {
+		return 0, err
+	}
+
+	return max(latest.Add(l.delayFor(failures)).Sub(now), 0), nil
+}
+
+// delayFor returns the delay imposed after the given number of failures. There is no delay
+// below DelayAfter failures; from there it starts at BaseDelay and doubles with every
+// failure up to MaxDelay.
+func (l *limiter) delayFor(failures int64) time.Duration {
+	if failures < l.cfg.DelayAfter {
+		return 0
+	}
+
+	delay := l.cfg.BaseDelay
+	for i := l.cfg.DelayAfter; i < failures && delay < l.cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, l.cfg.MaxDelay)
+}
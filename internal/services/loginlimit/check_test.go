@@ -0,0 +1,237 @@
+package loginlimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/loginattempt/mocks"
+	userMocks "github.com/luongtruong20201/bookmark-management/internal/repositories/user/mocks"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/stretchr/testify/assert"
+)
+
+// testConfig is the limiter configuration shared by the service tests.
+var testConfig = &Config{
+	Window:              15 * time.Minute,
+	MaxUsernameAttempts: 5,
+	MaxIPAttempts:       20,
+	LockoutDuration:     15 * time.Minute,
+	DelayAfter:          3,
+	BaseDelay:           time.Second,
+	MaxDelay:            30 * time.Second,
+}
+
+// testUserID is the ID of the account "JohnDoe" logs into when it exists.
+const testUserID = "550e8400-e29b-41d4-a716-446655440000"
+
+// newMockUsers returns a user repository resolving "JohnDoe" to the account with ID
+// testUserID, or to no account when exists is false.
+func newMockUsers(t *testing.T, ctx context.Context, exists bool) *userMocks.User {
+	usersMock := userMocks.NewUser(t)
+	if exists {
+		usersMock.On("GetUserByLogin", ctx, "JohnDoe").Return(&model.User{Base: model.Base{ID: testUserID}}, nil).Once()
+	} else {
+		usersMock.On("GetUserByLogin", ctx, "JohnDoe").Return(nil, dbutils.ErrNotFoundType).Once()
+	}
+	return usersMock
+}
+
+func TestLimiter_Check(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	testErrStorage := errors.New("storage error")
+
+	testCases := []struct {
+		name               string
+		accountExists      bool
+		setupMockStorage   func(t *testing.T, ctx context.Context) *mocks.Storage
+		expectedRetryAfter time.Duration
+		expectedError      error
+	}{
+		{
+			name: "allowed - no failures",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("GetLockTTL", ctx, "user:johndoe").Return(time.Duration(0), nil).Once()
+				storageMock.On("GetLockTTL", ctx, "ip:10.0.0.1").Return(time.Duration(0), nil).Once()
+				storageMock.On("GetFailures", ctx, "user:johndoe", now, testConfig.Window).Return(int64(0), time.Time{}, nil).Once()
+				return storageMock
+			},
+		},
+		{
+			name: "allowed - below delay threshold",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("GetLockTTL", ctx, "user:johndoe").Return(time.Duration(0), nil).Once()
+				storageMock.On("GetLockTTL", ctx, "ip:10.0.0.1").Return(time.Duration(0), nil).Once()
+				storageMock.On("GetFailures", ctx, "user:johndoe", now, testConfig.Window).Return(int64(2), now, nil).Once()
+				return storageMock
+			},
+		},
+		{
+			name: "allowed - delay elapsed",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("GetLockTTL", ctx, "user:johndoe").Return(time.Duration(0), nil).Once()
+				storageMock.On("GetLockTTL", ctx, "ip:10.0.0.1").Return(time.Duration(0), nil).Once()
+				storageMock.On("GetFailures", ctx, "user:johndoe", now, testConfig.Window).
+					Return(int64(4), now.Add(-5*time.Second), nil).Once()
+				return storageMock
+			},
+		},
+		{
+			name: "delayed - progressive delay doubles per failure",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("GetLockTTL", ctx, "user:johndoe").Return(time.Duration(0), nil).Once()
+				storageMock.On("GetLockTTL", ctx, "ip:10.0.0.1").Return(time.Duration(0), nil).Once()
+				storageMock.On("GetFailures", ctx, "user:johndoe", now, testConfig.Window).
+					Return(int64(4), now.Add(-500*time.Millisecond), nil).Once()
+				return storageMock
+			},
+			expectedRetryAfter: 1500 * time.Millisecond,
+		},
+		{
+			name: "locked - username",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("GetLockTTL", ctx, "user:johndoe").Return(10*time.Minute, nil).Once()
+				storageMock.On("GetLockTTL", ctx, "ip:10.0.0.1").Return(time.Duration(0), nil).Once()
+				return storageMock
+			},
+			expectedRetryAfter: 10 * time.Minute,
+		},
+		{
+			name: "locked - longest lockout wins",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("GetLockTTL", ctx, "user:johndoe").Return(time.Minute, nil).Once()
+				storageMock.On("GetLockTTL", ctx, "ip:10.0.0.1").Return(12*time.Minute, nil).Once()
+				return storageMock
+			},
+			expectedRetryAfter: 12 * time.Minute,
+		},
+		{
+			name:          "locked - account shared by its username and email",
+			accountExists: true,
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("GetLockTTL", ctx, "account:"+testUserID).Return(10*time.Minute, nil).Once()
+				storageMock.On("GetLockTTL", ctx, "ip:10.0.0.1").Return(time.Duration(0), nil).Once()
+				return storageMock
+			},
+			expectedRetryAfter: 10 * time.Minute,
+		},
+		{
+			name: "error - lock lookup fails",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("GetLockTTL", ctx, "user:johndoe").Return(time.Duration(0), testErrStorage).Once()
+				return storageMock
+			},
+			expectedError: testErrStorage,
+		},
+		{
+			name: "error - failures lookup fails",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("GetLockTTL", ctx, "user:johndoe").Return(time.Duration(0), nil).Once()
+				storageMock.On("GetLockTTL", ctx, "ip:10.0.0.1").Return(time.Duration(0), nil).Once()
+				storageMock.On("GetFailures", ctx, "user:johndoe", now, testConfig.Window).
+					Return(int64(0), time.Time{}, testErrStorage).Once()
+				return storageMock
+			},
+			expectedError: testErrStorage,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			l := &limiter{
+				storage: tc.setupMockStorage(t, ctx),
+				users:   newMockUsers(t, ctx, tc.accountExists),
+				cfg:     testConfig,
+				now:     func() time.Time { return now },
+			}
+
+			retryAfter, err := l.Check(ctx, "10.0.0.1", "JohnDoe")
+			assert.Equal(t, tc.expectedRetryAfter, retryAfter)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestLimiter_DelayFor(t *testing.T) {
+	t.Parallel()
+
+	l := &limiter{cfg: testConfig}
+
+	testCases := []struct {
+		failures      int64
+		expectedDelay time.Duration
+	}{
+		{failures: 0, expectedDelay: 0},
+		{failures: 2, expectedDelay: 0},
+		{failures: 3, expectedDelay: time.Second},
+		{failures: 4, expectedDelay: 2 * time.Second},
+		{failures: 7, expectedDelay: 16 * time.Second},
+		{failures: 8, expectedDelay: 30 * time.Second},
+		{failures: 100, expectedDelay: 30 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expectedDelay, l.delayFor(tc.failures), "failures: %d", tc.failures)
+	}
+}
+
+func TestLimiter_AccountSubject(t *testing.T) {
+	t.Parallel()
+
+	testErrDatabase := errors.New("database error")
+
+	testCases := []struct {
+		name            string
+		user            *model.User
+		lookupErr       error
+		expectedSubject string
+		expectedError   error
+	}{
+		{
+			name:            "account found by its username or email",
+			user:            &model.User{Base: model.Base{ID: testUserID}},
+			expectedSubject: "account:" + testUserID,
+		},
+		{
+			name:            "no account - lowercased login",
+			lookupErr:       dbutils.ErrNotFoundType,
+			expectedSubject: "user:johndoe",
+		},
+		{
+			name:          "error - lookup fails",
+			lookupErr:     testErrDatabase,
+			expectedError: testErrDatabase,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			usersMock := userMocks.NewUser(t)
+			usersMock.On("GetUserByLogin", ctx, "JohnDoe").Return(tc.user, tc.lookupErr).Once()
+			l := &limiter{users: usersMock, cfg: testConfig}
+
+			subject, err := l.accountSubject(ctx, "JohnDoe")
+			assert.Equal(t, tc.expectedSubject, subject)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package loginlimit
+
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Config holds the login throttling settings loaded from environment variables.
+//
+// Failed attempts are counted per account, whether the username or the email was typed,
+// and per client IP over a sliding Window. Once an account has DelayAfter failures, every
+// further attempt must wait BaseDelay, doubled for each extra failure and capped at
+// MaxDelay. Reaching MaxUsernameAttempts or MaxIPAttempts locks the account or IP out for
+// LockoutDuration.
+type Config struct {
+	Window              time.Duration `default:"15m" envconfig:"LOGIN_LIMIT_WINDOW"`
+	MaxUsernameAttempts int64         `default:"5" envconfig:"LOGIN_LIMIT_MAX_USERNAME_ATTEMPTS"`
+	MaxIPAttempts       int64         `default:"20" envconfig:"LOGIN_LIMIT_MAX_IP_ATTEMPTS"`
+	LockoutDuration     time.Duration `default:"15m" envconfig:"LOGIN_LIMIT_LOCKOUT_DURATION"`
+	DelayAfter          int64         `default:"3" envconfig:"LOGIN_LIMIT_DELAY_AFTER"`
+	BaseDelay           time.Duration `default:"1s" envconfig:"LOGIN_LIMIT_BASE_DELAY"`
+	MaxDelay            time.Duration `default:"30s" envconfig:"LOGIN_LIMIT_MAX_DELAY"`
+}
+
+// NewConfig creates a new configuration instance by reading environment variables.
+// Unset variables fall back to the defaults declared on Config.
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
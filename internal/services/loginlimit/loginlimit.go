@@ -0,0 +1,69 @@
+// Package loginlimit protects the endpoints verifying passwords against brute force attacks
+// by throttling failed attempts per account and per client IP.
+package loginlimit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/loginattempt"
+	userRepository "github.com/luongtruong20201/bookmark-management/internal/repositories/user"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+)
+
+// Limiter defines the interface for login throttling.
+//
+//go:generate mockery --name Limiter --filename limiter.go
+type Limiter interface {
+	// Check reports how long the client must wait before it may attempt to log in with the
+	// given username or email. A zero duration means the attempt is allowed.
+	Check(ctx context.Context, ip, username string) (time.Duration, error)
+	// RecordFailure records a failed login attempt and locks the account or IP out
+	// when it exceeds its limit.
+	RecordFailure(ctx context.Context, ip, username string) error
+	// Reset clears the failed attempts of the account after a successful login. The
+	// failures of the client IP are kept.
+	Reset(ctx context.Context, username string) error
+}
+
+// limiter implements the Limiter interface on top of the login attempt storage.
+type limiter struct {
+	storage loginattempt.Storage
+	users   userRepository.User
+	cfg     *Config
+	now     func() time.Time
+}
+
+// NewLimiter creates a new login limiter with the provided attempt storage, user repository
+// resolving the accounts logged into, and configuration.
+func NewLimiter(storage loginattempt.Storage, users userRepository.User, cfg *Config) Limiter {
+	return &limiter{
+		storage: storage,
+		users:   users,
+		cfg:     cfg,
+		now:     time.Now,
+	}
+}
+
+// accountSubject returns the storage subject of the account the username or email logs
+// into, so that every identifier of an account shares its limit. An identifier matching
+// no account is limited on its own, lowercased so that changing the case does not bypass
+// the limit.
+func (l *limiter) accountSubject(ctx context.Context, username string) (string, error) {
+	user, err := l.users.GetUserByLogin(ctx, username)
+	switch {
+	case err == nil:
+		return "account:" + user.ID, nil
+	case errors.Is(err, dbutils.ErrNotFoundType):
+		return "user:" + strings.ToLower(username), nil
+	default:
+		return "", err
+	}
+}
+
+// ipSubject returns the storage subject of a client IP.
+func ipSubject(ip string) string {
+	return "ip:" + ip
+}
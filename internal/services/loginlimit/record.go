@@ -0,0 +1,63 @@
+package loginlimit
+
+import (
+	"context"
+
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+)
+
+// RecordFailure adds the failure to the account and IP windows. A subject that reaches its
+// maximum number of attempts is locked out for LockoutDuration and its failures are cleared,
+// so that the count starts over once the lockout expires.
+func (l *limiter) RecordFailure(ctx context.Context, ip, username string) error {
+	account, err := l.accountSubject(ctx, username)
+	if err != nil {
+		return err
+	}
+	now := l.now()
+
+	limits := []struct {
+		subject     string
+		maxAttempts int64
+	}{
+		{subject: account, maxAttempts: l.cfg.MaxUsernameAttempts},
+		{subject: ipSubject(ip), maxAttempts: l.cfg.MaxIPAttempts},
+	}
+	for _, limit := range limits {
+		failures, err := l.storage.AddFailure(ctx, limit.subject, now, l.cfg.Window)
+		if err != nil {
+			return err
+		}
+		if failures < limit.maxAttempts {
+			continue
+		}
+
+		if err := l.storage.Lock(ctx, limit.subject, l.cfg.LockoutDuration); err != nil {
+			return err
+		}
+		if err := l.storage.ClearFailures(ctx, limit.subject); err != nil {
+			return err
+		}
+		logger.FromContext(ctx).Warn().
+			Str("subject", limit.subject).
+			Str("ip", ip).
+			Str("username", username).
+			Int64("failures", failures).
+			Dur("lockout", l.cfg.LockoutDuration).
+			Msg("login locked out after too many failed attempts")
+	}
+
+	return nil
+}
+
+// Reset clears the failed attempts recorded for the account. The IP window is left to
+// expire on its own: a client owning one account could otherwise log into it between
+// guesses against other accounts to start the count of its IP over.
+func (l *limiter) Reset(ctx context.Context, username string) error {
+	account, err := l.accountSubject(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	return l.storage.ClearFailures(ctx, account)
+}
@@ -0,0 +1,146 @@
+package loginlimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/loginattempt/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_RecordFailure(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	testErrStorage := errors.New("storage error")
+
+	testCases := []struct {
+		name             string
+		accountExists    bool
+		setupMockStorage func(t *testing.T, ctx context.Context) *mocks.Storage
+		expectedError    error
+	}{
+		{
+			name: "success - below limits",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("AddFailure", ctx, "user:johndoe", now, testConfig.Window).Return(int64(1), nil).Once()
+				storageMock.On("AddFailure", ctx, "ip:10.0.0.1", now, testConfig.Window).Return(int64(1), nil).Once()
+				return storageMock
+			},
+		},
+		{
+			name: "success - username locked out",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("AddFailure", ctx, "user:johndoe", now, testConfig.Window).Return(int64(5), nil).Once()
+				storageMock.On("Lock", ctx, "user:johndoe", testConfig.LockoutDuration).Return(nil).Once()
+				storageMock.On("ClearFailures", ctx, "user:johndoe").Return(nil).Once()
+				storageMock.On("AddFailure", ctx, "ip:10.0.0.1", now, testConfig.Window).Return(int64(5), nil).Once()
+				return storageMock
+			},
+		},
+		{
+			name:          "success - failure counted on the account",
+			accountExists: true,
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("AddFailure", ctx, "account:"+testUserID, now, testConfig.Window).Return(int64(1), nil).Once()
+				storageMock.On("AddFailure", ctx, "ip:10.0.0.1", now, testConfig.Window).Return(int64(1), nil).Once()
+				return storageMock
+			},
+		},
+		{
+			name: "success - ip locked out",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("AddFailure", ctx, "user:johndoe", now, testConfig.Window).Return(int64(1), nil).Once()
+				storageMock.On("AddFailure", ctx, "ip:10.0.0.1", now, testConfig.Window).Return(int64(20), nil).Once()
+				storageMock.On("Lock", ctx, "ip:10.0.0.1", testConfig.LockoutDuration).Return(nil).Once()
+				storageMock.On("ClearFailures", ctx, "ip:10.0.0.1").Return(nil).Once()
+				return storageMock
+			},
+		},
+		{
+			name: "error - add failure fails",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("AddFailure", ctx, "user:johndoe", now, testConfig.Window).Return(int64(0), testErrStorage).Once()
+				return storageMock
+			},
+			expectedError: testErrStorage,
+		},
+		{
+			name: "error - lock fails",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("AddFailure", ctx, "user:johndoe", now, testConfig.Window).Return(int64(5), nil).Once()
+				storageMock.On("Lock", ctx, "user:johndoe", testConfig.LockoutDuration).Return(testErrStorage).Once()
+				return storageMock
+			},
+			expectedError: testErrStorage,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			l := &limiter{
+				storage: tc.setupMockStorage(t, ctx),
+				users:   newMockUsers(t, ctx, tc.accountExists),
+				cfg:     testConfig,
+				now:     func() time.Time { return now },
+			}
+
+			err := l.RecordFailure(ctx, "10.0.0.1", "JohnDoe")
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestLimiter_Reset(t *testing.T) {
+	t.Parallel()
+
+	testErrStorage := errors.New("storage error")
+
+	testCases := []struct {
+		name            string
+		accountExists   bool
+		expectedSubject string
+		storageErr      error
+		expectedError   error
+	}{
+		{
+			name:            "success - unknown login",
+			expectedSubject: "user:johndoe",
+		},
+		{
+			name:            "success - account",
+			accountExists:   true,
+			expectedSubject: "account:" + testUserID,
+		},
+		{
+			name:            "error - storage fails",
+			expectedSubject: "user:johndoe",
+			storageErr:      testErrStorage,
+			expectedError:   testErrStorage,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			storageMock := mocks.NewStorage(t)
+			storageMock.On("ClearFailures", ctx, tc.expectedSubject).Return(tc.storageErr).Once()
+
+			err := NewLimiter(storageMock, newMockUsers(t, ctx, tc.accountExists), testConfig).Reset(ctx, "JohnDoe")
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	repository "github.com/luongtruong20201/bookmark-management/internal/repositories/oidc"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
+	"github.com/luongtruong20201/bookmark-management/pkg/stringutils"
+	"golang.org/x/oauth2"
+)
+
+// AuthCodeURL generates a random state, nonce and PKCE code verifier, stores them for the
+// callback and returns the authorization URL of the provider.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - provider: Name of the configured identity provider
+//
+// Returns:
+//   - string: Authorization URL to redirect the user agent to
+//   - error: ErrProviderNotFound if the provider is not configured, or a storage error
+func (s *oidcSvc) AuthCodeURL(ctx context.Context, provider string) (string, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return "", ErrProviderNotFound
+	}
+
+	state, err := stringutils.GenerateCode(stateLength)
+	if err != nil {
+		return "", err
+	}
+
+	nonce, err := stringutils.GenerateCode(nonceLength)
+	if err != nil {
+		return "", err
+	}
+
+	authState := &repository.AuthState{
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: oauth2.GenerateVerifier(),
+	}
+	if err := s.stateStorage.SaveState(ctx, state, authState); err != nil {
+		return "", err
+	}
+
+	return p.AuthCodeURL(state, authState.Nonce, authState.CodeVerifier), nil
+}
+
+// Callback consumes the stored state, exchanges the code for a verified identity and signs
+// in the user owning the verified email address, creating the account if needed.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - provider: Name of the configured identity provider
+//   - state: State value echoed back by the provider
+//   - code: Authorization code returned by the provider
+//   - device: User agent and IP address of the client, recorded on the session
+//
+// Returns:
+//   - string: JWT token string for authenticated requests
+//   - error: ErrProviderNotFound, ErrInvalidState, ErrExchangeFailed or ErrEmailNotVerified
+//     for rejected logins, or an error if storage, lookup or token generation fails
+func (s *oidcSvc) Callback(ctx context.Context, provider, state, code string, device sessionService.Device) (string, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return "", ErrProviderNotFound
+	}
+
+	authState, err := s.stateStorage.PopState(ctx, state)
+	if err != nil {
+		if errors.Is(err, repository.ErrStateNotFound) {
+			return "", ErrInvalidState
+		}
+		return "", err
+	}
+	if authState.Provider != provider {
+		return "", ErrInvalidState
+	}
+
+	identity, err := p.Exchange(ctx, code, authState.Nonce, authState.CodeVerifier)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrExchangeFailed, err)
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return "", ErrEmailNotVerified
+	}
+
+	return s.userSvc.LoginWithVerifiedEmail(ctx, identity.Email, identity.PreferredUsername, identity.Name, device)
+}
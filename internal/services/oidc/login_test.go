@@ -0,0 +1,300 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	repository "github.com/luongtruong20201/bookmark-management/internal/repositories/oidc"
+	mockRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/oidc/mocks"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
+	mockUserSvc "github.com/luongtruong20201/bookmark-management/internal/services/user/mocks"
+	oidcPkg "github.com/luongtruong20201/bookmark-management/pkg/oidc"
+	mockProvider "github.com/luongtruong20201/bookmark-management/pkg/oidc/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOIDCService_AuthCodeURL(t *testing.T) {
+	t.Parallel()
+
+	const mockAuthURL = "https://idp.example.com/authorize?state=abc"
+
+	testErrStorage := errors.New("storage error")
+
+	testCases := []struct {
+		name             string
+		provider         string
+		setupMockStorage func(t *testing.T, ctx context.Context) *mockRepo.StateStorage
+		setupMockIdP     func(t *testing.T) *mockProvider.Provider
+		expectedURL      string
+		expectedError    error
+	}{
+		{
+			name:     "success",
+			provider: "company",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mockRepo.StateStorage {
+				storageMock := mockRepo.NewStateStorage(t)
+				storageMock.On("SaveState", ctx, mock.AnythingOfType("string"), mock.MatchedBy(func(s *repository.AuthState) bool {
+					return s.Provider == "company" && len(s.Nonce) == nonceLength && s.CodeVerifier != ""
+				})).Return(nil).Once()
+				return storageMock
+			},
+			setupMockIdP: func(t *testing.T) *mockProvider.Provider {
+				idpMock := mockProvider.NewProvider(t)
+				idpMock.On("AuthCodeURL", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+					Return(mockAuthURL).Once()
+				return idpMock
+			},
+			expectedURL: mockAuthURL,
+		},
+		{
+			name:     "error - unknown provider",
+			provider: "unknown",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mockRepo.StateStorage {
+				return mockRepo.NewStateStorage(t)
+			},
+			setupMockIdP: func(t *testing.T) *mockProvider.Provider {
+				return mockProvider.NewProvider(t)
+			},
+			expectedError: ErrProviderNotFound,
+		},
+		{
+			name:     "error - storage fails",
+			provider: "company",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mockRepo.StateStorage {
+				storageMock := mockRepo.NewStateStorage(t)
+				storageMock.On("SaveState", ctx, mock.Anything, mock.Anything).Return(testErrStorage).Once()
+				return storageMock
+			},
+			setupMockIdP: func(t *testing.T) *mockProvider.Provider {
+				return mockProvider.NewProvider(t)
+			},
+			expectedError: testErrStorage,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			providers := map[string]oidcPkg.Provider{"company": tc.setupMockIdP(t)}
+			svc := NewOIDC(providers, tc.setupMockStorage(t, ctx), mockUserSvc.NewUser(t))
+
+			url, err := svc.AuthCodeURL(ctx, tc.provider)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Empty(t, url)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedURL, url)
+			}
+		})
+	}
+}
+
+func TestOIDCService_Callback(t *testing.T) {
+	t.Parallel()
+
+	const (
+		mockToken = "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9.test"
+		mockState = "state-1"
+		mockCode  = "code-1"
+	)
+
+	var (
+		testErrStorage  = errors.New("storage error")
+		testErrExchange = errors.New("invalid_grant")
+		testErrLogin    = errors.New("database error")
+	)
+
+	authState := &repository.AuthState{
+		Provider:     "company",
+		Nonce:        "nonce-1",
+		CodeVerifier: "verifier-1",
+	}
+	device := sessionService.Device{UserAgent: "Mozilla/5.0", IP: "203.0.113.7"}
+
+	testCases := []struct {
+		name             string
+		provider         string
+		setupMockStorage func(t *testing.T, ctx context.Context) *mockRepo.StateStorage
+		setupMockIdP     func(t *testing.T, ctx context.Context) *mockProvider.Provider
+		setupMockUserSvc func(t *testing.T, ctx context.Context) *mockUserSvc.User
+		expectedToken    string
+		expectedError    error
+	}{
+		{
+			name:     "success",
+			provider: "company",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mockRepo.StateStorage {
+				storageMock := mockRepo.NewStateStorage(t)
+				storageMock.On("PopState", ctx, mockState).Return(authState, nil).Once()
+				return storageMock
+			},
+			setupMockIdP: func(t *testing.T, ctx context.Context) *mockProvider.Provider {
+				idpMock := mockProvider.NewProvider(t)
+				idpMock.On("Exchange", ctx, mockCode, "nonce-1", "verifier-1").Return(&oidcPkg.Identity{
+					Email:             "john.doe@example.com",
+					EmailVerified:     true,
+					Name:              "John Doe",
+					PreferredUsername: "johndoe",
+				}, nil).Once()
+				return idpMock
+			},
+			setupMockUserSvc: func(t *testing.T, ctx context.Context) *mockUserSvc.User {
+				userMock := mockUserSvc.NewUser(t)
+				userMock.On("LoginWithVerifiedEmail", ctx, "john.doe@example.com", "johndoe", "John Doe", device).
+					Return(mockToken, nil).Once()
+				return userMock
+			},
+			expectedToken: mockToken,
+		},
+		{
+			name:     "error - unknown provider",
+			provider: "unknown",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mockRepo.StateStorage {
+				return mockRepo.NewStateStorage(t)
+			},
+			setupMockIdP: func(t *testing.T, ctx context.Context) *mockProvider.Provider {
+				return mockProvider.NewProvider(t)
+			},
+			setupMockUserSvc: func(t *testing.T, ctx context.Context) *mockUserSvc.User {
+				return mockUserSvc.NewUser(t)
+			},
+			expectedError: ErrProviderNotFound,
+		},
+		{
+			name:     "error - state not found",
+			provider: "company",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mockRepo.StateStorage {
+				storageMock := mockRepo.NewStateStorage(t)
+				storageMock.On("PopState", ctx, mockState).Return(nil, repository.ErrStateNotFound).Once()
+				return storageMock
+			},
+			setupMockIdP: func(t *testing.T, ctx context.Context) *mockProvider.Provider {
+				return mockProvider.NewProvider(t)
+			},
+			setupMockUserSvc: func(t *testing.T, ctx context.Context) *mockUserSvc.User {
+				return mockUserSvc.NewUser(t)
+			},
+			expectedError: ErrInvalidState,
+		},
+		{
+			name:     "error - state issued for another provider",
+			provider: "company",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mockRepo.StateStorage {
+				storageMock := mockRepo.NewStateStorage(t)
+				storageMock.On("PopState", ctx, mockState).Return(&repository.AuthState{Provider: "partner"}, nil).Once()
+				return storageMock
+			},
+			setupMockIdP: func(t *testing.T, ctx context.Context) *mockProvider.Provider {
+				return mockProvider.NewProvider(t)
+			},
+			setupMockUserSvc: func(t *testing.T, ctx context.Context) *mockUserSvc.User {
+				return mockUserSvc.NewUser(t)
+			},
+			expectedError: ErrInvalidState,
+		},
+		{
+			name:     "error - storage fails",
+			provider: "company",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mockRepo.StateStorage {
+				storageMock := mockRepo.NewStateStorage(t)
+				storageMock.On("PopState", ctx, mockState).Return(nil, testErrStorage).Once()
+				return storageMock
+			},
+			setupMockIdP: func(t *testing.T, ctx context.Context) *mockProvider.Provider {
+				return mockProvider.NewProvider(t)
+			},
+			setupMockUserSvc: func(t *testing.T, ctx context.Context) *mockUserSvc.User {
+				return mockUserSvc.NewUser(t)
+			},
+			expectedError: testErrStorage,
+		},
+		{
+			name:     "error - exchange fails",
+			provider: "company",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mockRepo.StateStorage {
+				storageMock := mockRepo.NewStateStorage(t)
+				storageMock.On("PopState", ctx, mockState).Return(authState, nil).Once()
+				return storageMock
+			},
+			setupMockIdP: func(t *testing.T, ctx context.Context) *mockProvider.Provider {
+				idpMock := mockProvider.NewProvider(t)
+				idpMock.On("Exchange", ctx, mockCode, "nonce-1", "verifier-1").Return(nil, testErrExchange).Once()
+				return idpMock
+			},
+			setupMockUserSvc: func(t *testing.T, ctx context.Context) *mockUserSvc.User {
+				return mockUserSvc.NewUser(t)
+			},
+			expectedError: ErrExchangeFailed,
+		},
+		{
+			name:     "error - email not verified",
+			provider: "company",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mockRepo.StateStorage {
+				storageMock := mockRepo.NewStateStorage(t)
+				storageMock.On("PopState", ctx, mockState).Return(authState, nil).Once()
+				return storageMock
+			},
+			setupMockIdP: func(t *testing.T, ctx context.Context) *mockProvider.Provider {
+				idpMock := mockProvider.NewProvider(t)
+				idpMock.On("Exchange", ctx, mockCode, "nonce-1", "verifier-1").Return(&oidcPkg.Identity{
+					Email:         "john.doe@example.com",
+					EmailVerified: false,
+				}, nil).Once()
+				return idpMock
+			},
+			setupMockUserSvc: func(t *testing.T, ctx context.Context) *mockUserSvc.User {
+				return mockUserSvc.NewUser(t)
+			},
+			expectedError: ErrEmailNotVerified,
+		},
+		{
+			name:     "error - login fails",
+			provider: "company",
+			setupMockStorage: func(t *testing.T, ctx context.Context) *mockRepo.StateStorage {
+				storageMock := mockRepo.NewStateStorage(t)
+				storageMock.On("PopState", ctx, mockState).Return(authState, nil).Once()
+				return storageMock
+			},
+			setupMockIdP: func(t *testing.T, ctx context.Context) *mockProvider.Provider {
+				idpMock := mockProvider.NewProvider(t)
+				idpMock.On("Exchange", ctx, mockCode, "nonce-1", "verifier-1").Return(&oidcPkg.Identity{
+					Email:         "john.doe@example.com",
+					EmailVerified: true,
+				}, nil).Once()
+				return idpMock
+			},
+			setupMockUserSvc: func(t *testing.T, ctx context.Context) *mockUserSvc.User {
+				userMock := mockUserSvc.NewUser(t)
+				userMock.On("LoginWithVerifiedEmail", ctx, "john.doe@example.com", "", "", device).Return("", testErrLogin).Once()
+				return userMock
+			},
+			expectedError: testErrLogin,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			providers := map[string]oidcPkg.Provider{"company": tc.setupMockIdP(t, ctx)}
+			svc := NewOIDC(providers, tc.setupMockStorage(t, ctx), tc.setupMockUserSvc(t, ctx))
+
+			token, err := svc.Callback(ctx, tc.provider, mockState, mockCode, device)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedToken, token)
+			}
+		})
+	}
+}
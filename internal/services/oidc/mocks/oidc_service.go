@@ -0,0 +1,86 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	session "github.com/luongtruong20201/bookmark-management/internal/services/session"
+)
+
+// OIDC is an autogenerated mock type for the OIDC type
+type OIDC struct {
+	mock.Mock
+}
+
+// AuthCodeURL provides a mock function with given fields: ctx, provider
+func (_m *OIDC) AuthCodeURL(ctx context.Context, provider string) (string, error) {
+	ret := _m.Called(ctx, provider)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthCodeURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, provider)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, provider)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Callback provides a mock function with given fields: ctx, provider, state, code, device
+func (_m *OIDC) Callback(ctx context.Context, provider string, state string, code string, device session.Device) (string, error) {
+	ret := _m.Called(ctx, provider, state, code, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Callback")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, session.Device) (string, error)); ok {
+		return rf(ctx, provider, state, code, device)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, session.Device) string); ok {
+		r0 = rf(ctx, provider, state, code, device)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, session.Device) error); ok {
+		r1 = rf(ctx, provider, state, code, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewOIDC creates a new instance of OIDC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOIDC(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OIDC {
+	mock := &OIDC{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,61 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+
+	repository "github.com/luongtruong20201/bookmark-management/internal/repositories/oidc"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
+	userService "github.com/luongtruong20201/bookmark-management/internal/services/user"
+	oidcPkg "github.com/luongtruong20201/bookmark-management/pkg/oidc"
+)
+
+const (
+	// stateLength is the length of the random state sent to the identity provider.
+	stateLength = 32
+	// nonceLength is the length of the random nonce bound to the ID token.
+	nonceLength = 32
+)
+
+var (
+	// ErrProviderNotFound is returned when the requested identity provider is not configured.
+	ErrProviderNotFound = errors.New("oidc provider not found")
+	// ErrInvalidState is returned when the state of a callback is unknown, expired, already used
+	// or was issued for another provider.
+	ErrInvalidState = errors.New("invalid or expired oidc state")
+	// ErrEmailNotVerified is returned when the identity provider does not vouch for the user's email.
+	ErrEmailNotVerified = errors.New("email is not verified by the identity provider")
+	// ErrExchangeFailed is returned when the authorization code cannot be exchanged for a valid ID token.
+	ErrExchangeFailed = errors.New("failed to authenticate with the identity provider")
+)
+
+// OIDC defines the interface for OpenID Connect login services.
+// It starts authorization-code logins with PKCE and completes them into application JWTs.
+//
+//go:generate mockery --name OIDC --filename oidc_service.go
+type OIDC interface {
+	// AuthCodeURL starts a login with the named provider and returns the URL the user
+	// must be redirected to.
+	AuthCodeURL(ctx context.Context, provider string) (string, error)
+	// Callback completes a login with the code and state returned by the provider and
+	// returns a JWT token for the linked user, bound to a session opened for the device.
+	Callback(ctx context.Context, provider, state, code string, device sessionService.Device) (string, error)
+}
+
+// oidcSvc implements the OIDC interface. It keeps pending logins in a state storage and
+// delegates account linking and token issuance to the user service.
+type oidcSvc struct {
+	providers    map[string]oidcPkg.Provider
+	stateStorage repository.StateStorage
+	userSvc      userService.User
+}
+
+// NewOIDC creates a new OpenID Connect login service with the configured providers, a
+// storage for pending logins and the user service used to link accounts.
+func NewOIDC(providers map[string]oidcPkg.Provider, stateStorage repository.StateStorage, userSvc userService.User) OIDC {
+	return &oidcSvc{
+		providers:    providers,
+		stateStorage: stateStorage,
+		userSvc:      userSvc,
+	}
+}
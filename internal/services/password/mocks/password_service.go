@@ -2,34 +2,39 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	password "github.com/luongtruong20201/bookmark-management/internal/services/password"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // Password is an autogenerated mock type for the Password type
 type Password struct {
 	mock.Mock
 }
 
-// GeneratePassword provides a mock function with no fields
-func (_m *Password) GeneratePassword() (string, error) {
-	ret := _m.Called()
+// GeneratePassword provides a mock function with given fields: opts
+func (_m *Password) GeneratePassword(opts password.Options) (*password.Generated, error) {
+	ret := _m.Called(opts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GeneratePassword")
 	}
 
-	var r0 string
+	var r0 *password.Generated
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (string, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(password.Options) (*password.Generated, error)); ok {
+		return rf(opts)
 	}
-	if rf, ok := ret.Get(0).(func() string); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(password.Options) *password.Generated); ok {
+		r0 = rf(opts)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*password.Generated)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(password.Options) error); ok {
+		r1 = rf(opts)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,20 +1,85 @@
 package password
 
-import "github.com/luongtruong20201/bookmark-management/pkg/stringutils"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
 
+	"github.com/luongtruong20201/bookmark-management/pkg/stringutils"
+)
+
+// Generation modes.
+const (
+	// ModePassword generates a random string of characters.
+	ModePassword = "password"
+	// ModePassphrase generates diceware-style words picked from a wordlist.
+	ModePassphrase = "passphrase"
+)
+
+// Bounds and defaults of the generation options.
+const (
+	DefaultLength    = 10
+	MinLength        = 8
+	MaxLength        = 128
+	DefaultWords     = 6
+	MinWords         = 3
+	MaxWords         = 20
+	DefaultSeparator = "-"
+)
+
+// Character classes passwords are built from.
 const (
-	passLength = 10
+	lowercase = "abcdefghijklmnopqrstuvwxyz"
+	uppercase = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digits    = "0123456789"
+	symbols   = "!#$%&()*+,-./:;<=>?@[]^_{}~"
+	// ambiguous holds the characters easily mistaken for one another when read or typed.
+	ambiguous = "Il1O0o"
 )
 
+// ErrInvalidOptions is returned when the generation options are out of bounds.
+var ErrInvalidOptions = errors.New("invalid password options")
+
+// Options configures the generation of a password. Zero values select the defaults:
+// a password of DefaultLength letters and digits, or a passphrase of DefaultWords words
+// joined with DefaultSeparator.
+type Options struct {
+	// Mode is ModePassword or ModePassphrase.
+	Mode string
+	// Length is the number of characters of a password.
+	Length int
+	// Symbols adds punctuation characters to the letters and digits of a password.
+	Symbols bool
+	// ExcludeAmbiguous leaves out characters such as "l", "1" and "O" from a password.
+	ExcludeAmbiguous bool
+	// RequireEachClass makes a password contain at least one lowercase letter, uppercase
+	// letter, digit and, when enabled, symbol.
+	RequireEachClass bool
+	// Words is the number of words of a passphrase.
+	Words int
+	// Separator joins the words of a passphrase.
+	Separator string
+}
+
+// Generated is a generated password with an estimate of its strength.
+type Generated struct {
+	Value string
+	// Entropy is the estimated strength in bits: the base 2 logarithm of the number of
+	// values the generator could have produced.
+	Entropy float64
+}
+
 // passwordService implements the Password interface and provides business logic
-// for password generation. It uses a key generator to create random alphanumeric passwords.
+// for password generation. It builds random passwords from character classes and
+// passphrases from an embedded wordlist.
 type passwordService struct{}
 
 // Password interface represents password service
 //
 //go:generate mockery --name Password --filename password_service.go
 type Password interface {
-	GeneratePassword() (string, error)
+	GeneratePassword(opts Options) (*Generated, error)
 }
 
 // NewPassword return a new instance of the password service
@@ -22,8 +87,119 @@ func NewPassword() Password {
 	return &passwordService{}
 }
 
-// GeneratePassword generates a random password of the specified length using
-// alphanumeric characters. Returns an error if random number generation fails.
-func (s *passwordService) GeneratePassword() (string, error) {
-	return stringutils.GenerateCode(passLength)
+// GeneratePassword generates a random password or passphrase as configured by opts,
+// along with its entropy estimate. Returns ErrInvalidOptions if the options are out of
+// bounds, or an error if random number generation fails.
+func (s *passwordService) GeneratePassword(opts Options) (*Generated, error) {
+	switch opts.Mode {
+	case "", ModePassword:
+		return generatePassword(opts)
+	case ModePassphrase:
+		return generatePassphrase(opts)
+	default:
+		return nil, fmt.Errorf("%w: unknown mode %q", ErrInvalidOptions, opts.Mode)
+	}
+}
+
+// generatePassword generates a password from the character classes selected by opts.
+func generatePassword(opts Options) (*Generated, error) {
+	length := opts.Length
+	if length == 0 {
+		length = DefaultLength
+	}
+	if length < MinLength || length > MaxLength {
+		return nil, fmt.Errorf("%w: length must be between %d and %d", ErrInvalidOptions, MinLength, MaxLength)
+	}
+
+	classes := []string{lowercase, uppercase, digits}
+	if opts.Symbols {
+		classes = append(classes, symbols)
+	}
+	if opts.ExcludeAmbiguous {
+		for i, class := range classes {
+			classes[i] = removeChars(class, ambiguous)
+		}
+	}
+	charset := strings.Join(classes, "")
+
+	// Required characters are drawn first, one per class, then the rest from the whole
+	// charset, and the result is shuffled so they do not sit at fixed positions.
+	var required []byte
+	if opts.RequireEachClass {
+		for _, class := range classes {
+			c, err := stringutils.GenerateFromCharset(1, class)
+			if err != nil {
+				return nil, err
+			}
+			required = append(required, c[0])
+		}
+	}
+	rest, err := stringutils.GenerateFromCharset(length-len(required), charset)
+	if err != nil {
+		return nil, err
+	}
+	value := append(required, rest...)
+	if err := shuffle(value); err != nil {
+		return nil, err
+	}
+
+	return &Generated{
+		Value:   string(value),
+		Entropy: entropy(length, len(charset)),
+	}, nil
+}
+
+// generatePassphrase generates a passphrase of words from the embedded wordlist.
+func generatePassphrase(opts Options) (*Generated, error) {
+	words := opts.Words
+	if words == 0 {
+		words = DefaultWords
+	}
+	if words < MinWords || words > MaxWords {
+		return nil, fmt.Errorf("%w: words must be between %d and %d", ErrInvalidOptions, MinWords, MaxWords)
+	}
+	separator := opts.Separator
+	if separator == "" {
+		separator = DefaultSeparator
+	}
+
+	value, err := stringutils.GeneratePassphrase(words, separator)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Generated{
+		Value:   value,
+		Entropy: entropy(words, stringutils.WordlistSize()),
+	}, nil
+}
+
+// entropy returns the entropy in bits of count symbols picked uniformly among size,
+// rounded to one decimal. Requiring each character class slightly lowers the actual
+// entropy of a password, which the estimate ignores.
+func entropy(count, size int) float64 {
+	return math.Round(float64(count)*math.Log2(float64(size))*10) / 10
+}
+
+// removeChars returns s without the characters of chars.
+func removeChars(s, chars string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(chars, r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// shuffle randomly permutes b in place with the Fisher-Yates algorithm.
+func shuffle(b []byte) error {
+	for i := len(b) - 1; i > 0; i-- {
+		j, err := stringutils.RandomInt(i + 1)
+		if err != nil {
+			return err
+		}
+		b[i], b[j] = b[j], b[i]
+	}
+
+	return nil
 }
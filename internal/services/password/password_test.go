@@ -1,31 +1,115 @@
 package password
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestPasswordService_GeneratePassword(t *testing.T) {
+	t.Parallel()
+
 	testCases := []struct {
-		name        string
-		expectedLen int
-		expectedErr error
+		name            string
+		opts            Options
+		expectedLen     int
+		expectedWords   int
+		expectedSep     string
+		expectedEntropy float64
+		allowedChars    string
+		requiredClasses []string
+		expectedErr     error
 	}{
 		{
-			name:        "normal case",
-			expectedLen: 10,
-			expectedErr: nil,
+			name:            "normal case",
+			opts:            Options{},
+			expectedLen:     10,
+			expectedEntropy: 59.5,
+			allowedChars:    lowercase + uppercase + digits,
+		},
+		{
+			name:            "success - symbols",
+			opts:            Options{Length: 32, Symbols: true},
+			expectedLen:     32,
+			expectedEntropy: 207.2,
+			allowedChars:    lowercase + uppercase + digits + symbols,
+		},
+		{
+			name:            "success - exclude ambiguous",
+			opts:            Options{Length: 64, ExcludeAmbiguous: true},
+			expectedLen:     64,
+			expectedEntropy: 371.7,
+			allowedChars:    removeChars(lowercase+uppercase+digits, ambiguous),
+		},
+		{
+			name:            "success - require each class",
+			opts:            Options{Mode: ModePassword, Length: 8, Symbols: true, RequireEachClass: true},
+			expectedLen:     8,
+			expectedEntropy: 51.8,
+			allowedChars:    lowercase + uppercase + digits + symbols,
+			requiredClasses: []string{lowercase, uppercase, digits, symbols},
+		},
+		{
+			name:            "success - passphrase defaults",
+			opts:            Options{Mode: ModePassphrase},
+			expectedWords:   6,
+			expectedSep:     "-",
+			expectedEntropy: 62,
+		},
+		{
+			name:            "success - passphrase with separator",
+			opts:            Options{Mode: ModePassphrase, Words: 4, Separator: " "},
+			expectedWords:   4,
+			expectedSep:     " ",
+			expectedEntropy: 41.4,
+		},
+		{
+			name:        "error - length too short",
+			opts:        Options{Length: MinLength - 1},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "error - length too long",
+			opts:        Options{Length: MaxLength + 1},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "error - too few words",
+			opts:        Options{Mode: ModePassphrase, Words: MinWords - 1},
+			expectedErr: ErrInvalidOptions,
+		},
+		{
+			name:        "error - unknown mode",
+			opts:        Options{Mode: "pin"},
+			expectedErr: ErrInvalidOptions,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
+
 			testSvc := NewPassword()
-			pass, err := testSvc.GeneratePassword()
-			assert.Equal(t, tc.expectedLen, len(pass))
-			assert.Equal(t, tc.expectedErr, err)
+			res, err := testSvc.GeneratePassword(tc.opts)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			if tc.expectedErr != nil {
+				assert.Nil(t, res)
+				return
+			}
+
+			assert.Equal(t, tc.expectedEntropy, res.Entropy)
+			if tc.expectedWords > 0 {
+				assert.Len(t, strings.Split(res.Value, tc.expectedSep), tc.expectedWords)
+				return
+			}
+			assert.Len(t, res.Value, tc.expectedLen)
+			for _, r := range res.Value {
+				assert.Contains(t, tc.allowedChars, string(r))
+			}
+			for _, class := range tc.requiredClasses {
+				assert.True(t, strings.ContainsAny(res.Value, class), "missing a character of %q", class)
+			}
 		})
 	}
 }
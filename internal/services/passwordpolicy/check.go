@@ -0,0 +1,96 @@
+package passwordpolicy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nbutton23/zxcvbn-go"
+)
+
+const (
+	// minUserInputLen is the length from which a user input is looked for in the password;
+	// shorter inputs would reject too many unrelated passwords.
+	minUserInputLen = 3
+	// maxEstimatedLen is the number of leading characters of the password whose strength is
+	// estimated. The cost of zxcvbn grows faster than the length of the password, and the
+	// handlers accept passwords of at most that length anyway.
+	maxEstimatedLen = 128
+)
+
+// Check applies every rule of the policy and reports all the broken ones, so that clients
+// can show them at once.
+func (p *policy) Check(ctx context.Context, password string, userInputs ...string) (*Result, error) {
+	inputs := normalizeUserInputs(userInputs)
+	strength := zxcvbn.PasswordStrength(truncate(password, maxEstimatedLen), inputs)
+
+	result := &Result{
+		Score:       strength.Score,
+		EntropyBits: math.Round(strength.Entropy*10) / 10,
+		CrackTime:   strength.CrackTimeDisplay,
+		Violations:  []Violation{},
+	}
+
+	if utf8.RuneCountInString(password) < p.cfg.MinLength {
+		result.addViolation(ViolationTooShort, fmt.Sprintf("password must be at least %d characters long", p.cfg.MinLength))
+	}
+	if strength.Score < p.cfg.MinScore {
+		result.addViolation(ViolationTooWeak, "password is too easy to guess")
+	}
+	lowerPassword := strings.ToLower(password)
+	for _, input := range inputs {
+		if strings.Contains(lowerPassword, input) {
+			result.addViolation(ViolationContainsUserInfo, "password must not contain the username or email")
+			break
+		}
+	}
+
+	count, err := p.breached.Count(ctx, password)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		result.addViolation(ViolationBreached, "password has appeared in a data breach")
+	}
+
+	return result, nil
+}
+
+// addViolation records a broken rule.
+func (r *Result) addViolation(code, message string) {
+	r.Violations = append(r.Violations, Violation{Code: code, Message: message})
+}
+
+// normalizeUserInputs lowercases the user inputs, adds the local part of email addresses
+// and drops the inputs too short to be looked for.
+func normalizeUserInputs(userInputs []string) []string {
+	inputs := make([]string, 0, len(userInputs)*2)
+	for _, input := range userInputs {
+		input = strings.ToLower(strings.TrimSpace(input))
+		candidates := []string{input}
+		if local, _, ok := strings.Cut(input, "@"); ok {
+			candidates = append(candidates, local)
+		}
+		for _, candidate := range candidates {
+			if utf8.RuneCountInString(candidate) >= minUserInputLen {
+				inputs = append(inputs, candidate)
+			}
+		}
+	}
+
+	return inputs
+}
+
+// truncate returns the first n characters of s.
+func truncate(s string, n int) string {
+	for i := range s {
+		if n == 0 {
+			return s[:i]
+		}
+		n--
+	}
+
+	return s
+}
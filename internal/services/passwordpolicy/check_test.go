@@ -0,0 +1,158 @@
+package passwordpolicy
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/breach/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// testConfig is the policy configuration shared by the service tests.
+var testConfig = &Config{
+	MinLength: 8,
+	MinScore:  2,
+}
+
+func TestPolicy_Check(t *testing.T) {
+	t.Parallel()
+
+	testErrStorage := errors.New("storage error")
+
+	testCases := []struct {
+		name               string
+		password           string
+		userInputs         []string
+		setupMockStorage   func(t *testing.T, ctx context.Context, password string) *mocks.Storage
+		expectedViolations []string
+		expectedError      error
+	}{
+		{
+			name:       "success - strong password",
+			password:   "Blue-Otter-Canyon-42",
+			userInputs: []string{"johndoe", "john.doe@example.com"},
+			setupMockStorage: func(t *testing.T, ctx context.Context, password string) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("Count", ctx, password).Return(int64(0), nil).Once()
+				return storageMock
+			},
+			expectedViolations: []string{},
+		},
+		{
+			name:     "short and weak password",
+			password: "12345",
+			setupMockStorage: func(t *testing.T, ctx context.Context, password string) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("Count", ctx, password).Return(int64(0), nil).Once()
+				return storageMock
+			},
+			expectedViolations: []string{ViolationTooShort, ViolationTooWeak},
+		},
+		{
+			name:       "contains username",
+			password:   "Canyon-JohnDoe-Otter-42",
+			userInputs: []string{"johndoe", "john.doe@example.com"},
+			setupMockStorage: func(t *testing.T, ctx context.Context, password string) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("Count", ctx, password).Return(int64(0), nil).Once()
+				return storageMock
+			},
+			expectedViolations: []string{ViolationContainsUserInfo},
+		},
+		{
+			name:       "contains email local part",
+			password:   "Canyon-jane.roe-Otter-42",
+			userInputs: []string{"johndoe", "jane.roe@example.com"},
+			setupMockStorage: func(t *testing.T, ctx context.Context, password string) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("Count", ctx, password).Return(int64(0), nil).Once()
+				return storageMock
+			},
+			expectedViolations: []string{ViolationContainsUserInfo},
+		},
+		{
+			name:     "success - long password estimated on its first characters",
+			password: strings.Repeat("Blue-Otter-Canyon-42 ", 500),
+			setupMockStorage: func(t *testing.T, ctx context.Context, password string) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("Count", ctx, password).Return(int64(0), nil).Once()
+				return storageMock
+			},
+			expectedViolations: []string{},
+		},
+		{
+			name:     "breached password",
+			password: "Blue-Otter-Canyon-42",
+			setupMockStorage: func(t *testing.T, ctx context.Context, password string) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("Count", ctx, password).Return(int64(12), nil).Once()
+				return storageMock
+			},
+			expectedViolations: []string{ViolationBreached},
+		},
+		{
+			name:     "error - storage",
+			password: "Blue-Otter-Canyon-42",
+			setupMockStorage: func(t *testing.T, ctx context.Context, password string) *mocks.Storage {
+				storageMock := mocks.NewStorage(t)
+				storageMock.On("Count", ctx, password).Return(int64(0), testErrStorage).Once()
+				return storageMock
+			},
+			expectedError: testErrStorage,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			svc := NewPolicy(tc.setupMockStorage(t, ctx, tc.password), testConfig)
+
+			result, err := svc.Check(ctx, tc.password, tc.userInputs...)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError != nil {
+				assert.Nil(t, result)
+				return
+			}
+			codes := make([]string, 0, len(result.Violations))
+			for _, violation := range result.Violations {
+				codes = append(codes, violation.Code)
+			}
+			assert.Equal(t, tc.expectedViolations, codes)
+			assert.Equal(t, len(tc.expectedViolations) == 0, result.Valid())
+			if result.Valid() {
+				assert.NoError(t, result.Err())
+			} else {
+				assert.ErrorIs(t, result.Err(), ErrPasswordRejected)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		value    string
+		n        int
+		expected string
+	}{
+		{name: "shorter than n", value: "otter", n: 8, expected: "otter"},
+		{name: "exactly n", value: "otter", n: 5, expected: "otter"},
+		{name: "longer than n", value: "otter-canyon", n: 5, expected: "otter"},
+		{name: "multibyte characters", value: "ñandú-río", n: 5, expected: "ñandú"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, truncate(tc.value, tc.n))
+		})
+	}
+}
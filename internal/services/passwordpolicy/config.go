@@ -0,0 +1,28 @@
+package passwordpolicy
+
+import (
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Config holds the password policy settings loaded from environment variables.
+//
+// A password must have at least MinLength characters and a zxcvbn strength score of at
+// least MinScore, from 0 (guessable in a few attempts) to 4 (very unguessable).
+// BreachedListDir is the directory of the offline breached password range files, see
+// breach.NewStorage; the breached password check is disabled when it is empty.
+type Config struct {
+	MinLength       int    `default:"8" envconfig:"PASSWORD_MIN_LENGTH"`
+	MinScore        int    `default:"2" envconfig:"PASSWORD_MIN_SCORE"`
+	BreachedListDir string `default:"" envconfig:"PASSWORD_BREACHED_LIST_DIR"`
+}
+
+// NewConfig creates a new configuration instance by reading environment variables.
+// Unset variables fall back to the defaults declared on Config.
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
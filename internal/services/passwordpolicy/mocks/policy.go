@@ -0,0 +1,66 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	passwordpolicy "github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Policy is an autogenerated mock type for the Policy type
+type Policy struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx, password, userInputs
+func (_m *Policy) Check(ctx context.Context, password string, userInputs ...string) (*passwordpolicy.Result, error) {
+	_va := make([]interface{}, len(userInputs))
+	for _i := range userInputs {
+		_va[_i] = userInputs[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, password)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 *passwordpolicy.Result
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...string) (*passwordpolicy.Result, error)); ok {
+		return rf(ctx, password, userInputs...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...string) *passwordpolicy.Result); ok {
+		r0 = rf(ctx, password, userInputs...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*passwordpolicy.Result)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...string) error); ok {
+		r1 = rf(ctx, password, userInputs...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPolicy creates a new instance of Policy. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPolicy(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Policy {
+	mock := &Policy{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
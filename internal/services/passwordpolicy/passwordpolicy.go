@@ -0,0 +1,104 @@
+// Package passwordpolicy decides whether a password is strong enough to be set on an account.
+package passwordpolicy
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/breach"
+)
+
+// Violation codes identify the rules of the policy a password breaks.
+const (
+	ViolationTooShort         = "too_short"
+	ViolationTooWeak          = "too_weak"
+	ViolationContainsUserInfo = "contains_user_info"
+	ViolationBreached         = "breached"
+)
+
+// ErrPasswordRejected is matched by the error returned for a password breaking the policy.
+var ErrPasswordRejected = errors.New("password does not meet the password policy")
+
+// Violation describes a rule of the policy broken by a password.
+type Violation struct {
+	Code    string `json:"code" example:"too_weak"`
+	Message string `json:"message" example:"password is too easy to guess"`
+}
+
+// Result is the evaluation of a password against the policy.
+type Result struct {
+	// Score is the zxcvbn strength score, from 0 (guessable in a few attempts) to 4.
+	Score int `json:"score" example:"3"`
+	// EntropyBits is the estimated entropy of the password.
+	EntropyBits float64 `json:"entropy_bits" example:"41.2"`
+	// CrackTime is a human readable estimate of the time an offline attack takes.
+	CrackTime string `json:"crack_time" example:"3 months"`
+	// Violations lists the broken rules; the password is accepted when it is empty.
+	Violations []Violation `json:"violations"`
+}
+
+// Valid reports whether the password meets the policy.
+func (r *Result) Valid() bool {
+	return len(r.Violations) == 0
+}
+
+// Err returns a RejectedError listing the violations, or nil if the password meets the policy.
+func (r *Result) Err() error {
+	if r.Valid() {
+		return nil
+	}
+
+	return &RejectedError{Violations: r.Violations}
+}
+
+// RejectedError is returned for a password breaking the policy. It matches ErrPasswordRejected.
+type RejectedError struct {
+	Violations []Violation
+}
+
+// Error joins the messages of the violations.
+func (e *RejectedError) Error() string {
+	return ErrPasswordRejected.Error() + ": " + strings.Join(e.Messages(), "; ")
+}
+
+// Is reports whether target is ErrPasswordRejected.
+func (e *RejectedError) Is(target error) bool {
+	return target == ErrPasswordRejected
+}
+
+// Messages returns the messages of the violations.
+func (e *RejectedError) Messages() []string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Message)
+	}
+
+	return messages
+}
+
+// Policy defines the interface for evaluating passwords against the password policy.
+//
+//go:generate mockery --name Policy --filename policy.go
+type Policy interface {
+	// Check evaluates the password. userInputs are values tied to the account, such as the
+	// username and email, that the password must not contain and that make it easier to
+	// guess. An error is returned only when the password could not be evaluated.
+	Check(ctx context.Context, password string, userInputs ...string) (*Result, error)
+}
+
+// policy implements the Policy interface with the zxcvbn strength estimator and a
+// breached password list.
+type policy struct {
+	breached breach.Storage
+	cfg      *Config
+}
+
+// NewPolicy creates a new password policy with the provided breached password storage and
+// configuration.
+func NewPolicy(breached breach.Storage, cfg *Config) Policy {
+	return &policy{
+		breached: breached,
+		cfg:      cfg,
+	}
+}
@@ -0,0 +1,38 @@
+package session
+
+import (
+	"context"
+	"errors"
+
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/session"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+)
+
+// Check reads the session on every call but only writes the last-seen time once it is
+// older than LastSeenInterval. A failed last-seen write is logged and does not fail the
+// check, because the session itself is still valid.
+func (s *service) Check(ctx context.Context, userID, sessionID string) error {
+	sess, err := s.storage.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if sess.UserID != userID {
+		return session.ErrSessionNotFound
+	}
+
+	now := s.now().UTC()
+	if now.Sub(sess.LastSeenAt) < s.cfg.LastSeenInterval {
+		return nil
+	}
+
+	err = s.storage.UpdateLastSeen(ctx, sess, now)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, session.ErrSessionNotFound):
+		return err
+	default:
+		logger.FromContext(ctx).Warn().Err(err).Str("uid", userID).Str("sid", sessionID).Msg("failed to update session last seen")
+		return nil
+	}
+}
@@ -0,0 +1,102 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/session"
+	mockSession "github.com/luongtruong20201/bookmark-management/internal/repositories/session/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Check(t *testing.T) {
+	t.Parallel()
+
+	recent := &session.Session{ID: "session-1", UserID: testUserID, LastSeenAt: testNow.Add(-30 * time.Second)}
+	stale := &session.Session{ID: "session-1", UserID: testUserID, LastSeenAt: testNow.Add(-5 * time.Minute)}
+	testErr := errors.New("redis error")
+
+	testCases := []struct {
+		name          string
+		setupStorage  func(t *testing.T, ctx context.Context) *mockSession.Storage
+		expectedError error
+	}{
+		{
+			name: "success - recently seen session is not written",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("Get", ctx, "session-1").Return(recent, nil).Once()
+				return storage
+			},
+		},
+		{
+			name: "success - stale last seen is updated",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("Get", ctx, "session-1").Return(stale, nil).Once()
+				storage.On("UpdateLastSeen", ctx, stale, testNow).Return(nil).Once()
+				return storage
+			},
+		},
+		{
+			name: "success - failed last seen update is ignored",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("Get", ctx, "session-1").Return(stale, nil).Once()
+				storage.On("UpdateLastSeen", ctx, stale, testNow).Return(testErr).Once()
+				return storage
+			},
+		},
+		{
+			name: "error - session revoked",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("Get", ctx, "session-1").Return(nil, session.ErrSessionNotFound).Once()
+				return storage
+			},
+			expectedError: session.ErrSessionNotFound,
+		},
+		{
+			name: "error - session revoked during update",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("Get", ctx, "session-1").Return(stale, nil).Once()
+				storage.On("UpdateLastSeen", ctx, stale, testNow).Return(session.ErrSessionNotFound).Once()
+				return storage
+			},
+			expectedError: session.ErrSessionNotFound,
+		},
+		{
+			name: "error - session of another user",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("Get", ctx, "session-1").Return(&session.Session{ID: "session-1", UserID: "another-user"}, nil).Once()
+				return storage
+			},
+			expectedError: session.ErrSessionNotFound,
+		},
+		{
+			name: "error - storage fails",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("Get", ctx, "session-1").Return(nil, testErr).Once()
+				return storage
+			},
+			expectedError: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			svc := &service{storage: tc.setupStorage(t, ctx), cfg: testConfig, now: func() time.Time { return testNow }}
+
+			err := svc.Check(ctx, testUserID, "session-1")
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package session
+
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Config holds the session settings loaded from environment variables.
+//
+// The last-seen time of a session is written at most once every LastSeenInterval,
+// so authenticated requests do not each cost a write to the session store.
+type Config struct {
+	LastSeenInterval time.Duration `default:"1m" envconfig:"SESSION_LAST_SEEN_INTERVAL"`
+}
+
+// NewConfig creates a new configuration instance by reading environment variables.
+// Unset variables fall back to the defaults declared on Config.
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,28 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/session"
+)
+
+// Create stores a new session with a random ID. Its creation and last-seen times are
+// both set to the current time.
+func (s *service) Create(ctx context.Context, userID string, device Device, ttl time.Duration) (*session.Session, error) {
+	now := s.now().UTC()
+	sess := &session.Session{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		UserAgent:  device.UserAgent,
+		IP:         device.IP,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	if err := s.storage.Create(ctx, sess, ttl); err != nil {
+		return nil, err
+	}
+
+	return sess, nil
+}
@@ -0,0 +1,72 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/session"
+	mockSession "github.com/luongtruong20201/bookmark-management/internal/repositories/session/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testUserID = "4d9326d6-980c-4c62-9709-dbc70a82cbfe"
+
+var (
+	testNow    = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	testConfig = &Config{LastSeenInterval: time.Minute}
+	testDevice = Device{UserAgent: "Mozilla/5.0", IP: "203.0.113.7"}
+)
+
+func TestService_Create(t *testing.T) {
+	t.Parallel()
+
+	testErr := errors.New("redis error")
+
+	testCases := []struct {
+		name          string
+		setupStorage  func(t *testing.T, ctx context.Context) *mockSession.Storage
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("Create", ctx, mock.MatchedBy(func(s *session.Session) bool {
+					return s.ID != "" && s.UserID == testUserID && s.UserAgent == testDevice.UserAgent &&
+						s.IP == testDevice.IP && s.CreatedAt.Equal(testNow) && s.LastSeenAt.Equal(testNow)
+				}), 24*time.Hour).Return(nil).Once()
+				return storage
+			},
+		},
+		{
+			name: "error - storage fails",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("Create", ctx, mock.Anything, 24*time.Hour).Return(testErr).Once()
+				return storage
+			},
+			expectedError: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			svc := &service{storage: tc.setupStorage(t, ctx), cfg: testConfig, now: func() time.Time { return testNow }}
+
+			res, err := svc.Create(ctx, testUserID, testDevice, 24*time.Hour)
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError != nil {
+				assert.Nil(t, res)
+				return
+			}
+			assert.NotEmpty(t, res.ID)
+			assert.Equal(t, testUserID, res.UserID)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package session
+
+import (
+	"context"
+	"sort"
+
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/session"
+)
+
+// List reads the sessions of the user and sorts them by last-seen time, newest first.
+func (s *service) List(ctx context.Context, userID string) ([]*session.Session, error) {
+	sessions, err := s.storage.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// Revoke deletes the session after making sure it belongs to the user, so a user cannot
+// sign anybody else out.
+func (s *service) Revoke(ctx context.Context, userID, sessionID string) error {
+	sess, err := s.storage.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if sess.UserID != userID {
+		return session.ErrSessionNotFound
+	}
+
+	return s.storage.Delete(ctx, userID, sessionID)
+}
+
+// RevokeAll deletes every session of the user.
+func (s *service) RevokeAll(ctx context.Context, userID string) error {
+	return s.storage.DeleteByUser(ctx, userID)
+}
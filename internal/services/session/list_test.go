@@ -0,0 +1,156 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/session"
+	mockSession "github.com/luongtruong20201/bookmark-management/internal/repositories/session/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_List(t *testing.T) {
+	t.Parallel()
+
+	older := &session.Session{ID: "older", UserID: testUserID, LastSeenAt: testNow.Add(-time.Hour)}
+	newer := &session.Session{ID: "newer", UserID: testUserID, LastSeenAt: testNow}
+	testErr := errors.New("redis error")
+
+	testCases := []struct {
+		name           string
+		setupStorage   func(t *testing.T, ctx context.Context) *mockSession.Storage
+		expectedResult []*session.Session
+		expectedError  error
+	}{
+		{
+			name: "success - most recently seen first",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("ListByUser", ctx, testUserID).Return([]*session.Session{older, newer}, nil).Once()
+				return storage
+			},
+			expectedResult: []*session.Session{newer, older},
+		},
+		{
+			name: "error - storage fails",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("ListByUser", ctx, testUserID).Return(nil, testErr).Once()
+				return storage
+			},
+			expectedError: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			svc := &service{storage: tc.setupStorage(t, ctx), cfg: testConfig}
+
+			res, err := svc.List(ctx, testUserID)
+			assert.Equal(t, tc.expectedResult, res)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_Revoke(t *testing.T) {
+	t.Parallel()
+
+	testErr := errors.New("redis error")
+
+	testCases := []struct {
+		name          string
+		setupStorage  func(t *testing.T, ctx context.Context) *mockSession.Storage
+		expectedError error
+	}{
+		{
+			name: "success",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("Get", ctx, "session-1").Return(&session.Session{ID: "session-1", UserID: testUserID}, nil).Once()
+				storage.On("Delete", ctx, testUserID, "session-1").Return(nil).Once()
+				return storage
+			},
+		},
+		{
+			name: "error - session not found",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("Get", ctx, "session-1").Return(nil, session.ErrSessionNotFound).Once()
+				return storage
+			},
+			expectedError: session.ErrSessionNotFound,
+		},
+		{
+			name: "error - session of another user",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("Get", ctx, "session-1").Return(&session.Session{ID: "session-1", UserID: "another-user"}, nil).Once()
+				return storage
+			},
+			expectedError: session.ErrSessionNotFound,
+		},
+		{
+			name: "error - delete fails",
+			setupStorage: func(t *testing.T, ctx context.Context) *mockSession.Storage {
+				storage := mockSession.NewStorage(t)
+				storage.On("Get", ctx, "session-1").Return(&session.Session{ID: "session-1", UserID: testUserID}, nil).Once()
+				storage.On("Delete", ctx, testUserID, "session-1").Return(testErr).Once()
+				return storage
+			},
+			expectedError: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			svc := &service{storage: tc.setupStorage(t, ctx), cfg: testConfig}
+
+			err := svc.Revoke(ctx, testUserID, "session-1")
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestService_RevokeAll(t *testing.T) {
+	t.Parallel()
+
+	testErr := errors.New("redis error")
+
+	testCases := []struct {
+		name          string
+		storageErr    error
+		expectedError error
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:          "error - storage fails",
+			storageErr:    testErr,
+			expectedError: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			storage := mockSession.NewStorage(t)
+			storage.On("DeleteByUser", ctx, testUserID).Return(tc.storageErr).Once()
+			svc := &service{storage: storage, cfg: testConfig}
+
+			err := svc.RevokeAll(ctx, testUserID)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	repositoriessession "github.com/luongtruong20201/bookmark-management/internal/repositories/session"
+	session "github.com/luongtruong20201/bookmark-management/internal/services/session"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Service is an autogenerated mock type for the Service type
+type Service struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: ctx, userID, sessionID
+func (_m *Service) Check(ctx context.Context, userID string, sessionID string) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: ctx, userID, device, ttl
+func (_m *Service) Create(ctx context.Context, userID string, device session.Device, ttl time.Duration) (*repositoriessession.Session, error) {
+	ret := _m.Called(ctx, userID, device, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *repositoriessession.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, session.Device, time.Duration) (*repositoriessession.Session, error)); ok {
+		return rf(ctx, userID, device, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, session.Device, time.Duration) *repositoriessession.Session); ok {
+		r0 = rf(ctx, userID, device, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*repositoriessession.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, session.Device, time.Duration) error); ok {
+		r1 = rf(ctx, userID, device, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, userID
+func (_m *Service) List(ctx context.Context, userID string) ([]*repositoriessession.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*repositoriessession.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*repositoriessession.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*repositoriessession.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*repositoriessession.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, userID, sessionID
+func (_m *Service) Revoke(ctx context.Context, userID string, sessionID string) error {
+	ret := _m.Called(ctx, userID, sessionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, sessionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeAll provides a mock function with given fields: ctx, userID
+func (_m *Service) RevokeAll(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAll")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Service {
+	mock := &Service{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,53 @@
+// Package session keeps track of where users are signed in. Every login opens a session
+// bound to the issued token through its "sid" claim, so a session can be revoked to sign
+// a lost device out before the token expires.
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/session"
+)
+
+// Device describes the client a user signed in from.
+type Device struct {
+	UserAgent string
+	IP        string
+}
+
+// Service defines the interface for managing the sessions of logged-in users.
+//
+//go:generate mockery --name Service --filename service.go
+type Service interface {
+	// Create opens a session for the user on the device. The session lives for ttl,
+	// which should match the lifetime of the token issued with it.
+	Create(ctx context.Context, userID string, device Device, ttl time.Duration) (*session.Session, error)
+	// List returns the active sessions of the user, most recently seen first.
+	List(ctx context.Context, userID string) ([]*session.Session, error)
+	// Revoke ends a session of the user. It returns session.ErrSessionNotFound if the
+	// session does not exist or belongs to another user.
+	Revoke(ctx context.Context, userID, sessionID string) error
+	// RevokeAll ends every session of the user, signing them out of all devices.
+	RevokeAll(ctx context.Context, userID string) error
+	// Check verifies that the session is still active and belongs to the user, and
+	// records the request as its last activity. It returns session.ErrSessionNotFound
+	// if the session has expired or was revoked.
+	Check(ctx context.Context, userID, sessionID string) error
+}
+
+// service implements the Service interface.
+type service struct {
+	storage session.Storage
+	cfg     *Config
+	now     func() time.Time
+}
+
+// NewService creates a new session service with the provided storage and configuration.
+func NewService(storage session.Storage, cfg *Config) Service {
+	return &service{
+		storage: storage,
+		cfg:     cfg,
+		now:     time.Now,
+	}
+}
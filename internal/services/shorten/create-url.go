@@ -1,11 +1,16 @@
 package shorten
 
-import "context"
+import (
+	"context"
+
+	"github.com/luongtruong20201/bookmark-management/pkg/metrics"
+)
 
 // ShortenURL generates a short code for the given URL and stores it in the repository.
 // It returns the generated code or an error if code generation or storage fails.
 // The expire parameter specifies the expiration time in seconds (0 means default expiration).
-func (s *shortenURL) ShortenURL(ctx context.Context, url string, expire int) (string, error) {
+// When ownerID is not empty, the code is added to the owner's set of short links.
+func (s *shortenURL) ShortenURL(ctx context.Context, url string, expire int, ownerID string) (string, error) {
 	code, err := s.keyGen.GenerateCode(urlCodeLength)
 	if err != nil {
 		return "", err
@@ -20,5 +25,12 @@ func (s *shortenURL) ShortenURL(ctx context.Context, url string, expire int) (st
 		return "", ErrDuplicatedKey
 	}
 
+	if ownerID != "" {
+		if err := s.repository.AddOwnedLink(ctx, ownerID, code); err != nil {
+			return "", err
+		}
+	}
+	metrics.LinkShortened()
+
 	return code, nil
 }
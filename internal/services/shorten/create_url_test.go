@@ -21,6 +21,7 @@ func TestShortenURL_ShortenURL(t *testing.T) {
 		setupKeyGen   func(t *testing.T) *mockKeyGen.KeyGenerator
 		url           string
 		exp           int
+		ownerID       string
 		expectedCode  string
 		expectedError error
 	}{
@@ -156,6 +157,48 @@ func TestShortenURL_ShortenURL(t *testing.T) {
 			expectedCode:  "maxexp01",
 			expectedError: nil,
 		},
+		{
+			name: "success with owner",
+			setupRepo: func(t *testing.T, ctx context.Context, url string, exp int) *mockStorage.URLStorage {
+				repo := mockStorage.NewURLStorage(t)
+				repo.On("StoreIfNotExists", ctx, "1234567", url, exp).Return(true, nil).Once()
+				repo.On("AddOwnedLink", ctx, "4d9326d6-980c-4c62-9709-dbc70a82cbfe", "1234567").Return(nil).Once()
+
+				return repo
+			},
+			setupKeyGen: func(t *testing.T) *mockKeyGen.KeyGenerator {
+				keyGen := mockKeyGen.NewKeyGenerator(t)
+				keyGen.On("GenerateCode", urlCodeLength).Return("1234567", nil).Once()
+
+				return keyGen
+			},
+			url:           "https://truonglq.com",
+			exp:           3600,
+			ownerID:       "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			expectedCode:  "1234567",
+			expectedError: nil,
+		},
+		{
+			name: "owner record error",
+			setupRepo: func(t *testing.T, ctx context.Context, url string, exp int) *mockStorage.URLStorage {
+				repo := mockStorage.NewURLStorage(t)
+				repo.On("StoreIfNotExists", ctx, "1234567", url, exp).Return(true, nil).Once()
+				repo.On("AddOwnedLink", ctx, "4d9326d6-980c-4c62-9709-dbc70a82cbfe", "1234567").Return(errors.New("redis connection failed")).Once()
+
+				return repo
+			},
+			setupKeyGen: func(t *testing.T) *mockKeyGen.KeyGenerator {
+				keyGen := mockKeyGen.NewKeyGenerator(t)
+				keyGen.On("GenerateCode", urlCodeLength).Return("1234567", nil).Once()
+
+				return keyGen
+			},
+			url:           "https://truonglq.com",
+			exp:           3600,
+			ownerID:       "4d9326d6-980c-4c62-9709-dbc70a82cbfe",
+			expectedCode:  "",
+			expectedError: errors.New("redis connection failed"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -168,7 +211,7 @@ func TestShortenURL_ShortenURL(t *testing.T) {
 			bookmarkRepo := mockBookmarkRepo.NewRepository(t)
 			svc := NewShortenURL(keyGen, repo, bookmarkRepo)
 
-			code, err := svc.ShortenURL(ctx, tc.url, tc.exp)
+			code, err := svc.ShortenURL(ctx, tc.url, tc.exp, tc.ownerID)
 
 			assert.Equal(t, err, tc.expectedError)
 			assert.Equal(t, tc.expectedCode, code)
@@ -41,9 +41,9 @@ func (_m *ShortenURL) GetURL(_a0 context.Context, _a1 string) (string, error) {
 	return r0, r1
 }
 
-// ShortenURL provides a mock function with given fields: _a0, _a1, _a2
-func (_m *ShortenURL) ShortenURL(_a0 context.Context, _a1 string, _a2 int) (string, error) {
-	ret := _m.Called(_a0, _a1, _a2)
+// ShortenURL provides a mock function with given fields: ctx, url, expire, ownerID
+func (_m *ShortenURL) ShortenURL(ctx context.Context, url string, expire int, ownerID string) (string, error) {
+	ret := _m.Called(ctx, url, expire, ownerID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ShortenURL")
@@ -51,17 +51,17 @@ func (_m *ShortenURL) ShortenURL(_a0 context.Context, _a1 string, _a2 int) (stri
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) (string, error)); ok {
-		return rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string) (string, error)); ok {
+		return rf(ctx, url, expire, ownerID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int) string); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, string) string); ok {
+		r0 = rf(ctx, url, expire, ownerID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
-		r1 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, string) error); ok {
+		r1 = rf(ctx, url, expire, ownerID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -27,7 +27,9 @@ var (
 //
 //go:generate mockery --name ShortenURL --filename shorten_url.go
 type ShortenURL interface {
-	ShortenURL(context.Context, string, int) (string, error)
+	// ShortenURL stores the URL under a new short code. When ownerID is not empty, the code
+	// is also recorded as owned by that user; an empty ownerID shortens the URL anonymously.
+	ShortenURL(ctx context.Context, url string, expire int, ownerID string) (string, error)
 	// GetURL retrieves the original URL associated with the given short code.
 	// It returns the original URL if found, or ErrCodeNotFound if the code does not exist.
 	GetURL(context.Context, string) (string, error)
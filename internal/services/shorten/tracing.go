@@ -0,0 +1,42 @@
+package shorten
+
+import (
+	"context"
+
+	"github.com/luongtruong20201/bookmark-management/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedShortenURL is a tracing decorator around the ShortenURL service. Every call is
+// recorded as a span named "shortenURL.<method>".
+type tracedShortenURL struct {
+	next ShortenURL
+}
+
+// NewTracedShortenURL creates a shorten URL service recording a span for each call to s.
+func NewTracedShortenURL(s ShortenURL) ShortenURL {
+	return &tracedShortenURL{
+		next: s,
+	}
+}
+
+// ShortenURL records a span around the creation of a short link.
+func (s *tracedShortenURL) ShortenURL(ctx context.Context, url string, expire int, ownerID string) (string, error) {
+	ctx, span := tracing.Start(ctx, "shortenURL.ShortenURL", attribute.String("user.id", ownerID))
+	code, err := s.next.ShortenURL(ctx, url, expire, ownerID)
+	if err == nil {
+		span.SetAttributes(attribute.String("link.code", code))
+	}
+	tracing.End(span, err)
+
+	return code, err
+}
+
+// GetURL records a span around the lookup of a short link.
+func (s *tracedShortenURL) GetURL(ctx context.Context, code string) (string, error) {
+	ctx, span := tracing.Start(ctx, "shortenURL.GetURL", attribute.String("link.code", code))
+	url, err := s.next.GetURL(ctx, code)
+	tracing.End(span, err)
+
+	return url, err
+}
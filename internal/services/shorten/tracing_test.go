@@ -0,0 +1,46 @@
+package shorten
+
+import (
+	"testing"
+
+	"github.com/luongtruong20201/bookmark-management/internal/services/shorten/mocks"
+	"github.com/luongtruong20201/bookmark-management/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestTracedShortenURL_ShortenURL(t *testing.T) {
+	t.Parallel()
+
+	ctx, spans := tracing.InitMockTracer(t)
+	svc := mocks.NewShortenURL(t)
+	svc.On("ShortenURL", mock.Anything, "https://example.com", 3600, "user-1").Return("abcdefg", nil)
+
+	code, err := NewTracedShortenURL(svc).ShortenURL(ctx, "https://example.com", 3600, "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "abcdefg", code)
+	recorded := spans()
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, "shortenURL.ShortenURL", recorded[0].Name)
+	assert.Contains(t, recorded[0].Attributes, attribute.String("link.code", "abcdefg"))
+}
+
+func TestTracedShortenURL_GetURL(t *testing.T) {
+	t.Parallel()
+
+	ctx, spans := tracing.InitMockTracer(t)
+	svc := mocks.NewShortenURL(t)
+	svc.On("GetURL", mock.Anything, "abcdefg").Return("", ErrCodeNotFound)
+
+	_, err := NewTracedShortenURL(svc).GetURL(ctx, "abcdefg")
+
+	assert.ErrorIs(t, err, ErrCodeNotFound)
+	recorded := spans()
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, "shortenURL.GetURL", recorded[0].Name)
+	assert.Contains(t, recorded[0].Attributes, attribute.String("link.code", "abcdefg"))
+	assert.Equal(t, codes.Error, recorded[0].Status.Code)
+}
@@ -2,12 +2,15 @@ package user
 
 import (
 	"context"
+	"strings"
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
 )
 
 // CreateUser creates a new user account with the provided information.
-// It hashes the password using bcrypt before storing the user in the database.
+// The username must not contain '@', so that it can never be taken for an email address at login.
+// The password must meet the password policy; it is hashed with the configured algorithm
+// before storing the user in the database.
 // The user ID is automatically generated as a UUID by the repository layer.
 //
 // Parameters:
@@ -19,9 +22,21 @@ import (
 //
 // Returns:
 //   - *model.User: The created user with generated UUID and hashed password
-//   - error: Returns an error if user creation fails (e.g., duplicate username/email, database error)
+//   - error: Returns ErrInvalidUsername if the username contains '@', a
+//     passwordpolicy.RejectedError if the password is rejected, or an error
+//     if user creation fails (e.g., duplicate username/email, database error)
 func (u *user) CreateUser(ctx context.Context, username, password, displayName, email string) (*model.User, error) {
-	hashPassword := u.hasher.HashPassword(password)
+	if strings.Contains(username, "@") {
+		return nil, ErrInvalidUsername
+	}
+	if err := u.checkPassword(ctx, password, username, email); err != nil {
+		return nil, err
+	}
+
+	hashPassword, err := u.hasher.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
 
 	newUser := model.User{
 		Username:    username,
@@ -37,3 +52,15 @@ func (u *user) CreateUser(ctx context.Context, username, password, displayName,
 
 	return res, nil
 }
+
+// checkPassword checks the password against the password policy, with the username and email
+// of the account as user inputs. It returns a passwordpolicy.RejectedError if the password is
+// rejected.
+func (u *user) checkPassword(ctx context.Context, password, username, email string) error {
+	result, err := u.policy.Check(ctx, password, username, email)
+	if err != nil {
+		return err
+	}
+
+	return result.Err()
+}
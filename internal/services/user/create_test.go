@@ -7,6 +7,8 @@ import (
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
 	mockRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/user/mocks"
+	"github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy"
+	mockPolicy "github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy/mocks"
 	mockUtils "github.com/luongtruong20201/bookmark-management/pkg/utils/mocks"
 	"github.com/stretchr/testify/assert"
 )
@@ -19,14 +21,24 @@ func TestUserService_CreateUser(t *testing.T) {
 	var (
 		testErrDatabase     = errors.New("database error")
 		testErrDuplicateKey = errors.New("duplicate key value violates unique constraint")
+		testErrPolicy       = errors.New("breached password list unavailable")
 	)
 
+	acceptPolicy := func(t *testing.T, ctx context.Context, password string) *mockPolicy.Policy {
+		policyMock := mockPolicy.NewPolicy(t)
+		policyMock.On("Check", ctx, password, "johndoe", "john.doe@example.com").
+			Return(&passwordpolicy.Result{Violations: []passwordpolicy.Violation{}}, nil).Once()
+		return policyMock
+	}
+	shortViolations := []passwordpolicy.Violation{{Code: passwordpolicy.ViolationTooShort, Message: "password must be at least 8 characters long"}}
+
 	testCases := []struct {
 		name            string
 		username        string
 		password        string
 		displayName     string
 		email           string
+		setupMockPolicy func(t *testing.T, ctx context.Context, password string) *mockPolicy.Policy
 		setupMockHasher func(t *testing.T, password string) *mockUtils.Hasher
 		setupMockRepo   func(t *testing.T, ctx context.Context) *mockRepo.User
 		expectedUser    *model.User
@@ -34,14 +46,15 @@ func TestUserService_CreateUser(t *testing.T) {
 		verifyPassword  bool
 	}{
 		{
-			name:        "success",
-			username:    "johndoe",
-			password:    "password123",
-			displayName: "John Doe",
-			email:       "john.doe@example.com",
+			name:            "success",
+			username:        "johndoe",
+			password:        "password123",
+			displayName:     "John Doe",
+			email:           "john.doe@example.com",
+			setupMockPolicy: acceptPolicy,
 			setupMockHasher: func(t *testing.T, password string) *mockUtils.Hasher {
 				hasherMock := mockUtils.NewHasher(t)
-				hasherMock.On("HashPassword", password).Return(mockHashedPassword).Once()
+				hasherMock.On("HashPassword", password).Return(mockHashedPassword, nil).Once()
 				return hasherMock
 			},
 			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
@@ -75,14 +88,15 @@ func TestUserService_CreateUser(t *testing.T) {
 			verifyPassword: true,
 		},
 		{
-			name:        "repository error",
-			username:    "johndoe",
-			password:    "password123",
-			displayName: "John Doe",
-			email:       "john.doe@example.com",
+			name:            "repository error",
+			username:        "johndoe",
+			password:        "password123",
+			displayName:     "John Doe",
+			email:           "john.doe@example.com",
+			setupMockPolicy: acceptPolicy,
 			setupMockHasher: func(t *testing.T, password string) *mockUtils.Hasher {
 				hasherMock := mockUtils.NewHasher(t)
-				hasherMock.On("HashPassword", password).Return(mockHashedPassword).Once()
+				hasherMock.On("HashPassword", password).Return(mockHashedPassword, nil).Once()
 				return hasherMock
 			},
 			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
@@ -101,14 +115,15 @@ func TestUserService_CreateUser(t *testing.T) {
 			verifyPassword: false,
 		},
 		{
-			name:        "duplicate username error",
-			username:    "johndoe",
-			password:    "password123",
-			displayName: "John Doe",
-			email:       "john.doe@example.com",
+			name:            "duplicate username error",
+			username:        "johndoe",
+			password:        "password123",
+			displayName:     "John Doe",
+			email:           "john.doe@example.com",
+			setupMockPolicy: acceptPolicy,
 			setupMockHasher: func(t *testing.T, password string) *mockUtils.Hasher {
 				hasherMock := mockUtils.NewHasher(t)
-				hasherMock.On("HashPassword", password).Return(mockHashedPassword).Once()
+				hasherMock.On("HashPassword", password).Return(mockHashedPassword, nil).Once()
 				return hasherMock
 			},
 			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
@@ -126,6 +141,68 @@ func TestUserService_CreateUser(t *testing.T) {
 			expectedError:  testErrDuplicateKey,
 			verifyPassword: false,
 		},
+		{
+			name:        "password rejected by policy",
+			username:    "johndoe",
+			password:    "pass",
+			displayName: "John Doe",
+			email:       "john.doe@example.com",
+			setupMockPolicy: func(t *testing.T, ctx context.Context, password string) *mockPolicy.Policy {
+				policyMock := mockPolicy.NewPolicy(t)
+				policyMock.On("Check", ctx, password, "johndoe", "john.doe@example.com").
+					Return(&passwordpolicy.Result{Violations: shortViolations}, nil).Once()
+				return policyMock
+			},
+			setupMockHasher: func(t *testing.T, password string) *mockUtils.Hasher {
+				return mockUtils.NewHasher(t)
+			},
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				return mockRepo.NewUser(t)
+			},
+			expectedUser:   nil,
+			expectedError:  passwordpolicy.ErrPasswordRejected,
+			verifyPassword: false,
+		},
+		{
+			name:        "policy error",
+			username:    "johndoe",
+			password:    "password123",
+			displayName: "John Doe",
+			email:       "john.doe@example.com",
+			setupMockPolicy: func(t *testing.T, ctx context.Context, password string) *mockPolicy.Policy {
+				policyMock := mockPolicy.NewPolicy(t)
+				policyMock.On("Check", ctx, password, "johndoe", "john.doe@example.com").Return(nil, testErrPolicy).Once()
+				return policyMock
+			},
+			setupMockHasher: func(t *testing.T, password string) *mockUtils.Hasher {
+				return mockUtils.NewHasher(t)
+			},
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				return mockRepo.NewUser(t)
+			},
+			expectedUser:   nil,
+			expectedError:  testErrPolicy,
+			verifyPassword: false,
+		},
+		{
+			name:        "username containing @",
+			username:    "jane.doe@example.com",
+			password:    "password123",
+			displayName: "John Doe",
+			email:       "john.doe@example.com",
+			setupMockPolicy: func(t *testing.T, ctx context.Context, password string) *mockPolicy.Policy {
+				return mockPolicy.NewPolicy(t)
+			},
+			setupMockHasher: func(t *testing.T, password string) *mockUtils.Hasher {
+				return mockUtils.NewHasher(t)
+			},
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				return mockRepo.NewUser(t)
+			},
+			expectedUser:   nil,
+			expectedError:  ErrInvalidUsername,
+			verifyPassword: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -135,12 +212,13 @@ func TestUserService_CreateUser(t *testing.T) {
 			ctx := t.Context()
 			hasherMock := tc.setupMockHasher(t, tc.password)
 			repoMock := tc.setupMockRepo(t, ctx)
-			svc := NewUser(repoMock, hasherMock, nil)
+			policyMock := tc.setupMockPolicy(t, ctx, tc.password)
+			svc := NewUser(repoMock, hasherMock, nil, nil, policyMock)
 
 			result, err := svc.CreateUser(ctx, tc.username, tc.password, tc.displayName, tc.email)
 
 			if tc.expectedError != nil {
-				assert.ErrorIs(t, tc.expectedError, err)
+				assert.ErrorIs(t, err, tc.expectedError)
 				assert.Nil(t, result)
 			} else {
 				assert.NoError(t, err)
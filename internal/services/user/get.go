@@ -19,3 +19,21 @@ import (
 func (u *user) GetUserByID(ctx context.Context, id string) (*model.User, error) {
 	return u.repo.GetUserByID(ctx, id)
 }
+
+// IsUserDisabled reports whether the account identified by id has been disabled by an administrator.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - id: UUID string identifying the user
+//
+// Returns:
+//   - bool: true if the account is disabled
+//   - error: Returns ErrNotFoundType if user doesn't exist, or an error if database query fails
+func (u *user) IsUserDisabled(ctx context.Context, id string) (bool, error) {
+	user, err := u.repo.GetUserByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return user.Disabled, nil
+}
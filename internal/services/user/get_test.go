@@ -133,7 +133,7 @@ func TestUserService_GetUserByID(t *testing.T) {
 
 			ctx := context.Background()
 			repoMock := tc.setupMockRepo(t, ctx, tc.userID)
-			svc := NewUser(repoMock, nil, nil)
+			svc := NewUser(repoMock, nil, nil, nil, nil)
 
 			result, err := svc.GetUserByID(ctx, tc.userID)
 
@@ -153,3 +153,61 @@ func TestUserService_GetUserByID(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_IsUserDisabled(t *testing.T) {
+	t.Parallel()
+
+	const mockUserID = "550e8400-e29b-41d4-a716-446655440000"
+
+	testErrDatabase := errors.New("database error")
+
+	testCases := []struct {
+		name             string
+		setupMockRepo    func(t *testing.T, ctx context.Context) *mockRepo.User
+		expectedDisabled bool
+		expectedError    error
+	}{
+		{
+			name: "success - active user",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByID", ctx, mockUserID).Return(&model.User{Base: model.Base{ID: mockUserID}}, nil).Once()
+				return repoMock
+			},
+			expectedDisabled: false,
+		},
+		{
+			name: "success - disabled user",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByID", ctx, mockUserID).
+					Return(&model.User{Base: model.Base{ID: mockUserID}, Disabled: true}, nil).Once()
+				return repoMock
+			},
+			expectedDisabled: true,
+		},
+		{
+			name: "error - database error",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByID", ctx, mockUserID).Return(nil, testErrDatabase).Once()
+				return repoMock
+			},
+			expectedError: testErrDatabase,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			svc := NewUser(tc.setupMockRepo(t, ctx), nil, nil, nil, nil)
+
+			disabled, err := svc.IsUserDisabled(ctx, mockUserID)
+
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedDisabled, disabled)
+		})
+	}
+}
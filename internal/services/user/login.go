@@ -2,44 +2,88 @@ package user
 
 import (
 	"context"
-	"database/sql"
-	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
 )
 
-// Login authenticates a user with the provided username and password.
-// It retrieves the user from the database, verifies the password hash, and generates a JWT token
-// upon successful authentication. The token includes the user ID and expiration time.
+// Login authenticates a user with the provided username or email address and password.
+// It retrieves the user from the database, ignoring the letter case of the identifier, verifies
+// the password hash, and upon successful authentication opens a session for the device and
+// generates a JWT token. The token includes the user ID, the session ID and expiration time.
+// A password hash produced by another algorithm or with weaker parameters than configured is
+// replaced by a new hash of the verified password.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and timeout
-//   - username: Username of the user attempting to log in
+//   - login: Username or email address of the user attempting to log in
 //   - password: Plain text password to verify against the stored hash
+//   - device: User agent and IP address of the client, recorded on the session
 //
 // Returns:
 //   - string: JWT token string for authenticated requests (valid for 24 hours)
-//   - error: Returns ErrClientErr if credentials are invalid or user doesn't exist,
-//     or an error if token generation fails
-func (u *user) Login(ctx context.Context, username, password string) (string, error) {
-	user, err := u.repo.GetUserByUsername(ctx, username)
+//   - error: Returns ErrClientErr if the password is invalid, dbutils.ErrNotFoundType if no
+//     user matches the login, ErrUserDisabled if the account is disabled,
+//     ErrPasswordResetRequired if the user must change the password first, or an error if
+//     session creation or token generation fails
+func (u *user) Login(ctx context.Context, login, password string, device sessionService.Device) (string, error) {
+	user, err := u.repo.GetUserByLogin(ctx, login)
 	if err != nil {
-		switch {
-		case errors.Is(err, sql.ErrNoRows):
-			return "", ErrClientErr
-		default:
-			return "", err
-		}
+		return "", err
 	}
 	if check := u.hasher.VerifyPassword(password, user.Password); !check {
 		return "", ErrClientErr
 	}
+	if user.Disabled {
+		return "", ErrUserDisabled
+	}
+	if user.PasswordResetRequired {
+		return "", ErrPasswordResetRequired
+	}
+	u.upgradePasswordHash(ctx, user, password)
+
+	return u.generateToken(ctx, user, device)
+}
+
+// upgradePasswordHash hashes the verified password again when its stored hash is outdated.
+// A failure is logged without failing the login, since the stored hash remains valid.
+func (u *user) upgradePasswordHash(ctx context.Context, user *model.User, password string) {
+	if !u.hasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	hashedPassword, err := u.hasher.HashPassword(password)
+	if err == nil {
+		err = u.repo.UpdatePasswordHash(ctx, user.ID, hashedPassword)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Warn().Err(err).Str("uid", user.ID).Msg("failed to upgrade password hash")
+	}
+}
+
+// generateToken opens a session for the given user on the device and issues a signed JWT
+// bound to it. The token carries the user ID as subject, the user role and the session ID,
+// and expires after tokenExpiresTime together with the session.
+func (u *user) generateToken(ctx context.Context, user *model.User, device sessionService.Device) (string, error) {
+	role := user.Role
+	if role == "" {
+		role = model.RoleUser
+	}
+
+	session, err := u.sessions.Create(ctx, user.ID, device, tokenExpiresTime)
+	if err != nil {
+		return "", err
+	}
 
 	jwtContent := jwt.MapClaims{
-		"sub": user.ID,
-		"iat": time.Now().Unix(),
-		"exp": time.Now().Add(tokenExpiresTime).Unix(),
+		"sub":  user.ID,
+		"role": role,
+		"sid":  session.ID,
+		"iat":  time.Now().Unix(),
+		"exp":  time.Now().Add(tokenExpiresTime).Unix(),
 	}
 	token, err := u.jwtGenerator.GenerateToken(jwtContent)
 	if err != nil {
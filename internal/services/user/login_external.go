@@ -0,0 +1,111 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	"github.com/luongtruong20201/bookmark-management/pkg/stringutils"
+)
+
+const (
+	// externalPasswordLength is the length of the random password set on accounts created
+	// through an external identity provider. Nobody knows it, so password login stays disabled.
+	externalPasswordLength = 32
+	// usernameSuffixLength is the length of the random suffix appended to a username that is
+	// already taken when creating an account from an external identity.
+	usernameSuffixLength = 6
+)
+
+// LoginWithVerifiedEmail signs in the user whose email address has been verified by an
+// external identity provider. If no account uses the email yet, a new one is created from
+// the provided username and display name, with the email marked as verified. An existing
+// account is only signed in if its own email is verified. The result is the same JWT
+// issued by Login.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - email: Email address verified by the identity provider
+//   - username: Preferred username; the local part of the email is used when empty
+//   - displayName: Display name; the username is used when empty
+//   - device: User agent and IP address of the client, recorded on the session
+//
+// Returns:
+//   - string: JWT token string for authenticated requests (valid for 24 hours)
+//   - error: ErrEmailNotVerified if the account using the email has not verified it,
+//     ErrUserDisabled if the linked account is disabled, ErrPasswordResetRequired if
+//     an administrator has forced a password reset on it, or an error if the lookup,
+//     account creation, session creation or token generation fails
+func (u *user) LoginWithVerifiedEmail(ctx context.Context, email, username, displayName string, device sessionService.Device) (string, error) {
+	existing, err := u.repo.GetUserByEmail(ctx, email)
+	switch {
+	case err == nil:
+		if !existing.EmailVerified {
+			return "", ErrEmailNotVerified
+		}
+		if existing.Disabled {
+			return "", ErrUserDisabled
+		}
+		if existing.PasswordResetRequired {
+			return "", ErrPasswordResetRequired
+		}
+		return u.generateToken(ctx, existing, device)
+	case !errors.Is(err, dbutils.ErrNotFoundType):
+		return "", err
+	}
+
+	created, err := u.createExternalUser(ctx, email, username, displayName)
+	if err != nil {
+		return "", err
+	}
+
+	return u.generateToken(ctx, created, device)
+}
+
+// createExternalUser creates an account for an external identity with an unusable random
+// password and the verified email. If the username is already taken, it retries once with a random suffix.
+func (u *user) createExternalUser(ctx context.Context, email, username, displayName string) (*model.User, error) {
+	if username == "" {
+		username = email
+	}
+	// Usernames must not contain '@', so only the local part of an email-like name is kept.
+	username, _, _ = strings.Cut(username, "@")
+	if displayName == "" {
+		displayName = username
+	}
+
+	password, err := stringutils.GenerateCode(externalPasswordLength)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := u.hasher.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	newUser := &model.User{
+		Username:      username,
+		DisplayName:   displayName,
+		Email:         email,
+		EmailVerified: true,
+		Password:      hashedPassword,
+	}
+
+	res, err := u.repo.CreateUser(ctx, newUser)
+	if !errors.Is(err, dbutils.ErrDuplicationType) {
+		return res, err
+	}
+
+	suffix, err := stringutils.GenerateCode(usernameSuffixLength)
+	if err != nil {
+		return nil, err
+	}
+	newUser.ID = ""
+	newUser.Username = username + "-" + strings.ToLower(suffix)
+
+	return u.repo.CreateUser(ctx, newUser)
+}
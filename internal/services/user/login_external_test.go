@@ -0,0 +1,304 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	mockRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/user/mocks"
+	mockSession "github.com/luongtruong20201/bookmark-management/internal/services/session/mocks"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	mockJWT "github.com/luongtruong20201/bookmark-management/pkg/jwt/mocks"
+	mockUtils "github.com/luongtruong20201/bookmark-management/pkg/utils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserService_LoginWithVerifiedEmail(t *testing.T) {
+	t.Parallel()
+
+	const (
+		mockHashedPassword = "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e"
+		mockToken          = "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9.test"
+		mockUserID         = "550e8400-e29b-41d4-a716-446655440000"
+		mockEmail          = "john.doe@example.com"
+	)
+
+	var (
+		testErrDatabase = errors.New("database error")
+		testErrJWT      = errors.New("jwt generation error")
+	)
+
+	existingUser := &model.User{
+		Base:          model.Base{ID: mockUserID},
+		Username:      "johndoe",
+		Password:      mockHashedPassword,
+		DisplayName:   "John Doe",
+		Email:         mockEmail,
+		EmailVerified: true,
+	}
+
+	testCases := []struct {
+		name            string
+		username        string
+		displayName     string
+		setupMockRepo   func(t *testing.T, ctx context.Context) *mockRepo.User
+		setupMockHasher func(t *testing.T) *mockUtils.Hasher
+		setupMockJWT    func(t *testing.T) *mockJWT.JWTGenerator
+		opensSession    bool
+		expectedToken   string
+		expectedError   error
+	}{
+		{
+			name:     "success - existing user linked by email",
+			username: "john",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByEmail", ctx, mockEmail).Return(existingUser, nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				return mockUtils.NewHasher(t)
+			},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.MatchedBy(func(claims map[string]any) bool {
+					return claims["sub"] == mockUserID && claims["sid"] == mockSessionID
+				})).Return(mockToken, nil).Once()
+				return jwtMock
+			},
+			opensSession:  true,
+			expectedToken: mockToken,
+		},
+		{
+			name:        "success - new user created",
+			username:    "john",
+			displayName: "John From IdP",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByEmail", ctx, mockEmail).Return(nil, dbutils.ErrNotFoundType).Once()
+				repoMock.On("CreateUser", ctx, mock.MatchedBy(func(u *model.User) bool {
+					return u.Username == "john" && u.DisplayName == "John From IdP" &&
+						u.Email == mockEmail && u.EmailVerified && u.Password == mockHashedPassword
+				})).Return(&model.User{Base: model.Base{ID: mockUserID}}, nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("HashPassword", mock.AnythingOfType("string")).Return(mockHashedPassword, nil).Once()
+				return hasherMock
+			},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.Anything).Return(mockToken, nil).Once()
+				return jwtMock
+			},
+			opensSession:  true,
+			expectedToken: mockToken,
+		},
+		{
+			name: "success - username and display name derived from email",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByEmail", ctx, mockEmail).Return(nil, dbutils.ErrNotFoundType).Once()
+				repoMock.On("CreateUser", ctx, mock.MatchedBy(func(u *model.User) bool {
+					return u.Username == "john.doe" && u.DisplayName == "john.doe"
+				})).Return(&model.User{Base: model.Base{ID: mockUserID}}, nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("HashPassword", mock.AnythingOfType("string")).Return(mockHashedPassword, nil).Once()
+				return hasherMock
+			},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.Anything).Return(mockToken, nil).Once()
+				return jwtMock
+			},
+			opensSession:  true,
+			expectedToken: mockToken,
+		},
+		{
+			name:     "success - email-like username reduced to its local part",
+			username: "jane.doe@example.org",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByEmail", ctx, mockEmail).Return(nil, dbutils.ErrNotFoundType).Once()
+				repoMock.On("CreateUser", ctx, mock.MatchedBy(func(u *model.User) bool {
+					return u.Username == "jane.doe" && u.DisplayName == "jane.doe"
+				})).Return(&model.User{Base: model.Base{ID: mockUserID}}, nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("HashPassword", mock.AnythingOfType("string")).Return(mockHashedPassword, nil).Once()
+				return hasherMock
+			},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.Anything).Return(mockToken, nil).Once()
+				return jwtMock
+			},
+			opensSession:  true,
+			expectedToken: mockToken,
+		},
+		{
+			name:     "success - username taken, retried with suffix",
+			username: "johndoe",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByEmail", ctx, mockEmail).Return(nil, dbutils.ErrNotFoundType).Once()
+				repoMock.On("CreateUser", ctx, mock.MatchedBy(func(u *model.User) bool {
+					return u.Username == "johndoe"
+				})).Return(nil, dbutils.ErrDuplicationType).Once()
+				repoMock.On("CreateUser", ctx, mock.MatchedBy(func(u *model.User) bool {
+					return strings.HasPrefix(u.Username, "johndoe-") && len(u.Username) == len("johndoe-")+usernameSuffixLength
+				})).Return(&model.User{Base: model.Base{ID: mockUserID}}, nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("HashPassword", mock.AnythingOfType("string")).Return(mockHashedPassword, nil).Once()
+				return hasherMock
+			},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.Anything).Return(mockToken, nil).Once()
+				return jwtMock
+			},
+			opensSession:  true,
+			expectedToken: mockToken,
+		},
+		{
+			name:     "error - existing user has not verified the email",
+			username: "john",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByEmail", ctx, mockEmail).
+					Return(&model.User{Base: model.Base{ID: mockUserID}, Email: mockEmail, Password: mockHashedPassword}, nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				return mockUtils.NewHasher(t)
+			},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				return mockJWT.NewJWTGenerator(t)
+			},
+			expectedError: ErrEmailNotVerified,
+		},
+		{
+			name:     "error - linked user disabled",
+			username: "john",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByEmail", ctx, mockEmail).
+					Return(&model.User{Base: model.Base{ID: mockUserID}, Email: mockEmail, EmailVerified: true, Disabled: true}, nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				return mockUtils.NewHasher(t)
+			},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				return mockJWT.NewJWTGenerator(t)
+			},
+			expectedError: ErrUserDisabled,
+		},
+		{
+			name:     "error - linked user must reset the password",
+			username: "john",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByEmail", ctx, mockEmail).
+					Return(&model.User{Base: model.Base{ID: mockUserID}, Email: mockEmail, EmailVerified: true, PasswordResetRequired: true}, nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				return mockUtils.NewHasher(t)
+			},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				return mockJWT.NewJWTGenerator(t)
+			},
+			expectedError: ErrPasswordResetRequired,
+		},
+		{
+			name:     "error - lookup fails",
+			username: "john",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByEmail", ctx, mockEmail).Return(nil, testErrDatabase).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				return mockUtils.NewHasher(t)
+			},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				return mockJWT.NewJWTGenerator(t)
+			},
+			expectedError: testErrDatabase,
+		},
+		{
+			name:     "error - create fails",
+			username: "john",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByEmail", ctx, mockEmail).Return(nil, dbutils.ErrNotFoundType).Once()
+				repoMock.On("CreateUser", ctx, mock.Anything).Return(nil, testErrDatabase).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("HashPassword", mock.AnythingOfType("string")).Return(mockHashedPassword, nil).Once()
+				return hasherMock
+			},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				return mockJWT.NewJWTGenerator(t)
+			},
+			expectedError: testErrDatabase,
+		},
+		{
+			name:     "error - JWT generation fails",
+			username: "john",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByEmail", ctx, mockEmail).Return(existingUser, nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				return mockUtils.NewHasher(t)
+			},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.Anything).Return("", testErrJWT).Once()
+				return jwtMock
+			},
+			opensSession:  true,
+			expectedError: testErrJWT,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			sessionMock := mockSession.NewService(t)
+			if tc.opensSession {
+				sessionMock = newSessionMock(t, mockUserID)
+			}
+			svc := NewUser(tc.setupMockRepo(t, ctx), tc.setupMockHasher(t), tc.setupMockJWT(t), sessionMock, nil)
+
+			token, err := svc.LoginWithVerifiedEmail(ctx, mockEmail, tc.username, tc.displayName, testDevice)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Empty(t, token)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedToken, token)
+			}
+		})
+	}
+}
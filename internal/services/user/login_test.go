@@ -2,18 +2,33 @@ package user
 
 import (
 	"context"
-	db "database/sql"
 	"errors"
 	"testing"
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/session"
 	mockRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/user/mocks"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
+	mockSession "github.com/luongtruong20201/bookmark-management/internal/services/session/mocks"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
 	mockJWT "github.com/luongtruong20201/bookmark-management/pkg/jwt/mocks"
 	mockUtils "github.com/luongtruong20201/bookmark-management/pkg/utils/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+const mockSessionID = "0b8f3c5e-2d4a-4e6b-9f1c-7a3d5e8b2c40"
+
+var testDevice = sessionService.Device{UserAgent: "Mozilla/5.0", IP: "203.0.113.7"}
+
+// newSessionMock expects a session to be opened for the user on testDevice.
+func newSessionMock(t *testing.T, userID string) *mockSession.Service {
+	sessionMock := mockSession.NewService(t)
+	sessionMock.On("Create", mock.Anything, userID, testDevice, tokenExpiresTime).
+		Return(&session.Session{ID: mockSessionID, UserID: userID}, nil).Once()
+	return sessionMock
+}
+
 func TestUserService_Login(t *testing.T) {
 	t.Parallel()
 
@@ -37,6 +52,7 @@ func TestUserService_Login(t *testing.T) {
 		setupMockRepo     func(t *testing.T, ctx context.Context, username string) *mockRepo.User
 		setupMockHasher   func(t *testing.T, password, hashedPassword string, shouldVerify bool) *mockUtils.Hasher
 		setupMockJWT      func(t *testing.T, userID string) *mockJWT.JWTGenerator
+		setupMockSession  func(t *testing.T, userID string) *mockSession.Service
 		expectedToken     string
 		expectedError     error
 		verifyTokenClaims bool
@@ -47,7 +63,7 @@ func TestUserService_Login(t *testing.T) {
 			password: "password123",
 			setupMockRepo: func(t *testing.T, ctx context.Context, username string) *mockRepo.User {
 				repoMock := mockRepo.NewUser(t)
-				repoMock.On("GetUserByUsername", ctx, username).Return(&model.User{
+				repoMock.On("GetUserByLogin", ctx, username).Return(&model.User{
 					Base: model.Base{
 						ID: mockUserID,
 					},
@@ -61,6 +77,7 @@ func TestUserService_Login(t *testing.T) {
 			setupMockHasher: func(t *testing.T, password, hashedPassword string, shouldVerify bool) *mockUtils.Hasher {
 				hasherMock := mockUtils.NewHasher(t)
 				hasherMock.On("VerifyPassword", password, hashedPassword).Return(shouldVerify).Once()
+				hasherMock.On("NeedsRehash", hashedPassword).Return(false).Once()
 				return hasherMock
 			},
 			setupMockJWT: func(t *testing.T, userID string) *mockJWT.JWTGenerator {
@@ -69,17 +86,18 @@ func TestUserService_Login(t *testing.T) {
 					Return(mockToken, nil).Once()
 				return jwtMock
 			},
+			setupMockSession:  newSessionMock,
 			expectedToken:     mockToken,
 			expectedError:     nil,
 			verifyTokenClaims: true,
 		},
 		{
-			name:     "error - user not found (db.ErrNoRows mapped to client error)",
+			name:     "error - user not found",
 			username: "nonexistent",
 			password: "password123",
 			setupMockRepo: func(t *testing.T, ctx context.Context, username string) *mockRepo.User {
 				repoMock := mockRepo.NewUser(t)
-				repoMock.On("GetUserByUsername", ctx, username).Return(nil, db.ErrNoRows).Once()
+				repoMock.On("GetUserByLogin", ctx, username).Return(nil, dbutils.ErrNotFoundType).Once()
 				return repoMock
 			},
 			setupMockHasher: func(t *testing.T, password, hashedPassword string, shouldVerify bool) *mockUtils.Hasher {
@@ -91,7 +109,7 @@ func TestUserService_Login(t *testing.T) {
 				return jwtMock
 			},
 			expectedToken:     "",
-			expectedError:     testErrClientErr,
+			expectedError:     dbutils.ErrNotFoundType,
 			verifyTokenClaims: false,
 		},
 		{
@@ -100,7 +118,7 @@ func TestUserService_Login(t *testing.T) {
 			password: "password123",
 			setupMockRepo: func(t *testing.T, ctx context.Context, username string) *mockRepo.User {
 				repoMock := mockRepo.NewUser(t)
-				repoMock.On("GetUserByUsername", ctx, username).Return(nil, testErrDatabase).Once()
+				repoMock.On("GetUserByLogin", ctx, username).Return(nil, testErrDatabase).Once()
 				return repoMock
 			},
 			setupMockHasher: func(t *testing.T, password, hashedPassword string, shouldVerify bool) *mockUtils.Hasher {
@@ -121,7 +139,7 @@ func TestUserService_Login(t *testing.T) {
 			password: "wrongpassword",
 			setupMockRepo: func(t *testing.T, ctx context.Context, username string) *mockRepo.User {
 				repoMock := mockRepo.NewUser(t)
-				repoMock.On("GetUserByUsername", ctx, username).Return(&model.User{
+				repoMock.On("GetUserByLogin", ctx, username).Return(&model.User{
 					Base: model.Base{
 						ID: mockUserID,
 					},
@@ -151,7 +169,7 @@ func TestUserService_Login(t *testing.T) {
 			password: "password123",
 			setupMockRepo: func(t *testing.T, ctx context.Context, username string) *mockRepo.User {
 				repoMock := mockRepo.NewUser(t)
-				repoMock.On("GetUserByUsername", ctx, username).Return(&model.User{
+				repoMock.On("GetUserByLogin", ctx, username).Return(&model.User{
 					Base: model.Base{
 						ID: mockUserID,
 					},
@@ -165,6 +183,7 @@ func TestUserService_Login(t *testing.T) {
 			setupMockHasher: func(t *testing.T, password, hashedPassword string, shouldVerify bool) *mockUtils.Hasher {
 				hasherMock := mockUtils.NewHasher(t)
 				hasherMock.On("VerifyPassword", password, hashedPassword).Return(true).Once()
+				hasherMock.On("NeedsRehash", hashedPassword).Return(false).Once()
 				return hasherMock
 			},
 			setupMockJWT: func(t *testing.T, userID string) *mockJWT.JWTGenerator {
@@ -172,17 +191,48 @@ func TestUserService_Login(t *testing.T) {
 				jwtMock.On("GenerateToken", mock.Anything).Return("", testErrJWT).Once()
 				return jwtMock
 			},
+			setupMockSession:  newSessionMock,
 			expectedToken:     "",
 			expectedError:     testErrJWT,
 			verifyTokenClaims: false,
 		},
+		{
+			name:     "error - session creation fails",
+			username: "johndoe",
+			password: "password123",
+			setupMockRepo: func(t *testing.T, ctx context.Context, username string) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByLogin", ctx, username).Return(&model.User{
+					Base:     model.Base{ID: mockUserID},
+					Username: "johndoe",
+					Password: mockHashedPassword,
+				}, nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T, password, hashedPassword string, shouldVerify bool) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("VerifyPassword", password, hashedPassword).Return(true).Once()
+				hasherMock.On("NeedsRehash", hashedPassword).Return(false).Once()
+				return hasherMock
+			},
+			setupMockJWT: func(t *testing.T, userID string) *mockJWT.JWTGenerator {
+				return mockJWT.NewJWTGenerator(t)
+			},
+			setupMockSession: func(t *testing.T, userID string) *mockSession.Service {
+				sessionMock := mockSession.NewService(t)
+				sessionMock.On("Create", mock.Anything, userID, testDevice, tokenExpiresTime).Return(nil, testErrDatabase).Once()
+				return sessionMock
+			},
+			expectedToken: "",
+			expectedError: testErrDatabase,
+		},
 		{
 			name:     "error - empty username",
 			username: "",
 			password: "password123",
 			setupMockRepo: func(t *testing.T, ctx context.Context, username string) *mockRepo.User {
 				repoMock := mockRepo.NewUser(t)
-				repoMock.On("GetUserByUsername", ctx, username).Return(nil, testErrNotFound).Once()
+				repoMock.On("GetUserByLogin", ctx, username).Return(nil, testErrNotFound).Once()
 				return repoMock
 			},
 			setupMockHasher: func(t *testing.T, password, hashedPassword string, shouldVerify bool) *mockUtils.Hasher {
@@ -197,13 +247,46 @@ func TestUserService_Login(t *testing.T) {
 			expectedError:     testErrNotFound,
 			verifyTokenClaims: false,
 		},
+		{
+			name:     "success - login with email address",
+			username: "John.Doe@Example.com",
+			password: "password123",
+			setupMockRepo: func(t *testing.T, ctx context.Context, username string) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByLogin", ctx, username).Return(&model.User{
+					Base: model.Base{
+						ID: mockUserID,
+					},
+					Username:    "johndoe",
+					Password:    mockHashedPassword,
+					DisplayName: "John Doe",
+					Email:       "john.doe@example.com",
+				}, nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T, password, hashedPassword string, shouldVerify bool) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("VerifyPassword", password, hashedPassword).Return(true).Once()
+				hasherMock.On("NeedsRehash", hashedPassword).Return(false).Once()
+				return hasherMock
+			},
+			setupMockJWT: func(t *testing.T, userID string) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.Anything).Return(mockToken, nil).Once()
+				return jwtMock
+			},
+			setupMockSession:  newSessionMock,
+			expectedToken:     mockToken,
+			expectedError:     nil,
+			verifyTokenClaims: true,
+		},
 		{
 			name:     "error - empty password",
 			username: "johndoe",
 			password: "",
 			setupMockRepo: func(t *testing.T, ctx context.Context, username string) *mockRepo.User {
 				repoMock := mockRepo.NewUser(t)
-				repoMock.On("GetUserByUsername", ctx, username).Return(&model.User{
+				repoMock.On("GetUserByLogin", ctx, username).Return(&model.User{
 					Base: model.Base{
 						ID: mockUserID,
 					},
@@ -237,9 +320,13 @@ func TestUserService_Login(t *testing.T) {
 			repoMock := tc.setupMockRepo(t, ctx, tc.username)
 			hasherMock := tc.setupMockHasher(t, tc.password, mockHashedPassword, tc.name == "success - valid username and password")
 			jwtMock := tc.setupMockJWT(t, mockUserID)
-			svc := NewUser(repoMock, hasherMock, jwtMock)
+			sessionMock := mockSession.NewService(t)
+			if tc.setupMockSession != nil {
+				sessionMock = tc.setupMockSession(t, mockUserID)
+			}
+			svc := NewUser(repoMock, hasherMock, jwtMock, sessionMock, nil)
 
-			token, err := svc.Login(ctx, tc.username, tc.password)
+			token, err := svc.Login(ctx, tc.username, tc.password, testDevice)
 
 			if tc.expectedError != nil {
 				assert.Error(t, err)
@@ -253,3 +340,165 @@ func TestUserService_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_Login_AccountStatus(t *testing.T) {
+	t.Parallel()
+
+	const (
+		mockHashedPassword = "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e"
+		mockToken          = "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9.test"
+		mockUserID         = "550e8400-e29b-41d4-a716-446655440000"
+	)
+
+	testCases := []struct {
+		name          string
+		user          *model.User
+		setupMockJWT  func(t *testing.T) *mockJWT.JWTGenerator
+		expectedToken string
+		expectedError error
+	}{
+		{
+			name: "success - admin role carried in claims",
+			user: &model.User{Base: model.Base{ID: mockUserID}, Password: mockHashedPassword, Role: model.RoleAdmin},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.MatchedBy(func(claims map[string]any) bool {
+					return claims["sub"] == mockUserID && claims["role"] == model.RoleAdmin && claims["sid"] == mockSessionID
+				})).Return(mockToken, nil).Once()
+				return jwtMock
+			},
+			expectedToken: mockToken,
+		},
+		{
+			name: "success - missing role defaults to user",
+			user: &model.User{Base: model.Base{ID: mockUserID}, Password: mockHashedPassword},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				jwtMock := mockJWT.NewJWTGenerator(t)
+				jwtMock.On("GenerateToken", mock.MatchedBy(func(claims map[string]any) bool {
+					return claims["role"] == model.RoleUser
+				})).Return(mockToken, nil).Once()
+				return jwtMock
+			},
+			expectedToken: mockToken,
+		},
+		{
+			name: "error - disabled user",
+			user: &model.User{Base: model.Base{ID: mockUserID}, Password: mockHashedPassword, Disabled: true},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				return mockJWT.NewJWTGenerator(t)
+			},
+			expectedError: ErrUserDisabled,
+		},
+		{
+			name: "error - password reset required",
+			user: &model.User{Base: model.Base{ID: mockUserID}, Password: mockHashedPassword, PasswordResetRequired: true},
+			setupMockJWT: func(t *testing.T) *mockJWT.JWTGenerator {
+				return mockJWT.NewJWTGenerator(t)
+			},
+			expectedError: ErrPasswordResetRequired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repoMock := mockRepo.NewUser(t)
+			repoMock.On("GetUserByLogin", ctx, "johndoe").Return(tc.user, nil).Once()
+			hasherMock := mockUtils.NewHasher(t)
+			hasherMock.On("VerifyPassword", "password123", mockHashedPassword).Return(true).Once()
+			sessionMock := mockSession.NewService(t)
+			if tc.expectedError == nil {
+				hasherMock.On("NeedsRehash", mockHashedPassword).Return(false).Once()
+				sessionMock = newSessionMock(t, mockUserID)
+			}
+			svc := NewUser(repoMock, hasherMock, tc.setupMockJWT(t), sessionMock, nil)
+
+			token, err := svc.Login(ctx, "johndoe", "password123", testDevice)
+
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedToken, token)
+		})
+	}
+}
+
+func TestUserService_Login_UpgradePasswordHash(t *testing.T) {
+	t.Parallel()
+
+	const (
+		mockHashedPassword   = "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e"
+		mockUpgradedPassword = "$argon2id$v=19$m=19456,t=2,p=1$c2FsdHNhbHRzYWx0c2FsdA$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U"
+		mockToken            = "eyJhbGciOiJSUzI1NiIsInR5cCI6IkpXVCJ9.test"
+		mockUserID           = "550e8400-e29b-41d4-a716-446655440000"
+	)
+
+	testErrHash := errors.New("hash error")
+	testErrDatabase := errors.New("database error")
+
+	testCases := []struct {
+		name            string
+		setupMockHasher func(t *testing.T) *mockUtils.Hasher
+		setupMockRepo   func(t *testing.T, ctx context.Context) *mockRepo.User
+	}{
+		{
+			name: "success - outdated hash replaced",
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("HashPassword", "password123").Return(mockUpgradedPassword, nil).Once()
+				return hasherMock
+			},
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("UpdatePasswordHash", ctx, mockUserID, mockUpgradedPassword).Return(nil).Once()
+				return repoMock
+			},
+		},
+		{
+			name: "success - hashing error does not fail the login",
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("HashPassword", "password123").Return("", testErrHash).Once()
+				return hasherMock
+			},
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				return mockRepo.NewUser(t)
+			},
+		},
+		{
+			name: "success - update error does not fail the login",
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("HashPassword", "password123").Return(mockUpgradedPassword, nil).Once()
+				return hasherMock
+			},
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("UpdatePasswordHash", ctx, mockUserID, mockUpgradedPassword).Return(testErrDatabase).Once()
+				return repoMock
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			repoMock := tc.setupMockRepo(t, ctx)
+			repoMock.On("GetUserByLogin", ctx, "johndoe").
+				Return(&model.User{Base: model.Base{ID: mockUserID}, Password: mockHashedPassword}, nil).Once()
+			hasherMock := tc.setupMockHasher(t)
+			hasherMock.On("VerifyPassword", "password123", mockHashedPassword).Return(true).Once()
+			hasherMock.On("NeedsRehash", mockHashedPassword).Return(true).Once()
+			jwtMock := mockJWT.NewJWTGenerator(t)
+			jwtMock.On("GenerateToken", mock.Anything).Return(mockToken, nil).Once()
+			svc := NewUser(repoMock, hasherMock, jwtMock, newSessionMock(t, mockUserID), nil)
+
+			token, err := svc.Login(ctx, "johndoe", "password123", testDevice)
+
+			assert.NoError(t, err)
+			assert.Equal(t, mockToken, token)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	context "context"
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	session "github.com/luongtruong20201/bookmark-management/internal/services/session"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,6 +15,24 @@ type User struct {
 	mock.Mock
 }
 
+// ChangePassword provides a mock function with given fields: ctx, username, currentPassword, newPassword
+func (_m *User) ChangePassword(ctx context.Context, username string, currentPassword string, newPassword string) error {
+	ret := _m.Called(ctx, username, currentPassword, newPassword)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangePassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, username, currentPassword, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateUser provides a mock function with given fields: ctx, username, password, displayName, email
 func (_m *User) CreateUser(ctx context.Context, username string, password string, displayName string, email string) (*model.User, error) {
 	ret := _m.Called(ctx, username, password, displayName, email)
@@ -74,9 +93,37 @@ func (_m *User) GetUserByID(ctx context.Context, id string) (*model.User, error)
 	return r0, r1
 }
 
-// Login provides a mock function with given fields: ctx, username, password
-func (_m *User) Login(ctx context.Context, username string, password string) (string, error) {
-	ret := _m.Called(ctx, username, password)
+// IsUserDisabled provides a mock function with given fields: ctx, id
+func (_m *User) IsUserDisabled(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsUserDisabled")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx, login, password, device
+func (_m *User) Login(ctx context.Context, login string, password string, device session.Device) (string, error) {
+	ret := _m.Called(ctx, login, password, device)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Login")
@@ -84,17 +131,45 @@ func (_m *User) Login(ctx context.Context, username string, password string) (st
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
-		return rf(ctx, username, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, session.Device) (string, error)); ok {
+		return rf(ctx, login, password, device)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, session.Device) string); ok {
+		r0 = rf(ctx, login, password, device)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, session.Device) error); ok {
+		r1 = rf(ctx, login, password, device)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginWithVerifiedEmail provides a mock function with given fields: ctx, email, username, displayName, device
+func (_m *User) LoginWithVerifiedEmail(ctx context.Context, email string, username string, displayName string, device session.Device) (string, error) {
+	ret := _m.Called(ctx, email, username, displayName, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoginWithVerifiedEmail")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, session.Device) (string, error)); ok {
+		return rf(ctx, email, username, displayName, device)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
-		r0 = rf(ctx, username, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, session.Device) string); ok {
+		r0 = rf(ctx, email, username, displayName, device)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, username, password)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, session.Device) error); ok {
+		r1 = rf(ctx, email, username, displayName, device)
 	} else {
 		r1 = ret.Error(1)
 	}
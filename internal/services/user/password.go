@@ -0,0 +1,48 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+)
+
+// ChangePassword replaces the password of a user after verifying the current password.
+// It is also the way out of a password reset forced by an administrator, since Login refuses
+// to issue tokens until the password has been changed.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout
+//   - username: Username of the account whose password is changed
+//   - currentPassword: Plain text current password to verify against the stored hash
+//   - newPassword: Plain text new password to hash and store
+//
+// Returns:
+//   - error: Returns ErrClientErr if the credentials are invalid, ErrUserDisabled if the
+//     account is disabled, a passwordpolicy.RejectedError if the new password is rejected,
+//     or an error if the update fails
+func (u *user) ChangePassword(ctx context.Context, username, currentPassword, newPassword string) error {
+	user, err := u.repo.GetUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, dbutils.ErrNotFoundType) {
+			return ErrClientErr
+		}
+		return err
+	}
+	if !u.hasher.VerifyPassword(currentPassword, user.Password) {
+		return ErrClientErr
+	}
+	if user.Disabled {
+		return ErrUserDisabled
+	}
+	if err := u.checkPassword(ctx, newPassword, user.Username, user.Email); err != nil {
+		return err
+	}
+
+	hashedPassword, err := u.hasher.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	return u.repo.UpdatePassword(ctx, user.ID, hashedPassword)
+}
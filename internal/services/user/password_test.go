@@ -0,0 +1,170 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	mockRepo "github.com/luongtruong20201/bookmark-management/internal/repositories/user/mocks"
+	"github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy"
+	mockPolicy "github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy/mocks"
+	"github.com/luongtruong20201/bookmark-management/pkg/dbutils"
+	mockUtils "github.com/luongtruong20201/bookmark-management/pkg/utils/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserService_ChangePassword(t *testing.T) {
+	t.Parallel()
+
+	const (
+		mockUserID          = "550e8400-e29b-41d4-a716-446655440000"
+		mockHashedPassword  = "$2a$10$7EqJtq98hPqEX7fNZaFWoOHi6rS8nY7b1p6K5j5p6v5Q5Z5Z5Z5e"
+		mockNewHashPassword = "$2a$10$newhashnewhashnewhashnewhashnewhashnewhashnewhashnew"
+	)
+
+	testErrDatabase := errors.New("database error")
+	weakViolations := []passwordpolicy.Violation{{Code: passwordpolicy.ViolationTooWeak, Message: "password is too easy to guess"}}
+
+	existingUser := &model.User{
+		Base:                  model.Base{ID: mockUserID},
+		Username:              "johndoe",
+		Password:              mockHashedPassword,
+		PasswordResetRequired: true,
+	}
+
+	testCases := []struct {
+		name            string
+		setupMockRepo   func(t *testing.T, ctx context.Context) *mockRepo.User
+		setupMockHasher func(t *testing.T) *mockUtils.Hasher
+		setupMockPolicy func(t *testing.T, ctx context.Context) *mockPolicy.Policy
+		expectedError   error
+	}{
+		{
+			name: "success",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByUsername", ctx, "johndoe").Return(existingUser, nil).Once()
+				repoMock.On("UpdatePassword", ctx, mockUserID, mockNewHashPassword).Return(nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("VerifyPassword", "old-password", mockHashedPassword).Return(true).Once()
+				hasherMock.On("HashPassword", "new-password").Return(mockNewHashPassword, nil).Once()
+				return hasherMock
+			},
+			setupMockPolicy: func(t *testing.T, ctx context.Context) *mockPolicy.Policy {
+				policyMock := mockPolicy.NewPolicy(t)
+				policyMock.On("Check", ctx, "new-password", "johndoe", "").
+					Return(&passwordpolicy.Result{Violations: []passwordpolicy.Violation{}}, nil).Once()
+				return policyMock
+			},
+		},
+		{
+			name: "error - user not found",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByUsername", ctx, "johndoe").Return(nil, dbutils.ErrNotFoundType).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				return mockUtils.NewHasher(t)
+			},
+			setupMockPolicy: func(t *testing.T, ctx context.Context) *mockPolicy.Policy {
+				return mockPolicy.NewPolicy(t)
+			},
+			expectedError: ErrClientErr,
+		},
+		{
+			name: "error - wrong current password",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByUsername", ctx, "johndoe").Return(existingUser, nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("VerifyPassword", "old-password", mockHashedPassword).Return(false).Once()
+				return hasherMock
+			},
+			setupMockPolicy: func(t *testing.T, ctx context.Context) *mockPolicy.Policy {
+				return mockPolicy.NewPolicy(t)
+			},
+			expectedError: ErrClientErr,
+		},
+		{
+			name: "error - disabled user",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByUsername", ctx, "johndoe").
+					Return(&model.User{Base: model.Base{ID: mockUserID}, Password: mockHashedPassword, Disabled: true}, nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("VerifyPassword", "old-password", mockHashedPassword).Return(true).Once()
+				return hasherMock
+			},
+			setupMockPolicy: func(t *testing.T, ctx context.Context) *mockPolicy.Policy {
+				return mockPolicy.NewPolicy(t)
+			},
+			expectedError: ErrUserDisabled,
+		},
+		{
+			name: "error - update fails",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByUsername", ctx, "johndoe").Return(existingUser, nil).Once()
+				repoMock.On("UpdatePassword", ctx, mockUserID, mockNewHashPassword).Return(testErrDatabase).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("VerifyPassword", "old-password", mockHashedPassword).Return(true).Once()
+				hasherMock.On("HashPassword", "new-password").Return(mockNewHashPassword, nil).Once()
+				return hasherMock
+			},
+			setupMockPolicy: func(t *testing.T, ctx context.Context) *mockPolicy.Policy {
+				policyMock := mockPolicy.NewPolicy(t)
+				policyMock.On("Check", ctx, "new-password", "johndoe", "").
+					Return(&passwordpolicy.Result{Violations: []passwordpolicy.Violation{}}, nil).Once()
+				return policyMock
+			},
+			expectedError: testErrDatabase,
+		},
+		{
+			name: "error - new password rejected",
+			setupMockRepo: func(t *testing.T, ctx context.Context) *mockRepo.User {
+				repoMock := mockRepo.NewUser(t)
+				repoMock.On("GetUserByUsername", ctx, "johndoe").Return(existingUser, nil).Once()
+				return repoMock
+			},
+			setupMockHasher: func(t *testing.T) *mockUtils.Hasher {
+				hasherMock := mockUtils.NewHasher(t)
+				hasherMock.On("VerifyPassword", "old-password", mockHashedPassword).Return(true).Once()
+				return hasherMock
+			},
+			setupMockPolicy: func(t *testing.T, ctx context.Context) *mockPolicy.Policy {
+				policyMock := mockPolicy.NewPolicy(t)
+				policyMock.On("Check", ctx, "new-password", "johndoe", "").
+					Return(&passwordpolicy.Result{Violations: weakViolations}, nil).Once()
+				return policyMock
+			},
+			expectedError: &passwordpolicy.RejectedError{Violations: weakViolations},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			svc := NewUser(tc.setupMockRepo(t, ctx), tc.setupMockHasher(t), nil, nil, tc.setupMockPolicy(t, ctx))
+
+			err := svc.ChangePassword(ctx, "johndoe", "old-password", "new-password")
+
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
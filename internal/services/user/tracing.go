@@ -0,0 +1,89 @@
+package user
+
+import (
+	"context"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
+	"github.com/luongtruong20201/bookmark-management/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedUser is a tracing decorator around the User service. Every call is recorded as a
+// span named "user.<method>". Passwords and tokens are never recorded.
+type tracedUser struct {
+	User
+}
+
+// NewTracedUser creates a user service recording a span for each call to s.
+func NewTracedUser(s User) User {
+	return &tracedUser{
+		User: s,
+	}
+}
+
+// CreateUser records a span around the registration of a user.
+func (u *tracedUser) CreateUser(ctx context.Context, username, password, displayName, email string) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "user.CreateUser")
+	user, err := u.User.CreateUser(ctx, username, password, displayName, email)
+	if err == nil {
+		span.SetAttributes(attribute.String("user.id", user.ID))
+	}
+	tracing.End(span, err)
+
+	return user, err
+}
+
+// Login records a span around a password login.
+func (u *tracedUser) Login(ctx context.Context, login, password string, device sessionService.Device) (string, error) {
+	ctx, span := tracing.Start(ctx, "user.Login")
+	token, err := u.User.Login(ctx, login, password, device)
+	tracing.End(span, err)
+
+	return token, err
+}
+
+// LoginWithVerifiedEmail records a span around a login through an external identity provider.
+func (u *tracedUser) LoginWithVerifiedEmail(ctx context.Context, email, username, displayName string, device sessionService.Device) (string, error) {
+	ctx, span := tracing.Start(ctx, "user.LoginWithVerifiedEmail")
+	token, err := u.User.LoginWithVerifiedEmail(ctx, email, username, displayName, device)
+	tracing.End(span, err)
+
+	return token, err
+}
+
+// GetUserByID records a span around the lookup of a user.
+func (u *tracedUser) GetUserByID(ctx context.Context, id string) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "user.GetUserByID", attribute.String("user.id", id))
+	user, err := u.User.GetUserByID(ctx, id)
+	tracing.End(span, err)
+
+	return user, err
+}
+
+// UpdateUserProfile records a span around the update of a user profile.
+func (u *tracedUser) UpdateUserProfile(ctx context.Context, id, displayName, email string) (*model.User, error) {
+	ctx, span := tracing.Start(ctx, "user.UpdateUserProfile", attribute.String("user.id", id))
+	user, err := u.User.UpdateUserProfile(ctx, id, displayName, email)
+	tracing.End(span, err)
+
+	return user, err
+}
+
+// ChangePassword records a span around a password change.
+func (u *tracedUser) ChangePassword(ctx context.Context, username, currentPassword, newPassword string) error {
+	ctx, span := tracing.Start(ctx, "user.ChangePassword")
+	err := u.User.ChangePassword(ctx, username, currentPassword, newPassword)
+	tracing.End(span, err)
+
+	return err
+}
+
+// IsUserDisabled records a span around the check of the status of a user.
+func (u *tracedUser) IsUserDisabled(ctx context.Context, id string) (bool, error) {
+	ctx, span := tracing.Start(ctx, "user.IsUserDisabled", attribute.String("user.id", id))
+	disabled, err := u.User.IsUserDisabled(ctx, id)
+	tracing.End(span, err)
+
+	return disabled, err
+}
@@ -0,0 +1,137 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
+	"github.com/luongtruong20201/bookmark-management/internal/services/user/mocks"
+	"github.com/luongtruong20201/bookmark-management/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestTracedUser(t *testing.T) {
+	t.Parallel()
+
+	device := sessionService.Device{UserAgent: "test", IP: "127.0.0.1"}
+
+	testCases := []struct {
+		name         string
+		setupMock    func(svc *mocks.User)
+		call         func(ctx context.Context, svc User) error
+		expectedName string
+		expectedAttr *attribute.KeyValue
+		expectedErr  error
+	}{
+		{
+			name: "create user",
+			setupMock: func(svc *mocks.User) {
+				svc.On("CreateUser", mock.Anything, "john", "password", "John", "john@example.com").Return(&model.User{Base: model.Base{ID: "user-1"}}, nil)
+			},
+			call: func(ctx context.Context, svc User) error {
+				_, err := svc.CreateUser(ctx, "john", "password", "John", "john@example.com")
+				return err
+			},
+			expectedName: "user.CreateUser",
+			expectedAttr: &attribute.KeyValue{Key: "user.id", Value: attribute.StringValue("user-1")},
+		},
+		{
+			name: "login fails",
+			setupMock: func(svc *mocks.User) {
+				svc.On("Login", mock.Anything, "john", "password", device).Return("", ErrClientErr)
+			},
+			call: func(ctx context.Context, svc User) error {
+				_, err := svc.Login(ctx, "john", "password", device)
+				return err
+			},
+			expectedName: "user.Login",
+			expectedErr:  ErrClientErr,
+		},
+		{
+			name: "login with verified email",
+			setupMock: func(svc *mocks.User) {
+				svc.On("LoginWithVerifiedEmail", mock.Anything, "john@example.com", "john", "John", device).Return("token", nil)
+			},
+			call: func(ctx context.Context, svc User) error {
+				_, err := svc.LoginWithVerifiedEmail(ctx, "john@example.com", "john", "John", device)
+				return err
+			},
+			expectedName: "user.LoginWithVerifiedEmail",
+		},
+		{
+			name: "get user by ID",
+			setupMock: func(svc *mocks.User) {
+				svc.On("GetUserByID", mock.Anything, "user-1").Return(&model.User{}, nil)
+			},
+			call: func(ctx context.Context, svc User) error {
+				_, err := svc.GetUserByID(ctx, "user-1")
+				return err
+			},
+			expectedName: "user.GetUserByID",
+			expectedAttr: &attribute.KeyValue{Key: "user.id", Value: attribute.StringValue("user-1")},
+		},
+		{
+			name: "update user profile",
+			setupMock: func(svc *mocks.User) {
+				svc.On("UpdateUserProfile", mock.Anything, "user-1", "John", "john@example.com").Return(&model.User{}, nil)
+			},
+			call: func(ctx context.Context, svc User) error {
+				_, err := svc.UpdateUserProfile(ctx, "user-1", "John", "john@example.com")
+				return err
+			},
+			expectedName: "user.UpdateUserProfile",
+			expectedAttr: &attribute.KeyValue{Key: "user.id", Value: attribute.StringValue("user-1")},
+		},
+		{
+			name: "change password",
+			setupMock: func(svc *mocks.User) {
+				svc.On("ChangePassword", mock.Anything, "john", "current", "new").Return(nil)
+			},
+			call: func(ctx context.Context, svc User) error {
+				return svc.ChangePassword(ctx, "john", "current", "new")
+			},
+			expectedName: "user.ChangePassword",
+		},
+		{
+			name: "is user disabled",
+			setupMock: func(svc *mocks.User) {
+				svc.On("IsUserDisabled", mock.Anything, "user-1").Return(false, nil)
+			},
+			call: func(ctx context.Context, svc User) error {
+				_, err := svc.IsUserDisabled(ctx, "user-1")
+				return err
+			},
+			expectedName: "user.IsUserDisabled",
+			expectedAttr: &attribute.KeyValue{Key: "user.id", Value: attribute.StringValue("user-1")},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, spans := tracing.InitMockTracer(t)
+			svc := mocks.NewUser(t)
+			tc.setupMock(svc)
+
+			err := tc.call(ctx, NewTracedUser(svc))
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			recorded := spans()
+			assert.Len(t, recorded, 1)
+			assert.Equal(t, tc.expectedName, recorded[0].Name)
+			if tc.expectedAttr != nil {
+				assert.Contains(t, recorded[0].Attributes, *tc.expectedAttr)
+			}
+			if tc.expectedErr != nil {
+				assert.Equal(t, codes.Error, recorded[0].Status.Code)
+			} else {
+				assert.Equal(t, codes.Unset, recorded[0].Status.Code)
+			}
+		})
+	}
+}
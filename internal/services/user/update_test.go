@@ -90,7 +90,7 @@ func TestUserService_UpdateUserProfile(t *testing.T) {
 
 			ctx := context.Background()
 			repoMock := tc.setupMockRepo(t, ctx, tc.userID, tc.displayName, tc.email)
-			svc := NewUser(repoMock, nil, nil)
+			svc := NewUser(repoMock, nil, nil, nil, nil)
 
 			result, err := svc.UpdateUserProfile(ctx, tc.userID, tc.displayName, tc.email)
 
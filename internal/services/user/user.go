@@ -7,6 +7,8 @@ import (
 
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
 	repository "github.com/luongtruong20201/bookmark-management/internal/repositories/user"
+	"github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy"
+	sessionService "github.com/luongtruong20201/bookmark-management/internal/services/session"
 	jwtPkg "github.com/luongtruong20201/bookmark-management/pkg/jwt"
 	"github.com/luongtruong20201/bookmark-management/pkg/utils"
 )
@@ -17,9 +19,23 @@ const (
 	tokenExpiresTime = 24 * time.Hour
 )
 
-// ErrClientErr is returned when user authentication fails due to invalid credentials.
-// It indicates that the provided username or password is incorrect.
-var ErrClientErr = errors.New("invalid username or password")
+var (
+	// ErrClientErr is returned when user authentication fails due to invalid credentials.
+	// It indicates that the provided username or password is incorrect.
+	ErrClientErr = errors.New("invalid username or password")
+	// ErrUserDisabled is returned when the account has been disabled by an administrator.
+	ErrUserDisabled = errors.New("user account is disabled")
+	// ErrPasswordResetRequired is returned by Login when an administrator has forced a
+	// password reset; the user must change the password before logging in.
+	ErrPasswordResetRequired = errors.New("password reset required")
+	// ErrInvalidUsername is returned by CreateUser when the username contains '@'. Such a
+	// username could match the email address of another account at login.
+	ErrInvalidUsername = errors.New("username must not contain '@'")
+	// ErrEmailNotVerified is returned by LoginWithVerifiedEmail when an account uses the
+	// email but has not verified it. Anyone may register an email address, so linking
+	// the external identity to that account would hand it to whoever registered it.
+	ErrEmailNotVerified = errors.New("an account already uses this email address without having verified it; log in with its password")
+)
 
 // User defines the interface for user service operations.
 // It provides methods to handle user-related business logic including user creation,
@@ -28,14 +44,20 @@ var ErrClientErr = errors.New("invalid username or password")
 //go:generate mockery --name User --filename user_service.go
 type User interface {
 	// CreateUser creates a new user account with hashed password.
-	// It validates input, hashes the password, and persists the user to the database.
-	// Returns the created user with generated UUID or an error if creation fails.
+	// It checks the password against the password policy, hashes it, and persists the user
+	// to the database. Returns the created user with generated UUID, a
+	// passwordpolicy.RejectedError if the password is rejected, or an error if creation fails.
 	CreateUser(ctx context.Context, username, password, displayName, email string) (*model.User, error)
 
-	// Login authenticates a user with username and password.
-	// It verifies credentials, and upon successful authentication, generates and returns a JWT token.
+	// Login authenticates a user with a username or email address and a password.
+	// It verifies credentials, and upon successful authentication, opens a session for the
+	// device and generates and returns a JWT token bound to it.
 	// Returns the JWT token string or an error if authentication fails.
-	Login(ctx context.Context, username, password string) (string, error)
+	Login(ctx context.Context, login, password string, device sessionService.Device) (string, error)
+
+	// LoginWithVerifiedEmail signs in the user owning an email address verified by an external
+	// identity provider. The account is created on first login. Returns a JWT token.
+	LoginWithVerifiedEmail(ctx context.Context, email, username, displayName string, device sessionService.Device) (string, error)
 
 	// GetUserByID retrieves a user by their unique identifier.
 	// Returns the user information or an error if the user is not found.
@@ -44,23 +66,37 @@ type User interface {
 	// UpdateUserProfile updates the display name and email of a user identified by ID.
 	// Returns the updated user information or an error if the update fails.
 	UpdateUserProfile(ctx context.Context, id, displayName, email string) (*model.User, error)
+
+	// ChangePassword replaces the password of the user after verifying the current one.
+	// The new password must meet the password policy. It also clears a password reset forced
+	// by an administrator.
+	ChangePassword(ctx context.Context, username, currentPassword, newPassword string) error
+
+	// IsUserDisabled reports whether the account identified by ID has been disabled.
+	IsUserDisabled(ctx context.Context, id string) (bool, error)
 }
 
 // user implements the User interface and provides business logic for user operations.
-// It encapsulates dependencies for repository access, password hashing, and JWT token generation.
+// It encapsulates dependencies for repository access, password hashing, JWT token generation,
+// the sessions opened at login, and the password policy.
 type user struct {
 	repo         repository.User
 	hasher       utils.Hasher
 	jwtGenerator jwtPkg.JWTGenerator
+	sessions     sessionService.Service
+	policy       passwordpolicy.Policy
 }
 
 // NewUser creates a new user service instance with the provided dependencies.
-// It initializes the service with a user repository, password hasher, and JWT generator.
+// It initializes the service with a user repository, password hasher, JWT generator,
+// session service, and password policy.
 //
 // Parameters:
 //   - repo: Repository interface for database operations
 //   - hasher: Hasher interface for password hashing and verification
 //   - jwtGenerator: JWT generator for creating authentication tokens
+//   - sessions: Session service recording every login
+//   - policy: Password policy applied to new and changed passwords
 //
 // Returns:
 //   - User: A new user service instance implementing the User interface
@@ -68,10 +104,14 @@ func NewUser(
 	repo repository.User,
 	hasher utils.Hasher,
 	jwtGenerator jwtPkg.JWTGenerator,
+	sessions sessionService.Service,
+	policy passwordpolicy.Policy,
 ) User {
 	return &user{
 		repo:         repo,
 		hasher:       hasher,
 		jwtGenerator: jwtGenerator,
+		sessions:     sessions,
+		policy:       policy,
 	}
 }
@@ -0,0 +1,42 @@
+package endpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/api/middlewares"
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessLogEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	app := newSessionTestApp(t)
+	token := loginFrom(t, app, "duc.pham", "P@ssw0rd4", "access-log-test")
+
+	var buf bytes.Buffer
+	req := httptest.NewRequest(http.MethodGet, "/v1/bookmarks", nil)
+	req = req.WithContext(logger.WithContext(req.Context(), zerolog.New(&buf)))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(middlewares.RequestIDHeader, "access-log-request")
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "access-log-request", rec.Header().Get(middlewares.RequestIDHeader))
+
+	var line map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "access-log-request", line["request_id"])
+	assert.NotEmpty(t, line["uid"])
+	assert.Equal(t, "/v1/bookmarks", line["route"])
+	assert.Equal(t, float64(http.StatusOK), line["status"])
+	assert.Equal(t, float64(rec.Body.Len()), line["bytes"])
+}
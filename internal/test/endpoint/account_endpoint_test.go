@@ -0,0 +1,85 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountEndpoint_Export(t *testing.T) {
+	t.Parallel()
+
+	app, _ := newAdminTestApp(t)
+
+	rec := serveJSON(app, http.MethodPost, "/v1/links/shorten", regularToken, map[string]any{
+		"url": "https://truonglq.com",
+		"exp": 3600,
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var shortened map[string]string
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &shortened))
+
+	rec = serveJSON(app, http.MethodGet, "/v1/self/export/status", regularToken, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = serveJSON(app, http.MethodPost, "/v1/self/export?format=json", regularToken, nil)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	assert.Eventually(t, func() bool {
+		rec := serveJSON(app, http.MethodGet, "/v1/self/export/status", regularToken, nil)
+		var job map[string]any
+		_ = json.Unmarshal(rec.Body.Bytes(), &job)
+		return job["status"] == "completed"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	rec = serveJSON(app, http.MethodGet, "/v1/self/export", regularToken, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var archive struct {
+		Profile    map[string]any      `json:"profile"`
+		Bookmarks  []map[string]any    `json:"bookmarks"`
+		ShortLinks []map[string]string `json:"short_links"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &archive))
+	assert.Equal(t, regularUserID, archive.Profile["id"])
+	assert.NotContains(t, archive.Profile, "password")
+	assert.NotNil(t, archive.Bookmarks)
+	assert.Equal(t, []map[string]string{{"code": shortened["code"], "url": "https://truonglq.com"}}, archive.ShortLinks)
+
+	rec = serveJSON(app, http.MethodGet, "/v1/self/export", adminToken, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAccountEndpoint_Deletion(t *testing.T) {
+	t.Parallel()
+
+	app, _ := newAdminTestApp(t)
+
+	rec := serveJSON(app, http.MethodDelete, "/v1/self", regularToken, map[string]any{"password": "wrong"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serveJSON(app, http.MethodDelete, "/v1/self/deletion", regularToken, nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = serveJSON(app, http.MethodDelete, "/v1/self", regularToken, map[string]any{"password": "P@ssw0rd4"})
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	var scheduled struct {
+		DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &scheduled))
+	assert.True(t, scheduled.DeletionScheduledAt.After(time.Now().Add(29*24*time.Hour)))
+
+	rec = serveJSON(app, http.MethodGet, "/v1/self/info", regularToken, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "deletion_scheduled_at")
+
+	rec = serveJSON(app, http.MethodDelete, "/v1/self/deletion", regularToken, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveJSON(app, http.MethodGet, "/v1/self/info", regularToken, nil)
+	assert.NotContains(t, rec.Body.String(), "deletion_scheduled_at")
+}
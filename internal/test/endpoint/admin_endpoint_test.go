@@ -0,0 +1,255 @@
+package endpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/luongtruong20201/bookmark-management/internal/api"
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/internal/test/fixture"
+	jwtMocks "github.com/luongtruong20201/bookmark-management/pkg/jwt/mocks"
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+const (
+	adminToken   = "admin-token"
+	regularToken = "regular-token"
+	// regularUserID identifies a seeded user without administrator privileges (duc.pham).
+	regularUserID = "7a9f2d41-5b4c-4f3e-9d21-3e8c6a5b4f72"
+)
+
+// newAdminTestApp builds an API backed by the administration fixture whose validator
+// accepts one administrator token and one regular user token, each bound to an open session.
+func newAdminTestApp(t *testing.T) (api.Engine, *gorm.DB) {
+	db := fixture.NewFixture(t, &fixture.UserAdminTestDB{})
+
+	validator := jwtMocks.NewJWTValidator(t)
+	validator.On("ValidateToken", adminToken).Return(jwt.MapClaims{
+		"sub":  fixture.AdminUserID,
+		"sid":  sessionIDOf(fixture.AdminUserID),
+		"role": model.RoleAdmin,
+	}, nil).Maybe()
+	validator.On("ValidateToken", regularToken).Return(jwt.MapClaims{
+		"sub":  regularUserID,
+		"sid":  sessionIDOf(regularUserID),
+		"role": model.RoleUser,
+	}, nil).Maybe()
+
+	generator := jwtMocks.NewJWTGenerator(t)
+	generator.On("GenerateToken", mock.Anything).Return("mock-token", nil).Maybe()
+
+	redis := redisPkg.InitMockRedis(t)
+	openSessions(t, db, redis)
+
+	app := api.New(&api.EngineOpts{
+		Engine:       gin.New(),
+		Cfg:          &api.Config{AppPort: "8080", ServiceName: "bookmark-service", InstanceId: "instance-1"},
+		DB:           db,
+		Redis:        redis,
+		JWTGenerator: generator,
+		JWTValidator: validator,
+	})
+
+	return app, db
+}
+
+// serveJSON sends a request to the API with an optional bearer token and JSON body.
+func serveJSON(app api.Engine, method, path, token string, body any) *httptest.ResponseRecorder {
+	var reader *bytes.Reader
+	if body != nil {
+		jsBody, _ := json.Marshal(body)
+		reader = bytes.NewReader(jsBody)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestAdminEndpoint_ListUsers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		token          string
+		query          string
+		expectedStatus int
+		verifyBody     func(t *testing.T, body map[string]any)
+	}{
+		{
+			name:           "success - list all users",
+			token:          adminToken,
+			query:          "?pageSize=5",
+			expectedStatus: http.StatusOK,
+			verifyBody: func(t *testing.T, body map[string]any) {
+				data := body["data"].([]any)
+				assert.Len(t, data, 5)
+				pagination := body["pagination"].(map[string]any)
+				assert.Equal(t, float64(11), pagination["total"])
+			},
+		},
+		{
+			name:           "success - search users",
+			token:          adminToken,
+			query:          "?search=BINH",
+			expectedStatus: http.StatusOK,
+			verifyBody: func(t *testing.T, body map[string]any) {
+				data := body["data"].([]any)
+				assert.Len(t, data, 1)
+				user := data[0].(map[string]any)
+				assert.Equal(t, "binh.tran", user["username"])
+				assert.Equal(t, true, user["disabled"])
+				_, exists := user["password"]
+				assert.False(t, exists)
+			},
+		},
+		{
+			name:           "error - not an administrator",
+			token:          regularToken,
+			expectedStatus: http.StatusForbidden,
+			verifyBody: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "forbidden", body["code"])
+				assert.Equal(t, "Insufficient permissions", body["message"])
+			},
+		},
+		{
+			name:           "error - missing token",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			app, _ := newAdminTestApp(t)
+			rec := serveJSON(app, http.MethodGet, "/v1/admin/users"+tc.query, tc.token, nil)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.verifyBody != nil {
+				var body map[string]any
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				tc.verifyBody(t, body)
+			}
+		})
+	}
+}
+
+func TestAdminEndpoint_DisableAndEnableUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	app, db := newAdminTestApp(t)
+
+	rec := serveJSON(app, http.MethodGet, "/v1/self/info", regularToken, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveJSON(app, http.MethodPut, "/v1/admin/users/"+regularUserID+"/disable", adminToken, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var user model.User
+	assert.NoError(t, db.Where("id = ?", regularUserID).First(&user).Error)
+	assert.True(t, user.Disabled)
+
+	rec = serveJSON(app, http.MethodGet, "/v1/self/info", regularToken, nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = serveJSON(app, http.MethodPost, "/v1/users/login", "", map[string]any{
+		"username": "duc.pham",
+		"password": "P@ssw0rd4",
+	})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = serveJSON(app, http.MethodPut, "/v1/admin/users/"+regularUserID+"/enable", adminToken, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveJSON(app, http.MethodGet, "/v1/self/info", regularToken, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveJSON(app, http.MethodPut, "/v1/admin/users/"+fixture.AdminUserID+"/disable", adminToken, nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serveJSON(app, http.MethodPut, "/v1/admin/users/00000000-0000-0000-0000-000000000000/disable", adminToken, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAdminEndpoint_ForcePasswordReset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	app, db := newAdminTestApp(t)
+	credentials := map[string]any{
+		"username": "duc.pham",
+		"password": "P@ssw0rd4",
+	}
+
+	rec := serveJSON(app, http.MethodPost, "/v1/users/login", "", credentials)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveJSON(app, http.MethodPost, "/v1/admin/users/"+regularUserID+"/password-reset", adminToken, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveJSON(app, http.MethodPost, "/v1/users/login", "", credentials)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = serveJSON(app, http.MethodPut, "/v1/users/password", "", map[string]any{
+		"username":         "duc.pham",
+		"current_password": "P@ssw0rd4",
+		"new_password":     "Blue-Otter-Canyon-42",
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var user model.User
+	assert.NoError(t, db.Where("id = ?", regularUserID).First(&user).Error)
+	assert.False(t, user.PasswordResetRequired)
+
+	rec = serveJSON(app, http.MethodPost, "/v1/users/login", "", map[string]any{
+		"username": "duc.pham",
+		"password": "Blue-Otter-Canyon-42",
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestAdminEndpoint_GetStats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	app, _ := newAdminTestApp(t)
+
+	rec := serveJSON(app, http.MethodPost, "/v1/links/shorten", "", map[string]any{
+		"url": "https://example.com",
+		"exp": 3600,
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveJSON(app, http.MethodGet, "/v1/admin/stats", adminToken, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var body map[string]any
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, map[string]any{
+		"users":     float64(11),
+		"bookmarks": float64(8),
+		"links":     float64(1),
+	}, body)
+
+	rec = serveJSON(app, http.MethodGet, "/v1/admin/stats", regularToken, nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
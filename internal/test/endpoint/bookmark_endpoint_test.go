@@ -62,6 +62,7 @@ func TestBookmarkEndpoint_CreateBookmark(t *testing.T) {
 				token := "valid-bookmark-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -115,7 +116,7 @@ func TestBookmarkEndpoint_CreateBookmark(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				errorMsg, ok := body["error"].(string)
+				errorMsg, ok := body["message"].(string)
 				assert.True(t, ok)
 				assert.Equal(t, "Authorization header is required", errorMsg)
 			},
@@ -143,6 +144,7 @@ func TestBookmarkEndpoint_CreateBookmark(t *testing.T) {
 				token := "invalid-body-bookmark-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -161,6 +163,7 @@ func TestBookmarkEndpoint_CreateBookmark(t *testing.T) {
 			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
 			jwtGen, jwtVal, token := tc.setupJWT(t, mockUserID)
 			redis := redisPkg.InitMockRedis(t)
+			openSessions(t, db, redis)
 
 			app := api.New(&api.EngineOpts{
 				Engine:       gin.New(),
@@ -235,6 +238,7 @@ func TestBookmarkEndpoint_GetBookmarks(t *testing.T) {
 				token := "valid-get-bookmarks-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -280,6 +284,7 @@ func TestBookmarkEndpoint_GetBookmarks(t *testing.T) {
 				token := "valid-get-bookmarks-default-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -311,6 +316,7 @@ func TestBookmarkEndpoint_GetBookmarks(t *testing.T) {
 				token := "valid-get-bookmarks-page2-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -345,9 +351,10 @@ func TestBookmarkEndpoint_GetBookmarks(t *testing.T) {
 				generator := jwtMocks.NewJWTGenerator(t)
 				validator := jwtMocks.NewJWTValidator(t)
 				token := "valid-get-bookmarks-empty-token"
-				emptyUserID := "00000000-0000-0000-0000-000000000000"
+				emptyUserID := "7a9f2d41-5b4c-4f3e-9d21-3e8c6a5b4f72"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": emptyUserID,
+					"sid": sessionIDOf(emptyUserID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -383,6 +390,7 @@ func TestBookmarkEndpoint_GetBookmarks(t *testing.T) {
 				anotherUserID := "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": anotherUserID,
+					"sid": sessionIDOf(anotherUserID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -426,7 +434,7 @@ func TestBookmarkEndpoint_GetBookmarks(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				errorMsg, ok := body["error"].(string)
+				errorMsg, ok := body["message"].(string)
 				assert.True(t, ok)
 				assert.Equal(t, "Authorization header is required", errorMsg)
 			},
@@ -448,6 +456,7 @@ func TestBookmarkEndpoint_GetBookmarks(t *testing.T) {
 				token := "invalid-pagination-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -474,6 +483,7 @@ func TestBookmarkEndpoint_GetBookmarks(t *testing.T) {
 				token := "invalid-pagesize-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -492,6 +502,7 @@ func TestBookmarkEndpoint_GetBookmarks(t *testing.T) {
 			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
 			jwtGen, jwtVal, token := tc.setupJWT(t, mockUserID)
 			redis := redisPkg.InitMockRedis(t)
+			openSessions(t, db, redis)
 
 			app := api.New(&api.EngineOpts{
 				Engine:       gin.New(),
@@ -572,6 +583,7 @@ func TestBookmarkEndpoint_UpdateBookmark(t *testing.T) {
 				token := "valid-update-bookmark-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -613,6 +625,7 @@ func TestBookmarkEndpoint_UpdateBookmark(t *testing.T) {
 				token := "valid-update-desc-only-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -654,6 +667,7 @@ func TestBookmarkEndpoint_UpdateBookmark(t *testing.T) {
 				token := "valid-update-notfound-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -662,7 +676,7 @@ func TestBookmarkEndpoint_UpdateBookmark(t *testing.T) {
 			},
 			expectedStatus: http.StatusNotFound,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				assert.Equal(t, "Bookmark not found", body["message"])
+				assert.Equal(t, "Resource not found", body["message"])
 			},
 		},
 		{
@@ -688,6 +702,7 @@ func TestBookmarkEndpoint_UpdateBookmark(t *testing.T) {
 				token := "valid-update-different-user-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": fixtureUserIDJohnDoe,
+					"sid": sessionIDOf(fixtureUserIDJohnDoe),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -696,7 +711,7 @@ func TestBookmarkEndpoint_UpdateBookmark(t *testing.T) {
 			},
 			expectedStatus: http.StatusNotFound,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				assert.Equal(t, "Bookmark not found", body["message"])
+				assert.Equal(t, "Resource not found", body["message"])
 			},
 		},
 		{
@@ -722,7 +737,7 @@ func TestBookmarkEndpoint_UpdateBookmark(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				errorMsg, ok := body["error"].(string)
+				errorMsg, ok := body["message"].(string)
 				assert.True(t, ok)
 				assert.Equal(t, "Authorization header is required", errorMsg)
 			},
@@ -750,6 +765,7 @@ func TestBookmarkEndpoint_UpdateBookmark(t *testing.T) {
 				token := "valid-update-invalid-url-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -768,6 +784,7 @@ func TestBookmarkEndpoint_UpdateBookmark(t *testing.T) {
 			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
 			jwtGen, jwtVal, token := tc.setupJWT(t, fixtureUserIDAnNguyen)
 			redis := redisPkg.InitMockRedis(t)
+			openSessions(t, db, redis)
 
 			app := api.New(&api.EngineOpts{
 				Engine:       gin.New(),
@@ -845,6 +862,7 @@ func TestBookmarkEndpoint_DeleteBookmark(t *testing.T) {
 				token := "valid-delete-bookmark-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -878,6 +896,7 @@ func TestBookmarkEndpoint_DeleteBookmark(t *testing.T) {
 				token := "valid-delete-notfound-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -886,7 +905,7 @@ func TestBookmarkEndpoint_DeleteBookmark(t *testing.T) {
 			},
 			expectedStatus: http.StatusNotFound,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				assert.Equal(t, "Bookmark not found", body["message"])
+				assert.Equal(t, "Resource not found", body["message"])
 			},
 		},
 		{
@@ -906,6 +925,7 @@ func TestBookmarkEndpoint_DeleteBookmark(t *testing.T) {
 				token := "valid-delete-different-user-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": fixtureUserIDJohnDoe,
+					"sid": sessionIDOf(fixtureUserIDJohnDoe),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -914,7 +934,7 @@ func TestBookmarkEndpoint_DeleteBookmark(t *testing.T) {
 			},
 			expectedStatus: http.StatusNotFound,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				assert.Equal(t, "Bookmark not found", body["message"])
+				assert.Equal(t, "Resource not found", body["message"])
 			},
 		},
 		{
@@ -934,7 +954,7 @@ func TestBookmarkEndpoint_DeleteBookmark(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				errorMsg, ok := body["error"].(string)
+				errorMsg, ok := body["message"].(string)
 				assert.True(t, ok)
 				assert.Equal(t, "Authorization header is required", errorMsg)
 			},
@@ -948,6 +968,7 @@ func TestBookmarkEndpoint_DeleteBookmark(t *testing.T) {
 			db := fixture.NewFixture(t, &fixture.BookmarkCommonTestDB{})
 			jwtGen, jwtVal, token := tc.setupJWT(t, fixtureUserIDAnNguyen)
 			redis := redisPkg.InitMockRedis(t)
+			openSessions(t, db, redis)
 
 			app := api.New(&api.EngineOpts{
 				Engine:       gin.New(),
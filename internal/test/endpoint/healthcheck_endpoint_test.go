@@ -1,15 +1,27 @@
 package endpoint
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/luongtruong20201/bookmark-management/internal/api"
+	healthcheckService "github.com/luongtruong20201/bookmark-management/internal/services/healthcheck"
+	"github.com/luongtruong20201/bookmark-management/internal/test/fixture"
+	"github.com/luongtruong20201/bookmark-management/migrations"
+	jwtPkg "github.com/luongtruong20201/bookmark-management/pkg/jwt"
+	"github.com/luongtruong20201/bookmark-management/pkg/migration"
 	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestHealthcheckEndPoint(t *testing.T) {
@@ -76,3 +88,206 @@ func TestHealthcheckEndPoint(t *testing.T) {
 		})
 	}
 }
+
+func TestHealthcheckEndPoint_GracefulShutdown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	// freePort returns a port no listener is bound to.
+	freePort := func() string {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		port := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+		assert.NoError(t, listener.Close())
+		return port
+	}
+	port, metricsPort := freePort(), freePort()
+
+	app := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg: &api.Config{
+			AppPort:         port,
+			MetricsPort:     metricsPort,
+			ServiceName:     "12345",
+			InstanceId:      "12345",
+			ShutdownDelay:   500 * time.Millisecond,
+			ShutdownTimeout: 5 * time.Second,
+		},
+		DB:    fixture.NewFixture(t, &fixture.UserAdminTestDB{}),
+		Redis: redisPkg.InitMockRedis(t),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Start(ctx)
+	}()
+
+	status := func(url string) int {
+		res, err := http.Get(url)
+		if err != nil {
+			return 0
+		}
+		defer res.Body.Close()
+		return res.StatusCode
+	}
+	healthStatus := func() int {
+		return status(fmt.Sprintf("http://127.0.0.1:%s/health-check", port))
+	}
+	metricsStatus := func() int {
+		return status(fmt.Sprintf("http://127.0.0.1:%s/metrics", metricsPort))
+	}
+
+	assert.Eventually(t, func() bool { return healthStatus() == http.StatusOK }, 5*time.Second, 20*time.Millisecond)
+	assert.Eventually(t, func() bool { return metricsStatus() == http.StatusOK }, 5*time.Second, 20*time.Millisecond)
+	cancel()
+	assert.Eventually(t, func() bool { return healthStatus() == http.StatusServiceUnavailable }, 400*time.Millisecond, 20*time.Millisecond)
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+	assert.Zero(t, healthStatus())
+	assert.Zero(t, metricsStatus())
+}
+
+func TestHealthcheckEndPoint_Probes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	latestVersion, err := migration.LatestVersion(migrations.FS)
+	assert.NoError(t, err)
+
+	// schemaAt returns a database whose schema is recorded at the given migration version.
+	schemaAt := func(version uint) func(t *testing.T) *gorm.DB {
+		return func(t *testing.T) *gorm.DB {
+			db := fixture.NewFixture(t, &fixture.UserAdminTestDB{})
+			assert.NoError(t, db.Exec("CREATE TABLE schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)").Error)
+			assert.NoError(t, db.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, false)", version).Error)
+			return db
+		}
+	}
+	migratedDB := schemaAt(latestVersion)
+
+	testCases := []struct {
+		name             string
+		path             string
+		setupDB          func(t *testing.T) *gorm.DB
+		setupRedis       func(t *testing.T) *redis.Client
+		expectedStatus   int
+		expectedReport   string
+		expectedFailures map[string]string
+	}{
+		{
+			name:           "live",
+			path:           "/livez",
+			setupDB:        migratedDB,
+			setupRedis:     redisPkg.InitMockRedis,
+			expectedStatus: http.StatusOK,
+			expectedReport: healthcheckService.StatusOK,
+		},
+		{
+			name:           "ready",
+			path:           "/readyz",
+			setupDB:        migratedDB,
+			setupRedis:     redisPkg.InitMockRedis,
+			expectedStatus: http.StatusOK,
+			expectedReport: healthcheckService.StatusOK,
+		},
+		{
+			name:    "redis down",
+			path:    "/readyz",
+			setupDB: migratedDB,
+			setupRedis: func(t *testing.T) *redis.Client {
+				redis := redisPkg.InitMockRedis(t)
+				_ = redis.Close()
+				return redis
+			},
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedReport:   healthcheckService.StatusNotOK,
+			expectedFailures: map[string]string{"redis": "unavailable"},
+		},
+		{
+			name:             "schema behind the latest migration",
+			path:             "/readyz",
+			setupDB:          schemaAt(latestVersion - 1),
+			setupRedis:       redisPkg.InitMockRedis,
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedReport:   healthcheckService.StatusNotOK,
+			expectedFailures: map[string]string{"migrations": "migration version is behind"},
+		},
+		{
+			name: "postgres down and not migrated",
+			path: "/readyz",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserAdminTestDB{})
+				sqlDB, err := db.DB()
+				assert.NoError(t, err)
+				_ = sqlDB.Close()
+				return db
+			},
+			setupRedis:       redisPkg.InitMockRedis,
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedReport:   healthcheckService.StatusNotOK,
+			expectedFailures: map[string]string{"postgres": "unavailable", "migrations": "unavailable"},
+		},
+		{
+			name: "live while postgres down",
+			path: "/livez",
+			setupDB: func(t *testing.T) *gorm.DB {
+				db := fixture.NewFixture(t, &fixture.UserAdminTestDB{})
+				sqlDB, err := db.DB()
+				assert.NoError(t, err)
+				_ = sqlDB.Close()
+				return db
+			},
+			setupRedis:     redisPkg.InitMockRedis,
+			expectedStatus: http.StatusOK,
+			expectedReport: healthcheckService.StatusOK,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			generator, err := jwtPkg.NewJWTGenerator("../../../pkg/jwt/private_test.pem")
+			assert.NoError(t, err)
+			validator, err := jwtPkg.NewJWTValidator("../../../pkg/jwt/public_test.pem")
+			assert.NoError(t, err)
+			app := api.New(&api.EngineOpts{
+				Engine:       gin.New(),
+				Cfg:          &api.Config{AppPort: "8080", ServiceName: "12345", InstanceId: "12345"},
+				DB:           tc.setupDB(t),
+				Redis:        tc.setupRedis(t),
+				JWTGenerator: generator,
+				JWTValidator: validator,
+			})
+
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			var report healthcheckService.Report
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedReport, report.Status)
+
+			var failures map[string]string
+			for _, check := range report.Checks {
+				if check.Status != healthcheckService.StatusOK {
+					if failures == nil {
+						failures = map[string]string{}
+					}
+					failures[check.Name] = check.Error
+				}
+			}
+			assert.Equal(t, tc.expectedFailures, failures)
+			if tc.path == "/readyz" {
+				assert.Len(t, report.Checks, 4)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/luongtruong20201/bookmark-management/internal/api"
+	"github.com/luongtruong20201/bookmark-management/internal/test/fixture"
+	jwtPkg "github.com/luongtruong20201/bookmark-management/pkg/jwt"
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWKSEndpoint(t *testing.T) {
+	t.Parallel()
+
+	// The active ECDSA key of the set signs tokens while a retired RSA key still verifies them.
+	dir := t.TempDir()
+	retiredID, err := jwtPkg.GenerateKey(dir, jwtPkg.AlgorithmRS256, 2048)
+	assert.NoError(t, err)
+	activeID, err := jwtPkg.GenerateKey(dir, jwtPkg.AlgorithmES256, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, jwtPkg.PromoteKey(dir, activeID))
+	assert.NoError(t, jwtPkg.RetireKey(dir, retiredID))
+	keySet, err := jwtPkg.LoadKeySetFromDir(dir)
+	assert.NoError(t, err)
+	cfg := &jwtPkg.Config{
+		Issuer:         "bookmark-service",
+		Audience:       "bookmark-service",
+		Leeway:         time.Minute,
+		RequiredClaims: []string{"sub", "exp", "iat"},
+	}
+
+	app := api.New(&api.EngineOpts{
+		Engine:       gin.New(),
+		Cfg:          &api.Config{AppPort: "8080", ServiceName: "bookmark-service", InstanceId: "instance-1"},
+		DB:           fixture.NewFixture(t, &fixture.UserAdminTestDB{}),
+		Redis:        redisPkg.InitMockRedis(t),
+		JWTGenerator: jwtPkg.NewKeySetGenerator(keySet, cfg),
+		JWTValidator: jwtPkg.NewKeySetValidator(keySet, cfg),
+	})
+
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.NotEmpty(t, rec.Header().Get("Cache-Control"))
+
+	var jwks jwtPkg.JWKS
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &jwks))
+	keys := make(map[string]jwtPkg.JWK, len(jwks.Keys))
+	for _, key := range jwks.Keys {
+		keys[key.Kid] = key
+	}
+	assert.Len(t, keys, 2)
+	assert.Equal(t, "RSA", keys[retiredID].Kty)
+	assert.Equal(t, "RS256", keys[retiredID].Alg)
+	assert.NotEmpty(t, keys[retiredID].N)
+	assert.Equal(t, "EC", keys[activeID].Kty)
+	assert.Equal(t, "ES256", keys[activeID].Alg)
+	assert.Equal(t, "P-256", keys[activeID].Crv)
+	assert.NotEmpty(t, keys[activeID].X)
+	assert.NotEmpty(t, keys[activeID].Y)
+
+	// Tokens issued at login name the active key, which is published above.
+	token := loginFrom(t, app, "duc.pham", "P@ssw0rd4", "laptop")
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, activeID, parsed.Header["kid"])
+	assert.Equal(t, "ES256", parsed.Header["alg"])
+	claims := parsed.Claims.(jwt.MapClaims)
+	assert.Equal(t, "bookmark-service", claims["iss"])
+	assert.Equal(t, "bookmark-service", claims["aud"])
+
+	rec = serveJSON(app, http.MethodGet, "/v1/self/info", token, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// A token signed with the same key for another service is rejected.
+	otherToken, err := jwtPkg.NewKeySetGenerator(keySet, &jwtPkg.Config{Issuer: cfg.Issuer, Audience: "other-service"}).
+		GenerateToken(jwt.MapClaims{"sub": claims["sub"], "iat": time.Now().Unix(), "exp": time.Now().Add(time.Hour).Unix()})
+	assert.NoError(t, err)
+	rec = serveJSON(app, http.MethodGet, "/v1/self/info", otherToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `Bearer error="invalid_token", error_description="The access token is not intended for this service"`, rec.Header().Get("WWW-Authenticate"))
+}
@@ -0,0 +1,60 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/pkg/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	app := newSessionTestApp(t)
+
+	rec := serveJSON(app, http.MethodPost, "/v1/users/login", "", map[string]string{"username": "duc.pham", "password": "wrong-password"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	token := loginFrom(t, app, "duc.pham", "P@ssw0rd4", "metrics-test")
+	rec = serveJSON(app, http.MethodPost, "/v1/bookmarks", token, map[string]string{"url": "https://example.com/metrics"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	for range 2 {
+		rec = serveJSON(app, http.MethodGet, "/v1/bookmarks", token, nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	rec = serveJSON(app, http.MethodPost, "/v1/links/shorten", "", map[string]any{"url": "https://example.com/metrics", "exp": 3600})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var shortened map[string]string
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &shortened))
+	rec = serveJSON(app, http.MethodGet, "/v1/links/redirect/"+shortened["code"], "", nil)
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+
+	// The metrics are served by their own listener, not by the API.
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	for _, series := range []string{
+		`bookmark_http_requests_total{method="POST",route="/v1/users/login",status="400"}`,
+		`bookmark_http_request_duration_seconds_count{method="GET",route="/v1/links/redirect/:code",status="301"}`,
+		`bookmark_login_failures_total{reason="invalid_credentials"}`,
+		`bookmark_cache_requests_total{cache="bookmarks",result="hit"}`,
+		`bookmark_cache_requests_total{cache="bookmarks",result="miss"}`,
+		`bookmark_bookmarks_created_total`,
+		`bookmark_links_shortened_total`,
+		`bookmark_redirects_served_total`,
+	} {
+		assert.Contains(t, body, series)
+	}
+}
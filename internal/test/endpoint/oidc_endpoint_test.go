@@ -0,0 +1,226 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/luongtruong20201/bookmark-management/internal/api"
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/internal/test/fixture"
+	jwtMocks "github.com/luongtruong20201/bookmark-management/pkg/jwt/mocks"
+	oidcPkg "github.com/luongtruong20201/bookmark-management/pkg/oidc"
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+const oidcRedirectURL = "http://localhost:8080/v1/users/oidc/company/callback"
+
+// followAuthorize calls the identity provider's authorization URL without following the
+// redirect back to the API and returns the callback query the provider would send.
+func followAuthorize(t *testing.T, authURL string) string {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatal("fail to call authorize endpoint: ", err)
+	}
+	defer resp.Body.Close()
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal("fail to parse redirect location: ", err)
+	}
+
+	return location.RawQuery
+}
+
+func TestOIDCEndpoint_Login(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	cfg := &api.Config{
+		AppPort:     "8080",
+		ServiceName: "bookmark-service",
+		InstanceId:  "instance-1",
+	}
+
+	testCases := []struct {
+		name           string
+		claims         jwt.MapClaims
+		setupDB        func(t *testing.T, db *gorm.DB)
+		setupCallback  func(t *testing.T, app api.Engine) string
+		expectedStatus int
+		verifyBody     func(t *testing.T, body map[string]any)
+		verifyUser     func(t *testing.T, db *gorm.DB)
+	}{
+		{
+			name: "success - existing user linked by verified email",
+			claims: jwt.MapClaims{
+				"sub":            "idp-john",
+				"email":          "john.doe@example.com",
+				"email_verified": true,
+			},
+			setupDB: func(t *testing.T, db *gorm.DB) {
+				err := db.Model(&model.User{}).Where("email = ?", "john.doe@example.com").Update("email_verified", true).Error
+				assert.NoError(t, err)
+			},
+			expectedStatus: http.StatusOK,
+			verifyBody: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "mock-token", body["token"])
+			},
+			verifyUser: func(t *testing.T, db *gorm.DB) {
+				var count int64
+				err := db.Model(&model.User{}).Where("email = ?", "john.doe@example.com").Count(&count).Error
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), count)
+			},
+		},
+		{
+			name: "success - new user created",
+			claims: jwt.MapClaims{
+				"sub":                "idp-jane",
+				"email":              "jane.roe@example.com",
+				"email_verified":     true,
+				"name":               "Jane Roe",
+				"preferred_username": "janeroe",
+			},
+			expectedStatus: http.StatusOK,
+			verifyBody: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "mock-token", body["token"])
+			},
+			verifyUser: func(t *testing.T, db *gorm.DB) {
+				var user model.User
+				err := db.Where("email = ?", "jane.roe@example.com").First(&user).Error
+				assert.NoError(t, err)
+				assert.Equal(t, "janeroe", user.Username)
+				assert.Equal(t, "Jane Roe", user.DisplayName)
+				assert.NotEmpty(t, user.Password)
+			},
+		},
+		{
+			name: "error - existing user has not verified the email",
+			claims: jwt.MapClaims{
+				"sub":            "idp-john",
+				"email":          "john.doe@example.com",
+				"email_verified": true,
+			},
+			expectedStatus: http.StatusConflict,
+			verifyBody: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "conflict", body["code"])
+				assert.Nil(t, body["token"])
+			},
+		},
+		{
+			name: "error - email not verified",
+			claims: jwt.MapClaims{
+				"sub":            "idp-john",
+				"email":          "john.doe@example.com",
+				"email_verified": false,
+			},
+			expectedStatus: http.StatusForbidden,
+			verifyBody: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "email is not verified by the identity provider", body["message"])
+			},
+		},
+		{
+			name: "error - unknown state",
+			setupCallback: func(t *testing.T, app api.Engine) string {
+				return "code=unknown-code&state=unknown-state"
+			},
+			expectedStatus: http.StatusBadRequest,
+			verifyBody: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "invalid or expired oidc state", body["message"])
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			idp := oidcPkg.InitMockIdP(t)
+			if tc.claims != nil {
+				idp.SetClaims(tc.claims)
+			}
+			provider, err := oidcPkg.NewProvider(ctx, idp.Config(oidcRedirectURL))
+			if err != nil {
+				t.Fatal("fail to create oidc provider: ", err)
+			}
+
+			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			if tc.setupDB != nil {
+				tc.setupDB(t, db)
+			}
+			generator := jwtMocks.NewJWTGenerator(t)
+			generator.On("GenerateToken", mock.Anything).Return("mock-token", nil).Maybe()
+			app := api.New(&api.EngineOpts{
+				Engine:        gin.New(),
+				Cfg:           cfg,
+				DB:            db,
+				Redis:         redisPkg.InitMockRedis(t),
+				JWTGenerator:  generator,
+				JWTValidator:  jwtMocks.NewJWTValidator(t),
+				OIDCProviders: map[string]oidcPkg.Provider{"company": provider},
+			})
+
+			var query string
+			if tc.setupCallback != nil {
+				query = tc.setupCallback(t, app)
+			} else {
+				req := httptest.NewRequest(http.MethodGet, "/v1/users/oidc/company/login", nil)
+				rec := httptest.NewRecorder()
+				app.ServeHTTP(rec, req)
+				assert.Equal(t, http.StatusFound, rec.Code)
+
+				query = followAuthorize(t, rec.Header().Get("Location"))
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/users/oidc/company/callback?"+query, nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			var body map[string]any
+			err = json.Unmarshal(rec.Body.Bytes(), &body)
+			assert.NoError(t, err)
+
+			if tc.verifyBody != nil {
+				tc.verifyBody(t, body)
+			}
+			if tc.verifyUser != nil {
+				tc.verifyUser(t, db)
+			}
+		})
+	}
+}
+
+func TestOIDCEndpoint_UnknownProvider(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	app := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg:    &api.Config{AppPort: "8080"},
+		Redis:  redisPkg.InitMockRedis(t),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/oidc/unknown/login", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
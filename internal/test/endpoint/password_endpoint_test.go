@@ -1,12 +1,15 @@
 package endpoint
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/luongtruong20201/bookmark-management/internal/api"
+	"github.com/luongtruong20201/bookmark-management/internal/services/passwordpolicy"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -37,6 +40,17 @@ func TestPasswordEndpoint(t *testing.T) {
 			expectedStatus:  http.StatusOK,
 			expectedRespLen: 10,
 		},
+		{
+			name: "success - custom length with symbols",
+			setupTestHTTP: func(api api.Engine) *httptest.ResponseRecorder {
+				req := httptest.NewRequest(http.MethodGet, "/gen-pass?length=24&symbols=true&require_each_class=true", nil)
+				respRec := httptest.NewRecorder()
+				api.ServeHTTP(respRec, req)
+				return respRec
+			},
+			expectedStatus:  http.StatusOK,
+			expectedRespLen: 24,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -48,8 +62,101 @@ func TestPasswordEndpoint(t *testing.T) {
 			})
 			rec := tc.setupTestHTTP(app)
 
-			assert.Equal(t, tc.expectedStatus, http.StatusOK)
+			assert.Equal(t, tc.expectedStatus, rec.Code)
 			assert.Equal(t, tc.expectedRespLen, len(rec.Body.Bytes()))
 		})
 	}
 }
+
+func TestPasswordEndpoint_Passphrase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	app := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg:    &api.Config{AppPort: "8080", ServiceName: "12345", InstanceId: "12345"},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/gen-pass?mode=passphrase&words=5&separator=_&format=json", nil)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res struct {
+		Password    string  `json:"password"`
+		Mode        string  `json:"mode"`
+		EntropyBits float64 `json:"entropy_bits"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Len(t, strings.Split(res.Password, "_"), 5)
+	assert.Equal(t, "passphrase", res.Mode)
+	assert.Equal(t, 51.7, res.EntropyBits)
+
+	req = httptest.NewRequest(http.MethodGet, "/gen-pass?words=2&mode=passphrase", nil)
+	rec = httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestPasswordEndpoint_CheckStrength(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	app := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg:    &api.Config{AppPort: "8080", ServiceName: "12345", InstanceId: "12345"},
+		PasswordPolicy: &passwordpolicy.Config{
+			MinLength:       8,
+			MinScore:        2,
+			BreachedListDir: "../../repositories/breach/testdata/range",
+		},
+	})
+
+	testCases := []struct {
+		name          string
+		body          map[string]any
+		expectedValid bool
+		expectedCodes []string
+	}{
+		{
+			name:          "strong password",
+			body:          map[string]any{"password": "Blue-Otter-Canyon-42", "username": "johndoe"},
+			expectedValid: true,
+			expectedCodes: []string{},
+		},
+		{
+			name:          "weak and breached password",
+			body:          map[string]any{"password": "password"},
+			expectedValid: false,
+			expectedCodes: []string{passwordpolicy.ViolationTooWeak, passwordpolicy.ViolationBreached},
+		},
+		{
+			name:          "short password containing the email",
+			body:          map[string]any{"password": "jdoe", "email": "jdoe@example.com"},
+			expectedValid: false,
+			expectedCodes: []string{passwordpolicy.ViolationTooShort, passwordpolicy.ViolationTooWeak, passwordpolicy.ViolationContainsUserInfo},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := serveJSON(app, http.MethodPost, "/v1/password/strength", "", tc.body)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var res struct {
+				Valid      bool                       `json:"valid"`
+				Score      int                        `json:"score"`
+				Violations []passwordpolicy.Violation `json:"violations"`
+			}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Equal(t, tc.expectedValid, res.Valid)
+			codes := make([]string, 0, len(res.Violations))
+			for _, violation := range res.Violations {
+				codes = append(codes, violation.Code)
+			}
+			assert.Equal(t, tc.expectedCodes, codes)
+		})
+	}
+}
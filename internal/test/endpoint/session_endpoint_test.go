@@ -0,0 +1,137 @@
+package endpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/api"
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/internal/repositories/session"
+	"github.com/luongtruong20201/bookmark-management/internal/test/fixture"
+	jwtPkg "github.com/luongtruong20201/bookmark-management/pkg/jwt"
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/luongtruong20201/bookmark-management/pkg/response"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// sessionIDOf returns the ID of the session opened by openSessions for the user, to be used
+// as the "sid" claim of tokens mocked for that user.
+func sessionIDOf(userID string) string {
+	return "session-" + userID
+}
+
+// openSessions opens a session for every user seeded in the database, so mocked tokens
+// carrying the "sid" claim returned by sessionIDOf pass the session check.
+func openSessions(t *testing.T, db *gorm.DB, client redis.UniversalClient) {
+	var users []*model.User
+	assert.NoError(t, db.Find(&users).Error)
+
+	storage := session.NewStorage(client)
+	for _, user := range users {
+		err := storage.Create(t.Context(), &session.Session{
+			ID:         sessionIDOf(user.ID),
+			UserID:     user.ID,
+			CreatedAt:  time.Now().UTC(),
+			LastSeenAt: time.Now().UTC(),
+		}, time.Hour)
+		assert.NoError(t, err)
+	}
+}
+
+// newSessionTestApp builds an API that signs and validates real tokens, so the session
+// bound to a token at login is checked on every authenticated request.
+func newSessionTestApp(t *testing.T) api.Engine {
+	generator, err := jwtPkg.NewJWTGenerator("../../../pkg/jwt/private_test.pem")
+	assert.NoError(t, err)
+	validator, err := jwtPkg.NewJWTValidator("../../../pkg/jwt/public_test.pem")
+	assert.NoError(t, err)
+
+	return api.New(&api.EngineOpts{
+		Engine:       gin.New(),
+		Cfg:          &api.Config{AppPort: "8080", ServiceName: "bookmark-service", InstanceId: "instance-1"},
+		DB:           fixture.NewFixture(t, &fixture.UserAdminTestDB{}),
+		Redis:        redisPkg.InitMockRedis(t),
+		JWTGenerator: generator,
+		JWTValidator: validator,
+	})
+}
+
+// loginFrom logs in with the given user agent and returns the issued token.
+func loginFrom(t *testing.T, app api.Engine, username, password, userAgent string) string {
+	body, _ := json.Marshal(map[string]string{"username": username, "password": password})
+	req := httptest.NewRequest(http.MethodPost, "/v1/users/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res map[string]string
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return res["token"]
+}
+
+func TestSessionEndpoint_ListAndRevoke(t *testing.T) {
+	t.Parallel()
+
+	app := newSessionTestApp(t)
+
+	phoneToken := loginFrom(t, app, "duc.pham", "P@ssw0rd4", "phone")
+	laptopToken := loginFrom(t, app, "duc.pham", "P@ssw0rd4", "laptop")
+	adminToken := loginFrom(t, app, "an.nguyen", "P@ssw0rd1", "admin-laptop")
+
+	rec := serveJSON(app, http.MethodGet, "/v1/self/sessions", laptopToken, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var list struct {
+		Data []struct {
+			ID        string `json:"id"`
+			UserAgent string `json:"user_agent"`
+			Current   bool   `json:"current"`
+		} `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Len(t, list.Data, 2)
+
+	var phoneSessionID string
+	for _, s := range list.Data {
+		switch s.UserAgent {
+		case "phone":
+			phoneSessionID = s.ID
+			assert.False(t, s.Current)
+		case "laptop":
+			assert.True(t, s.Current)
+		default:
+			t.Errorf("unexpected session %q", s.UserAgent)
+		}
+	}
+	assert.NotEmpty(t, phoneSessionID)
+
+	rec = serveJSON(app, http.MethodGet, "/v1/self/info", phoneToken, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveJSON(app, http.MethodDelete, "/v1/self/sessions/"+phoneSessionID, adminToken, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = serveJSON(app, http.MethodDelete, "/v1/self/sessions/"+phoneSessionID, laptopToken, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveJSON(app, http.MethodGet, "/v1/self/info", phoneToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	var problem response.Problem
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, response.CodeUnauthorized, problem.Code)
+	assert.Equal(t, "Session has been revoked or expired", problem.Message)
+
+	rec = serveJSON(app, http.MethodGet, "/v1/self/info", laptopToken, nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveJSON(app, http.MethodDelete, "/v1/self/sessions/"+phoneSessionID, laptopToken, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
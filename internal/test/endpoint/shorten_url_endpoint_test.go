@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/luongtruong20201/bookmark-management/internal/api"
+	"github.com/luongtruong20201/bookmark-management/internal/api/middlewares"
 	"github.com/luongtruong20201/bookmark-management/internal/test/fixture"
 	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
 	"github.com/redis/go-redis/v9"
@@ -93,7 +94,8 @@ func TestShortenURLEndpoint_ShortenURL(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				assert.Equal(t, body["message"], "Input Error")
+				assert.Equal(t, "invalid_input", body["code"])
+				assert.Equal(t, "Input error", body["message"])
 			},
 		},
 	}
@@ -152,9 +154,10 @@ func TestShortenURLEndpoint_GetURL(t *testing.T) {
 
 				return rec
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusNotFound,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				assert.Equal(t, body["message"], "url not found")
+				assert.Equal(t, "not_found", body["code"])
+				assert.Equal(t, "url not found", body["message"])
 			},
 		},
 		{
@@ -174,7 +177,8 @@ func TestShortenURLEndpoint_GetURL(t *testing.T) {
 			},
 			expectedStatus: http.StatusInternalServerError,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				assert.Equal(t, body["message"], "internal server error")
+				assert.Equal(t, "internal_error", body["code"])
+				assert.Equal(t, "Processing Error", body["message"])
 			},
 		},
 		{
@@ -230,9 +234,10 @@ func TestShortenURLEndpoint_GetURL(t *testing.T) {
 
 				return rec
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusNotFound,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				assert.Equal(t, body["message"], "url not found")
+				assert.Equal(t, "not_found", body["code"])
+				assert.Equal(t, "url not found", body["message"])
 			},
 		},
 		{
@@ -249,9 +254,10 @@ func TestShortenURLEndpoint_GetURL(t *testing.T) {
 
 				return rec
 			},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusNotFound,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				assert.Equal(t, body["message"], "url not found")
+				assert.Equal(t, "not_found", body["code"])
+				assert.Equal(t, "url not found", body["message"])
 			},
 		},
 	}
@@ -285,3 +291,53 @@ func TestShortenURLEndpoint_GetURL(t *testing.T) {
 		})
 	}
 }
+
+func TestShortenURLEndpoint_RateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	var policies middlewares.RateLimitPolicies
+	if err := policies.Decode("default=100/1m,shorten=2/1m"); err != nil {
+		t.Fatal("fail to decode rate limit policies: ", err)
+	}
+
+	app := api.New(&api.EngineOpts{
+		Engine: gin.New(),
+		Cfg:    &api.Config{AppPort: "8080"},
+		Redis:  redisPkg.InitMockRedis(t),
+		RateLimit: &middlewares.RateLimitConfig{
+			Enabled:  true,
+			Policies: policies,
+		},
+	})
+
+	shorten := func(remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/links/shorten", bytes.NewBufferString(`{"url":"https://example.com","exp":60}`))
+		req.Header.Set("Content-Type", "application/json")
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := range 2 {
+		rec := shorten("192.0.2.1:1234", "")
+		assert.Equal(t, http.StatusOK, rec.Code, "request %d", i+1)
+		assert.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+	}
+
+	rec := shorten("192.0.2.1:1234", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	// No proxy is trusted, so a forged X-Forwarded-For does not get a fresh bucket.
+	rec = shorten("192.0.2.1:1234", "198.51.100.7")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	rec = shorten("192.0.2.2:1234", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,77 @@
+package endpoint
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/luongtruong20201/bookmark-management/internal/api"
+	"github.com/luongtruong20201/bookmark-management/internal/test/fixture"
+	jwtPkg "github.com/luongtruong20201/bookmark-management/pkg/jwt"
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
+	"github.com/luongtruong20201/bookmark-management/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracingEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	generator, err := jwtPkg.NewJWTGenerator("../../../pkg/jwt/private_test.pem")
+	assert.NoError(t, err)
+	validator, err := jwtPkg.NewJWTValidator("../../../pkg/jwt/public_test.pem")
+	assert.NoError(t, err)
+	db := fixture.NewFixture(t, &fixture.UserAdminTestDB{})
+	assert.NoError(t, db.Use(tracing.NewGORMPlugin()))
+	redis := redisPkg.InitMockRedis(t)
+	redis.AddHook(tracing.NewRedisHook())
+
+	app := api.New(&api.EngineOpts{
+		Engine:       gin.New(),
+		Cfg:          &api.Config{AppPort: "8080", ServiceName: "bookmark-service", InstanceId: "instance-1"},
+		DB:           db,
+		Redis:        redis,
+		JWTGenerator: generator,
+		JWTValidator: validator,
+	})
+	token := loginFrom(t, app, "duc.pham", "P@ssw0rd4", "tracing-test")
+
+	ctx, spans := tracing.InitMockTracer(t)
+	req := httptest.NewRequest(http.MethodGet, "/v1/bookmarks", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	rec := httptest.NewRecorder()
+	app.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	recorded := spans()
+	names := make([]string, 0, len(recorded))
+	var server trace.SpanContext
+	redisSpans := 0
+	for _, span := range recorded {
+		names = append(names, span.Name)
+		if strings.HasPrefix(span.Name, "redis.") {
+			redisSpans++
+		}
+		if span.Name == "GET /v1/bookmarks" {
+			server = span.SpanContext
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind)
+			assert.Equal(t, trace.SpanContextFromContext(ctx).SpanID(), span.Parent.SpanID())
+		}
+	}
+	for _, name := range []string{"GET /v1/bookmarks", "bookmarkCache.GetBookmarks", "bookmarkSvc.GetBookmarks", "gorm.query"} {
+		assert.Contains(t, names, name)
+	}
+	assert.NotZero(t, redisSpans)
+	assert.True(t, server.IsValid())
+	for _, span := range recorded {
+		if span.Name == "bookmarkCache.GetBookmarks" {
+			assert.Equal(t, server.SpanID(), span.Parent.SpanID())
+		}
+	}
+}
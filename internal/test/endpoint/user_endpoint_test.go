@@ -3,9 +3,12 @@ package endpoint
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"errors"
 
@@ -13,9 +16,11 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/luongtruong20201/bookmark-management/internal/api"
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"github.com/luongtruong20201/bookmark-management/internal/services/loginlimit"
 	"github.com/luongtruong20201/bookmark-management/internal/test/fixture"
 	jwtPkg "github.com/luongtruong20201/bookmark-management/pkg/jwt"
 	jwtMocks "github.com/luongtruong20201/bookmark-management/pkg/jwt/mocks"
+	redisPkg "github.com/luongtruong20201/bookmark-management/pkg/redis"
 	sqldb "github.com/luongtruong20201/bookmark-management/pkg/sql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -44,7 +49,7 @@ func TestUserEndpoint_RegisterUser(t *testing.T) {
 			setupHTTP: func(api api.Engine) *httptest.ResponseRecorder {
 				body := map[string]any{
 					"username":     "johndoe",
-					"password":     "password123",
+					"password":     "Blue-Otter-Canyon-42",
 					"display_name": "John Doe",
 					"email":        "john.doe@example.com",
 				}
@@ -77,8 +82,8 @@ func TestUserEndpoint_RegisterUser(t *testing.T) {
 				assert.Equal(t, "John Doe", user.DisplayName)
 				assert.Equal(t, "john.doe@example.com", user.Email)
 				assert.NotEmpty(t, user.ID)
-				assert.NotEqual(t, "password123", user.Password)
-				assert.Contains(t, user.Password, "$2a$")
+				assert.NotEqual(t, "Blue-Otter-Canyon-42", user.Password)
+				assert.True(t, strings.HasPrefix(user.Password, "$argon2id$"))
 			},
 		},
 		{
@@ -107,7 +112,7 @@ func TestUserEndpoint_RegisterUser(t *testing.T) {
 			setupHTTP: func(api api.Engine) *httptest.ResponseRecorder {
 				body := map[string]any{
 					"username":     "johndoe",
-					"password":     "password123",
+					"password":     "Blue-Otter-Canyon-42",
 					"display_name": "John Doe",
 					"email":        "invalid-email",
 				}
@@ -127,11 +132,11 @@ func TestUserEndpoint_RegisterUser(t *testing.T) {
 			verifyUser: nil,
 		},
 		{
-			name: "invalid request body - password too short",
+			name: "password rejected by policy",
 			setupHTTP: func(api api.Engine) *httptest.ResponseRecorder {
 				body := map[string]any{
 					"username":     "johndoe",
-					"password":     "12345",
+					"password":     "johndoe1",
 					"display_name": "John Doe",
 					"email":        "john.doe@example.com",
 				}
@@ -146,7 +151,14 @@ func TestUserEndpoint_RegisterUser(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				assert.Equal(t, body["message"], "Input error")
+				assert.Equal(t, "password does not meet the password policy", body["message"])
+				details, ok := body["details"].([]any)
+				assert.True(t, ok)
+				codes := make([]any, 0, len(details))
+				for _, detail := range details {
+					codes = append(codes, detail.(map[string]any)["code"])
+				}
+				assert.Equal(t, []any{"too_weak", "contains_user_info"}, codes)
 			},
 			verifyUser: nil,
 		},
@@ -163,7 +175,8 @@ func TestUserEndpoint_RegisterUser(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				assert.Equal(t, body["message"], "Input Error")
+				assert.Equal(t, "invalid_input", body["code"])
+				assert.Equal(t, "Input error", body["message"])
 			},
 			verifyUser: nil,
 		},
@@ -172,7 +185,7 @@ func TestUserEndpoint_RegisterUser(t *testing.T) {
 			setupHTTP: func(api api.Engine) *httptest.ResponseRecorder {
 				body := map[string]any{
 					"username":     "existinguser",
-					"password":     "password123",
+					"password":     "Blue-Otter-Canyon-42",
 					"display_name": "Existing User",
 					"email":        "existinguser@example.com",
 				}
@@ -185,12 +198,71 @@ func TestUserEndpoint_RegisterUser(t *testing.T) {
 
 				return rec
 			},
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusConflict,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				assert.Equal(t, body["message"], "Processing Error")
+				assert.Equal(t, "conflict", body["code"])
+				assert.Equal(t, "username already taken", body["message"])
 			},
 			verifyUser: nil,
 		},
+		{
+			name: "duplicate username - different letter case",
+			setupHTTP: func(api api.Engine) *httptest.ResponseRecorder {
+				body := map[string]any{
+					"username":     "ExistingUser",
+					"password":     "Blue-Otter-Canyon-42",
+					"display_name": "Existing User",
+					"email":        "another@example.com",
+				}
+				jsBody, _ := json.Marshal(body)
+				req := httptest.NewRequest(http.MethodPost, "/v1/users/register", bytes.NewReader(jsBody))
+				req.Header.Set("Content-Type", "application/json")
+				rec := httptest.NewRecorder()
+
+				api.ServeHTTP(rec, req)
+
+				return rec
+			},
+			expectedStatus: http.StatusConflict,
+			verifyBody: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "conflict", body["code"])
+				assert.Equal(t, "username already taken", body["message"])
+			},
+			verifyUser: func(t *testing.T, db *gorm.DB) {
+				var count int64
+				db.Model(&model.User{}).Count(&count)
+				assert.Equal(t, int64(1), count)
+			},
+		},
+		{
+			name: "duplicate email - different letter case",
+			setupHTTP: func(api api.Engine) *httptest.ResponseRecorder {
+				body := map[string]any{
+					"username":     "anotheruser",
+					"password":     "Blue-Otter-Canyon-42",
+					"display_name": "Existing User",
+					"email":        "ExistingUser@Example.com",
+				}
+				jsBody, _ := json.Marshal(body)
+				req := httptest.NewRequest(http.MethodPost, "/v1/users/register", bytes.NewReader(jsBody))
+				req.Header.Set("Content-Type", "application/json")
+				rec := httptest.NewRecorder()
+
+				api.ServeHTTP(rec, req)
+
+				return rec
+			},
+			expectedStatus: http.StatusConflict,
+			verifyBody: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "conflict", body["code"])
+				assert.Equal(t, "email already taken", body["message"])
+			},
+			verifyUser: func(t *testing.T, db *gorm.DB) {
+				var count int64
+				db.Model(&model.User{}).Count(&count)
+				assert.Equal(t, int64(1), count)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -201,7 +273,7 @@ func TestUserEndpoint_RegisterUser(t *testing.T) {
 			if err := db.AutoMigrate(&model.User{}); err != nil {
 				t.Fatalf("failed to migrate user schema: %v", err)
 			}
-			if tc.name == "duplicate username" {
+			if strings.HasPrefix(tc.name, "duplicate ") {
 				existingUser := &model.User{
 					Base: model.Base{
 						ID: "550e8400-e29b-41d4-a716-446655440000",
@@ -290,6 +362,33 @@ func TestUserEndpoint_Login(t *testing.T) {
 				assert.NotEmpty(t, token, "token should not be empty")
 			},
 		},
+		{
+			name: "success - email address regardless of case",
+			setupHTTP: func(api api.Engine) *httptest.ResponseRecorder {
+				body := map[string]any{
+					"username": "John.Doe@Example.com",
+					"password": "P@ssw0rd11",
+				}
+				jsBody, _ := json.Marshal(body)
+				req := httptest.NewRequest(http.MethodPost, "/v1/users/login", bytes.NewReader(jsBody))
+				req.Header.Set("Content-Type", "application/json")
+				rec := httptest.NewRecorder()
+
+				api.ServeHTTP(rec, req)
+
+				return rec
+			},
+			setupJWT: func(t *testing.T) (jwtPkg.JWTGenerator, jwtPkg.JWTValidator) {
+				generator := jwtMocks.NewJWTGenerator(t)
+				generator.On("GenerateToken", mock.Anything).Return("mock-token", nil).Once()
+				validator := jwtMocks.NewJWTValidator(t)
+				return generator, validator
+			},
+			expectedStatus: http.StatusOK,
+			verifyBody: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "mock-token", body["token"])
+			},
+		},
 		{
 			name: "invalid request body - missing username",
 			setupHTTP: func(api api.Engine) *httptest.ResponseRecorder {
@@ -358,7 +457,8 @@ func TestUserEndpoint_Login(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				assert.Equal(t, body["message"], "Input Error")
+				assert.Equal(t, "invalid_input", body["code"])
+				assert.Equal(t, "Input error", body["message"])
 			},
 		},
 		{
@@ -384,14 +484,8 @@ func TestUserEndpoint_Login(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				errorMsg, ok := body["error"].(string)
-				if !ok {
-					message, ok := body["message"].(string)
-					assert.True(t, ok)
-					assert.Contains(t, message, "invalid username or password")
-					return
-				}
-				assert.Contains(t, errorMsg, "invalid username or password")
+				assert.Equal(t, "invalid_credentials", body["code"])
+				assert.Equal(t, "invalid username or password", body["message"])
 			},
 		},
 		{
@@ -433,6 +527,7 @@ func TestUserEndpoint_Login(t *testing.T) {
 			app := api.New(&api.EngineOpts{
 				Engine:       gin.New(),
 				DB:           db,
+				Redis:        redisPkg.InitMockRedis(t),
 				JWTGenerator: jwtGen,
 				JWTValidator: jwtVal,
 				Cfg:          cfg,
@@ -496,6 +591,7 @@ func TestUserEndpoint_GetProfile(t *testing.T) {
 				token := "valid-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -529,7 +625,7 @@ func TestUserEndpoint_GetProfile(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				errorMsg, ok := body["error"].(string)
+				errorMsg, ok := body["message"].(string)
 				assert.True(t, ok)
 				assert.Equal(t, "Authorization header is required", errorMsg)
 			},
@@ -552,7 +648,7 @@ func TestUserEndpoint_GetProfile(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				errorMsg, ok := body["error"].(string)
+				errorMsg, ok := body["message"].(string)
 				assert.True(t, ok)
 				assert.Equal(t, "Authorization header format is wrong", errorMsg)
 			},
@@ -577,7 +673,7 @@ func TestUserEndpoint_GetProfile(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				errorMsg, ok := body["error"].(string)
+				errorMsg, ok := body["message"].(string)
 				assert.True(t, ok)
 				assert.Equal(t, "Invalid token", errorMsg)
 			},
@@ -599,17 +695,18 @@ func TestUserEndpoint_GetProfile(t *testing.T) {
 				token := "valid-nonexistent-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": "non-existent-user-id",
+					"sid": sessionIDOf("non-existent-user-id"),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
 
 				return generator, validator, token
 			},
-			expectedStatus: http.StatusInternalServerError,
+			expectedStatus: http.StatusUnauthorized,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				message, ok := body["message"].(string)
+				errorMsg, ok := body["message"].(string)
 				assert.True(t, ok)
-				assert.Equal(t, "Processing Error", message)
+				assert.Equal(t, "Invalid token", errorMsg)
 			},
 		},
 	}
@@ -619,11 +716,14 @@ func TestUserEndpoint_GetProfile(t *testing.T) {
 			t.Parallel()
 
 			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			redis := redisPkg.InitMockRedis(t)
+			openSessions(t, db, redis)
 			jwtGen, jwtVal, token := tc.setupJWT(t, mockUserID)
 			// app := api.New(cfg, nil, db, jwtGen, jwtVal)
 			app := api.New(&api.EngineOpts{
 				Engine:       gin.New(),
 				DB:           db,
+				Redis:        redis,
 				JWTGenerator: jwtGen,
 				JWTValidator: jwtVal,
 				Cfg:          cfg,
@@ -692,6 +792,7 @@ func TestUserEndpoint_UpdateProfile(t *testing.T) {
 				token := "valid-update-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -729,7 +830,7 @@ func TestUserEndpoint_UpdateProfile(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 			verifyBody: func(t *testing.T, body map[string]any) {
-				errorMsg, ok := body["error"].(string)
+				errorMsg, ok := body["message"].(string)
 				assert.True(t, ok)
 				assert.Equal(t, "Authorization header is required", errorMsg)
 			},
@@ -757,6 +858,7 @@ func TestUserEndpoint_UpdateProfile(t *testing.T) {
 				token := "invalid-body-token"
 				validator.On("ValidateToken", token).Return(jwt.MapClaims{
 					"sub": userID,
+					"sid": sessionIDOf(userID),
 					"iat": 1600000000,
 					"exp": 1600086400,
 				}, nil).Once()
@@ -775,11 +877,14 @@ func TestUserEndpoint_UpdateProfile(t *testing.T) {
 			t.Parallel()
 
 			db := fixture.NewFixture(t, &fixture.UserCommonTestDB{})
+			redis := redisPkg.InitMockRedis(t)
+			openSessions(t, db, redis)
 			jwtGen, jwtVal, token := tc.setupJWT(t, mockUserID)
 			// app := api.New(cfg, nil, db, jwtGen, jwtVal)
 			app := api.New(&api.EngineOpts{
 				Engine:       gin.New(),
 				DB:           db,
+				Redis:        redis,
 				JWTGenerator: jwtGen,
 				JWTValidator: jwtVal,
 				Cfg:          cfg,
@@ -805,3 +910,176 @@ func TestUserEndpoint_UpdateProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestUserEndpoint_LoginRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	limitCfg := &loginlimit.Config{
+		Window:              15 * time.Minute,
+		MaxUsernameAttempts: 3,
+		MaxIPAttempts:       20,
+		LockoutDuration:     15 * time.Minute,
+		DelayAfter:          10,
+		BaseDelay:           time.Second,
+		MaxDelay:            30 * time.Second,
+	}
+
+	login := func(app api.Engine, username, password string) *httptest.ResponseRecorder {
+		jsBody, _ := json.Marshal(map[string]any{
+			"username": username,
+			"password": password,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/v1/users/login", bytes.NewReader(jsBody))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		app.ServeHTTP(rec, req)
+		return rec
+	}
+
+	testCases := []struct {
+		name           string
+		usernames      []string
+		passwords      []string
+		expectedStatus []int
+		verifyLast     func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name:           "locked out after too many failures",
+			passwords:      []string{"wrong", "wrong", "wrong", "P@ssw0rd1"},
+			expectedStatus: []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusBadRequest, http.StatusTooManyRequests},
+			verifyLast: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Equal(t, "900", rec.Header().Get("Retry-After"))
+			},
+		},
+		{
+			name:           "username and email share the limit of the account",
+			usernames:      []string{"an.nguyen", "An.Nguyen@example.com", "an.nguyen", "an.nguyen@example.com"},
+			passwords:      []string{"wrong", "wrong", "wrong", "P@ssw0rd1"},
+			expectedStatus: []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusBadRequest, http.StatusTooManyRequests},
+		},
+		{
+			name:           "successful login resets failures",
+			passwords:      []string{"wrong", "wrong", "P@ssw0rd1", "wrong", "wrong", "P@ssw0rd1"},
+			expectedStatus: []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusOK, http.StatusBadRequest, http.StatusBadRequest, http.StatusOK},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			generator := jwtMocks.NewJWTGenerator(t)
+			generator.On("GenerateToken", mock.Anything).Return("mock-token", nil).Maybe()
+			app := api.New(&api.EngineOpts{
+				Engine:       gin.New(),
+				Cfg:          &api.Config{AppPort: "8080"},
+				DB:           fixture.NewFixture(t, &fixture.UserCommonTestDB{}),
+				Redis:        redisPkg.InitMockRedis(t),
+				JWTGenerator: generator,
+				JWTValidator: jwtMocks.NewJWTValidator(t),
+				LoginLimit:   limitCfg,
+			})
+
+			var rec *httptest.ResponseRecorder
+			for i, password := range tc.passwords {
+				username := "an.nguyen"
+				if tc.usernames != nil {
+					username = tc.usernames[i]
+				}
+				rec = login(app, username, password)
+				assert.Equal(t, tc.expectedStatus[i], rec.Code, "attempt %d", i+1)
+			}
+			if tc.verifyLast != nil {
+				tc.verifyLast(t, rec)
+			}
+		})
+	}
+}
+
+func TestUserEndpoint_LoginIPLimitTrustedProxies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	t.Parallel()
+
+	limitCfg := &loginlimit.Config{
+		Window:              15 * time.Minute,
+		MaxUsernameAttempts: 10,
+		MaxIPAttempts:       3,
+		LockoutDuration:     15 * time.Minute,
+		DelayAfter:          10,
+		BaseDelay:           time.Second,
+		MaxDelay:            30 * time.Second,
+	}
+
+	testCases := []struct {
+		name           string
+		trustedProxies []string
+		expectedStatus []int
+	}{
+		{
+			name:           "forged forwarding headers ignored without trusted proxies",
+			expectedStatus: []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusBadRequest, http.StatusTooManyRequests},
+		},
+		{
+			name:           "forwarding headers of a trusted proxy give the client IP",
+			trustedProxies: []string{"192.0.2.0/24"},
+			expectedStatus: []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusBadRequest, http.StatusBadRequest},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			app := api.New(&api.EngineOpts{
+				Engine:       gin.New(),
+				Cfg:          &api.Config{AppPort: "8080", TrustedProxies: tc.trustedProxies},
+				DB:           fixture.NewFixture(t, &fixture.UserCommonTestDB{}),
+				Redis:        redisPkg.InitMockRedis(t),
+				JWTGenerator: jwtMocks.NewJWTGenerator(t),
+				JWTValidator: jwtMocks.NewJWTValidator(t),
+				LoginLimit:   limitCfg,
+			})
+
+			for i, expectedStatus := range tc.expectedStatus {
+				jsBody, _ := json.Marshal(map[string]any{
+					"username": "an.nguyen",
+					"password": "wrong",
+				})
+				req := httptest.NewRequest(http.MethodPost, "/v1/users/login", bytes.NewReader(jsBody))
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d", i+1))
+				rec := httptest.NewRecorder()
+				app.ServeHTTP(rec, req)
+				assert.Equal(t, expectedStatus, rec.Code, "attempt %d", i+1)
+			}
+		})
+	}
+}
+
+func TestUserEndpoint_LoginUpgradesPasswordHash(t *testing.T) {
+	t.Parallel()
+
+	generator, err := jwtPkg.NewJWTGenerator("../../../pkg/jwt/private_test.pem")
+	assert.NoError(t, err)
+	db := fixture.NewFixture(t, &fixture.UserAdminTestDB{})
+	app := api.New(&api.EngineOpts{
+		Engine:       gin.New(),
+		Cfg:          &api.Config{AppPort: "8080", ServiceName: "bookmark-service", InstanceId: "instance-1"},
+		DB:           db,
+		Redis:        redisPkg.InitMockRedis(t),
+		JWTGenerator: generator,
+	})
+
+	user := &model.User{}
+	assert.NoError(t, db.Where("username = ?", "duc.pham").First(user).Error)
+	assert.True(t, strings.HasPrefix(user.Password, "$2a$"))
+
+	loginFrom(t, app, "duc.pham", "P@ssw0rd4", "laptop")
+
+	assert.NoError(t, db.Where("username = ?", "duc.pham").First(user).Error)
+	assert.True(t, strings.HasPrefix(user.Password, "$argon2id$v=19$m=19456,t=2,p=1$"))
+	assert.False(t, user.PasswordResetRequired)
+
+	loginFrom(t, app, "duc.pham", "P@ssw0rd4", "laptop")
+}
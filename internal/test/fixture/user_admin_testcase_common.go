@@ -0,0 +1,52 @@
+package fixture
+
+import (
+	model "github.com/luongtruong20201/bookmark-management/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// AdminUserID identifies the seeded administrator (an.nguyen).
+	AdminUserID = "9b5c1e3e-7c3b-4f4e-8e7c-6e7a2f5d3a91"
+	// DisabledUserID identifies the seeded disabled account (binh.tran).
+	DisabledUserID = "2f6c9d14-9e42-4c31-9e6a-0f8c4b2a7c55"
+	// ResetRequiredUserID identifies the seeded account that must change its password (huy.le).
+	ResetRequiredUserID = "e3c2a8f1-1d3b-4c62-8e54-6b7f9a2d1c90"
+)
+
+// UserAdminTestDB extends the common bookmark dataset with account roles and statuses.
+// It promotes one user to administrator, disables another, and flags a third for a
+// mandatory password reset, which is what the administration tests rely on.
+type UserAdminTestDB struct {
+	base
+}
+
+// Migrate applies the database schema for users and bookmarks used in tests.
+func (f *UserAdminTestDB) Migrate() error {
+	return f.db.AutoMigrate(&model.User{}, &model.Bookmark{})
+}
+
+// GenerateData seeds the common users and bookmarks (via BookmarkCommonTestDB) and
+// then updates the role and status columns of the users referenced by the constants above.
+func (f *UserAdminTestDB) GenerateData() error {
+	bookmarkFixture := &BookmarkCommonTestDB{}
+	bookmarkFixture.SetupDB(f.db)
+	if err := bookmarkFixture.GenerateData(); err != nil {
+		return err
+	}
+
+	db := f.db.Session(&gorm.Session{})
+
+	updates := map[string]map[string]any{
+		AdminUserID:         {"role": model.RoleAdmin},
+		DisabledUserID:      {"disabled": true},
+		ResetRequiredUserID: {"password_reset_required": true},
+	}
+	for id, columns := range updates {
+		if err := db.Model(&model.User{}).Where("id = ?", id).Updates(columns).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ package fixture
 import (
 	model "github.com/luongtruong20201/bookmark-management/internal/models"
 	"github.com/luongtruong20201/bookmark-management/pkg/utils"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -123,9 +124,15 @@ func (f *UserCommonTestDB) GenerateData() error {
 		},
 	}
 
-	hasher := utils.NewHasher()
+	// Fixture passwords are hashed with cheap bcrypt parameters, which also lets the
+	// endpoint tests exercise the upgrade of outdated hashes at login.
+	hasher := utils.NewHasher(&utils.HashConfig{Algorithm: utils.AlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
 	for _, u := range users {
-		u.Password = hasher.HashPassword(u.Password)
+		hashedPassword, err := hasher.HashPassword(u.Password)
+		if err != nil {
+			return err
+		}
+		u.Password = hashedPassword
 	}
 
 	return db.CreateInBatches(users, len(users)).Error
@@ -0,0 +1,6 @@
+ALTER TABLE users
+    ADD COLUMN role                    VARCHAR(16) NOT NULL DEFAULT 'user',
+    ADD COLUMN disabled                BOOLEAN     NOT NULL DEFAULT FALSE,
+    ADD COLUMN password_reset_required BOOLEAN     NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_users_role ON users (role);
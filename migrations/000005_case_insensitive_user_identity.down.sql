@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS uni_users_email_lower;
+DROP INDEX IF EXISTS uni_users_username_lower;
+
+ALTER TABLE users ADD CONSTRAINT uni_username UNIQUE (username);
+ALTER TABLE users ADD CONSTRAINT uni_email UNIQUE (email);
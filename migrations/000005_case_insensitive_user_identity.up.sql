@@ -0,0 +1,7 @@
+-- Usernames and emails are unique regardless of letter case. Run `migrate precheck`
+-- first: the indexes cannot be created while accounts collide on the lowercased values.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS uni_username;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS uni_email;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uni_users_username_lower ON users (LOWER(username));
+CREATE UNIQUE INDEX IF NOT EXISTS uni_users_email_lower ON users (LOWER(email));
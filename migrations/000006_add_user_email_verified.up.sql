@@ -0,0 +1,5 @@
+-- Whether the email address of the account has been verified, so far only by an external
+-- identity provider. Existing accounts start unverified and are no longer signed in
+-- through an identity provider until their email is verified.
+ALTER TABLE users
+    ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE;
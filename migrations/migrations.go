@@ -0,0 +1,10 @@
+// Package migrations embeds the SQL migrations of the database schema, so the binaries
+// apply them without reading the migrations directory at run time.
+package migrations
+
+import "embed"
+
+// FS holds the up and down files of the migrations, at its root.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFS(t *testing.T) {
+	t.Parallel()
+
+	ups, err := fs.Glob(FS, "*.up.sql")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, ups)
+
+	for _, up := range ups {
+		down := strings.TrimSuffix(up, ".up.sql") + ".down.sql"
+		_, err := fs.Stat(FS, down)
+		assert.NoError(t, err, "%s has no down migration", up)
+	}
+}
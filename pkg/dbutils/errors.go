@@ -1,21 +1,32 @@
 // Package dbutils provides helpers for normalizing and classifying database errors.
-// It translates low-level driver or GORM errors into consistent application error types.
+// It translates low-level driver or GORM errors into consistent application error types,
+// and runs transactions that are retried when the database aborts them.
 package dbutils
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"regexp"
 	"strings"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
 var errorFilters = []func(err error) (bool, error){
-	filterDuplicationType,
+	filterTranslated,
+	filterPgError,
+	filterSQLiteError,
+	filterTimeout,
 	filterRecordNotFound,
 }
 
 // CatchDBErr inspects a low-level database error and attempts to map it to a
 // well-defined application error (for example duplication or not-found).
+// PostgreSQL errors are classified by their SQLSTATE code; SQLite errors, used by the
+// tests, by their message. Constraint violations are returned as a *ConstraintError
+// matching the sentinel of their kind with errors.Is.
 // If no mapping rule matches, the original error is returned unchanged.
 func CatchDBErr(err error) error {
 	if err == nil {
@@ -33,14 +44,197 @@ func CatchDBErr(err error) error {
 }
 
 var (
+	// ErrDuplicationType is returned when a row breaks a unique constraint.
 	ErrDuplicationType = errors.New("duplicate type")
-	ErrNotFoundType    = errors.New("not found type")
+	// ErrNotFoundType is returned when the requested record does not exist.
+	ErrNotFoundType = errors.New("not found type")
+	// ErrForeignKeyType is returned when a row references a row that does not exist.
+	ErrForeignKeyType = errors.New("foreign key type")
+	// ErrCheckType is returned when a row breaks a check constraint.
+	ErrCheckType = errors.New("check type")
+	// ErrNotNullType is returned when a required column is left null.
+	ErrNotNullType = errors.New("not null type")
+	// ErrSerializationType is returned when the database aborts a transaction that
+	// conflicts with a concurrent one; running it again may succeed.
+	ErrSerializationType = errors.New("serialization failure type")
+	// ErrDeadlockType is returned when the database aborts a transaction to break a
+	// deadlock; running it again may succeed.
+	ErrDeadlockType = errors.New("deadlock type")
+	// ErrTimeoutType is returned when a query runs out of time or waits too long for a lock.
+	ErrTimeoutType = errors.New("timeout type")
 )
 
-// filterDuplicationType detects unique-constraint violations and maps them
-// to ErrDuplicationType.
-func filterDuplicationType(err error) (bool, error) {
-	return strings.Contains(err.Error(), "unique constraint"), ErrDuplicationType
+// ConstraintError is a violation of an integrity constraint. Kind is the sentinel of the
+// violation, such as ErrDuplicationType, which the error matches with errors.Is. The
+// constraint, table and column are set when the database reports them: a duplicate
+// caught by an expression index, such as a case-insensitive one, may only report the
+// constraint name with SQLite.
+type ConstraintError struct {
+	Kind       error
+	Constraint string
+	Table      string
+	Column     string
+	Err        error
+}
+
+// Error describes the violation with the constraint and the column.
+func (e *ConstraintError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Kind.Error())
+	if e.Constraint != "" {
+		b.WriteString(" on constraint " + e.Constraint)
+	}
+	if e.Column != "" {
+		column := e.Column
+		if e.Table != "" {
+			column = e.Table + "." + column
+		}
+		b.WriteString(" of column " + column)
+	}
+
+	return b.String()
+}
+
+// Is reports whether the target is the kind of the violation.
+func (e *ConstraintError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Unwrap returns the error of the driver.
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}
+
+// PostgreSQL error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const (
+	pgUniqueViolation      = "23505"
+	pgForeignKeyViolation  = "23503"
+	pgCheckViolation       = "23514"
+	pgNotNullViolation     = "23502"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+	pgQueryCanceled        = "57014"
+	pgLockNotAvailable     = "55P03"
+)
+
+// pgKeyColumnPattern extracts the first column from the detail of a PostgreSQL unique or
+// foreign key violation, such as `Key (email)=(...)` or, for an expression index,
+// `Key (lower(email::text))=(...)`.
+var pgKeyColumnPattern = regexp.MustCompile(`^Key \(\W*(?:\w+\(\W*)?(\w+)`)
+
+// sqliteConstraintPattern matches the message of a SQLite constraint violation, such as
+// `UNIQUE constraint failed: users.email` or `UNIQUE constraint failed: index 'name'`.
+var sqliteConstraintPattern = regexp.MustCompile(`(UNIQUE|FOREIGN KEY|CHECK|NOT NULL) constraint failed(?:: (.+))?`)
+
+// sqliteConstraintKinds maps the SQLite constraint types to the violation sentinels.
+var sqliteConstraintKinds = map[string]error{
+	"UNIQUE":      ErrDuplicationType,
+	"FOREIGN KEY": ErrForeignKeyType,
+	"CHECK":       ErrCheckType,
+	"NOT NULL":    ErrNotNullType,
+}
+
+// filterTranslated leaves the errors already translated by CatchDBErr unchanged, so
+// translating an error twice is harmless.
+func filterTranslated(err error) (bool, error) {
+	for _, kind := range []error{
+		ErrDuplicationType, ErrNotFoundType, ErrForeignKeyType, ErrCheckType,
+		ErrNotNullType, ErrSerializationType, ErrDeadlockType, ErrTimeoutType,
+	} {
+		if errors.Is(err, kind) {
+			return true, err
+		}
+	}
+
+	return false, nil
+}
+
+// filterPgError classifies the PostgreSQL errors by their SQLSTATE code.
+func filterPgError(err error) (bool, error) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false, nil
+	}
+
+	newConstraintErr := func(kind error, column string) error {
+		return &ConstraintError{
+			Kind:       kind,
+			Constraint: pgErr.ConstraintName,
+			Table:      pgErr.TableName,
+			Column:     column,
+			Err:        err,
+		}
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return true, newConstraintErr(ErrDuplicationType, pgKeyColumn(pgErr.Detail))
+	case pgForeignKeyViolation:
+		return true, newConstraintErr(ErrForeignKeyType, pgKeyColumn(pgErr.Detail))
+	case pgCheckViolation:
+		return true, newConstraintErr(ErrCheckType, pgErr.ColumnName)
+	case pgNotNullViolation:
+		return true, newConstraintErr(ErrNotNullType, pgErr.ColumnName)
+	case pgSerializationFailure:
+		return true, fmt.Errorf("%w: %w", ErrSerializationType, err)
+	case pgDeadlockDetected:
+		return true, fmt.Errorf("%w: %w", ErrDeadlockType, err)
+	case pgQueryCanceled, pgLockNotAvailable:
+		return true, fmt.Errorf("%w: %w", ErrTimeoutType, err)
+	}
+
+	return false, nil
+}
+
+// pgKeyColumn returns the first column of the key reported in the detail of a violation,
+// or an empty string if the detail reports none.
+func pgKeyColumn(detail string) string {
+	match := pgKeyColumnPattern.FindStringSubmatch(detail)
+	if match == nil {
+		return ""
+	}
+
+	return match[1]
+}
+
+// filterSQLiteError classifies the SQLite errors by their message.
+func filterSQLiteError(err error) (bool, error) {
+	message := err.Error()
+	if strings.Contains(message, "database is locked") || strings.Contains(message, "database table is locked") {
+		return true, fmt.Errorf("%w: %w", ErrTimeoutType, err)
+	}
+
+	match := sqliteConstraintPattern.FindStringSubmatch(message)
+	if match == nil {
+		return false, nil
+	}
+
+	constraintErr := &ConstraintError{Kind: sqliteConstraintKinds[match[1]], Err: err}
+	target := match[2]
+	switch {
+	case strings.HasPrefix(target, "index "):
+		constraintErr.Constraint = strings.Trim(strings.TrimPrefix(target, "index "), "'")
+	case match[1] == "CHECK":
+		constraintErr.Constraint = target
+	default:
+		// Multi-column constraints list every column; the first one is reported.
+		column, _, _ := strings.Cut(target, ",")
+		if table, name, ok := strings.Cut(column, "."); ok {
+			constraintErr.Table, constraintErr.Column = table, name
+		}
+	}
+
+	return true, constraintErr
+}
+
+// filterTimeout detects queries that ran past the deadline of their context and maps
+// them to ErrTimeoutType.
+func filterTimeout(err error) (bool, error) {
+	if !errors.Is(err, context.DeadlineExceeded) {
+		return false, nil
+	}
+
+	return true, fmt.Errorf("%w: %w", ErrTimeoutType, err)
 }
 
 // filterRecordNotFound detects GORM's ErrRecordNotFound and maps it
@@ -1 +1,220 @@
 package dbutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCatchDBErr(t *testing.T) {
+	t.Parallel()
+
+	testErr := errors.New("connection refused")
+	translatedErr := &ConstraintError{Kind: ErrDuplicationType, Constraint: "uni_bookmark_code"}
+
+	testCases := []struct {
+		name               string
+		err                error
+		expectedKind       error
+		expectedConstraint *ConstraintError
+		expectedErr        error
+	}{
+		{
+			name: "nil error",
+		},
+		{
+			name:         "record not found",
+			err:          fmt.Errorf("query: %w", gorm.ErrRecordNotFound),
+			expectedKind: ErrNotFoundType,
+		},
+		{
+			name: "postgres unique violation on an expression index",
+			err: &pgconn.PgError{
+				Code:           pgUniqueViolation,
+				TableName:      "users",
+				ConstraintName: "uni_users_email_lower",
+				Detail:         "Key (lower(email::text))=(an.nguyen@example.com) already exists.",
+			},
+			expectedKind:       ErrDuplicationType,
+			expectedConstraint: &ConstraintError{Constraint: "uni_users_email_lower", Table: "users", Column: "email"},
+		},
+		{
+			name: "postgres unique violation on a column",
+			err: &pgconn.PgError{
+				Code:           pgUniqueViolation,
+				TableName:      "bookmarks",
+				ConstraintName: "uni_bookmark_code",
+				Detail:         "Key (code)=(abc123) already exists.",
+			},
+			expectedKind:       ErrDuplicationType,
+			expectedConstraint: &ConstraintError{Constraint: "uni_bookmark_code", Table: "bookmarks", Column: "code"},
+		},
+		{
+			name: "postgres foreign key violation",
+			err: &pgconn.PgError{
+				Code:           pgForeignKeyViolation,
+				TableName:      "bookmarks",
+				ConstraintName: "fk_bookmarks_user",
+				Detail:         `Key (user_id)=(42) is not present in table "users".`,
+			},
+			expectedKind:       ErrForeignKeyType,
+			expectedConstraint: &ConstraintError{Constraint: "fk_bookmarks_user", Table: "bookmarks", Column: "user_id"},
+		},
+		{
+			name:               "postgres check violation",
+			err:                &pgconn.PgError{Code: pgCheckViolation, TableName: "users", ConstraintName: "chk_users_role"},
+			expectedKind:       ErrCheckType,
+			expectedConstraint: &ConstraintError{Constraint: "chk_users_role", Table: "users"},
+		},
+		{
+			name:               "postgres not null violation",
+			err:                &pgconn.PgError{Code: pgNotNullViolation, TableName: "users", ColumnName: "email"},
+			expectedKind:       ErrNotNullType,
+			expectedConstraint: &ConstraintError{Table: "users", Column: "email"},
+		},
+		{
+			name:         "postgres serialization failure",
+			err:          &pgconn.PgError{Code: pgSerializationFailure},
+			expectedKind: ErrSerializationType,
+		},
+		{
+			name:         "postgres deadlock",
+			err:          &pgconn.PgError{Code: pgDeadlockDetected},
+			expectedKind: ErrDeadlockType,
+		},
+		{
+			name:         "postgres statement timeout",
+			err:          &pgconn.PgError{Code: pgQueryCanceled},
+			expectedKind: ErrTimeoutType,
+		},
+		{
+			name:         "postgres lock timeout",
+			err:          &pgconn.PgError{Code: pgLockNotAvailable},
+			expectedKind: ErrTimeoutType,
+		},
+		{
+			name:         "context deadline exceeded",
+			err:          fmt.Errorf("query: %w", context.DeadlineExceeded),
+			expectedKind: ErrTimeoutType,
+		},
+		{
+			name:               "sqlite unique violation on a column",
+			err:                errors.New("UNIQUE constraint failed: bookmarks.code"),
+			expectedKind:       ErrDuplicationType,
+			expectedConstraint: &ConstraintError{Table: "bookmarks", Column: "code"},
+		},
+		{
+			name:               "sqlite unique violation on several columns",
+			err:                errors.New("UNIQUE constraint failed: sessions.user_id, sessions.device"),
+			expectedKind:       ErrDuplicationType,
+			expectedConstraint: &ConstraintError{Table: "sessions", Column: "user_id"},
+		},
+		{
+			name:               "sqlite unique violation on an expression index",
+			err:                errors.New("UNIQUE constraint failed: index 'uni_users_username_lower'"),
+			expectedKind:       ErrDuplicationType,
+			expectedConstraint: &ConstraintError{Constraint: "uni_users_username_lower"},
+		},
+		{
+			name:               "sqlite foreign key violation",
+			err:                errors.New("FOREIGN KEY constraint failed"),
+			expectedKind:       ErrForeignKeyType,
+			expectedConstraint: &ConstraintError{},
+		},
+		{
+			name:               "sqlite check violation",
+			err:                errors.New("CHECK constraint failed: chk_users_role"),
+			expectedKind:       ErrCheckType,
+			expectedConstraint: &ConstraintError{Constraint: "chk_users_role"},
+		},
+		{
+			name:               "sqlite not null violation",
+			err:                errors.New("NOT NULL constraint failed: users.email"),
+			expectedKind:       ErrNotNullType,
+			expectedConstraint: &ConstraintError{Table: "users", Column: "email"},
+		},
+		{
+			name:         "sqlite database locked",
+			err:          errors.New("database is locked"),
+			expectedKind: ErrTimeoutType,
+		},
+		{
+			name:        "already translated",
+			err:         translatedErr,
+			expectedErr: translatedErr,
+		},
+		{
+			name:        "unknown error",
+			err:         testErr,
+			expectedErr: testErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CatchDBErr(tc.err)
+
+			if tc.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			if tc.expectedErr != nil {
+				assert.Same(t, tc.expectedErr, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tc.expectedKind)
+			if tc.expectedConstraint == nil {
+				return
+			}
+			var constraintErr *ConstraintError
+			if assert.ErrorAs(t, err, &constraintErr) {
+				assert.Equal(t, tc.expectedConstraint.Constraint, constraintErr.Constraint)
+				assert.Equal(t, tc.expectedConstraint.Table, constraintErr.Table)
+				assert.Equal(t, tc.expectedConstraint.Column, constraintErr.Column)
+				assert.ErrorIs(t, err, tc.err, "the driver error must stay reachable")
+			}
+		})
+	}
+}
+
+func TestConstraintError_Error(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		err      *ConstraintError
+		expected string
+	}{
+		{
+			name:     "constraint and column",
+			err:      &ConstraintError{Kind: ErrDuplicationType, Constraint: "uni_users_email_lower", Table: "users", Column: "email"},
+			expected: "duplicate type on constraint uni_users_email_lower of column users.email",
+		},
+		{
+			name:     "column without table",
+			err:      &ConstraintError{Kind: ErrNotNullType, Column: "email"},
+			expected: "not null type of column email",
+		},
+		{
+			name:     "kind only",
+			err:      &ConstraintError{Kind: ErrForeignKeyType},
+			expected: "foreign key type",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.err.Error())
+		})
+	}
+}
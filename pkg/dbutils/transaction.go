@@ -0,0 +1,42 @@
+package dbutils
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// maxTransactionAttempts is the number of times Transaction runs a transaction that
+	// keeps being aborted by the database.
+	maxTransactionAttempts = 3
+	// retryBackoff is the wait before the second attempt; later attempts wait longer.
+	retryBackoff = 20 * time.Millisecond
+)
+
+// IsRetryable reports whether the error aborted a transaction that may succeed when run
+// again: a serialization failure or a deadlock.
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrSerializationType) || errors.Is(err, ErrDeadlockType)
+}
+
+// Transaction runs fn in a transaction of the database bound to ctx, and returns the
+// error of the transaction translated by CatchDBErr. A transaction aborted by a
+// serialization failure or a deadlock is rolled back and run again, up to
+// maxTransactionAttempts times, so fn must not have side effects outside the transaction.
+func Transaction(ctx context.Context, db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	for attempt := 1; ; attempt++ {
+		err := CatchDBErr(db.WithContext(ctx).Transaction(fn))
+		if !IsRetryable(err) || attempt == maxTransactionAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * retryBackoff):
+		}
+	}
+}
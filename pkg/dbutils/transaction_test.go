@@ -0,0 +1,119 @@
+package dbutils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	sqldb "github.com/luongtruong20201/bookmark-management/pkg/sql"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// testRow is the table written by the transactions of the tests.
+type testRow struct {
+	ID   int
+	Name string
+}
+
+func TestTransaction(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		failures         []error
+		cancelled        bool
+		expectedErr      error
+		expectedAttempts int
+		expectedRows     int64
+	}{
+		{
+			name:             "success - committed on the first attempt",
+			expectedAttempts: 1,
+			expectedRows:     1,
+		},
+		{
+			name:             "success - retried after a serialization failure",
+			failures:         []error{&pgconn.PgError{Code: pgSerializationFailure}},
+			expectedAttempts: 2,
+			expectedRows:     1,
+		},
+		{
+			name:             "success - retried after a deadlock",
+			failures:         []error{&pgconn.PgError{Code: pgDeadlockDetected}, &pgconn.PgError{Code: pgSerializationFailure}},
+			expectedAttempts: 3,
+			expectedRows:     1,
+		},
+		{
+			name: "error - gives up after the last attempt",
+			failures: []error{
+				&pgconn.PgError{Code: pgSerializationFailure},
+				&pgconn.PgError{Code: pgSerializationFailure},
+				&pgconn.PgError{Code: pgSerializationFailure},
+			},
+			expectedErr:      ErrSerializationType,
+			expectedAttempts: maxTransactionAttempts,
+		},
+		{
+			name:             "error - not retried on other errors",
+			failures:         []error{gorm.ErrRecordNotFound},
+			expectedErr:      ErrNotFoundType,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "error - not retried once the context is done",
+			failures:         []error{&pgconn.PgError{Code: pgDeadlockDetected}},
+			cancelled:        true,
+			expectedErr:      ErrDeadlockType,
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := sqldb.InitMockDB(t)
+			assert.NoError(t, db.AutoMigrate(&testRow{}))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			attempts := 0
+			err := Transaction(ctx, db, func(tx *gorm.DB) error {
+				attempts++
+				if err := tx.Create(&testRow{ID: 1, Name: "row"}).Error; err != nil {
+					return err
+				}
+				if attempts <= len(tc.failures) {
+					if tc.cancelled {
+						cancel()
+					}
+					return tc.failures[attempts-1]
+				}
+				return nil
+			})
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedAttempts, attempts)
+
+			var rows int64
+			assert.NoError(t, db.Model(&testRow{}).Count(&rows).Error)
+			assert.Equal(t, tc.expectedRows, rows, "failed attempts must be rolled back")
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsRetryable(CatchDBErr(&pgconn.PgError{Code: pgSerializationFailure})))
+	assert.True(t, IsRetryable(CatchDBErr(&pgconn.PgError{Code: pgDeadlockDetected})))
+	assert.False(t, IsRetryable(CatchDBErr(&pgconn.PgError{Code: pgQueryCanceled})))
+	assert.False(t, IsRetryable(errors.New("connection refused")))
+}
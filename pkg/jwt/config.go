@@ -0,0 +1,108 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Config holds the sources of the keys used to sign and verify tokens, loaded from
+// environment variables. The first configured source is used:
+//
+//   - KeysDir: a key directory, see LoadKeySetFromDir.
+//   - SigningKey: a base64 encoded PEM private key signing tokens as ActiveKeyID,
+//     which defaults to the key thumbprint. VerificationKeys adds base64 encoded PEM
+//     public keys by ID, formatted as "kid1:key1,kid2:key2".
+//   - PrivateKeyPath: a single PEM private key file identified by its thumbprint.
+//
+// Issuer and Audience are written to the "iss" and "aud" claims of issued tokens, and a
+// validated token must name both. Leeway tolerates clock skew between servers when
+// checking the time based claims, and RequiredClaims lists the claims every token must
+// carry.
+type Config struct {
+	KeysDir          string            `default:"" envconfig:"JWT_KEYS_DIR"`
+	SigningKey       string            `default:"" envconfig:"JWT_SIGNING_KEY"`
+	ActiveKeyID      string            `default:"" envconfig:"JWT_ACTIVE_KEY_ID"`
+	VerificationKeys map[string]string `default:"" envconfig:"JWT_VERIFICATION_KEYS"`
+	PrivateKeyPath   string            `default:"./private.pem" envconfig:"JWT_PRIVATE_KEY_PATH"`
+	Issuer           string            `default:"bookmark-service" envconfig:"JWT_ISSUER"`
+	Audience         string            `default:"bookmark-service" envconfig:"JWT_AUDIENCE"`
+	Leeway           time.Duration     `default:"30s" envconfig:"JWT_LEEWAY"`
+	RequiredClaims   []string          `default:"sub,exp,iat" envconfig:"JWT_REQUIRED_CLAIMS"`
+}
+
+// NewConfig creates a new configuration instance by reading environment variables.
+// Unset variables fall back to the defaults declared on Config.
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// LoadKeySet loads the key set from the first source configured in cfg.
+func LoadKeySet(cfg *Config) (*KeySet, error) {
+	switch {
+	case cfg.KeysDir != "":
+		return LoadKeySetFromDir(cfg.KeysDir)
+	case cfg.SigningKey != "":
+		return loadKeySetFromEnv(cfg)
+	default:
+		data, err := os.ReadFile(cfg.PrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		key, err := parseKey("", data)
+		if err != nil {
+			return nil, err
+		}
+		key.ID = Thumbprint(key.Public)
+
+		return NewKeySet(key.ID, key)
+	}
+}
+
+// loadKeySetFromEnv decodes the signing and verification keys passed as base64
+// encoded PEM in environment variables.
+func loadKeySetFromEnv(cfg *Config) (*KeySet, error) {
+	signingKey, err := decodeKey(cfg.ActiveKeyID, cfg.SigningKey)
+	if err != nil {
+		return nil, fmt.Errorf("JWT_SIGNING_KEY: %w", err)
+	}
+	if signingKey.Private == nil {
+		return nil, fmt.Errorf("JWT_SIGNING_KEY: %w", ErrNoSigningKey)
+	}
+	if signingKey.ID == "" {
+		signingKey.ID = Thumbprint(signingKey.Public)
+	}
+
+	keys := []*Key{signingKey}
+	for kid, value := range cfg.VerificationKeys {
+		if kid == signingKey.ID {
+			continue
+		}
+		key, err := decodeKey(kid, value)
+		if err != nil {
+			return nil, fmt.Errorf("JWT_VERIFICATION_KEYS %q: %w", kid, err)
+		}
+		// A verification key never signs, even when a private key is configured.
+		keys = append(keys, &Key{ID: kid, Public: key.Public})
+	}
+
+	return NewKeySet(signingKey.ID, keys...)
+}
+
+// decodeKey decodes a base64 encoded PEM key.
+func decodeKey(kid, value string) (*Key, error) {
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseKey(kid, data)
+}
@@ -0,0 +1,99 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadKeySet(t *testing.T) {
+	t.Parallel()
+
+	privatePEM, err := os.ReadFile("./private_test.pem")
+	assert.NoError(t, err)
+	publicPEM, err := os.ReadFile("./public_test.pem")
+	assert.NoError(t, err)
+	signingKey := base64.StdEncoding.EncodeToString(privatePEM)
+	verificationKey := base64.StdEncoding.EncodeToString(publicPEM)
+	thumbprint := loadTestKey(t).ID
+
+	testCases := []struct {
+		name             string
+		cfg              *Config
+		expectedActiveID string
+		expectedIDs      []string
+		expectedErrStr   string
+	}{
+		{
+			name: "success - key directory takes precedence",
+			cfg:  &Config{KeysDir: generateKeyDir(t), SigningKey: signingKey},
+		},
+		{
+			name: "success - environment",
+			cfg: &Config{
+				SigningKey:       signingKey,
+				ActiveKeyID:      "current",
+				VerificationKeys: map[string]string{"previous": verificationKey, "current": verificationKey},
+			},
+			expectedActiveID: "current",
+			expectedIDs:      []string{"current", "previous"},
+		},
+		{
+			name:             "success - environment without key ID",
+			cfg:              &Config{SigningKey: signingKey},
+			expectedActiveID: thumbprint,
+			expectedIDs:      []string{thumbprint},
+		},
+		{
+			name:             "success - legacy private key file",
+			cfg:              &Config{PrivateKeyPath: "./private_test.pem"},
+			expectedActiveID: thumbprint,
+			expectedIDs:      []string{thumbprint},
+		},
+		{
+			name:           "error - signing key is not base64",
+			cfg:            &Config{SigningKey: "not base64!"},
+			expectedErrStr: "JWT_SIGNING_KEY",
+		},
+		{
+			name:           "error - signing key is a public key",
+			cfg:            &Config{SigningKey: verificationKey},
+			expectedErrStr: ErrNoSigningKey.Error(),
+		},
+		{
+			name:           "error - invalid verification key",
+			cfg:            &Config{SigningKey: signingKey, VerificationKeys: map[string]string{"previous": "bm90IGEga2V5"}},
+			expectedErrStr: `JWT_VERIFICATION_KEYS "previous"`,
+		},
+		{
+			name:           "error - private key file not found",
+			cfg:            &Config{PrivateKeyPath: "./missing.pem"},
+			expectedErrStr: "no such file or directory",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ks, err := LoadKeySet(tc.cfg)
+			if tc.expectedErrStr != "" {
+				assert.ErrorContains(t, err, tc.expectedErrStr)
+				assert.Nil(t, ks)
+				return
+			}
+			assert.NoError(t, err)
+			if tc.expectedIDs != nil {
+				assert.Equal(t, tc.expectedActiveID, ks.Active().ID)
+				assert.Equal(t, tc.expectedIDs, ks.IDs())
+			}
+			// Only the signing key signs, verification keys never do.
+			for _, id := range ks.IDs() {
+				key, _ := ks.Key(id)
+				assert.Equal(t, id == ks.Active().ID, key.Private != nil)
+			}
+		})
+	}
+}
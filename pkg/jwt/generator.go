@@ -1,14 +1,15 @@
 package jwt
 
 import (
-	"crypto/rsa"
+	"crypto"
+	"fmt"
 	"os"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
 // JWTGenerator defines the interface for JWT token generation.
-// It provides methods to create signed JWT tokens using RSA private keys.
+// It provides methods to create signed JWT tokens using RSA, ECDSA or Ed25519 private keys.
 //
 //go:generate mockery --name JWTGenerator --filename jwt_generator.go
 type JWTGenerator interface {
@@ -16,36 +17,75 @@ type JWTGenerator interface {
 }
 
 // jwtGenerator implements the JWTGenerator interface and provides JWT token generation
-// functionality using RSA private keys. It uses the RS256 signing algorithm to create
-// signed JWT tokens with the provided claims.
+// functionality using a private key. It signs tokens with the algorithm matching the key
+// type: RS256 for RSA keys, ES256, ES384 or ES512 for ECDSA keys depending on the curve,
+// and EdDSA for Ed25519 keys. When a key ID is set, it is written to the "kid" header so
+// validators know which key verifies the token. A configured issuer and audience are
+// added to claims that do not set them.
 type jwtGenerator struct {
-	privateKey *rsa.PrivateKey
+	kid        string
+	method     jwt.SigningMethod
+	privateKey crypto.Signer
+	issuer     string
+	audience   string
 }
 
 // NewJWTGenerator creates a new JWT generator instance by loading and parsing
-// an RSA private key from the specified file path. The private key is used
-// to sign JWT tokens using RS256 algorithm.
+// a PEM private key from the specified file path. The key type is detected from
+// the PEM data and selects the signing algorithm.
 func NewJWTGenerator(privateKeyPath string) (JWTGenerator, error) {
 	privateKeyData, err := os.ReadFile(privateKeyPath)
 	if err != nil {
 		return nil, err
 	}
 
-	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privateKeyData)
+	key, err := parseKey("", privateKeyData)
 	if err != nil {
 		return nil, err
 	}
+	if key.Private == nil {
+		return nil, fmt.Errorf("%w: %s holds no private key", ErrNoSigningKey, privateKeyPath)
+	}
 
 	return &jwtGenerator{
-		privateKey: privateKey,
+		method:     key.SigningMethod(),
+		privateKey: key.Private,
 	}, nil
 }
 
+// NewKeySetGenerator creates a new JWT generator signing tokens with the active key
+// of the key set. Tokens carry the key ID in their "kid" header and cfg.Issuer and
+// cfg.Audience in their "iss" and "aud" claims.
+func NewKeySetGenerator(keySet *KeySet, cfg *Config) JWTGenerator {
+	active := keySet.Active()
+	return &jwtGenerator{
+		kid:        active.ID,
+		method:     active.SigningMethod(),
+		privateKey: active.Private,
+		issuer:     cfg.Issuer,
+		audience:   cfg.Audience,
+	}
+}
+
 // GenerateToken creates a new JWT token with the provided claims and signs it
-// using the RS256 algorithm with the loaded private key. Returns the signed
-// token string or an error if signing fails.
+// with the loaded private key. Returns the signed token string or an error if
+// signing fails.
 func (g *jwtGenerator) GenerateToken(jwtContent jwt.MapClaims) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwtContent)
+	claims := make(jwt.MapClaims, len(jwtContent)+2)
+	if g.issuer != "" {
+		claims["iss"] = g.issuer
+	}
+	if g.audience != "" {
+		claims["aud"] = g.audience
+	}
+	for name, value := range jwtContent {
+		claims[name] = value
+	}
+
+	token := jwt.NewWithClaims(g.method, claims)
+	if g.kid != "" {
+		token.Header["kid"] = g.kid
+	}
 	tokenString, err := token.SignedString(g.privateKey)
 	if err != nil {
 		return "", err
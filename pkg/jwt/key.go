@@ -0,0 +1,162 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signing algorithms of the supported key types, as written to the "alg" header.
+const (
+	// AlgorithmRS256 signs with an RSA key and is the default algorithm.
+	AlgorithmRS256 = "RS256"
+	// AlgorithmES256 signs with an ECDSA key on the P-256 curve.
+	AlgorithmES256 = "ES256"
+	// AlgorithmES384 signs with an ECDSA key on the P-384 curve.
+	AlgorithmES384 = "ES384"
+	// AlgorithmES512 signs with an ECDSA key on the P-521 curve.
+	AlgorithmES512 = "ES512"
+	// AlgorithmEdDSA signs with an Ed25519 key.
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// DefaultRSABits is the size of generated RSA keys when none is given.
+const DefaultRSABits = 2048
+
+var (
+	// ErrUnsupportedKey is returned for keys whose type or curve has no signing algorithm.
+	ErrUnsupportedKey = errors.New("unsupported key type")
+	// ErrUnsupportedAlgorithm is returned when generating a key for an unknown algorithm.
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+)
+
+// Key is an RSA, ECDSA or Ed25519 key identified by a key ID ("kid"). Private is nil for
+// keys that can only verify tokens, such as keys retired after a rotation. The signing
+// algorithm follows from the key type, see SigningMethod.
+type Key struct {
+	ID      string
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+// SigningMethod returns the method signing and verifying tokens with the key.
+func (k *Key) SigningMethod() jwt.SigningMethod {
+	method, _ := signingMethod(k.Public)
+	return method
+}
+
+// signingMethod returns the signing method matching the type of a public key.
+func signingMethod(key crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512, nil
+		}
+		return nil, fmt.Errorf("%w: ECDSA curve %s", ErrUnsupportedKey, key.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, key)
+	}
+}
+
+// NewPrivateKey generates a private key for the signing algorithm. bits sets the size
+// of RSA keys and is ignored by the other algorithms, whose size follows from the curve.
+func NewPrivateKey(algorithm string, bits int) (crypto.Signer, error) {
+	switch algorithm {
+	case AlgorithmRS256:
+		if bits == 0 {
+			bits = DefaultRSABits
+		}
+		return rsa.GenerateKey(rand.Reader, bits)
+	case AlgorithmES256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case AlgorithmES384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case AlgorithmES512:
+		return ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	case AlgorithmEdDSA:
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		return privateKey, err
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, algorithm)
+	}
+}
+
+// MarshalPrivateKey encodes a private key as a PKCS #8 PEM block.
+func MarshalPrivateKey(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// MarshalPublicKey encodes a public key as a PKIX PEM block.
+func MarshalPublicKey(key crypto.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// parseKey parses a PEM encoded private or public key. Private keys may be PKCS #8,
+// PKCS #1 (RSA) or SEC 1 (ECDSA) encoded, public keys PKIX or PKCS #1 encoded.
+func parseKey(kid string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, jwt.ErrKeyMustBePEMEncoded
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%w: PEM block %q", ErrUnsupportedKey, block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &Key{ID: kid}
+	if signer, ok := key.(crypto.Signer); ok {
+		parsed.Private = signer
+		parsed.Public = signer.Public()
+	} else {
+		parsed.Public = key
+	}
+	if _, err := signingMethod(parsed.Public); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
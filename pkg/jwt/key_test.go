@@ -0,0 +1,119 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		algorithm     string
+		expectedKty   string
+		expectedCrv   string
+		expectedError error
+	}{
+		{name: "RS256", algorithm: AlgorithmRS256, expectedKty: "RSA"},
+		{name: "ES256", algorithm: AlgorithmES256, expectedKty: "EC", expectedCrv: "P-256"},
+		{name: "ES384", algorithm: AlgorithmES384, expectedKty: "EC", expectedCrv: "P-384"},
+		{name: "ES512", algorithm: AlgorithmES512, expectedKty: "EC", expectedCrv: "P-521"},
+		{name: "EdDSA", algorithm: AlgorithmEdDSA, expectedKty: "OKP", expectedCrv: "Ed25519"},
+		{name: "error - symmetric algorithm", algorithm: "HS256", expectedError: ErrUnsupportedAlgorithm},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			privateKey, err := NewPrivateKey(tc.algorithm, 0)
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError != nil {
+				return
+			}
+
+			// The key survives a PEM round trip and keeps its algorithm.
+			privatePEM, err := MarshalPrivateKey(privateKey)
+			assert.NoError(t, err)
+			key, err := parseKey("kid", privatePEM)
+			assert.NoError(t, err)
+			assert.NotNil(t, key.Private)
+			assert.Equal(t, tc.algorithm, key.SigningMethod().Alg())
+
+			publicPEM, err := MarshalPublicKey(privateKey.Public())
+			assert.NoError(t, err)
+			key, err = parseKey("kid", publicPEM)
+			assert.NoError(t, err)
+			assert.Nil(t, key.Private)
+			assert.Equal(t, tc.algorithm, key.SigningMethod().Alg())
+
+			jwk := newJWK("kid", key.Public)
+			assert.Equal(t, tc.expectedKty, jwk.Kty)
+			assert.Equal(t, tc.expectedCrv, jwk.Crv)
+			assert.Equal(t, tc.algorithm, jwk.Alg)
+		})
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	t.Parallel()
+
+	p224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	assert.NoError(t, err)
+	p224DER, err := x509.MarshalECPrivateKey(p224)
+	assert.NoError(t, err)
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	p256DER, err := x509.MarshalECPrivateKey(p256)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		data           []byte
+		expectedMethod jwt.SigningMethod
+		expectedError  error
+	}{
+		{
+			name:           "success - SEC 1 ECDSA private key",
+			data:           pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: p256DER}),
+			expectedMethod: jwt.SigningMethodES256,
+		},
+		{
+			name:          "error - unsupported curve",
+			data:          pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: p224DER}),
+			expectedError: ErrUnsupportedKey,
+		},
+		{
+			name:          "error - unsupported PEM block",
+			data:          pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: []byte("csr")}),
+			expectedError: ErrUnsupportedKey,
+		},
+		{
+			name:          "error - not PEM encoded",
+			data:          []byte("not a key"),
+			expectedError: jwt.ErrKeyMustBePEMEncoded,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := parseKey("kid", tc.data)
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError != nil {
+				assert.Nil(t, key)
+				return
+			}
+			assert.Equal(t, tc.expectedMethod, key.SigningMethod())
+		})
+	}
+}
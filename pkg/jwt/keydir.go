@@ -0,0 +1,183 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// keyFileExt is the extension of key files in a key directory; the file name without
+	// it is the key ID.
+	keyFileExt = ".pem"
+	// activeFileName is the file of a key directory holding the ID of the signing key.
+	activeFileName = "active"
+	// keyIDFormat is the layout of the time prefixing the IDs of generated keys, so they
+	// sort by age.
+	keyIDFormat = "20060102150405"
+	// keyIDThumbprintLen is the length of the thumbprint suffix keeping the IDs of keys
+	// generated within the same second unique.
+	keyIDThumbprintLen = 8
+)
+
+// ErrActiveKey is returned when retiring the key that currently signs tokens.
+var ErrActiveKey = errors.New("the active key cannot be retired")
+
+// LoadKeySetFromDir loads a key set from a directory holding one PEM file per key,
+// named "<kid>.pem". A file holding a private key can sign and verify tokens, a file
+// holding only a public key can verify them. The "active" file names the signing key;
+// it may be omitted when the directory holds a single private key.
+func LoadKeySetFromDir(dir string) (*KeySet, error) {
+	keys, activeID, err := ListKeys(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if activeID == "" {
+		return nil, fmt.Errorf("%w: no %s file in %s", ErrNoSigningKey, activeFileName, dir)
+	}
+
+	return NewKeySet(activeID, keys...)
+}
+
+// ListKeys returns the keys of the key directory ordered by ID, and the ID of the signing
+// key, which is empty when no key has been promoted yet. Unlike LoadKeySetFromDir, it does
+// not require the directory to hold a signing key.
+func ListKeys(dir string) ([]*Key, string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	keys := make([]*Key, 0, len(entries))
+	privateIDs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != keyFileExt {
+			continue
+		}
+		key, err := readKeyFile(dir, strings.TrimSuffix(entry.Name(), keyFileExt))
+		if err != nil {
+			return nil, "", err
+		}
+		keys = append(keys, key)
+		if key.Private != nil {
+			privateIDs = append(privateIDs, key.ID)
+		}
+	}
+
+	activeID, err := readActiveID(dir)
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist):
+		if len(privateIDs) == 1 {
+			activeID = privateIDs[0]
+		}
+	default:
+		return nil, "", err
+	}
+
+	return keys, activeID, nil
+}
+
+// GenerateKey creates a new private key for the signing algorithm in the key directory
+// and returns its ID. bits sets the size of RSA keys, see NewPrivateKey. The key is not
+// promoted, so it can be rolled out to every instance for verification before any
+// instance signs with it.
+func GenerateKey(dir, algorithm string, bits int) (string, error) {
+	privateKey, err := NewPrivateKey(algorithm, bits)
+	if err != nil {
+		return "", err
+	}
+	data, err := MarshalPrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	kid := time.Now().UTC().Format(keyIDFormat) + "-" + Thumbprint(privateKey.Public())[:keyIDThumbprintLen]
+	file, err := os.OpenFile(keyFilePath(dir, kid), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if _, err := file.Write(data); err != nil {
+		return "", err
+	}
+
+	return kid, nil
+}
+
+// PromoteKey makes the key with the given ID the signing key of the key directory.
+// The key must hold a private key.
+func PromoteKey(dir, kid string) error {
+	key, err := readKeyFile(dir, kid)
+	if err != nil {
+		return err
+	}
+	if key.Private == nil {
+		return fmt.Errorf("%w: %q holds no private key", ErrNoSigningKey, kid)
+	}
+
+	return os.WriteFile(filepath.Join(dir, activeFileName), []byte(kid+"\n"), 0o600)
+}
+
+// RetireKey replaces the private key with the given ID by its public key, so it keeps
+// verifying the tokens it signed but can no longer sign new ones. The active key
+// cannot be retired.
+func RetireKey(dir, kid string) error {
+	keys, activeID, err := ListKeys(dir)
+	if err != nil {
+		return err
+	}
+	if activeID == kid {
+		return ErrActiveKey
+	}
+	idx := slices.IndexFunc(keys, func(key *Key) bool { return key.ID == kid })
+	if idx < 0 {
+		return fmt.Errorf("%w: %q", ErrKeyNotFound, kid)
+	}
+	key := keys[idx]
+
+	data, err := MarshalPublicKey(key.Public)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(keyFilePath(dir, kid), data, 0o600)
+}
+
+// readActiveID reads the ID of the signing key from the active file of the key directory.
+func readActiveID(dir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, activeFileName))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// readKeyFile reads the key with the given ID from the key directory.
+func readKeyFile(dir, kid string) (*Key, error) {
+	data, err := os.ReadFile(keyFilePath(dir, kid))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := parseKey(kid, data)
+	if err != nil {
+		return nil, fmt.Errorf("key %q: %w", kid, err)
+	}
+
+	return key, nil
+}
+
+// keyFilePath returns the path of the file holding the key with the given ID.
+func keyFilePath(dir, kid string) string {
+	return filepath.Join(dir, kid+keyFileExt)
+}
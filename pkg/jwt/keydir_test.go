@@ -0,0 +1,144 @@
+package jwt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyDir_Rotation(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	claims := jwt.MapClaims{"sub": "truonglq"}
+
+	oldID, err := GenerateKey(dir, AlgorithmRS256, 2048)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, RetireKey(dir, oldID), ErrActiveKey)
+	assert.NoError(t, PromoteKey(dir, oldID))
+
+	ks, err := LoadKeySetFromDir(dir)
+	assert.NoError(t, err)
+	oldToken, err := NewKeySetGenerator(ks, &Config{}).GenerateToken(claims)
+	assert.NoError(t, err)
+
+	// A new key is rolled out for verification before it is promoted.
+	newID, err := GenerateKey(dir, AlgorithmRS256, 2048)
+	assert.NoError(t, err)
+	assert.NotEqual(t, oldID, newID)
+
+	ks, err = LoadKeySetFromDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, oldID, ks.Active().ID)
+	assert.ElementsMatch(t, []string{oldID, newID}, ks.IDs())
+
+	assert.NoError(t, PromoteKey(dir, newID))
+	assert.ErrorIs(t, RetireKey(dir, newID), ErrActiveKey)
+	assert.ErrorIs(t, RetireKey(dir, "missing"), ErrKeyNotFound)
+	assert.NoError(t, RetireKey(dir, oldID))
+
+	ks, err = LoadKeySetFromDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, newID, ks.Active().ID)
+	retired, ok := ks.Key(oldID)
+	assert.True(t, ok)
+	assert.Nil(t, retired.Private)
+	assert.ErrorIs(t, PromoteKey(dir, oldID), ErrNoSigningKey)
+
+	validator := NewKeySetValidator(ks, &Config{})
+	res, err := validator.ValidateToken(oldToken)
+	assert.NoError(t, err)
+	assert.Equal(t, claims, res)
+
+	newToken, err := NewKeySetGenerator(ks, &Config{}).GenerateToken(claims)
+	assert.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, jwt.MapClaims{})
+	assert.NoError(t, err)
+	assert.Equal(t, newID, parsed.Header["kid"])
+	res, err = validator.ValidateToken(newToken)
+	assert.NoError(t, err)
+	assert.Equal(t, claims, res)
+}
+
+func TestLoadKeySetFromDir(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		setupDir      func(t *testing.T) string
+		expectedIDs   []string
+		expectedError error
+	}{
+		{
+			name: "success - single private key without active file",
+			setupDir: func(t *testing.T) string {
+				dir := t.TempDir()
+				copyTestFile(t, "./private_test.pem", filepath.Join(dir, "key-1.pem"))
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("ignored"), 0o600))
+				return dir
+			},
+			expectedIDs: []string{"key-1"},
+		},
+		{
+			name: "success - public keys verify only",
+			setupDir: func(t *testing.T) string {
+				dir := t.TempDir()
+				copyTestFile(t, "./private_test.pem", filepath.Join(dir, "key-2.pem"))
+				copyTestFile(t, "./public_test.pem", filepath.Join(dir, "key-1.pem"))
+				return dir
+			},
+			expectedIDs: []string{"key-1", "key-2"},
+		},
+		{
+			name: "error - several private keys without active file",
+			setupDir: func(t *testing.T) string {
+				dir := t.TempDir()
+				copyTestFile(t, "./private_test.pem", filepath.Join(dir, "key-1.pem"))
+				copyTestFile(t, "./private_test.pem", filepath.Join(dir, "key-2.pem"))
+				return dir
+			},
+			expectedError: ErrNoSigningKey,
+		},
+		{
+			name: "error - active key is not a private key",
+			setupDir: func(t *testing.T) string {
+				dir := t.TempDir()
+				copyTestFile(t, "./public_test.pem", filepath.Join(dir, "key-1.pem"))
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, "active"), []byte("key-1\n"), 0o600))
+				return dir
+			},
+			expectedError: ErrNoSigningKey,
+		},
+		{
+			name: "error - directory not found",
+			setupDir: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "missing")
+			},
+			expectedError: os.ErrNotExist,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ks, err := LoadKeySetFromDir(tc.setupDir(t))
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError != nil {
+				assert.Nil(t, ks)
+				return
+			}
+			assert.Equal(t, tc.expectedIDs, ks.IDs())
+		})
+	}
+}
+
+// copyTestFile copies a key file of the package into a key directory.
+func copyTestFile(t *testing.T, src, dst string) {
+	data, err := os.ReadFile(src)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(dst, data, 0o600))
+}
@@ -0,0 +1,185 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+var (
+	// ErrNoSigningKey is returned when a key set has no active key able to sign tokens.
+	ErrNoSigningKey = errors.New("no active signing key")
+	// ErrKeyNotFound is returned when a key ID does not match any key of a key set.
+	ErrKeyNotFound = errors.New("key not found")
+)
+
+// KeySet holds the keys used to sign and verify tokens. Tokens are signed with the
+// active key only, while every key of the set is accepted for verification, so tokens
+// issued before a rotation stay valid until they expire.
+type KeySet struct {
+	activeID string
+	keys     map[string]*Key
+}
+
+// NewKeySet creates a key set from the given keys with activeID as the signing key.
+// The active key must exist and hold a private key, and every key must be of a
+// supported type. Keys of different types may be mixed, for example while rotating from
+// an RSA to an ECDSA key.
+func NewKeySet(activeID string, keys ...*Key) (*KeySet, error) {
+	ks := &KeySet{
+		activeID: activeID,
+		keys:     make(map[string]*Key, len(keys)),
+	}
+	for _, key := range keys {
+		if key.Public == nil && key.Private != nil {
+			key.Public = key.Private.Public()
+		}
+		if _, err := signingMethod(key.Public); err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.ID, err)
+		}
+		ks.keys[key.ID] = key
+	}
+
+	active, ok := ks.keys[activeID]
+	if !ok || active.Private == nil {
+		return nil, fmt.Errorf("%w: %q", ErrNoSigningKey, activeID)
+	}
+
+	return ks, nil
+}
+
+// Active returns the key used to sign new tokens.
+func (ks *KeySet) Active() *Key {
+	return ks.keys[ks.activeID]
+}
+
+// Key returns the key with the given ID.
+func (ks *KeySet) Key(id string) (*Key, bool) {
+	key, ok := ks.keys[id]
+	return key, ok
+}
+
+// IDs returns the IDs of all keys of the set in lexical order.
+func (ks *KeySet) IDs() []string {
+	ids := make([]string, 0, len(ks.keys))
+	for id := range ks.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// Algorithms returns the signing algorithms of the keys of the set, in lexical order.
+func (ks *KeySet) Algorithms() []string {
+	seen := make(map[string]bool, len(ks.keys))
+	algs := make([]string, 0, len(ks.keys))
+	for _, key := range ks.keys {
+		alg := key.SigningMethod().Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			algs = append(algs, alg)
+		}
+	}
+	sort.Strings(algs)
+
+	return algs
+}
+
+// JWK is the JSON Web Key representation of a public key (RFC 7517). RSA keys set N
+// and E, ECDSA keys Crv, X and Y, and Ed25519 keys Crv and X (RFC 8037).
+type JWK struct {
+	Kty string `json:"kty" example:"RSA"`
+	Use string `json:"use" example:"sig"`
+	Alg string `json:"alg" example:"RS256"`
+	Kid string `json:"kid" example:"20250301100000-NzbLsXh8"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty" example:"AQAB"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set (RFC 7517) listing the keys that verify tokens.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys of the set, ordered by key ID.
+func (ks *KeySet) JWKS() JWKS {
+	set := JWKS{Keys: make([]JWK, 0, len(ks.keys))}
+	for _, id := range ks.IDs() {
+		set.Keys = append(set.Keys, newJWK(id, ks.keys[id].Public))
+	}
+
+	return set
+}
+
+// newJWK encodes a public key of a supported type as a JWK with the given key ID.
+func newJWK(kid string, key crypto.PublicKey) JWK {
+	jwk := JWK{Use: "sig", Kid: kid}
+	if method, err := signingMethod(key); err == nil {
+		jwk.Alg = method.Alg()
+	}
+
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	case *ecdsa.PublicKey:
+		// Coordinates are padded to the curve size (RFC 7518, section 6.2.1).
+		size := (key.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = key.Curve.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, size)))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(key)
+	}
+
+	return jwk
+}
+
+// Thumbprint returns the RFC 7638 JWK thumbprint of a public key. It is used as the
+// key ID of keys that are not given one explicitly.
+func Thumbprint(key crypto.PublicKey) string {
+	jwk := newJWK("", key)
+	// RFC 7638 hashes the required members only, in lexical order and without whitespace.
+	var members any
+	switch jwk.Kty {
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{Crv: jwk.Crv, Kty: jwk.Kty, X: jwk.X, Y: jwk.Y}
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{Crv: jwk.Crv, Kty: jwk.Kty, X: jwk.X}
+	default:
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{E: jwk.E, Kty: jwk.Kty, N: jwk.N}
+	}
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,102 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThumbprint(t *testing.T) {
+	t.Parallel()
+
+	// Example key and thumbprint from RFC 7638, section 3.1.
+	n, _ := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+	key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537}
+
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", Thumbprint(key))
+
+	// Example Ed25519 key and thumbprint from RFC 8037, appendix A.3.
+	x, _ := base64.RawURLEncoding.DecodeString("11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo")
+	assert.Equal(t, "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", Thumbprint(ed25519.PublicKey(x)))
+}
+
+func TestNewKeySet(t *testing.T) {
+	t.Parallel()
+
+	signing := loadTestKey(t)
+	verifyOnly := &Key{ID: "old", Public: signing.Public}
+
+	testCases := []struct {
+		name          string
+		activeID      string
+		keys          []*Key
+		expectedIDs   []string
+		expectedError error
+	}{
+		{
+			name:        "success",
+			activeID:    "new",
+			keys:        []*Key{{ID: "new", Private: signing.Private}, verifyOnly},
+			expectedIDs: []string{"new", "old"},
+		},
+		{
+			name:          "error - active key missing",
+			activeID:      "missing",
+			keys:          []*Key{{ID: "new", Private: signing.Private}},
+			expectedError: ErrNoSigningKey,
+		},
+		{
+			name:          "error - active key cannot sign",
+			activeID:      "old",
+			keys:          []*Key{verifyOnly},
+			expectedError: ErrNoSigningKey,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ks, err := NewKeySet(tc.activeID, tc.keys...)
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError != nil {
+				assert.Nil(t, ks)
+				return
+			}
+			assert.Equal(t, tc.expectedIDs, ks.IDs())
+			assert.Equal(t, tc.activeID, ks.Active().ID)
+			assert.NotNil(t, ks.Active().Public)
+		})
+	}
+}
+
+func TestKeySet_JWKS(t *testing.T) {
+	t.Parallel()
+
+	signing := loadTestKey(t)
+	ks, err := NewKeySet("b", &Key{ID: "b", Private: signing.Private}, &Key{ID: "a", Public: signing.Public})
+	assert.NoError(t, err)
+
+	jwks := ks.JWKS()
+	assert.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "a", jwks.Keys[0].Kid)
+	assert.Equal(t, "b", jwks.Keys[1].Kid)
+	for _, jwk := range jwks.Keys {
+		assert.Equal(t, "RSA", jwk.Kty)
+		assert.Equal(t, "sig", jwk.Use)
+		assert.Equal(t, "RS256", jwk.Alg)
+		assert.Equal(t, "AQAB", jwk.E)
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(signing.Public.(*rsa.PublicKey).N.Bytes()), jwk.N)
+	}
+}
+
+// loadTestKey loads the private key used by the generator tests.
+func loadTestKey(t *testing.T) *Key {
+	ks, err := LoadKeySet(&Config{PrivateKeyPath: "./private_test.pem"})
+	assert.NoError(t, err)
+	return ks.Active()
+}
@@ -3,7 +3,8 @@
 package mocks
 
 import (
-	jwt "github.com/golang-jwt/jwt/v5"
+	v5 "github.com/golang-jwt/jwt/v5"
+	jwt "github.com/luongtruong20201/bookmark-management/pkg/jwt"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -12,24 +13,42 @@ type JWTValidator struct {
 	mock.Mock
 }
 
+// JWKS provides a mock function with no fields
+func (_m *JWTValidator) JWKS() jwt.JWKS {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 jwt.JWKS
+	if rf, ok := ret.Get(0).(func() jwt.JWKS); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(jwt.JWKS)
+	}
+
+	return r0
+}
+
 // ValidateToken provides a mock function with given fields: _a0
-func (_m *JWTValidator) ValidateToken(_a0 string) (jwt.MapClaims, error) {
+func (_m *JWTValidator) ValidateToken(_a0 string) (v5.MapClaims, error) {
 	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateToken")
 	}
 
-	var r0 jwt.MapClaims
+	var r0 v5.MapClaims
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (jwt.MapClaims, error)); ok {
+	if rf, ok := ret.Get(0).(func(string) (v5.MapClaims, error)); ok {
 		return rf(_a0)
 	}
-	if rf, ok := ret.Get(0).(func(string) jwt.MapClaims); ok {
+	if rf, ok := ret.Get(0).(func(string) v5.MapClaims); ok {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(jwt.MapClaims)
+			r0 = ret.Get(0).(v5.MapClaims)
 		}
 	}
 
@@ -1,62 +1,169 @@
 package jwt
 
 import (
-	"crypto/rsa"
 	"errors"
+	"fmt"
 	"os"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
 var (
-	// errInvalidToken is returned when a JWT token cannot be validated or is malformed.
-	errInvalidToken = errors.New("invalid token")
+	// ErrInvalidToken is returned when a JWT token cannot be validated or is malformed.
+	// Every other validation error wraps it.
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenExpired is returned when the token is past its "exp" claim.
+	ErrTokenExpired = fmt.Errorf("%w: token is expired", ErrInvalidToken)
+	// ErrTokenNotValidYet is returned when the token is used before its "nbf" or "iat" claim.
+	ErrTokenNotValidYet = fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	// ErrTokenSignature is returned when the token signature does not verify, or the token
+	// is signed with an algorithm or key that is not accepted.
+	ErrTokenSignature = fmt.Errorf("%w: token signature is invalid", ErrInvalidToken)
+	// ErrTokenAudience is returned when the "aud" claim does not name the configured audience.
+	ErrTokenAudience = fmt.Errorf("%w: token has invalid audience", ErrInvalidToken)
+	// ErrTokenIssuer is returned when the "iss" claim is not the configured issuer.
+	ErrTokenIssuer = fmt.Errorf("%w: token has invalid issuer", ErrInvalidToken)
+	// ErrTokenClaimMissing is returned when the token lacks a required claim.
+	ErrTokenClaimMissing = fmt.Errorf("%w: token is missing required claim", ErrInvalidToken)
 )
 
 // JWTValidator defines the interface for JWT token validation.
-// It provides methods to verify and parse JWT tokens using RSA public keys.
+// It provides methods to verify and parse JWT tokens using RSA, ECDSA or Ed25519
+// public keys, and to publish those keys for other services.
 //
 //go:generate mockery --name JWTValidator --filename jwt_validator.go
 type JWTValidator interface {
 	ValidateToken(string) (jwt.MapClaims, error)
+	// JWKS returns the public keys accepted by ValidateToken as a JSON Web Key Set.
+	JWKS() JWKS
 }
 
 // jwtValidator implements the JWTValidator interface and provides JWT token validation
-// functionality using RSA public keys. It verifies token signatures and validates
+// functionality using public keys. It verifies token signatures and validates
 // token claims to ensure authenticity and integrity.
 type jwtValidator struct {
-	publicKey *rsa.PublicKey
+	keySet         *KeySet
+	parser         *jwt.Parser
+	requiredClaims []string
 }
 
 // NewJWTValidator creates a new JWT validator instance by loading and parsing
-// an RSA public key from the specified file path. The public key is used
-// to verify JWT token signatures.
+// a PEM public key from the specified file path. The public key is used to verify
+// JWT token signatures with the algorithm matching its type; the issuer and audience
+// are not checked.
 func NewJWTValidator(publicKeyPath string) (JWTValidator, error) {
 	publicKeyData, err := os.ReadFile(publicKeyPath)
 	if err != nil {
 		return nil, err
 	}
 
-	publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicKeyData)
+	key, err := parseKey("", publicKeyData)
 	if err != nil {
 		return nil, err
 	}
 
+	key.ID = Thumbprint(key.Public)
+	keySet := &KeySet{
+		activeID: key.ID,
+		keys:     map[string]*Key{key.ID: {ID: key.ID, Public: key.Public}},
+	}
 	return &jwtValidator{
-		publicKey: publicKey,
+		keySet: keySet,
+		parser: newParser(keySet, &Config{}),
 	}, nil
 }
 
+// NewKeySetValidator creates a new JWT validator accepting tokens signed by any key of
+// the key set. The key is selected by the "kid" header of the token. Tokens must carry
+// cfg.RequiredClaims and name cfg.Issuer and cfg.Audience. Their time based claims are
+// checked with cfg.Leeway.
+func NewKeySetValidator(keySet *KeySet, cfg *Config) JWTValidator {
+	return &jwtValidator{
+		keySet:         keySet,
+		parser:         newParser(keySet, cfg),
+		requiredClaims: cfg.RequiredClaims,
+	}
+}
+
+// newParser creates a token parser accepting the algorithms of the keys of the key set
+// only and checking the registered claims configured in cfg. Empty issuer and audience
+// are not checked.
+func newParser(keySet *KeySet, cfg *Config) *jwt.Parser {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(keySet.Algorithms()),
+		jwt.WithLeeway(cfg.Leeway),
+		jwt.WithIssuedAt(),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	return jwt.NewParser(opts...)
+}
+
 // ValidateToken verifies the signature and validity of a JWT token string.
-// It parses the token, validates it against the loaded public key, and returns
-// the token claims if valid. Returns an error if the token is invalid or malformed.
+// It parses the token, validates it against the public key named by its "kid" header
+// with the algorithm of that key, and returns the token claims if valid. Tokens without a "kid" header were issued
+// before key rotation was supported and are verified with the active key.
+// Returns ErrInvalidToken or one of the errors wrapping it if the token is rejected.
 func (v *jwtValidator) ValidateToken(tokenStr string) (jwt.MapClaims, error) {
-	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (any, error) {
-		return v.publicKey, nil
+	token, err := v.parser.Parse(tokenStr, func(token *jwt.Token) (any, error) {
+		key := v.keySet.Active()
+		if kid, ok := token.Header["kid"].(string); ok {
+			if key, ok = v.keySet.Key(kid); !ok {
+				return nil, ErrKeyNotFound
+			}
+		}
+		// A key only verifies tokens signed with its own algorithm, so a token cannot
+		// pick the algorithm used to check it.
+		if token.Method.Alg() != key.SigningMethod().Alg() {
+			return nil, ErrTokenSignature
+		}
+		return key.Public, nil
 	})
-	if err != nil || !token.Valid {
-		return nil, errInvalidToken
+	if err != nil {
+		return nil, validationError(err)
+	}
+	if !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	for _, name := range v.requiredClaims {
+		if _, ok := claims[name]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrTokenClaimMissing, name)
+		}
 	}
 
-	return token.Claims.(jwt.MapClaims), nil
+	return claims, nil
+}
+
+// validationError maps a parsing error of the jwt library to the validation error
+// reported to callers.
+func validationError(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return ErrTokenNotValidYet
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, ErrTokenSignature),
+		errors.Is(err, ErrKeyNotFound):
+		return ErrTokenSignature
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return ErrTokenAudience
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return ErrTokenIssuer
+	case errors.Is(err, jwt.ErrTokenRequiredClaimMissing):
+		return ErrTokenClaimMissing
+	default:
+		return ErrInvalidToken
+	}
+}
+
+// JWKS returns the public keys of the validator's key set.
+func (v *jwtValidator) JWKS() JWKS {
+	return v.keySet.JWKS()
 }
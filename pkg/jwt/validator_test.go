@@ -1,8 +1,11 @@
 package jwt
 
 import (
+	"crypto/x509"
+	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
@@ -62,3 +65,311 @@ func TestJWTValidator_ValidateToken(t *testing.T) {
 		})
 	}
 }
+
+func TestKeySetValidator_ValidateToken(t *testing.T) {
+	t.Parallel()
+
+	signing := loadTestKey(t)
+	other, err := LoadKeySetFromDir(generateKeyDir(t))
+	assert.NoError(t, err)
+
+	ecKey, err := NewPrivateKey(AlgorithmES256, 0)
+	assert.NoError(t, err)
+	edKey, err := NewPrivateKey(AlgorithmEdDSA, 0)
+	assert.NoError(t, err)
+
+	current, err := NewKeySet("current",
+		&Key{ID: "current", Private: signing.Private},
+		&Key{ID: "previous", Public: other.Active().Public},
+		&Key{ID: "ec", Public: ecKey.Public()},
+		&Key{ID: "ed", Public: edKey.Public()},
+	)
+	assert.NoError(t, err)
+	ecSigner, err := NewKeySet("ec", &Key{ID: "ec", Private: ecKey})
+	assert.NoError(t, err)
+	edSigner, err := NewKeySet("ed", &Key{ID: "ed", Private: edKey})
+	assert.NoError(t, err)
+	mismatched, err := NewKeySet("previous", &Key{ID: "previous", Private: ecKey})
+	assert.NoError(t, err)
+	previous, err := NewKeySet("previous", &Key{ID: "previous", Private: other.Active().Private})
+	assert.NoError(t, err)
+	unknown, err := NewKeySet("unknown", &Key{ID: "unknown", Private: other.Active().Private})
+	assert.NoError(t, err)
+	legacy, err := NewJWTGenerator("./private_test.pem")
+	assert.NoError(t, err)
+
+	claims := jwt.MapClaims{"name": "truonglq"}
+
+	testCases := []struct {
+		name           string
+		generator      JWTGenerator
+		expectedOutput jwt.MapClaims
+		expectedErrStr string
+	}{
+		{
+			name:           "success - active key",
+			generator:      NewKeySetGenerator(current, &Config{}),
+			expectedOutput: claims,
+		},
+		{
+			name:           "success - verification key",
+			generator:      NewKeySetGenerator(previous, &Config{}),
+			expectedOutput: claims,
+		},
+		{
+			name:           "success - token without kid uses active key",
+			generator:      legacy,
+			expectedOutput: claims,
+		},
+		{
+			name:           "success - ECDSA key",
+			generator:      NewKeySetGenerator(ecSigner, &Config{}),
+			expectedOutput: claims,
+		},
+		{
+			name:           "success - Ed25519 key",
+			generator:      NewKeySetGenerator(edSigner, &Config{}),
+			expectedOutput: claims,
+		},
+		{
+			name:           "error - algorithm does not match the kid key",
+			generator:      NewKeySetGenerator(mismatched, &Config{}),
+			expectedErrStr: ErrTokenSignature.Error(),
+		},
+		{
+			name:           "error - unknown kid",
+			generator:      NewKeySetGenerator(unknown, &Config{}),
+			expectedErrStr: ErrTokenSignature.Error(),
+		},
+	}
+
+	validator := NewKeySetValidator(current, &Config{})
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			token, err := tc.generator.GenerateToken(claims)
+			assert.NoError(t, err)
+
+			res, err := validator.ValidateToken(token)
+			assert.Equal(t, tc.expectedOutput, res)
+			if tc.expectedErrStr != "" {
+				assert.ErrorContains(t, err, tc.expectedErrStr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestKeySetValidator_ValidateClaims(t *testing.T) {
+	t.Parallel()
+
+	signing := loadTestKey(t)
+	keySet, err := NewKeySet(signing.ID, signing)
+	assert.NoError(t, err)
+	cfg := &Config{
+		Issuer:         "bookmark-service",
+		Audience:       "bookmark-service",
+		Leeway:         time.Minute,
+		RequiredClaims: []string{"sub", "exp", "iat"},
+	}
+	now := time.Now()
+
+	// generate signs claims valid for an hour, with the given changes applied.
+	generate := func(t *testing.T, generatorCfg *Config, changes jwt.MapClaims) string {
+		claims := jwt.MapClaims{"sub": "user-1", "iat": now.Unix(), "exp": now.Add(time.Hour).Unix()}
+		for name, value := range changes {
+			if value == nil {
+				delete(claims, name)
+				continue
+			}
+			claims[name] = value
+		}
+		token, err := NewKeySetGenerator(keySet, generatorCfg).GenerateToken(claims)
+		assert.NoError(t, err)
+		return token
+	}
+
+	testCases := []struct {
+		name          string
+		token         func(t *testing.T) string
+		expectedError error
+	}{
+		{
+			name: "success",
+			token: func(t *testing.T) string {
+				return generate(t, cfg, nil)
+			},
+		},
+		{
+			name: "success - expired within leeway",
+			token: func(t *testing.T) string {
+				return generate(t, cfg, jwt.MapClaims{"exp": now.Add(-30 * time.Second).Unix()})
+			},
+		},
+		{
+			name: "success - audience list",
+			token: func(t *testing.T) string {
+				return generate(t, cfg, jwt.MapClaims{"aud": []string{"other-service", "bookmark-service"}})
+			},
+		},
+		{
+			name: "error - expired",
+			token: func(t *testing.T) string {
+				return generate(t, cfg, jwt.MapClaims{"exp": now.Add(-2 * time.Minute).Unix()})
+			},
+			expectedError: ErrTokenExpired,
+		},
+		{
+			name: "error - not valid yet",
+			token: func(t *testing.T) string {
+				return generate(t, cfg, jwt.MapClaims{"nbf": now.Add(2 * time.Minute).Unix()})
+			},
+			expectedError: ErrTokenNotValidYet,
+		},
+		{
+			name: "error - issued in the future",
+			token: func(t *testing.T) string {
+				return generate(t, cfg, jwt.MapClaims{"iat": now.Add(2 * time.Minute).Unix()})
+			},
+			expectedError: ErrTokenNotValidYet,
+		},
+		{
+			name: "error - wrong audience",
+			token: func(t *testing.T) string {
+				return generate(t, &Config{Issuer: cfg.Issuer, Audience: "other-service"}, nil)
+			},
+			expectedError: ErrTokenAudience,
+		},
+		{
+			name: "error - wrong issuer",
+			token: func(t *testing.T) string {
+				return generate(t, &Config{Issuer: "other-service", Audience: cfg.Audience}, nil)
+			},
+			expectedError: ErrTokenIssuer,
+		},
+		{
+			name: "error - missing issuer",
+			token: func(t *testing.T) string {
+				return generate(t, &Config{Audience: cfg.Audience}, nil)
+			},
+			expectedError: ErrTokenClaimMissing,
+		},
+		{
+			name: "error - missing audience",
+			token: func(t *testing.T) string {
+				return generate(t, &Config{Issuer: cfg.Issuer}, nil)
+			},
+			expectedError: ErrTokenClaimMissing,
+		},
+		{
+			name: "error - token issued without issuer and audience",
+			token: func(t *testing.T) string {
+				return generate(t, &Config{}, nil)
+			},
+			expectedError: ErrTokenClaimMissing,
+		},
+		{
+			name: "error - missing required claim",
+			token: func(t *testing.T) string {
+				return generate(t, cfg, jwt.MapClaims{"sub": nil})
+			},
+			expectedError: ErrTokenClaimMissing,
+		},
+		{
+			name: "error - symmetric algorithm keyed with the public key",
+			token: func(t *testing.T) string {
+				publicKey, err := x509.MarshalPKIXPublicKey(signing.Public)
+				assert.NoError(t, err)
+				token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+					"iss": cfg.Issuer, "aud": cfg.Audience, "sub": "user-1",
+					"iat": now.Unix(), "exp": now.Add(time.Hour).Unix(),
+				}).SignedString(publicKey)
+				assert.NoError(t, err)
+				return token
+			},
+			expectedError: ErrTokenSignature,
+		},
+		{
+			name: "error - unsigned token",
+			token: func(t *testing.T) string {
+				token, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+					"iss": cfg.Issuer, "aud": cfg.Audience, "sub": "user-1",
+					"iat": now.Unix(), "exp": now.Add(time.Hour).Unix(),
+				}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+				assert.NoError(t, err)
+				return token
+			},
+			expectedError: ErrTokenSignature,
+		},
+		{
+			name: "error - malformed",
+			token: func(t *testing.T) string {
+				return "not-a-token"
+			},
+			expectedError: ErrInvalidToken,
+		},
+	}
+
+	validator := NewKeySetValidator(keySet, cfg)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			claims, err := validator.ValidateToken(tc.token(t))
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				assert.Nil(t, claims)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "user-1", claims["sub"])
+		})
+	}
+}
+
+func TestJWTValidator_KeyTypes(t *testing.T) {
+	t.Parallel()
+
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmES256, AlgorithmES384, AlgorithmES512, AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			privateKey, err := NewPrivateKey(algorithm, 0)
+			assert.NoError(t, err)
+			privatePEM, err := MarshalPrivateKey(privateKey)
+			assert.NoError(t, err)
+			publicPEM, err := MarshalPublicKey(privateKey.Public())
+			assert.NoError(t, err)
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, "private.pem"), privatePEM, 0o600))
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, "public.pem"), publicPEM, 0o600))
+
+			generator, err := NewJWTGenerator(filepath.Join(dir, "private.pem"))
+			assert.NoError(t, err)
+			validator, err := NewJWTValidator(filepath.Join(dir, "public.pem"))
+			assert.NoError(t, err)
+
+			token, err := generator.GenerateToken(jwt.MapClaims{"name": "truonglq"})
+			assert.NoError(t, err)
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+			assert.NoError(t, err)
+			assert.Equal(t, algorithm, parsed.Header["alg"])
+
+			claims, err := validator.ValidateToken(token)
+			assert.NoError(t, err)
+			assert.Equal(t, jwt.MapClaims{"name": "truonglq"}, claims)
+			assert.Equal(t, algorithm, validator.JWKS().Keys[0].Alg)
+		})
+	}
+}
+
+// generateKeyDir creates a key directory holding a single generated key.
+func generateKeyDir(t *testing.T) string {
+	dir := t.TempDir()
+	_, err := GenerateKey(dir, AlgorithmRS256, 2048)
+	assert.NoError(t, err)
+	return dir
+}
@@ -0,0 +1,24 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// FromContext returns the logger stored in the context by the request logging middleware,
+// which carries the request ID and the authenticated user ID. Outside a request, or when
+// no logger has been stored, it returns the global logger.
+func FromContext(ctx context.Context) *zerolog.Logger {
+	if l := zerolog.Ctx(ctx); l != zerolog.Ctx(context.Background()) {
+		return l
+	}
+
+	return &log.Logger
+}
+
+// WithContext returns a copy of the context holding the given logger.
+func WithContext(ctx context.Context, l zerolog.Logger) context.Context {
+	return l.WithContext(ctx)
+}
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	requestLogger := zerolog.New(&buf).With().Str("request_id", "req-1").Logger()
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		expected *zerolog.Logger
+	}{
+		{
+			name:     "success - logger stored in context",
+			ctx:      WithContext(context.Background(), requestLogger),
+			expected: &requestLogger,
+		},
+		{
+			name:     "success - falls back to the global logger",
+			ctx:      context.Background(),
+			expected: &log.Logger,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, FromContext(tc.ctx))
+		})
+	}
+}
+
+func TestFromContext_WritesContextFields(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	ctx := WithContext(context.Background(), zerolog.New(&buf).With().Str("request_id", "req-1").Logger())
+
+	FromContext(ctx).Info().Msg("hello")
+
+	assert.JSONEq(t, `{"level":"info","request_id":"req-1","message":"hello"}`, buf.String())
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type gormPluginTestRow struct {
+	ID   int
+	Name string
+}
+
+func TestGORMPlugin(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Use(NewGORMPlugin()))
+	assert.NoError(t, db.AutoMigrate(&gormPluginTestRow{}))
+
+	assert.NoError(t, db.Create(&gormPluginTestRow{ID: 1, Name: "first"}).Error)
+	var row gormPluginTestRow
+	assert.NoError(t, db.First(&row, 1).Error)
+	assert.Error(t, db.First(&row, 2).Error)
+	assert.Error(t, db.Table("missing_table").Find(&[]gormPluginTestRow{}).Error)
+
+	assert.Equal(t, uint64(1), histogramCount(t, dbQueryDuration, "create", "gorm_plugin_test_rows"))
+	assert.Equal(t, uint64(2), histogramCount(t, dbQueryDuration, "query", "gorm_plugin_test_rows"))
+	assert.Equal(t, float64(0), testutil.ToFloat64(dbQueryErrors.WithLabelValues("query", "gorm_plugin_test_rows")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(dbQueryErrors.WithLabelValues("query", "missing_table")))
+}
@@ -0,0 +1,166 @@
+// Package metrics defines the Prometheus metrics of the service and the helpers that
+// record them. The collectors are registered with a registry owned by the package, which
+// also exports the Go runtime and process metrics, and are served by Handler.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes the name of every metric of the service.
+const namespace = "bookmark"
+
+// Cache lookup results.
+const (
+	cacheHit  = "hit"
+	cacheMiss = "miss"
+)
+
+// Reasons a login fails, reported by LoginFailed.
+const (
+	LoginFailureInvalidCredentials = "invalid_credentials"
+	LoginFailureDisabled           = "disabled"
+	LoginFailurePasswordReset      = "password_reset_required"
+	LoginFailureThrottled          = "throttled"
+)
+
+// registry holds the collectors of the service.
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+	httpRequestDuration = promauto.With(registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by method, route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	dbQueryDuration = promauto.With(registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Latency of database queries by operation and table.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"operation", "table"})
+	dbQueryErrors = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_query_errors_total",
+		Help:      "Number of failed database queries by operation and table; record not found is not a failure.",
+	}, []string{"operation", "table"})
+
+	redisCommandDuration = promauto.With(registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "redis_command_duration_seconds",
+		Help:      "Latency of Redis commands by command name; pipelines are reported as \"pipeline\".",
+		Buckets:   []float64{.0001, .0005, .001, .0025, .005, .01, .025, .05, .1, .25},
+	}, []string{"command"})
+	redisCommandErrors = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "redis_command_errors_total",
+		Help:      "Number of failed Redis commands by command name; a missing key is not a failure.",
+	}, []string{"command"})
+
+	cacheRequests = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_requests_total",
+		Help:      "Number of cache lookups by cache and result, hit or miss.",
+	}, []string{"cache", "result"})
+
+	bookmarksCreated = promauto.With(registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bookmarks_created_total",
+		Help:      "Number of bookmarks created.",
+	})
+	linksShortened = promauto.With(registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "links_shortened_total",
+		Help:      "Number of links shortened.",
+	})
+	redirectsServed = promauto.With(registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "redirects_served_total",
+		Help:      "Number of short link redirects served.",
+	})
+	loginFailures = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_failures_total",
+		Help:      "Number of failed logins by reason.",
+	}, []string{"reason"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler returns the HTTP handler exposing the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest records an HTTP request served for the route template, e.g.
+// "/v1/bookmarks/:id", so that requests to different resources share a series.
+func ObserveRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpRequestDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// ObserveQuery records a database query and whether it failed.
+func ObserveQuery(operation, table string, duration time.Duration, failed bool) {
+	dbQueryDuration.WithLabelValues(operation, table).Observe(duration.Seconds())
+	if failed {
+		dbQueryErrors.WithLabelValues(operation, table).Inc()
+	}
+}
+
+// ObserveRedisCommand records a Redis command and whether it failed.
+func ObserveRedisCommand(command string, duration time.Duration, failed bool) {
+	redisCommandDuration.WithLabelValues(command).Observe(duration.Seconds())
+	if failed {
+		redisCommandErrors.WithLabelValues(command).Inc()
+	}
+}
+
+// CacheHit records a lookup served from the named cache.
+func CacheHit(cache string) {
+	cacheRequests.WithLabelValues(cache, cacheHit).Inc()
+}
+
+// CacheMiss records a lookup the named cache could not serve.
+func CacheMiss(cache string) {
+	cacheRequests.WithLabelValues(cache, cacheMiss).Inc()
+}
+
+// BookmarkCreated records the creation of a bookmark.
+func BookmarkCreated() {
+	bookmarksCreated.Inc()
+}
+
+// LinkShortened records the creation of a short link.
+func LinkShortened() {
+	linksShortened.Inc()
+}
+
+// RedirectServed records a redirect from a short link to its URL.
+func RedirectServed() {
+	redirectsServed.Inc()
+}
+
+// LoginFailed records a rejected login attempt for the given reason.
+func LoginFailed(reason string) {
+	loginFailures.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_Record(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		record  func()
+		counter func() float64
+	}{
+		{
+			name:    "request",
+			record:  func() { ObserveRequest(http.MethodGet, "/test/:id", http.StatusOK, time.Millisecond) },
+			counter: func() float64 { return testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/test/:id", "200")) },
+		},
+		{
+			name:    "failed query",
+			record:  func() { ObserveQuery("query", "test_failed", time.Millisecond, true) },
+			counter: func() float64 { return testutil.ToFloat64(dbQueryErrors.WithLabelValues("query", "test_failed")) },
+		},
+		{
+			name:    "failed redis command",
+			record:  func() { ObserveRedisCommand("test_failed", time.Millisecond, true) },
+			counter: func() float64 { return testutil.ToFloat64(redisCommandErrors.WithLabelValues("test_failed")) },
+		},
+		{
+			name:    "cache hit",
+			record:  func() { CacheHit("test") },
+			counter: func() float64 { return testutil.ToFloat64(cacheRequests.WithLabelValues("test", cacheHit)) },
+		},
+		{
+			name:    "cache miss",
+			record:  func() { CacheMiss("test") },
+			counter: func() float64 { return testutil.ToFloat64(cacheRequests.WithLabelValues("test", cacheMiss)) },
+		},
+		{
+			name:    "bookmark created",
+			record:  BookmarkCreated,
+			counter: func() float64 { return testutil.ToFloat64(bookmarksCreated) },
+		},
+		{
+			name:    "link shortened",
+			record:  LinkShortened,
+			counter: func() float64 { return testutil.ToFloat64(linksShortened) },
+		},
+		{
+			name:    "redirect served",
+			record:  RedirectServed,
+			counter: func() float64 { return testutil.ToFloat64(redirectsServed) },
+		},
+		{
+			name:    "login failed",
+			record:  func() { LoginFailed("test") },
+			counter: func() float64 { return testutil.ToFloat64(loginFailures.WithLabelValues("test")) },
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			before := tc.counter()
+			tc.record()
+
+			assert.GreaterOrEqual(t, tc.counter()-before, float64(1))
+		})
+	}
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	t.Parallel()
+
+	ObserveRequest(http.MethodGet, "/test/handler", http.StatusOK, time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `bookmark_http_requests_total{method="GET",route="/test/handler",status="200"}`)
+	assert.Contains(t, rec.Body.String(), `bookmark_http_request_duration_seconds_bucket{method="GET",route="/test/handler",status="200",le="0.005"}`)
+	assert.Contains(t, rec.Body.String(), "go_goroutines")
+}
+
+// histogramCount returns the number of observations of the histogram with the given labels.
+func histogramCount(t *testing.T, vec *prometheus.HistogramVec, labels ...string) uint64 {
+	metric := &dto.Metric{}
+	assert.NoError(t, vec.WithLabelValues(labels...).(prometheus.Metric).Write(metric))
+
+	return metric.GetHistogram().GetSampleCount()
+}
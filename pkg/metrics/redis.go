@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisHook is a go-redis hook recording the latency of every command.
+type redisHook struct{}
+
+// NewRedisHook creates a go-redis hook that records the latency of the commands sent
+// through the client, by command name, with ObserveRedisCommand. Install it with
+// client.AddHook.
+func NewRedisHook() redis.Hook {
+	return redisHook{}
+}
+
+// DialHook leaves dialing unchanged.
+func (redisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook records the latency of a single command.
+func (redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		startedAt := time.Now()
+		err := next(ctx, cmd)
+		ObserveRedisCommand(cmd.Name(), time.Since(startedAt), failed(err))
+
+		return err
+	}
+}
+
+// ProcessPipelineHook records the latency of a pipeline or transaction as a whole.
+func (redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		startedAt := time.Now()
+		err := next(ctx, cmds)
+		ObserveRedisCommand("pipeline", time.Since(startedAt), failed(err))
+
+		return err
+	}
+}
+
+// failed reports whether a command failed; a missing key is a normal outcome.
+func failed(err error) bool {
+	return err != nil && !errors.Is(err, redis.Nil)
+}
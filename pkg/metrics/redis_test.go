@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisHook(t *testing.T) {
+	t.Parallel()
+
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	client.AddHook(NewRedisHook())
+	ctx := t.Context()
+
+	before := testutil.ToFloat64(redisCommandErrors.WithLabelValues("get"))
+	assert.NoError(t, client.Set(ctx, "key", "value", 0).Err())
+	assert.ErrorIs(t, client.Get(ctx, "missing").Err(), redis.Nil)
+	_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Get(ctx, "key")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, before, testutil.ToFloat64(redisCommandErrors.WithLabelValues("get")))
+	assert.Positive(t, histogramCount(t, redisCommandDuration, "set"))
+	assert.Positive(t, histogramCount(t, redisCommandDuration, "pipeline"))
+}
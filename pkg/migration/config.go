@@ -0,0 +1,24 @@
+package migration
+
+import "github.com/kelseyhightower/envconfig"
+
+// Config holds the migration settings loaded from environment variables.
+//
+// Path is the golang-migrate source URL of the migrations, such as file://./migrations;
+// when empty, the migrations embedded in the binary are used.
+// AutoMigrate applies the pending migrations when the API server starts; disable it when
+// the migrations are run by the migrate command as a separate deployment step.
+type Config struct {
+	Path        string `envconfig:"MIGRATIONS_PATH"`
+	AutoMigrate bool   `default:"true" envconfig:"DB_AUTO_MIGRATE"`
+}
+
+// NewConfig creates a new configuration instance by reading environment variables.
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,73 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// versionLayout formats the creation time into the version of a new migration.
+const versionLayout = "20060102150405"
+
+var (
+	// ErrInvalidName is returned when the name of a new migration has no letter or digit.
+	ErrInvalidName = errors.New("migration name must contain letters or digits")
+	// ErrNotLocal is returned when creating migrations in a source that is not a directory.
+	ErrNotLocal = errors.New("migrations path is not a local directory")
+)
+
+// nameSeparatorPattern matches the characters replaced by underscores in migration names.
+var nameSeparatorPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Create scaffolds the up and down files of a new migration in the directory, versioned
+// by the creation time, such as 20250401100000_add_tags.up.sql. The name is lowercased,
+// with runs of other characters than letters and digits replaced by an underscore. It
+// returns the paths of the up and down files; existing files are never overwritten.
+func Create(dir, name string, now time.Time) (string, string, error) {
+	name = strings.Trim(nameSeparatorPattern.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", ErrInvalidName
+	}
+
+	base := filepath.Join(dir, now.UTC().Format(versionLayout)+"_"+name)
+	upPath, downPath := base+".up.sql", base+".down.sql"
+	if err := writeNewFile(upPath, "-- Statements applying the migration "+name+".\n"); err != nil {
+		return "", "", err
+	}
+	if err := writeNewFile(downPath, "-- Statements reverting the migration "+name+".\n"); err != nil {
+		os.Remove(upPath)
+		return "", "", err
+	}
+
+	return upPath, downPath, nil
+}
+
+// Dir returns the directory of a file:// source URL, or the path itself when it has no
+// scheme. Other sources, such as embedded ones, have no directory to create files in.
+func Dir(sourceURL string) (string, error) {
+	scheme, path, found := strings.Cut(sourceURL, "://")
+	switch {
+	case !found:
+		return sourceURL, nil
+	case scheme == "file":
+		return path, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrNotLocal, sourceURL)
+	}
+}
+
+// writeNewFile writes the content to a file that must not exist yet.
+func writeNewFile(path, content string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(content)
+	return err
+}
@@ -0,0 +1,112 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 4, 1, 17, 30, 5, 0, time.FixedZone("ICT", 7*60*60))
+
+	testCases := []struct {
+		name         string
+		migration    string
+		existing     string
+		expectedErr  bool
+		expectedBase string
+	}{
+		{
+			name:         "success - versioned by the UTC time",
+			migration:    "add_tags",
+			expectedBase: "20250401103005_add_tags",
+		},
+		{
+			name:         "success - name normalized",
+			migration:    " Add Tags-To Bookmarks! ",
+			expectedBase: "20250401103005_add_tags_to_bookmarks",
+		},
+		{
+			name:        "error - name without letters or digits",
+			migration:   "--",
+			expectedErr: true,
+		},
+		{
+			name:        "error - existing migration not overwritten",
+			migration:   "add_tags",
+			existing:    "20250401103005_add_tags.down.sql",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			if tc.existing != "" {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, tc.existing), []byte("keep"), 0o644))
+			}
+
+			upPath, downPath, err := Create(dir, tc.migration, now)
+
+			if tc.expectedErr {
+				assert.Error(t, err)
+				entries, readErr := os.ReadDir(dir)
+				assert.NoError(t, readErr)
+				for _, entry := range entries {
+					assert.Equal(t, tc.existing, entry.Name(), "no file must be left behind")
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, filepath.Join(dir, tc.expectedBase+".up.sql"), upPath)
+			assert.Equal(t, filepath.Join(dir, tc.expectedBase+".down.sql"), downPath)
+			assert.FileExists(t, upPath)
+			assert.FileExists(t, downPath)
+		})
+	}
+}
+
+func TestDir(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		sourceURL   string
+		expectedDir string
+		expectedErr error
+	}{
+		{
+			name:        "file source",
+			sourceURL:   "file://./migrations",
+			expectedDir: "./migrations",
+		},
+		{
+			name:        "plain path",
+			sourceURL:   "/srv/migrations",
+			expectedDir: "/srv/migrations",
+		},
+		{
+			name:        "remote source",
+			sourceURL:   "github://owner/repo/migrations",
+			expectedErr: ErrNotLocal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir, err := Dir(tc.sourceURL)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedDir, dir)
+		})
+	}
+}
@@ -0,0 +1,364 @@
+// Package migration applies, reverts and inspects the SQL migrations of the database
+// schema, and scaffolds new migration files. It wraps golang-migrate, adding the listing
+// of the migrations and dry runs of the commands.
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/source"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+var (
+	// ErrDirty is returned when a previous migration failed halfway. The schema must be
+	// fixed by hand, then marked with Force as being at the last version fully applied.
+	ErrDirty = errors.New("database schema is dirty")
+	// ErrInvalidSteps is returned when the number of migrations to revert is not positive.
+	ErrInvalidSteps = errors.New("number of migrations must be positive")
+	// ErrTooManySteps is returned when reverting more migrations than are applied.
+	ErrTooManySteps = errors.New("not enough applied migrations")
+	// ErrUnknownVersion is returned when a version matches no migration of the source.
+	ErrUnknownVersion = errors.New("unknown migration version")
+	// ErrSchemaAhead is returned when the schema has a migration applied that is newer
+	// than the migrations of the source, such as after rolling back the service.
+	ErrSchemaAhead = errors.New("database schema is ahead of the migrations")
+)
+
+// Migration is a migration of the source.
+type Migration struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// Step is a migration applied, or reverted when Up is false, by a command.
+type Step struct {
+	Migration
+	Up bool
+}
+
+// Status is the state of the database schema against the migrations of the source.
+// Version is 0 when no migration has been applied.
+type Status struct {
+	Version    uint
+	Dirty      bool
+	Migrations []Migration
+}
+
+// Migrator applies and reverts the migrations of a source on a database. The Plan
+// methods report the steps the matching command would run, without changing the schema.
+type Migrator interface {
+	// Up applies all the pending migrations.
+	Up() error
+	// Down reverts the last n applied migrations.
+	Down(n int) error
+	// Goto applies or reverts migrations until the schema is at the given version;
+	// version 0 reverts every migration.
+	Goto(version uint) error
+	// Force marks the schema as being at the given version and clean, without running
+	// any migration; version -1 marks it as having no migration applied. It recovers
+	// from a failed migration once the schema has been fixed by hand.
+	Force(version int) error
+	// Version returns the version of the schema, 0 when no migration has been applied,
+	// and whether the last migration failed halfway.
+	Version() (uint, bool, error)
+	// Status lists the migrations of the source, marking the applied ones.
+	Status() (*Status, error)
+	// CheckVersion returns ErrSchemaAhead when the schema version is newer than the
+	// latest migration of the source.
+	CheckVersion() error
+	// PlanUp returns the steps run by Up.
+	PlanUp() ([]Step, error)
+	// PlanDown returns the steps run by Down.
+	PlanDown(n int) ([]Step, error)
+	// PlanGoto returns the steps run by Goto.
+	PlanGoto(version uint) ([]Step, error)
+	// Close releases the source and the database driver.
+	Close() error
+}
+
+// migrator implements Migrator with golang-migrate.
+type migrator struct {
+	m          *migrate.Migrate
+	migrations []Migration
+}
+
+// OpenSource opens the migrations found at the source URL, such as file://./migrations,
+// or the migrations at the root of the embedded file system when the URL is empty.
+func OpenSource(sourceURL string, embedded fs.FS) (source.Driver, error) {
+	if sourceURL == "" {
+		return iofs.New(embedded, ".")
+	}
+
+	return source.Open(sourceURL)
+}
+
+// New creates a Migrator running the migrations of the source on the database driver.
+// Closing the Migrator closes the source and the driver, including on error.
+func New(src source.Driver, driver database.Driver) (Migrator, error) {
+	migrations, err := readMigrations(src)
+	if err != nil {
+		src.Close()
+		driver.Close()
+		return nil, err
+	}
+
+	m, err := migrate.NewWithInstance("source", src, "database", driver)
+	if err != nil {
+		src.Close()
+		driver.Close()
+		return nil, err
+	}
+
+	return &migrator{m: m, migrations: migrations}, nil
+}
+
+// LatestVersion returns the version of the latest migration at the root of the embedded
+// file system, 0 when it holds none.
+func LatestVersion(embedded fs.FS) (uint, error) {
+	src, err := iofs.New(embedded, ".")
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	migrations, err := readMigrations(src)
+	if err != nil || len(migrations) == 0 {
+		return 0, err
+	}
+
+	return migrations[len(migrations)-1].Version, nil
+}
+
+// readMigrations lists the migrations of the source in version order.
+func readMigrations(src source.Driver) ([]Migration, error) {
+	var migrations []Migration
+
+	version, err := src.First()
+	for err == nil {
+		name, nameErr := migrationName(src, version)
+		if nameErr != nil {
+			return nil, nameErr
+		}
+		migrations = append(migrations, Migration{Version: version, Name: name})
+
+		version, err = src.Next(version)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	return migrations, nil
+}
+
+// migrationName returns the name of the migration of the given version, read from its up
+// file or, for a migration that cannot be applied, its down file.
+func migrationName(src source.Driver, version uint) (string, error) {
+	r, name, err := src.ReadUp(version)
+	if errors.Is(err, fs.ErrNotExist) {
+		r, name, err = src.ReadDown(version)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return name, r.Close()
+}
+
+// Up applies all the pending migrations.
+func (m *migrator) Up() error {
+	return translate(m.m.Up())
+}
+
+// Down reverts the last n applied migrations.
+func (m *migrator) Down(n int) error {
+	if _, err := m.PlanDown(n); err != nil {
+		return err
+	}
+
+	return translate(m.m.Steps(-n))
+}
+
+// Goto applies or reverts migrations until the schema is at the given version.
+func (m *migrator) Goto(version uint) error {
+	if _, err := m.PlanGoto(version); err != nil {
+		return err
+	}
+	if version == 0 {
+		return translate(m.m.Down())
+	}
+
+	return translate(m.m.Migrate(version))
+}
+
+// Force marks the schema as being at the given version and clean.
+func (m *migrator) Force(version int) error {
+	if version != database.NilVersion && !m.known(uint(version)) {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return translate(m.m.Force(version))
+}
+
+// Version returns the version of the schema and whether it is dirty.
+func (m *migrator) Version() (uint, bool, error) {
+	version, dirty, err := m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+
+	return version, dirty, err
+}
+
+// Status lists the migrations of the source, marking the applied ones.
+func (m *migrator) Status() (*Status, error) {
+	version, dirty, err := m.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{Version: version, Dirty: dirty, Migrations: make([]Migration, len(m.migrations))}
+	for i, migration := range m.migrations {
+		migration.Applied = version != 0 && migration.Version <= version
+		status.Migrations[i] = migration
+	}
+
+	return status, nil
+}
+
+// CheckVersion returns ErrSchemaAhead when the schema version is newer than the latest
+// migration of the source.
+func (m *migrator) CheckVersion() error {
+	version, _, err := m.Version()
+	if err != nil {
+		return err
+	}
+
+	var latest uint
+	if len(m.migrations) > 0 {
+		latest = m.migrations[len(m.migrations)-1].Version
+	}
+	if version > latest {
+		return fmt.Errorf("%w: schema at version %d, latest migration %d", ErrSchemaAhead, version, latest)
+	}
+
+	return nil
+}
+
+// PlanUp returns the pending migrations, in the order they are applied.
+func (m *migrator) PlanUp() ([]Step, error) {
+	status, err := m.cleanStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	return upSteps(status, ^uint(0)), nil
+}
+
+// PlanDown returns the last n applied migrations, in the order they are reverted.
+func (m *migrator) PlanDown(n int) ([]Step, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidSteps, n)
+	}
+	status, err := m.cleanStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	steps := downSteps(status, 0)
+	if n > len(steps) {
+		return nil, fmt.Errorf("%w: %d requested, %d applied", ErrTooManySteps, n, len(steps))
+	}
+
+	return steps[:n], nil
+}
+
+// PlanGoto returns the migrations applied or reverted to reach the version.
+func (m *migrator) PlanGoto(version uint) ([]Step, error) {
+	if version != 0 && !m.known(version) {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	status, err := m.cleanStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	if version > status.Version {
+		return upSteps(status, version), nil
+	}
+
+	return downSteps(status, version), nil
+}
+
+// Close releases the source and the database driver.
+func (m *migrator) Close() error {
+	sourceErr, databaseErr := m.m.Close()
+
+	return errors.Join(sourceErr, databaseErr)
+}
+
+// cleanStatus returns the status of the schema, or ErrDirty if it is dirty, as no
+// migration can run until it is forced to a version.
+func (m *migrator) cleanStatus() (*Status, error) {
+	status, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+	if status.Dirty {
+		return nil, fmt.Errorf("%w at version %d", ErrDirty, status.Version)
+	}
+
+	return status, nil
+}
+
+// known reports whether the version matches a migration of the source.
+func (m *migrator) known(version uint) bool {
+	return slices.ContainsFunc(m.migrations, func(migration Migration) bool {
+		return migration.Version == version
+	})
+}
+
+// upSteps returns the pending migrations up to the target version, in ascending order.
+func upSteps(status *Status, target uint) []Step {
+	var steps []Step
+	for _, migration := range status.Migrations {
+		if !migration.Applied && migration.Version <= target {
+			steps = append(steps, Step{Migration: migration, Up: true})
+		}
+	}
+
+	return steps
+}
+
+// downSteps returns the applied migrations above the target version, in descending order.
+func downSteps(status *Status, target uint) []Step {
+	var steps []Step
+	for i := len(status.Migrations) - 1; i >= 0; i-- {
+		migration := status.Migrations[i]
+		if migration.Applied && migration.Version > target {
+			steps = append(steps, Step{Migration: migration})
+		}
+	}
+
+	return steps
+}
+
+// translate ignores the absence of change, and reports a dirty schema as ErrDirty.
+func translate(err error) error {
+	var dirtyErr migrate.ErrDirty
+	switch {
+	case err == nil, errors.Is(err, migrate.ErrNoChange):
+		return nil
+	case errors.As(err, &dirtyErr):
+		return fmt.Errorf("%w at version %d", ErrDirty, dirtyErr.Version)
+	default:
+		return err
+	}
+}
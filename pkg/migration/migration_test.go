@@ -0,0 +1,336 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/golang-migrate/migrate/v4/database/stub"
+	"github.com/stretchr/testify/assert"
+)
+
+// testMigrations are the migrations 1, 2 and 3, each running its file name and direction.
+var testMigrations = []string{"1_create_users", "2_create_bookmarks", "3_add_user_role"}
+
+// newTestMigrator creates a Migrator for the embedded testMigrations on an in-memory
+// database, after applying the first applied ones.
+func newTestMigrator(t *testing.T, applied int) (Migrator, *stub.Stub) {
+	embedded := fstest.MapFS{}
+	for _, name := range testMigrations {
+		embedded[name+".up.sql"] = &fstest.MapFile{Data: []byte(name + " up")}
+		embedded[name+".down.sql"] = &fstest.MapFile{Data: []byte(name + " down")}
+	}
+
+	src, err := OpenSource("", embedded)
+	assert.NoError(t, err)
+	driver, err := stub.WithInstance(nil, &stub.Config{})
+	assert.NoError(t, err)
+	migrator, err := New(src, driver)
+	assert.NoError(t, err)
+	t.Cleanup(func() { migrator.Close() })
+
+	if applied > 0 {
+		assert.NoError(t, migrator.Goto(uint(applied)))
+	}
+	db := driver.(*stub.Stub)
+	db.MigrationSequence = nil
+
+	return migrator, db
+}
+
+func upStep(version uint, name string) Step {
+	return Step{Migration: Migration{Version: version, Name: name}, Up: true}
+}
+
+func downStep(version uint, name string) Step {
+	return Step{Migration: Migration{Version: version, Name: name, Applied: true}}
+}
+
+func TestOpenSource(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range testMigrations[:2] {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name+".up.sql"), []byte(name+" up"), 0o644))
+	}
+	embedded := fstest.MapFS{"9_embedded.up.sql": &fstest.MapFile{Data: []byte("embedded up")}}
+
+	src, err := OpenSource("file://"+dir, embedded)
+	assert.NoError(t, err)
+	driver, err := stub.WithInstance(nil, &stub.Config{})
+	assert.NoError(t, err)
+	migrator, err := New(src, driver)
+	assert.NoError(t, err)
+	defer migrator.Close()
+
+	status, err := migrator.Status()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 1, Name: "create_users"},
+		{Version: 2, Name: "create_bookmarks"},
+	}, status.Migrations, "the path overrides the embedded migrations")
+}
+
+func TestLatestVersion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		files           []string
+		expectedVersion uint
+	}{
+		{
+			name:            "latest migration",
+			files:           []string{"3_add_user_role.up.sql", "1_create_users.up.sql", "2_create_bookmarks.up.sql", "3_add_user_role.down.sql"},
+			expectedVersion: 3,
+		},
+		{
+			name:            "down migration only",
+			files:           []string{"1_create_users.up.sql", "2_drop_users.down.sql"},
+			expectedVersion: 2,
+		},
+		{
+			name:            "no migration",
+			expectedVersion: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			embedded := fstest.MapFS{}
+			for _, file := range tc.files {
+				embedded[file] = &fstest.MapFile{Data: []byte(file)}
+			}
+
+			version, err := LatestVersion(embedded)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedVersion, version)
+		})
+	}
+}
+
+func TestMigrator_CheckVersion(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		version     int
+		expectedErr error
+	}{
+		{
+			name:    "no migration applied",
+			version: -1,
+		},
+		{
+			name:    "pending migrations",
+			version: 2,
+		},
+		{
+			name:    "latest migration applied",
+			version: 3,
+		},
+		{
+			name:        "schema ahead of the migrations",
+			version:     4,
+			expectedErr: ErrSchemaAhead,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			migrator, db := newTestMigrator(t, 0)
+			db.CurrentVersion = tc.version
+
+			err := migrator.CheckVersion()
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestMigrator_Status(t *testing.T) {
+	t.Parallel()
+
+	migrator, _ := newTestMigrator(t, 2)
+
+	status, err := migrator.Status()
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Status{
+		Version: 2,
+		Migrations: []Migration{
+			{Version: 1, Name: "create_users", Applied: true},
+			{Version: 2, Name: "create_bookmarks", Applied: true},
+			{Version: 3, Name: "add_user_role"},
+		},
+	}, status)
+}
+
+func TestMigrator_Commands(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		applied          int
+		dirty            bool
+		run              func(m Migrator) ([]Step, error)
+		apply            func(m Migrator) error
+		expectedErr      error
+		expectedSteps    []Step
+		expectedSequence []string
+		expectedVersion  uint
+	}{
+		{
+			name:             "up - applies the pending migrations",
+			applied:          1,
+			run:              Migrator.PlanUp,
+			apply:            Migrator.Up,
+			expectedSteps:    []Step{upStep(2, "create_bookmarks"), upStep(3, "add_user_role")},
+			expectedSequence: []string{"2_create_bookmarks up", "3_add_user_role up"},
+			expectedVersion:  3,
+		},
+		{
+			name:            "up - nothing pending",
+			applied:         3,
+			run:             Migrator.PlanUp,
+			apply:           Migrator.Up,
+			expectedVersion: 3,
+		},
+		{
+			name:             "down - reverts the last migrations",
+			applied:          3,
+			run:              func(m Migrator) ([]Step, error) { return m.PlanDown(2) },
+			apply:            func(m Migrator) error { return m.Down(2) },
+			expectedSteps:    []Step{downStep(3, "add_user_role"), downStep(2, "create_bookmarks")},
+			expectedSequence: []string{"3_add_user_role down", "2_create_bookmarks down"},
+			expectedVersion:  1,
+		},
+		{
+			name:            "down - more migrations than applied",
+			applied:         1,
+			run:             func(m Migrator) ([]Step, error) { return m.PlanDown(2) },
+			apply:           func(m Migrator) error { return m.Down(2) },
+			expectedErr:     ErrTooManySteps,
+			expectedVersion: 1,
+		},
+		{
+			name:            "down - no migration",
+			applied:         1,
+			run:             func(m Migrator) ([]Step, error) { return m.PlanDown(0) },
+			apply:           func(m Migrator) error { return m.Down(0) },
+			expectedErr:     ErrInvalidSteps,
+			expectedVersion: 1,
+		},
+		{
+			name:             "goto - applies up to a later version",
+			applied:          1,
+			run:              func(m Migrator) ([]Step, error) { return m.PlanGoto(2) },
+			apply:            func(m Migrator) error { return m.Goto(2) },
+			expectedSteps:    []Step{upStep(2, "create_bookmarks")},
+			expectedSequence: []string{"2_create_bookmarks up"},
+			expectedVersion:  2,
+		},
+		{
+			name:             "goto - reverts every migration",
+			applied:          2,
+			run:              func(m Migrator) ([]Step, error) { return m.PlanGoto(0) },
+			apply:            func(m Migrator) error { return m.Goto(0) },
+			expectedSteps:    []Step{downStep(2, "create_bookmarks"), downStep(1, "create_users")},
+			expectedSequence: []string{"2_create_bookmarks down", "1_create_users down"},
+			expectedVersion:  0,
+		},
+		{
+			name:            "goto - unknown version",
+			applied:         1,
+			run:             func(m Migrator) ([]Step, error) { return m.PlanGoto(7) },
+			apply:           func(m Migrator) error { return m.Goto(7) },
+			expectedErr:     ErrUnknownVersion,
+			expectedVersion: 1,
+		},
+		{
+			name:            "up - dirty schema",
+			applied:         2,
+			dirty:           true,
+			run:             Migrator.PlanUp,
+			apply:           Migrator.Up,
+			expectedErr:     ErrDirty,
+			expectedVersion: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			migrator, db := newTestMigrator(t, tc.applied)
+			db.IsDirty = tc.dirty
+
+			steps, err := tc.run(migrator)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedSteps, steps)
+			assert.Empty(t, db.MigrationSequence, "planning must not run migrations")
+
+			err = tc.apply(migrator)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedSequence, db.MigrationSequence)
+
+			version, dirty, err := migrator.Version()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedVersion, version)
+			assert.Equal(t, tc.dirty, dirty)
+		})
+	}
+}
+
+func TestMigrator_Force(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name            string
+		version         int
+		expectedErr     error
+		expectedVersion uint
+	}{
+		{
+			name:            "success - marks the version as clean",
+			version:         1,
+			expectedVersion: 1,
+		},
+		{
+			name:            "success - marks no migration as applied",
+			version:         -1,
+			expectedVersion: 0,
+		},
+		{
+			name:            "error - unknown version",
+			version:         7,
+			expectedErr:     ErrUnknownVersion,
+			expectedVersion: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			migrator, db := newTestMigrator(t, 2)
+			db.IsDirty = true
+
+			err := migrator.Force(tc.version)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Empty(t, db.MigrationSequence)
+			version, dirty, err := migrator.Version()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedVersion, version)
+			assert.Equal(t, tc.expectedErr != nil, dirty)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package oidc
+
+import (
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Config holds the OpenID Connect client configuration for a single identity provider.
+// It is loaded from environment variables prefixed with the provider name, for example
+// COMPANY_OIDC_ISSUER_URL for a provider named "company".
+type Config struct {
+	IssuerURL    string   `envconfig:"OIDC_ISSUER_URL" required:"true"`
+	ClientID     string   `envconfig:"OIDC_CLIENT_ID" required:"true"`
+	ClientSecret string   `default:"" envconfig:"OIDC_CLIENT_SECRET"`
+	RedirectURL  string   `envconfig:"OIDC_REDIRECT_URL" required:"true"`
+	Scopes       []string `default:"openid,email,profile" envconfig:"OIDC_SCOPES"`
+}
+
+// providersConfig holds the list of identity provider names enabled for login.
+type providersConfig struct {
+	Providers []string `default:"" envconfig:"OIDC_PROVIDERS"`
+}
+
+// NewConfig creates a new provider configuration by reading environment variables
+// with the specified prefix. Returns an error if a required variable is missing.
+func NewConfig(prefix string) (*Config, error) {
+	cfg := &Config{}
+	if err := envconfig.Process(prefix, cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// newProvidersConfig reads the OIDC_PROVIDERS environment variable, a comma separated
+// list of provider names. Returns an error if environment variable processing fails.
+func newProvidersConfig() (*providersConfig, error) {
+	cfg := &providersConfig{}
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,237 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	// mockClientID is the client ID accepted by the mock identity provider.
+	mockClientID = "bookmark-test-client"
+	// mockClientSecret is the client secret accepted by the mock identity provider.
+	mockClientSecret = "bookmark-test-secret"
+	// mockKeyID is the key ID advertised in the mock provider's JWKS.
+	mockKeyID = "mock-signing-key"
+)
+
+// mockAuthRequest holds the parameters of an authorization request until its code is redeemed.
+type mockAuthRequest struct {
+	codeChallenge string
+	nonce         string
+}
+
+// MockIdP is an in-process OpenID Connect provider backed by httptest. It serves discovery,
+// JWKS, authorization and token endpoints, enforces PKCE and signs ID tokens with a
+// throw-away RSA key. The server is closed automatically when the test completes.
+type MockIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu       sync.Mutex
+	claims   jwt.MapClaims
+	requests map[string]mockAuthRequest
+}
+
+// InitMockIdP starts a mock OpenID Connect provider for testing purposes. By default it
+// issues ID tokens for a user with a verified email; use SetClaims to change the identity.
+func InitMockIdP(t *testing.T) *MockIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal("fail to generate idp key: ", err)
+	}
+
+	m := &MockIdP{
+		key: key,
+		claims: jwt.MapClaims{
+			"sub":                "mock-user",
+			"email":              "oidc.user@example.com",
+			"email_verified":     true,
+			"name":               "OIDC User",
+			"preferred_username": "oidc.user",
+		},
+		requests: map[string]mockAuthRequest{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", m.discovery)
+	mux.HandleFunc("GET /jwks", m.jwks)
+	mux.HandleFunc("GET /authorize", m.authorize)
+	mux.HandleFunc("POST /token", m.token)
+
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+
+	return m
+}
+
+// Issuer returns the issuer URL of the mock provider.
+func (m *MockIdP) Issuer() string {
+	return m.server.URL
+}
+
+// Config returns a provider configuration pointing at the mock provider with the given redirect URL.
+func (m *MockIdP) Config(redirectURL string) *Config {
+	return &Config{
+		IssuerURL:    m.Issuer(),
+		ClientID:     mockClientID,
+		ClientSecret: mockClientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"openid", "email", "profile"},
+	}
+}
+
+// SetClaims replaces the identity claims included in ID tokens issued from now on.
+func (m *MockIdP) SetClaims(claims jwt.MapClaims) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.claims = claims
+}
+
+// discovery serves the OpenID Connect discovery document.
+func (m *MockIdP) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                m.Issuer(),
+		"authorization_endpoint":                m.Issuer() + "/authorize",
+		"token_endpoint":                        m.Issuer() + "/token",
+		"jwks_uri":                              m.Issuer() + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// jwks serves the public part of the signing key as a JSON Web Key Set.
+func (m *MockIdP) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": mockKeyID,
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(m.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.key.E)).Bytes()),
+			},
+		},
+	})
+}
+
+// authorize approves every authorization request immediately and redirects back to the
+// client with a fresh code, as if the user had signed in successfully.
+func (m *MockIdP) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != mockClientID || q.Get("response_type") != "code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	redirectURL, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	code := uuid.New().String()
+	m.mu.Lock()
+	m.requests[code] = mockAuthRequest{
+		codeChallenge: q.Get("code_challenge"),
+		nonce:         q.Get("nonce"),
+	}
+	m.mu.Unlock()
+
+	params := redirectURL.Query()
+	params.Set("code", code)
+	params.Set("state", q.Get("state"))
+	redirectURL.RawQuery = params.Encode()
+
+	http.Redirect(w, r, redirectURL.String(), http.StatusFound)
+}
+
+// token redeems an authorization code. It authenticates the client, verifies the PKCE code
+// verifier against the stored challenge and returns a signed ID token.
+func (m *MockIdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != mockClientID || clientSecret != mockClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	m.mu.Lock()
+	authReq, ok := m.requests[code]
+	delete(m.requests, code)
+	claims := jwt.MapClaims{}
+	for k, v := range m.claims {
+		claims[k] = v
+	}
+	m.mu.Unlock()
+
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	verifierHash := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(verifierHash[:]) != authReq.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims["iss"] = m.Issuer()
+	claims["aud"] = mockClientID
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(time.Hour).Unix()
+	if authReq.nonce != "" {
+		claims["nonce"] = authReq.nonce
+	}
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = mockKeyID
+	signed, err := idToken.SignedString(m.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": uuid.New().String(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     signed,
+	})
+}
+
+// writeJSON writes the value as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
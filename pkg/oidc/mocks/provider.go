@@ -0,0 +1,77 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	oidc "github.com/luongtruong20201/bookmark-management/pkg/oidc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Provider is an autogenerated mock type for the Provider type
+type Provider struct {
+	mock.Mock
+}
+
+// AuthCodeURL provides a mock function with given fields: state, nonce, codeVerifier
+func (_m *Provider) AuthCodeURL(state string, nonce string, codeVerifier string) string {
+	ret := _m.Called(state, nonce, codeVerifier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthCodeURL")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
+		r0 = rf(state, nonce, codeVerifier)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Exchange provides a mock function with given fields: ctx, code, nonce, codeVerifier
+func (_m *Provider) Exchange(ctx context.Context, code string, nonce string, codeVerifier string) (*oidc.Identity, error) {
+	ret := _m.Called(ctx, code, nonce, codeVerifier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exchange")
+	}
+
+	var r0 *oidc.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*oidc.Identity, error)); ok {
+		return rf(ctx, code, nonce, codeVerifier)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *oidc.Identity); ok {
+		r0 = rf(ctx, code, nonce, codeVerifier)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oidc.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, code, nonce, codeVerifier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewProvider creates a new instance of Provider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Provider {
+	mock := &Provider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,154 @@
+// Package oidc provides an OpenID Connect client for signing users in with external
+// identity providers using the authorization code flow with PKCE.
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+var (
+	// ErrMissingIDToken is returned when the token response of the provider does not contain an ID token.
+	ErrMissingIDToken = errors.New("id_token is missing from token response")
+	// ErrInvalidNonce is returned when the nonce of the ID token does not match the one sent in the auth request.
+	ErrInvalidNonce = errors.New("id_token nonce does not match")
+)
+
+// Identity represents the verified identity of a user returned by an OpenID Connect provider.
+type Identity struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// idTokenClaims holds the standard profile claims read from a verified ID token.
+type idTokenClaims struct {
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// Provider defines the interface for an OpenID Connect identity provider.
+// It builds authorization URLs and exchanges authorization codes for verified identities.
+//
+//go:generate mockery --name Provider --filename provider.go
+type Provider interface {
+	// AuthCodeURL returns the provider URL the user agent is redirected to in order to sign in.
+	// The state and nonce are echoed back by the provider, and the code verifier is sent as an
+	// S256 code challenge.
+	AuthCodeURL(state, nonce, codeVerifier string) string
+	// Exchange trades an authorization code for tokens, verifies the ID token signature against
+	// the provider's JWKS, checks the nonce and returns the identity it carries.
+	Exchange(ctx context.Context, code, nonce, codeVerifier string) (*Identity, error)
+}
+
+// provider implements the Provider interface on top of an OAuth2 client configuration
+// and an ID token verifier discovered from the issuer URL.
+type provider struct {
+	oauth2Cfg *oauth2.Config
+	verifier  *gooidc.IDTokenVerifier
+}
+
+// NewProvider discovers the provider metadata from the issuer URL in the configuration and
+// returns a Provider ready to start logins. Returns an error if discovery fails.
+func NewProvider(ctx context.Context, cfg *Config) (Provider, error) {
+	p, err := gooidc.NewProvider(ctx, cfg.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &provider{
+		oauth2Cfg: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     p.Endpoint(),
+			Scopes:       cfg.Scopes,
+		},
+		verifier: p.Verifier(&gooidc.Config{ClientID: cfg.ClientID}),
+	}, nil
+}
+
+// NewProviders creates a Provider for every name listed in the OIDC_PROVIDERS environment
+// variable. Each provider is configured from variables prefixed with its name. The returned
+// map is keyed by the lower-cased provider name and is empty when no provider is enabled.
+func NewProviders(ctx context.Context) (map[string]Provider, error) {
+	providersCfg, err := newProvidersConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make(map[string]Provider, len(providersCfg.Providers))
+	for _, name := range providersCfg.Providers {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		cfg, err := NewConfig(name)
+		if err != nil {
+			return nil, fmt.Errorf("oidc provider %s: %w", name, err)
+		}
+
+		p, err := NewProvider(ctx, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("oidc provider %s: %w", name, err)
+		}
+
+		providers[name] = p
+	}
+
+	return providers, nil
+}
+
+// AuthCodeURL returns the authorization endpoint URL with the state, nonce and the S256
+// challenge derived from the code verifier.
+func (p *provider) AuthCodeURL(state, nonce, codeVerifier string) string {
+	return p.oauth2Cfg.AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier))
+}
+
+// Exchange trades the authorization code for tokens using the PKCE code verifier, verifies
+// the returned ID token and extracts the user identity from its claims.
+func (p *provider) Exchange(ctx context.Context, code, nonce, codeVerifier string) (*Identity, error) {
+	token, err := p.oauth2Cfg.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, ErrMissingIDToken
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if idToken.Nonce != nonce {
+		return nil, ErrInvalidNonce
+	}
+
+	claims := &idTokenClaims{}
+	if err := idToken.Claims(claims); err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		Issuer:            idToken.Issuer,
+		Subject:           idToken.Subject,
+		Email:             claims.Email,
+		EmailVerified:     claims.EmailVerified,
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+	}, nil
+}
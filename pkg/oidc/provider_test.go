@@ -0,0 +1,188 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+const testRedirectURL = "http://localhost:8080/v1/users/oidc/company/callback"
+
+// authorize follows the authorization URL against the mock provider without following the
+// redirect back to the client, and returns the code and state from the redirect location.
+func authorize(t *testing.T, authURL string) (string, string) {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatal("fail to call authorize endpoint: ", err)
+	}
+	defer resp.Body.Close()
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal("fail to parse redirect location: ", err)
+	}
+
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		claims           jwt.MapClaims
+		exchangeNonce    string
+		exchangeVerifier func(verifier string) string
+		expectedIdentity *Identity
+		expectedErr      error
+		expectedErrStr   string
+	}{
+		{
+			name:             "success",
+			exchangeNonce:    "nonce-1",
+			exchangeVerifier: func(verifier string) string { return verifier },
+			expectedIdentity: &Identity{
+				Subject:           "mock-user",
+				Email:             "oidc.user@example.com",
+				EmailVerified:     true,
+				Name:              "OIDC User",
+				PreferredUsername: "oidc.user",
+			},
+		},
+		{
+			name: "success - unverified email",
+			claims: jwt.MapClaims{
+				"sub":            "other-user",
+				"email":          "other@example.com",
+				"email_verified": false,
+			},
+			exchangeNonce:    "nonce-1",
+			exchangeVerifier: func(verifier string) string { return verifier },
+			expectedIdentity: &Identity{
+				Subject:       "other-user",
+				Email:         "other@example.com",
+				EmailVerified: false,
+			},
+		},
+		{
+			name:             "error - nonce mismatch",
+			exchangeNonce:    "another-nonce",
+			exchangeVerifier: func(verifier string) string { return verifier },
+			expectedErr:      ErrInvalidNonce,
+		},
+		{
+			name:             "error - wrong code verifier",
+			exchangeNonce:    "nonce-1",
+			exchangeVerifier: func(string) string { return oauth2.GenerateVerifier() },
+			expectedErrStr:   "invalid_grant",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			idp := InitMockIdP(t)
+			if tc.claims != nil {
+				idp.SetClaims(tc.claims)
+			}
+
+			p, err := NewProvider(ctx, idp.Config(testRedirectURL))
+			assert.NoError(t, err)
+
+			verifier := oauth2.GenerateVerifier()
+			code, state := authorize(t, p.AuthCodeURL("state-1", "nonce-1", verifier))
+			assert.Equal(t, "state-1", state)
+
+			identity, err := p.Exchange(ctx, code, tc.exchangeNonce, tc.exchangeVerifier(verifier))
+			switch {
+			case tc.expectedErr != nil:
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, identity)
+			case tc.expectedErrStr != "":
+				assert.ErrorContains(t, err, tc.expectedErrStr)
+				assert.Nil(t, identity)
+			default:
+				assert.NoError(t, err)
+				tc.expectedIdentity.Issuer = idp.Issuer()
+				assert.Equal(t, tc.expectedIdentity, identity)
+			}
+		})
+	}
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	t.Parallel()
+
+	idp := InitMockIdP(t)
+	p, err := NewProvider(context.Background(), idp.Config(testRedirectURL))
+	assert.NoError(t, err)
+
+	authURL, err := url.Parse(p.AuthCodeURL("state-1", "nonce-1", "verifier"))
+	assert.NoError(t, err)
+
+	q := authURL.Query()
+	assert.Equal(t, idp.Issuer()+"/authorize", authURL.Scheme+"://"+authURL.Host+authURL.Path)
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, testRedirectURL, q.Get("redirect_uri"))
+	assert.Equal(t, "state-1", q.Get("state"))
+	assert.Equal(t, "nonce-1", q.Get("nonce"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.Equal(t, oauth2.S256ChallengeFromVerifier("verifier"), q.Get("code_challenge"))
+	assert.Equal(t, "openid email profile", q.Get("scope"))
+}
+
+func TestNewProvider_DiscoveryError(t *testing.T) {
+	t.Parallel()
+
+	idp := InitMockIdP(t)
+	cfg := idp.Config(testRedirectURL)
+	cfg.IssuerURL = idp.Issuer() + "/unknown"
+
+	p, err := NewProvider(context.Background(), cfg)
+	assert.Error(t, err)
+	assert.Nil(t, p)
+}
+
+func TestNewProviders(t *testing.T) {
+	idp := InitMockIdP(t)
+
+	t.Run("no provider enabled", func(t *testing.T) {
+		t.Setenv("OIDC_PROVIDERS", "")
+
+		providers, err := NewProviders(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, providers)
+	})
+
+	t.Run("provider configured from prefixed variables", func(t *testing.T) {
+		t.Setenv("OIDC_PROVIDERS", "Company")
+		t.Setenv("COMPANY_OIDC_ISSUER_URL", idp.Issuer())
+		t.Setenv("COMPANY_OIDC_CLIENT_ID", mockClientID)
+		t.Setenv("COMPANY_OIDC_REDIRECT_URL", testRedirectURL)
+
+		providers, err := NewProviders(context.Background())
+		assert.NoError(t, err)
+		assert.Contains(t, providers, "company")
+	})
+
+	t.Run("missing required variable", func(t *testing.T) {
+		t.Setenv("OIDC_PROVIDERS", "partner")
+
+		providers, err := NewProviders(context.Background())
+		assert.ErrorContains(t, err, "oidc provider partner")
+		assert.Nil(t, providers)
+	})
+}
@@ -1,24 +1,131 @@
 package redis
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/kelseyhightower/envconfig"
 )
 
+const (
+	// ModeStandalone connects to a single Redis server at the first address.
+	ModeStandalone = "standalone"
+	// ModeSentinel connects to the master named MasterName, discovered through the
+	// Sentinel servers at the addresses, and follows its failovers.
+	ModeSentinel = "sentinel"
+	// ModeCluster connects to a Redis Cluster through the seed nodes at the addresses.
+	ModeCluster = "cluster"
+)
+
+var (
+	// ErrUnknownMode is returned when REDIS_MODE is not a supported mode.
+	ErrUnknownMode = errors.New("unknown REDIS_MODE")
+	// ErrNoAddress is returned when no address is configured.
+	ErrNoAddress = errors.New("REDIS_ADDRESS must list at least one address")
+	// ErrNoMasterName is returned when the Sentinel mode has no master name.
+	ErrNoMasterName = errors.New("REDIS_MASTER_NAME is required in sentinel mode")
+	// ErrClusterDB is returned when a database other than 0 is selected in cluster mode,
+	// which only has database 0.
+	ErrClusterDB = errors.New("REDIS_DB must be 0 in cluster mode")
+)
+
 // config holds the Redis connection configuration loaded from environment variables.
+//
+// Mode is one of ModeStandalone, ModeSentinel or ModeCluster. Address lists the server,
+// the Sentinel servers or the cluster seed nodes, comma separated. Username and Password
+// authenticate with the Redis ACL; SentinelUsername and SentinelPassword authenticate with
+// the Sentinel servers when they differ.
+//
+// TLS enables TLS, verifying the server certificate against the system roots, or the PEM
+// certificate authorities of TLSCAFile, for the name TLSServerName, which defaults to the
+// host of each address.
 type config struct {
-	Address  string `default:"localhost:6379" envconfig:"REDIS_ADDRESS"`
-	Password string `default:"" envconfig:"REDIS_PASSWORD"`
-	DB       int    `default:"0" envconfig:"REDIS_DB"`
+	Mode             string        `default:"standalone" envconfig:"REDIS_MODE"`
+	Address          []string      `default:"localhost:6379" envconfig:"REDIS_ADDRESS"`
+	MasterName       string        `default:"" envconfig:"REDIS_MASTER_NAME"`
+	Username         string        `default:"" envconfig:"REDIS_USERNAME"`
+	Password         string        `default:"" envconfig:"REDIS_PASSWORD"`
+	SentinelUsername string        `default:"" envconfig:"REDIS_SENTINEL_USERNAME"`
+	SentinelPassword string        `default:"" envconfig:"REDIS_SENTINEL_PASSWORD"`
+	DB               int           `default:"0" envconfig:"REDIS_DB"`
+	TLS              bool          `default:"false" envconfig:"REDIS_TLS"`
+	TLSCAFile        string        `default:"" envconfig:"REDIS_TLS_CA_FILE"`
+	TLSServerName    string        `default:"" envconfig:"REDIS_TLS_SERVER_NAME"`
+	PoolSize         int           `default:"0" envconfig:"REDIS_POOL_SIZE"`
+	DialTimeout      time.Duration `default:"5s" envconfig:"REDIS_DIAL_TIMEOUT"`
+	ReadTimeout      time.Duration `default:"3s" envconfig:"REDIS_READ_TIMEOUT"`
+	WriteTimeout     time.Duration `default:"3s" envconfig:"REDIS_WRITE_TIMEOUT"`
 }
 
 // newConfig creates a new configuration instance by reading environment variables.
-// The prefix parameter is used to prefix environment variable names (currently unused).
+// The prefix parameter is used to prefix environment variable names, none when empty.
+// It returns an error when the settings do not fit the mode.
 func newConfig(prefix string) (*config, error) {
 	cfg := &config{}
 
 	if err := envconfig.Process(prefix, cfg); err != nil {
 		return nil, err
 	}
+	cfg.Address = slices.DeleteFunc(cfg.Address, func(address string) bool {
+		return strings.TrimSpace(address) == ""
+	})
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
 
 	return cfg, nil
 }
+
+// validate checks that the settings fit the mode.
+func (cfg *config) validate() error {
+	if len(cfg.Address) == 0 {
+		return ErrNoAddress
+	}
+
+	switch cfg.Mode {
+	case ModeStandalone:
+		return nil
+	case ModeSentinel:
+		if cfg.MasterName == "" {
+			return ErrNoMasterName
+		}
+		return nil
+	case ModeCluster:
+		if cfg.DB != 0 {
+			return ErrClusterDB
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownMode, cfg.Mode)
+	}
+}
+
+// tlsConfig returns the TLS settings of the connections, nil when TLS is disabled.
+func (cfg *config) tlsConfig() (*tls.Config, error) {
+	if !cfg.TLS {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.TLSServerName,
+	}
+	if cfg.TLSCAFile != "" {
+		data, err := os.ReadFile(cfg.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("REDIS_TLS_CA_FILE: %w", err)
+		}
+		tlsCfg.RootCAs = x509.NewCertPool()
+		if !tlsCfg.RootCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("REDIS_TLS_CA_FILE: no PEM certificate in %s", cfg.TLSCAFile)
+		}
+	}
+
+	return tlsCfg, nil
+}
@@ -0,0 +1,165 @@
+package redis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfig(t *testing.T) {
+	defaults := func(update func(cfg *config)) *config {
+		cfg := &config{
+			Mode:         ModeStandalone,
+			Address:      []string{"localhost:6379"},
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+		}
+		update(cfg)
+		return cfg
+	}
+
+	testCases := []struct {
+		name          string
+		prefix        string
+		env           map[string]string
+		expectedCfg   *config
+		expectedError error
+	}{
+		{
+			name:        "success - defaults",
+			expectedCfg: defaults(func(cfg *config) {}),
+		},
+		{
+			name: "success - unprefixed variables",
+			env:  map[string]string{"REDIS_ADDRESS": "redis:6379", "REDIS_DB": "2"},
+			expectedCfg: defaults(func(cfg *config) {
+				cfg.Address = []string{"redis:6379"}
+				cfg.DB = 2
+			}),
+		},
+		{
+			name:   "success - prefixed variables",
+			prefix: "cache",
+			env: map[string]string{
+				"REDIS_ADDRESS":       "redis:6379",
+				"CACHE_REDIS_ADDRESS": "cache:6379",
+				"CACHE_REDIS_DB":      "1",
+			},
+			expectedCfg: defaults(func(cfg *config) {
+				cfg.Address = []string{"cache:6379"}
+				cfg.DB = 1
+			}),
+		},
+		{
+			name: "success - sentinel with ACL and TLS",
+			env: map[string]string{
+				"REDIS_MODE":              ModeSentinel,
+				"REDIS_ADDRESS":           "sentinel-1:26379,sentinel-2:26379",
+				"REDIS_MASTER_NAME":       "bookmarks",
+				"REDIS_USERNAME":          "bookmark_service",
+				"REDIS_PASSWORD":          "secret",
+				"REDIS_SENTINEL_PASSWORD": "sentinel-secret",
+				"REDIS_TLS":               "true",
+			},
+			expectedCfg: defaults(func(cfg *config) {
+				cfg.Mode = ModeSentinel
+				cfg.Address = []string{"sentinel-1:26379", "sentinel-2:26379"}
+				cfg.MasterName = "bookmarks"
+				cfg.Username = "bookmark_service"
+				cfg.Password = "secret"
+				cfg.SentinelPassword = "sentinel-secret"
+				cfg.TLS = true
+			}),
+		},
+		{
+			name:          "error - unknown mode",
+			env:           map[string]string{"REDIS_MODE": "replication"},
+			expectedError: ErrUnknownMode,
+		},
+		{
+			name:          "error - sentinel without master name",
+			env:           map[string]string{"REDIS_MODE": ModeSentinel},
+			expectedError: ErrNoMasterName,
+		},
+		{
+			name:          "error - cluster with a database",
+			env:           map[string]string{"REDIS_MODE": ModeCluster, "REDIS_DB": "1"},
+			expectedError: ErrClusterDB,
+		},
+		{
+			name:          "error - no address",
+			env:           map[string]string{"REDIS_ADDRESS": ","},
+			expectedError: ErrNoAddress,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := newConfig(tc.prefix)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedCfg, cfg)
+		})
+	}
+}
+
+func TestConfig_TLSConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	invalidCA := filepath.Join(dir, "invalid.pem")
+	assert.NoError(t, os.WriteFile(invalidCA, []byte("not a certificate"), 0o600))
+
+	testCases := []struct {
+		name           string
+		cfg            *config
+		expectedTLS    bool
+		expectedErrStr string
+	}{
+		{
+			name: "disabled",
+			cfg:  &config{},
+		},
+		{
+			name:        "enabled with the system roots",
+			cfg:         &config{TLS: true, TLSServerName: "redis.internal"},
+			expectedTLS: true,
+		},
+		{
+			name:           "missing CA file",
+			cfg:            &config{TLS: true, TLSCAFile: filepath.Join(dir, "missing.pem")},
+			expectedErrStr: "no such file or directory",
+		},
+		{
+			name:           "CA file without certificate",
+			cfg:            &config{TLS: true, TLSCAFile: invalidCA},
+			expectedErrStr: "no PEM certificate",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tlsCfg, err := tc.cfg.tlsConfig()
+
+			if tc.expectedErrStr != "" {
+				assert.ErrorContains(t, err, tc.expectedErrStr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedTLS, tlsCfg != nil)
+			if tlsCfg != nil {
+				assert.Equal(t, tc.cfg.TLSServerName, tlsCfg.ServerName)
+			}
+		})
+	}
+}
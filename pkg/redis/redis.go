@@ -1,21 +1,60 @@
 package redis
 
-import "github.com/redis/go-redis/v9"
+import (
+	"github.com/luongtruong20201/bookmark-management/pkg/metrics"
+	"github.com/luongtruong20201/bookmark-management/pkg/tracing"
+	"github.com/redis/go-redis/v9"
+)
 
-// NewClient creates a new Redis client instance using configuration from environment variables.
-// The prefix parameter is currently unused but reserved for future use.
-// It returns a Redis client configured with address, password, and database settings.
-func NewClient(prefix string) (*redis.Client, error) {
-	cfg, err := newConfig("")
+// NewClient creates a new Redis client instance using configuration from environment variables
+// read with the specified prefix, such as CACHE_REDIS_ADDRESS for the prefix "cache".
+// The client connects to a single server, to the master of a Sentinel deployment or to a
+// Redis Cluster according to REDIS_MODE, optionally over TLS. Commands are traced and
+// their durations recorded in the service metrics.
+func NewClient(prefix string) (redis.UniversalClient, error) {
+	cfg, err := newConfig(prefix)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := cfg.universalOptions()
 	if err != nil {
 		return nil, err
 	}
 
-	client := redis.NewClient(&redis.Options{
-		Addr:     cfg.Address,
-		Password: cfg.Password,
-		DB:       cfg.DB,
-	})
+	var client redis.UniversalClient
+	switch cfg.Mode {
+	case ModeSentinel:
+		client = redis.NewFailoverClient(opts.Failover())
+	case ModeCluster:
+		client = redis.NewClusterClient(opts.Cluster())
+	default:
+		client = redis.NewClient(opts.Simple())
+	}
+	client.AddHook(metrics.NewRedisHook())
+	client.AddHook(tracing.NewRedisHook())
 
 	return client, nil
 }
+
+// universalOptions converts the configuration into the options of the Redis clients.
+func (cfg *config) universalOptions() (*redis.UniversalOptions, error) {
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &redis.UniversalOptions{
+		Addrs:            cfg.Address,
+		MasterName:       cfg.MasterName,
+		Username:         cfg.Username,
+		Password:         cfg.Password,
+		SentinelUsername: cfg.SentinelUsername,
+		SentinelPassword: cfg.SentinelPassword,
+		DB:               cfg.DB,
+		TLSConfig:        tlsCfg,
+		PoolSize:         cfg.PoolSize,
+		DialTimeout:      cfg.DialTimeout,
+		ReadTimeout:      cfg.ReadTimeout,
+		WriteTimeout:     cfg.WriteTimeout,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClient(t *testing.T) {
+	testCases := []struct {
+		name          string
+		env           map[string]string
+		expectedType  redis.UniversalClient
+		expectedPing  bool
+		expectedError error
+	}{
+		{
+			name:         "success - standalone",
+			env:          map[string]string{"REDIS_MODE": ModeStandalone},
+			expectedType: &redis.Client{},
+			expectedPing: true,
+		},
+		{
+			name:         "success - sentinel",
+			env:          map[string]string{"REDIS_MODE": ModeSentinel, "REDIS_MASTER_NAME": "bookmarks"},
+			expectedType: &redis.Client{},
+		},
+		{
+			name:         "success - cluster",
+			env:          map[string]string{"REDIS_MODE": ModeCluster},
+			expectedType: &redis.ClusterClient{},
+		},
+		{
+			name:          "error - invalid configuration",
+			env:           map[string]string{"REDIS_MODE": "replication"},
+			expectedError: ErrUnknownMode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := miniredis.RunT(t)
+			t.Setenv("REDIS_ADDRESS", server.Addr())
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			client, err := NewClient("")
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			if tc.expectedError != nil {
+				assert.Nil(t, client)
+				return
+			}
+			defer client.Close()
+			assert.IsType(t, tc.expectedType, client)
+			if tc.expectedPing {
+				assert.NoError(t, client.Ping(t.Context()).Err())
+			}
+		})
+	}
+}
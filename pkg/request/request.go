@@ -13,40 +13,35 @@ import (
 
 // BindInputFromRequest binds and validates all supported input sources (JSON body,
 // URI params, query params, and headers) into a typed struct T. On validation
-// failure it writes a 400 response using response.InputFieldError and aborts
-// the Gin context, returning the encountered error.
+// failure it aborts with a 400 problem using response.InputError, returning the
+// encountered error.
 func BindInputFromRequest[T any](c *gin.Context) (*T, error) {
 	reqInput := new(T)
 
 	if err := c.ShouldBindJSON(reqInput); err != nil {
-		c.JSON(http.StatusBadRequest, response.InputFieldError(err))
-		c.Abort()
+		response.InputError(c, err)
 		return nil, err
 	}
 
 	if err := c.ShouldBindUri(reqInput); err != nil {
-		c.JSON(http.StatusBadRequest, response.InputFieldError(err))
-		c.Abort()
+		response.InputError(c, err)
 		return nil, err
 	}
 
 	if err := c.ShouldBindQuery(reqInput); err != nil {
-		c.JSON(http.StatusBadRequest, response.InputFieldError(err))
-		c.Abort()
+		response.InputError(c, err)
 		return nil, err
 	}
 
 	if err := c.ShouldBindHeader(reqInput); err != nil {
-		c.JSON(http.StatusBadRequest, response.InputFieldError(err))
-		c.Abort()
+		response.InputError(c, err)
 		return nil, err
 	}
 
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
 	if err := validate.Struct(reqInput); err != nil {
-		c.JSON(http.StatusBadRequest, response.InputFieldError(err))
-		c.Abort()
+		response.InputError(c, err)
 		return nil, err
 	}
 
@@ -56,8 +51,8 @@ func BindInputFromRequest[T any](c *gin.Context) (*T, error) {
 // BindInputFromRequestWithAuth binds and validates all supported input sources (JSON body,
 // URI params, query params, and headers) and extracts the user ID from JWT claims.
 // It returns the bound input, user ID, and any error.
-// On validation failure it writes a 400 response using response.InputFieldError and aborts
-// the Gin context. If user ID extraction fails, it writes a 401 response and aborts the context.
+// On validation failure it aborts with a 400 problem using response.InputError.
+// If user ID extraction fails, it aborts with a 401 problem.
 func BindInputFromRequestWithAuth[T any](c *gin.Context) (*T, string, error) {
 	input, err := BindInputFromRequest[T](c)
 	if err != nil {
@@ -66,10 +61,7 @@ func BindInputFromRequestWithAuth[T any](c *gin.Context) (*T, string, error) {
 
 	userId, err := utils.GetUserIDFromRequest(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, &response.Message{
-			Message: "Invalid jwt token",
-		})
-		c.Abort()
+		abortInvalidToken(c)
 		return nil, "", err
 	}
 
@@ -78,31 +70,26 @@ func BindInputFromRequestWithAuth[T any](c *gin.Context) (*T, string, error) {
 
 // BindInputFromQueryWithAuth binds and validates query parameters from the request
 // and extracts the user ID from JWT claims. It returns the bound input, user ID, and any error.
-// On validation failure it writes a 400 response using response.InputFieldError and aborts
-// the Gin context. If user ID extraction fails, it writes a 401 response and aborts the context.
+// On validation failure it aborts with a 400 problem using response.InputError.
+// If user ID extraction fails, it aborts with a 401 problem.
 func BindInputFromQueryWithAuth[T any](c *gin.Context) (*T, string, error) {
 	reqInput := new(T)
 
 	if err := c.ShouldBindQuery(reqInput); err != nil {
-		c.JSON(http.StatusBadRequest, response.InputFieldError(err))
-		c.Abort()
+		response.InputError(c, err)
 		return nil, "", err
 	}
 
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
 	if err := validate.Struct(reqInput); err != nil {
-		c.JSON(http.StatusBadRequest, response.InputFieldError(err))
-		c.Abort()
+		response.InputError(c, err)
 		return nil, "", err
 	}
 
 	userId, err := utils.GetUserIDFromRequest(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, &response.Message{
-			Message: "Invalid jwt token",
-		})
-		c.Abort()
+		abortInvalidToken(c)
 		return nil, "", err
 	}
 
@@ -111,33 +98,34 @@ func BindInputFromQueryWithAuth[T any](c *gin.Context) (*T, string, error) {
 
 // BindInputFromUriWithAuth binds and validates URI parameters from the request
 // and extracts the user ID from JWT claims. It returns the bound input, user ID, and any error.
-// On validation failure it writes a 400 response using response.InputFieldError and aborts
-// the Gin context. If user ID extraction fails, it writes a 401 response and aborts the context.
+// On validation failure it aborts with a 400 problem using response.InputError.
+// If user ID extraction fails, it aborts with a 401 problem.
 func BindInputFromUriWithAuth[T any](c *gin.Context) (*T, string, error) {
 	reqInput := new(T)
 
 	if err := c.ShouldBindUri(reqInput); err != nil {
-		c.JSON(http.StatusBadRequest, response.InputFieldError(err))
-		c.Abort()
+		response.InputError(c, err)
 		return nil, "", err
 	}
 
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
 	if err := validate.Struct(reqInput); err != nil {
-		c.JSON(http.StatusBadRequest, response.InputFieldError(err))
-		c.Abort()
+		response.InputError(c, err)
 		return nil, "", err
 	}
 
 	userId, err := utils.GetUserIDFromRequest(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, &response.Message{
-			Message: "Invalid jwt token",
-		})
-		c.Abort()
+		abortInvalidToken(c)
 		return nil, "", err
 	}
 
 	return reqInput, userId, nil
 }
+
+// abortInvalidToken aborts with the 401 problem returned when the user ID cannot be
+// extracted from the JWT claims.
+func abortInvalidToken(c *gin.Context) {
+	response.Abort(c, response.NewProblem(http.StatusUnauthorized, response.CodeUnauthorized, "Invalid token"))
+}
@@ -0,0 +1,122 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// ProblemContentType is the media type of the error responses, defined by RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// RequestIDKey is the Gin context key holding the ID of the request, set by the request ID
+// middleware and reported in the error responses.
+const RequestIDKey = "request_id"
+
+// Error codes reported in the "code" member of the error responses. They are part of the
+// API contract: clients may rely on them, so existing codes must never change meaning.
+const (
+	// CodeInvalidInput is reported when the request fails binding or validation.
+	CodeInvalidInput = "invalid_input"
+	// CodeInvalidRequest is reported when a valid request cannot be applied.
+	CodeInvalidRequest = "invalid_request"
+	// CodeInvalidCredentials is reported when a username or password is wrong.
+	CodeInvalidCredentials = "invalid_credentials"
+	// CodePasswordRejected is reported when a new password breaks the password policy.
+	CodePasswordRejected = "password_rejected"
+	// CodeUnauthorized is reported when the request is not authenticated.
+	CodeUnauthorized = "unauthorized"
+	// CodeForbidden is reported when the authenticated user may not perform the request.
+	CodeForbidden = "forbidden"
+	// CodeAccountDisabled is reported when the account has been disabled by an administrator.
+	CodeAccountDisabled = "account_disabled"
+	// CodePasswordResetRequired is reported when the password must be changed before login.
+	CodePasswordResetRequired = "password_reset_required"
+	// CodeNotFound is reported when the requested resource or route does not exist.
+	CodeNotFound = "not_found"
+	// CodeConflict is reported when the request conflicts with the current state.
+	CodeConflict = "conflict"
+	// CodeRateLimited is reported when the caller sent too many requests.
+	CodeRateLimited = "rate_limited"
+	// CodeInternal is reported when the request failed on an unexpected error.
+	CodeInternal = "internal_error"
+	// CodeUnavailable is reported when a dependency needed by the request is unavailable.
+	CodeUnavailable = "service_unavailable"
+)
+
+// Problem is the error envelope of every failed request, an RFC 7807 problem details
+// object served as application/problem+json. Besides the standard members, it carries
+// a stable machine-readable code, a human-readable message, optional details such as
+// the invalid fields, and the ID of the request for support.
+type Problem struct {
+	Type      string `json:"type" example:"about:blank"`
+	Title     string `json:"title" example:"Bad Request"`
+	Status    int    `json:"status" example:"400"`
+	Code      string `json:"code" example:"invalid_input"`
+	Message   string `json:"message" example:"Input error"`
+	Details   any    `json:"details,omitempty"`
+	RequestID string `json:"request_id,omitempty" example:"6f1c3f0e-2b1d-4c1a-9a51-3a0f4c1d2e7b"`
+}
+
+// FieldError describes a field of the request that failed validation.
+type FieldError struct {
+	Field   string `json:"field" example:"Url"`
+	Rule    string `json:"rule" example:"required"`
+	Message string `json:"message" example:"Url is invalid (required)"`
+}
+
+// NewProblem creates a problem of the given status, code and message. The type is
+// "about:blank" and the title is the status text, as RFC 7807 defines for problems
+// without a dedicated type.
+func NewProblem(status int, code, message string) *Problem {
+	return &Problem{
+		Type:    "about:blank",
+		Title:   http.StatusText(status),
+		Status:  status,
+		Code:    code,
+		Message: message,
+	}
+}
+
+// WithDetails sets the details of the problem and returns it.
+func (p *Problem) WithDetails(details any) *Problem {
+	p.Details = details
+	return p
+}
+
+// Abort writes the problem as application/problem+json with the ID of the request and
+// aborts the Gin context.
+func Abort(c *gin.Context, p *Problem) {
+	p.RequestID = c.GetString(RequestIDKey)
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
+
+// InternalError aborts the request with the 500 problem hiding the cause of the failure.
+func InternalError(c *gin.Context) {
+	Abort(c, NewProblem(http.StatusInternalServerError, CodeInternal, "Processing Error"))
+}
+
+// InputError aborts the request with a 400 problem describing a binding or validation
+// error. Validation errors list the invalid fields in the details; other errors, such
+// as a malformed body, carry no details.
+func InputError(c *gin.Context, err error) {
+	problem := NewProblem(http.StatusBadRequest, CodeInvalidInput, "Input error")
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]FieldError, 0, len(validationErrs))
+		for _, fieldErr := range validationErrs {
+			fields = append(fields, FieldError{
+				Field:   fieldErr.Field(),
+				Rule:    fieldErr.Tag(),
+				Message: fieldErr.Field() + " is invalid (" + fieldErr.Tag() + ")",
+			})
+		}
+		problem.WithDetails(fields)
+	}
+
+	Abort(c, problem)
+}
@@ -1,42 +1,50 @@
 package response
 
 import (
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestInputFieldError(t *testing.T) {
+func TestNewProblem(t *testing.T) {
 	t.Parallel()
 
+	problem := NewProblem(http.StatusNotFound, CodeNotFound, "Resource not found").WithDetails([]string{"detail"})
+
+	assert.Equal(t, &Problem{
+		Type:    "about:blank",
+		Title:   "Not Found",
+		Status:  http.StatusNotFound,
+		Code:    CodeNotFound,
+		Message: "Resource not found",
+		Details: []string{"detail"},
+	}, problem)
+}
+
+func TestAbort(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
 	testCases := []struct {
-		name            string
-		err             error
-		expectedMsg     string
-		expectedDetails interface{}
-		hasDetails      bool
+		name         string
+		requestID    string
+		expectedBody string
 	}{
 		{
-			name:            "error - non-validation error returns InputErrResponse",
-			err:             errors.New("some error"),
-			expectedMsg:     "Input Error",
-			expectedDetails: nil,
-			hasDetails:      false,
-		},
-		{
-			name:            "error - nil error returns InputErrResponse",
-			err:             nil,
-			expectedMsg:     "Input Error",
-			expectedDetails: nil,
-			hasDetails:      false,
+			name:         "success - with request ID",
+			requestID:    "req-1",
+			expectedBody: `{"type":"about:blank","title":"Conflict","status":409,"code":"conflict","message":"Resource already exists","request_id":"req-1"}`,
 		},
 		{
-			name:        "success - validation errors are formatted",
-			err:         createValidationError(),
-			expectedMsg: "Input error",
-			hasDetails:  true,
+			name:         "success - without request ID",
+			expectedBody: `{"type":"about:blank","title":"Conflict","status":409,"code":"conflict","message":"Resource already exists"}`,
 		},
 	}
 
@@ -44,63 +52,48 @@ func TestInputFieldError(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			result := InputFieldError(tc.err)
-
-			assert.Equal(t, tc.expectedMsg, result.Message)
-
-			if tc.hasDetails {
-				assert.NotNil(t, result.Details)
-				details, ok := result.Details.([]string)
-				assert.True(t, ok, "details should be a slice of strings")
-				assert.NotEmpty(t, details)
-				for _, detail := range details {
-					assert.Contains(t, detail, "is invalid")
-					assert.Contains(t, detail, "(")
-					assert.Contains(t, detail, ")")
-				}
-			} else {
-				assert.Equal(t, tc.expectedDetails, result.Details)
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+			if tc.requestID != "" {
+				c.Set(RequestIDKey, tc.requestID)
 			}
+
+			Abort(c, NewProblem(http.StatusConflict, CodeConflict, "Resource already exists"))
+
+			assert.True(t, c.IsAborted())
+			assert.Equal(t, http.StatusConflict, rec.Code)
+			assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+			assert.JSONEq(t, tc.expectedBody, rec.Body.String())
 		})
 	}
 }
 
-func TestInputFieldError_ValidationErrors(t *testing.T) {
+func TestInputError(t *testing.T) {
 	t.Parallel()
 
-	type TestStruct struct {
-		Email    string `validate:"required,email"`
-		Username string `validate:"required,min=3"`
-		Age      int    `validate:"required,min=18"`
-	}
+	gin.SetMode(gin.TestMode)
 
-	validate := validator.New()
+	type input struct {
+		Email string `validate:"required,email"`
+		Name  string `validate:"required"`
+	}
 
 	testCases := []struct {
 		name            string
-		data            TestStruct
-		expectedFields  []string
-		expectedMessage string
+		err             error
+		expectedDetails []FieldError
 	}{
 		{
-			name: "success - multiple validation errors",
-			data: TestStruct{
-				Email:    "invalid-email",
-				Username: "ab",
-				Age:      15,
+			name: "success - validation errors list the fields",
+			err:  validator.New().Struct(input{Email: "invalid-email"}),
+			expectedDetails: []FieldError{
+				{Field: "Email", Rule: "email", Message: "Email is invalid (email)"},
+				{Field: "Name", Rule: "required", Message: "Name is invalid (required)"},
 			},
-			expectedFields:  []string{"Email", "Username", "Age"},
-			expectedMessage: "Input error",
 		},
 		{
-			name: "success - single validation error",
-			data: TestStruct{
-				Email:    "",
-				Username: "validuser",
-				Age:      25,
-			},
-			expectedFields:  []string{"Email"},
-			expectedMessage: "Input error",
+			name: "success - other errors carry no details",
+			err:  errors.New("malformed body"),
 		},
 	}
 
@@ -108,80 +101,21 @@ func TestInputFieldError_ValidationErrors(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			err := validate.Struct(tc.data)
-			assert.Error(t, err)
-
-			result := InputFieldError(err)
-
-			assert.Equal(t, tc.expectedMessage, result.Message)
-			assert.NotNil(t, result.Details)
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
 
-			details, ok := result.Details.([]string)
-			assert.True(t, ok)
-			assert.Len(t, details, len(tc.expectedFields))
+			InputError(c, tc.err)
 
-			for _, field := range tc.expectedFields {
-				found := false
-				for _, detail := range details {
-					if containsField(detail, field) {
-						found = true
-						break
-					}
-				}
-				assert.True(t, found, "field %s should be in details", field)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			var problem struct {
+				Code    string       `json:"code"`
+				Message string       `json:"message"`
+				Details []FieldError `json:"details"`
 			}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, CodeInvalidInput, problem.Code)
+			assert.Equal(t, "Input error", problem.Message)
+			assert.Equal(t, tc.expectedDetails, problem.Details)
 		})
 	}
 }
-
-func TestMessage_Structure(t *testing.T) {
-	t.Parallel()
-
-	t.Run("InternalErrResponse has correct structure", func(t *testing.T) {
-		t.Parallel()
-
-		assert.Equal(t, "Processing Error", InternalErrResponse.Message)
-		assert.Nil(t, InternalErrResponse.Details)
-	})
-
-	t.Run("InputErrResponse has correct structure", func(t *testing.T) {
-		t.Parallel()
-
-		assert.Equal(t, "Input Error", InputErrResponse.Message)
-		assert.Nil(t, InputErrResponse.Details)
-	})
-
-	t.Run("Message with details", func(t *testing.T) {
-		t.Parallel()
-
-		msg := Message{
-			Message: "Test message",
-			Details: []string{"detail1", "detail2"},
-		}
-
-		assert.Equal(t, "Test message", msg.Message)
-		assert.NotNil(t, msg.Details)
-		details, ok := msg.Details.([]string)
-		assert.True(t, ok)
-		assert.Len(t, details, 2)
-	})
-}
-
-func createValidationError() error {
-	type TestStruct struct {
-		Email string `validate:"required,email"`
-		Name  string `validate:"required"`
-	}
-
-	validate := validator.New()
-	testData := TestStruct{
-		Email: "invalid-email",
-		Name:  "",
-	}
-
-	return validate.Struct(testData)
-}
-
-func containsField(detail, field string) bool {
-	return len(detail) > len(field) && detail[:len(field)] == field
-}
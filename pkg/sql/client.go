@@ -1,25 +1,100 @@
 package sqldb
 
 import (
+	"database/sql"
+
+	"github.com/luongtruong20201/bookmark-management/pkg/metrics"
+	"github.com/luongtruong20201/bookmark-management/pkg/tracing"
+	"github.com/rs/zerolog/log"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 // NewClient creates a new GORM database client connection to a PostgreSQL database.
 // It reads database configuration from environment variables using the specified prefix,
-// constructs a DSN, and establishes a connection. Returns the GORM DB instance or an error
-// if configuration loading or connection establishment fails.
+// constructs a DSN, and establishes a connection with a bounded connection pool. Returns
+// the GORM DB instance or an error if configuration loading or connection establishment
+// fails. Queries are traced, their durations recorded in the service metrics, and the
+// slow ones logged. The configured read replicas serve the queries of Read.
 func NewClient(prefix string) (*gorm.DB, error) {
 	cfg, err := newConfig(prefix)
 	if err != nil {
 		return nil, err
 	}
 
-	dsn := cfg.GetDSN()
-	db, err := gorm.Open(postgres.Open(dsn))
+	db, err := open(cfg, cfg.GetDSN())
 	if err != nil {
 		return nil, err
 	}
 
+	replicas := make([]*sql.DB, 0, len(cfg.ReplicaURLs))
+	for _, url := range cfg.ReplicaURLs {
+		replica, err := open(cfg, url)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := replica.DB()
+		if err != nil {
+			return nil, err
+		}
+		replicas = append(replicas, pool)
+	}
+	err = useReplicas(db, replicas, func(pool *sql.DB) gorm.Dialector {
+		return postgres.New(postgres.Config{Conn: pool, PreferSimpleProtocol: !cfg.PreparedStatements})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Use(metrics.NewGORMPlugin()); err != nil {
+		return nil, err
+	}
+	if err := db.Use(tracing.NewGORMPlugin()); err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
+
+// open connects to the database of the DSN with the pool settings of the configuration.
+func open(cfg *config, dsn string) (*gorm.DB, error) {
+	dialector := postgres.New(postgres.Config{
+		DSN:                  dsn,
+		PreferSimpleProtocol: !cfg.PreparedStatements,
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: newLogger(cfg)})
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	return db, nil
+}
+
+// newLogger creates a GORM logger writing the failed and slow queries to the service log,
+// without their parameters which may hold personal data.
+func newLogger(cfg *config) logger.Interface {
+	return logger.New(logWriter{}, logger.Config{
+		SlowThreshold:             cfg.SlowThreshold,
+		LogLevel:                  logger.Warn,
+		IgnoreRecordNotFoundError: true,
+		ParameterizedQueries:      true,
+	})
+}
+
+// logWriter writes the GORM log lines as warnings of the service log.
+type logWriter struct{}
+
+// Printf logs the formatted line.
+func (logWriter) Printf(format string, args ...interface{}) {
+	log.Warn().Msgf(format, args...)
+}
@@ -0,0 +1,131 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/luongtruong20201/bookmark-management/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// replicasResolver names the dbresolver resolver holding the read replicas. Queries only
+// reach the replicas when opted in with Reader; every other query, and every query of a
+// transaction, runs on the primary.
+const replicasResolver = "replicas"
+
+// replicasPluginName registers the read replicas among the GORM plugins of the client.
+const replicasPluginName = "bookmark:replicas"
+
+// primaryKey is the context key forcing the reads on the primary.
+type primaryKey struct{}
+
+// WithPrimary returns a copy of the context whose reads run on the primary, for the
+// requests that must see their own writes.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// PrimaryForced reports whether the reads of the context must run on the primary.
+func PrimaryForced(ctx context.Context) bool {
+	forced, _ := ctx.Value(primaryKey{}).(bool)
+	return forced
+}
+
+// Reader returns a session of the database whose queries run on a read replica, unless
+// the context forces the primary. Without replicas, the queries run on the primary. It is
+// meant for the queries that tolerate the replication lag.
+func Reader(ctx context.Context, db *gorm.DB) *gorm.DB {
+	db = db.WithContext(ctx)
+	if PrimaryForced(ctx) {
+		return db
+	}
+
+	return db.Clauses(dbresolver.Use(replicasResolver))
+}
+
+// Read runs the query on a session returned by Reader. When the query fails on a read
+// replica, for instance because the replica is down, it runs the query again on the
+// primary; a missing record or a canceled context is reported as is.
+func Read(ctx context.Context, db *gorm.DB, query func(tx *gorm.DB) error) error {
+	err := query(Reader(ctx, db))
+	if err == nil || !readsReplica(ctx, db) || errors.Is(err, gorm.ErrRecordNotFound) || ctx.Err() != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Warn().Err(err).Msg("replica query failed, retrying on the primary")
+	return query(db.WithContext(ctx))
+}
+
+// readsReplica reports whether the sessions returned by Reader run on a read replica.
+func readsReplica(ctx context.Context, db *gorm.DB) bool {
+	if PrimaryForced(ctx) || len(Replicas(db)) == 0 {
+		return false
+	}
+	_, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter)
+
+	return !inTransaction
+}
+
+// Replicas returns the connection pools of the read replicas of the database, in the
+// order they are configured.
+func Replicas(db *gorm.DB) []*sql.DB {
+	if plugin, ok := db.Config.Plugins[replicasPluginName].(*replicasPlugin); ok {
+		return plugin.pools
+	}
+
+	return nil
+}
+
+// Close closes the connection pools of the database and of its read replicas.
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	errs := []error{sqlDB.Close()}
+	for _, pool := range Replicas(db) {
+		errs = append(errs, pool.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// replicasPlugin routes the queries opted in with Reader to the read replicas.
+type replicasPlugin struct {
+	pools     []*sql.DB
+	dialector func(*sql.DB) gorm.Dialector
+}
+
+// useReplicas registers the connection pools of the read replicas on the database; the
+// dialector wraps a pool into a GORM dialector of the database driver. It must be called
+// before registering other plugins, which dbresolver would initialize again on the
+// replicas.
+func useReplicas(db *gorm.DB, pools []*sql.DB, dialector func(*sql.DB) gorm.Dialector) error {
+	if len(pools) == 0 {
+		return nil
+	}
+
+	return db.Use(&replicasPlugin{pools: pools, dialector: dialector})
+}
+
+// Name returns the name of the plugin.
+func (p *replicasPlugin) Name() string {
+	return replicasPluginName
+}
+
+// Initialize registers the replicas as the replicasResolver resolver, picked at random for
+// each query.
+func (p *replicasPlugin) Initialize(db *gorm.DB) error {
+	dialectors := make([]gorm.Dialector, len(p.pools))
+	for i, pool := range p.pools {
+		dialectors[i] = p.dialector(pool)
+	}
+
+	return db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   dbresolver.RandomPolicy{},
+	}, replicasResolver))
+}
@@ -0,0 +1,182 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// testRow is a row telling which database it was read from.
+type testRow struct {
+	ID     int
+	Source string
+}
+
+func TestReader(t *testing.T) {
+	t.Parallel()
+
+	// newTestDB creates a database holding a single row with the source.
+	newTestDB := func(t *testing.T, source string) *gorm.DB {
+		db := InitMockDB(t)
+		assert.NoError(t, db.AutoMigrate(&testRow{}))
+		assert.NoError(t, db.Create(&testRow{ID: 1, Source: source}).Error)
+		return db
+	}
+
+	testCases := []struct {
+		name           string
+		replicas       bool
+		query          func(t *testing.T, ctx context.Context, db *gorm.DB) *gorm.DB
+		expectedSource string
+	}{
+		{
+			name:           "reader - runs on the replica",
+			replicas:       true,
+			query:          func(t *testing.T, ctx context.Context, db *gorm.DB) *gorm.DB { return Reader(ctx, db) },
+			expectedSource: "replica",
+		},
+		{
+			name:     "reader - primary forced by the context",
+			replicas: true,
+			query: func(t *testing.T, ctx context.Context, db *gorm.DB) *gorm.DB {
+				return Reader(WithPrimary(ctx), db)
+			},
+			expectedSource: "primary",
+		},
+		{
+			name:           "reader - runs on the primary without replicas",
+			query:          func(t *testing.T, ctx context.Context, db *gorm.DB) *gorm.DB { return Reader(ctx, db) },
+			expectedSource: "primary",
+		},
+		{
+			name:     "other queries - run on the primary",
+			replicas: true,
+			query: func(t *testing.T, ctx context.Context, db *gorm.DB) *gorm.DB {
+				return db.WithContext(ctx)
+			},
+			expectedSource: "primary",
+		},
+		{
+			name:     "transactions - run on the primary",
+			replicas: true,
+			query: func(t *testing.T, ctx context.Context, db *gorm.DB) *gorm.DB {
+				tx := db.Begin()
+				t.Cleanup(func() { tx.Rollback() })
+				return Reader(ctx, tx)
+			},
+			expectedSource: "primary",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := newTestDB(t, "primary")
+			var pools []*sql.DB
+			if tc.replicas {
+				pool, err := newTestDB(t, "replica").DB()
+				assert.NoError(t, err)
+				pools = append(pools, pool)
+			}
+			err := useReplicas(db, pools, func(pool *sql.DB) gorm.Dialector {
+				return &sqlite.Dialector{Conn: pool}
+			})
+			assert.NoError(t, err)
+
+			var row testRow
+			err = tc.query(t, t.Context(), db).First(&row, 1).Error
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSource, row.Source)
+			assert.Equal(t, pools, Replicas(db))
+		})
+	}
+}
+
+func TestClose(t *testing.T) {
+	t.Parallel()
+
+	db := InitMockDB(t)
+	replica, err := InitMockDB(t).DB()
+	assert.NoError(t, err)
+	assert.NoError(t, useReplicas(db, []*sql.DB{replica}, func(pool *sql.DB) gorm.Dialector {
+		return &sqlite.Dialector{Conn: pool}
+	}))
+
+	assert.NoError(t, Close(db))
+
+	primary, err := db.DB()
+	assert.NoError(t, err)
+	assert.EqualError(t, primary.Ping(), "sql: database is closed")
+	assert.EqualError(t, replica.Ping(), "sql: database is closed")
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		closeReplica   bool
+		id             int
+		expectedSource string
+		expectedCalls  int
+		expectedError  error
+	}{
+		{
+			name:           "success - runs on the replica",
+			id:             1,
+			expectedSource: "replica",
+			expectedCalls:  1,
+		},
+		{
+			name:           "success - falls back to the primary when the replica fails",
+			closeReplica:   true,
+			id:             1,
+			expectedSource: "primary",
+			expectedCalls:  2,
+		},
+		{
+			name:          "error - missing record not retried on the primary",
+			id:            2,
+			expectedCalls: 1,
+			expectedError: gorm.ErrRecordNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := InitMockDB(t)
+			assert.NoError(t, db.AutoMigrate(&testRow{}))
+			assert.NoError(t, db.Create(&testRow{ID: 1, Source: "primary"}).Error)
+			replicaDB := InitMockDB(t)
+			assert.NoError(t, replicaDB.AutoMigrate(&testRow{}))
+			assert.NoError(t, replicaDB.Create(&testRow{ID: 1, Source: "replica"}).Error)
+			replica, err := replicaDB.DB()
+			assert.NoError(t, err)
+			assert.NoError(t, useReplicas(db, []*sql.DB{replica}, func(pool *sql.DB) gorm.Dialector {
+				return &sqlite.Dialector{Conn: pool}
+			}))
+			if tc.closeReplica {
+				assert.NoError(t, replica.Close())
+			}
+
+			var row testRow
+			calls := 0
+			err = Read(t.Context(), db, func(tx *gorm.DB) error {
+				calls++
+				return tx.First(&row, tc.id).Error
+			})
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedSource, row.Source)
+			assert.Equal(t, tc.expectedCalls, calls)
+		})
+	}
+}
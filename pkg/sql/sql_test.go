@@ -0,0 +1,143 @@
+package sqldb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfig(t *testing.T) {
+	defaults := func(update func(cfg *config)) *config {
+		cfg := &config{
+			Host:               "localhost",
+			Port:               "5433",
+			User:               "postgres",
+			Password:           "postgres",
+			DBName:             "bookmark_service",
+			SSLMode:            "prefer",
+			ApplicationName:    "bookmark_service",
+			StatementTimeout:   30 * time.Second,
+			PreparedStatements: true,
+			MaxOpenConns:       25,
+			MaxIdleConns:       10,
+			ConnMaxLifetime:    30 * time.Minute,
+			ConnMaxIdleTime:    5 * time.Minute,
+			SlowThreshold:      200 * time.Millisecond,
+		}
+		update(cfg)
+		return cfg
+	}
+
+	testCases := []struct {
+		name          string
+		prefix        string
+		env           map[string]string
+		expectedCfg   *config
+		expectedError error
+	}{
+		{
+			name:        "success - defaults",
+			expectedCfg: defaults(func(cfg *config) {}),
+		},
+		{
+			name: "success - pool and TLS",
+			env: map[string]string{
+				"DB_SSL_MODE":            "verify-full",
+				"DB_SSL_ROOT_CERT":       "/etc/ssl/db-ca.pem",
+				"DB_MAX_OPEN_CONNS":      "50",
+				"DB_PREPARED_STATEMENTS": "false",
+			},
+			expectedCfg: defaults(func(cfg *config) {
+				cfg.SSLMode = "verify-full"
+				cfg.SSLRootCert = "/etc/ssl/db-ca.pem"
+				cfg.MaxOpenConns = 50
+				cfg.PreparedStatements = false
+			}),
+		},
+		{
+			name: "success - read replicas",
+			env: map[string]string{
+				"DB_REPLICA_URLS": "postgres://replica-1/bookmark_service,postgres://replica-2/bookmark_service",
+			},
+			expectedCfg: defaults(func(cfg *config) {
+				cfg.ReplicaURLs = []string{"postgres://replica-1/bookmark_service", "postgres://replica-2/bookmark_service"}
+			}),
+		},
+		{
+			name:   "success - prefixed variables",
+			prefix: "replica",
+			env: map[string]string{
+				"DB_HOST":              "primary",
+				"REPLICA_DB_HOST":      "replica",
+				"REPLICA_DATABASE_URL": "postgres://replica/bookmark_service",
+			},
+			expectedCfg: defaults(func(cfg *config) {
+				cfg.Host = "replica"
+				cfg.URL = "postgres://replica/bookmark_service"
+			}),
+		},
+		{
+			name:          "error - unknown SSL mode",
+			env:           map[string]string{"DB_SSL_MODE": "on"},
+			expectedError: ErrInvalidSSLMode,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := newConfig(tc.prefix)
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedCfg, cfg)
+		})
+	}
+}
+
+func TestConfig_GetDSN(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		cfg         *config
+		expectedDSN string
+	}{
+		{
+			name: "connection fields",
+			cfg: &config{
+				Host: "db", Port: "5432", User: "app", Password: "secret", DBName: "bookmarks",
+				SSLMode: "verify-full", SSLRootCert: "/etc/ssl/db-ca.pem", ApplicationName: "bookmark_service",
+				StatementTimeout: 1500 * time.Millisecond,
+			},
+			expectedDSN: "host=db port=5432 user=app password=secret dbname=bookmarks sslmode=verify-full " +
+				"sslrootcert=/etc/ssl/db-ca.pem application_name=bookmark_service statement_timeout=1500",
+		},
+		{
+			name:        "empty values and statement timeout omitted",
+			cfg:         &config{Host: "db", Port: "5432", User: "app", DBName: "bookmarks", SSLMode: "disable"},
+			expectedDSN: "host=db port=5432 user=app dbname=bookmarks sslmode=disable",
+		},
+		{
+			name:        "values with spaces, quotes and backslashes quoted",
+			cfg:         &config{Host: "db", Password: `it's a \secret`, ApplicationName: "bookmark api"},
+			expectedDSN: `host=db password='it\'s a \\secret' application_name='bookmark api'`,
+		},
+		{
+			name:        "database URL used as is",
+			cfg:         &config{URL: "postgres://app:secret@db:5432/bookmarks?sslmode=require", Host: "ignored"},
+			expectedDSN: "postgres://app:secret@db:5432/bookmarks?sslmode=require",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedDSN, tc.cfg.GetDSN())
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package stringutils
+
+import (
+	_ "embed"
+	"strings"
+)
+
+// wordlistData holds the passphrase words, one per line. The list has 6^4 = 1296 short,
+// common English words, so a word can also be picked by rolling four dice.
+//
+//go:embed wordlist.txt
+var wordlistData string
+
+// wordlist is the list of words passphrases are built from.
+var wordlist = strings.Fields(wordlistData)
+
+// WordlistSize returns the number of words passphrases are built from.
+func WordlistSize() int {
+	return len(wordlist)
+}
+
+// GeneratePassphrase generates a diceware-style passphrase of the specified number of
+// words picked uniformly from the embedded wordlist and joined with separator.
+// Returns an error if random number generation fails.
+func GeneratePassphrase(words int, separator string) (string, error) {
+	picked := make([]string, 0, words)
+	for range words {
+		i, err := RandomInt(len(wordlist))
+		if err != nil {
+			return "", err
+		}
+		picked = append(picked, wordlist[i])
+	}
+
+	return strings.Join(picked, separator), nil
+}
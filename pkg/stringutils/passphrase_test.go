@@ -0,0 +1,62 @@
+package stringutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordlist(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 1296, WordlistSize())
+
+	seen := make(map[string]bool, WordlistSize())
+	for _, word := range wordlist {
+		assert.False(t, seen[word], "duplicate word %q", word)
+		seen[word] = true
+		assert.Regexp(t, "^[a-z]{3,8}$", word)
+	}
+}
+
+func TestGeneratePassphrase(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		words     int
+		separator string
+	}{
+		{
+			name:      "success - dash separator",
+			words:     6,
+			separator: "-",
+		},
+		{
+			name:      "success - multi character separator",
+			words:     4,
+			separator: " + ",
+		},
+		{
+			name:      "success - single word",
+			words:     1,
+			separator: ".",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := GeneratePassphrase(tc.words, tc.separator)
+			assert.NoError(t, err)
+
+			words := strings.Split(res, tc.separator)
+			assert.Len(t, words, tc.words)
+			for _, word := range words {
+				assert.Contains(t, wordlist, word)
+			}
+		})
+	}
+}
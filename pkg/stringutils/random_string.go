@@ -38,15 +38,31 @@ func (k *keyGen) GenerateCode(length int) (string, error) {
 // It uses cryptographically secure random number generation to select characters from the charset.
 // Returns an error if random number generation fails.
 func GenerateCode(length int) (string, error) {
-	var sb bytes.Buffer
+	return GenerateFromCharset(length, charset)
+}
 
+// GenerateFromCharset generates a random string of the specified length with characters
+// picked uniformly from the given ASCII charset.
+// Returns an error if random number generation fails.
+func GenerateFromCharset(length int, charset string) (string, error) {
+	var sb bytes.Buffer
 	for range length {
-		i, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		i, err := RandomInt(len(charset))
 		if err != nil {
 			return "", err
 		}
-		sb.WriteByte(charset[i.Int64()])
+		sb.WriteByte(charset[i])
 	}
 
 	return sb.String(), nil
 }
+
+// RandomInt returns a cryptographically secure, uniformly distributed random integer in [0, n).
+func RandomInt(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(i.Int64()), nil
+}
@@ -32,3 +32,42 @@ func TestKeyGenerator_GenerateCode(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateFromCharset(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		length  int
+		charset string
+	}{
+		{
+			name:    "success - single character charset",
+			length:  8,
+			charset: "x",
+		},
+		{
+			name:    "success - symbols",
+			length:  64,
+			charset: "!@#$%",
+		},
+		{
+			name:    "success - empty string",
+			length:  0,
+			charset: "abc",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := GenerateFromCharset(tc.length, tc.charset)
+			assert.NoError(t, err)
+			assert.Len(t, res, tc.length)
+			for _, r := range res {
+				assert.Contains(t, tc.charset, string(r))
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Trace exporters.
+const (
+	// ExporterNone records no trace; the W3C trace context is still propagated.
+	ExporterNone = "none"
+	// ExporterOTLP sends the traces to an OpenTelemetry collector over OTLP/HTTP.
+	ExporterOTLP = "otlp"
+	// ExporterStdout writes the traces to the standard output.
+	ExporterStdout = "stdout"
+	// ExporterFile appends the traces to FilePath.
+	ExporterFile = "file"
+)
+
+var (
+	// ErrUnknownExporter is returned when TRACING_EXPORTER names no supported exporter.
+	ErrUnknownExporter = errors.New("unknown trace exporter")
+	// ErrInvalidSampleRatio is returned when TRACING_SAMPLE_RATIO is not between 0 and 1.
+	ErrInvalidSampleRatio = errors.New("trace sample ratio must be between 0 and 1")
+)
+
+// Config holds the tracing settings loaded from environment variables.
+//
+// OTLPEndpoint is the host and port of the collector; OTLPInsecure sends the traces over
+// plain HTTP. SampleRatio is the fraction of new traces that are recorded; traces started
+// by a caller follow the caller's sampling decision.
+type Config struct {
+	Exporter     string  `default:"none" envconfig:"TRACING_EXPORTER"`
+	ServiceName  string  `default:"bookmark-api" envconfig:"SERVICE_NAME"`
+	OTLPEndpoint string  `default:"localhost:4318" envconfig:"TRACING_OTLP_ENDPOINT"`
+	OTLPInsecure bool    `default:"true" envconfig:"TRACING_OTLP_INSECURE"`
+	FilePath     string  `default:"traces.json" envconfig:"TRACING_FILE_PATH"`
+	SampleRatio  float64 `default:"1" envconfig:"TRACING_SAMPLE_RATIO"`
+}
+
+// NewConfig creates a new configuration instance by reading environment variables.
+// It returns an error if the exporter is unknown or the sample ratio is not between 0 and 1.
+func NewConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, err
+	}
+	if !slices.Contains([]string{ExporterNone, ExporterOTLP, ExporterStdout, ExporterFile}, cfg.Exporter) {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownExporter, cfg.Exporter)
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSampleRatio, cfg.SampleRatio)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,68 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfig(t *testing.T) {
+	testCases := []struct {
+		name          string
+		env           map[string]string
+		expectedCfg   *Config
+		expectedError error
+	}{
+		{
+			name: "success - defaults",
+			expectedCfg: &Config{
+				Exporter:     ExporterNone,
+				ServiceName:  "bookmark-api",
+				OTLPEndpoint: "localhost:4318",
+				OTLPInsecure: true,
+				FilePath:     "traces.json",
+				SampleRatio:  1,
+			},
+		},
+		{
+			name: "success - otlp",
+			env: map[string]string{
+				"TRACING_EXPORTER":      ExporterOTLP,
+				"TRACING_OTLP_ENDPOINT": "collector:4318",
+				"TRACING_OTLP_INSECURE": "false",
+				"TRACING_SAMPLE_RATIO":  "0.25",
+				"SERVICE_NAME":          "bookmark-test",
+			},
+			expectedCfg: &Config{
+				Exporter:     ExporterOTLP,
+				ServiceName:  "bookmark-test",
+				OTLPEndpoint: "collector:4318",
+				FilePath:     "traces.json",
+				SampleRatio:  0.25,
+			},
+		},
+		{
+			name:          "error - unknown exporter",
+			env:           map[string]string{"TRACING_EXPORTER": "jaeger"},
+			expectedError: ErrUnknownExporter,
+		},
+		{
+			name:          "error - sample ratio above 1",
+			env:           map[string]string{"TRACING_SAMPLE_RATIO": "1.5"},
+			expectedError: ErrInvalidSampleRatio,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			cfg, err := NewConfig()
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedCfg, cfg)
+		})
+	}
+}
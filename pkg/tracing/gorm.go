@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"errors"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// querySpanKey is the instance key holding the span of a query.
+const querySpanKey = "tracing:query_span"
+
+// gormPlugin is a GORM plugin recording a span for every query.
+type gormPlugin struct{}
+
+// NewGORMPlugin creates a GORM plugin that records a client span for each query run
+// through the connection, as a child of the span in the statement context. Pass the
+// request context with db.WithContext for the query to join the request trace. Install
+// it with db.Use.
+func NewGORMPlugin() gorm.Plugin {
+	return gormPlugin{}
+}
+
+// Name returns the name of the plugin.
+func (gormPlugin) Name() string {
+	return "tracing"
+}
+
+// Initialize registers a callback before and after each kind of query.
+func (gormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	for _, err := range []error{
+		callbacks.Create().Before("gorm:create").Register("tracing:before_create", startQuery("create")),
+		callbacks.Create().After("gorm:create").Register("tracing:after_create", endQuery),
+		callbacks.Query().Before("gorm:query").Register("tracing:before_query", startQuery("query")),
+		callbacks.Query().After("gorm:query").Register("tracing:after_query", endQuery),
+		callbacks.Update().Before("gorm:update").Register("tracing:before_update", startQuery("update")),
+		callbacks.Update().After("gorm:update").Register("tracing:after_update", endQuery),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", startQuery("delete")),
+		callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", endQuery),
+		callbacks.Row().Before("gorm:row").Register("tracing:before_row", startQuery("row")),
+		callbacks.Row().After("gorm:row").Register("tracing:after_row", endQuery),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", startQuery("raw")),
+		callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", endQuery),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// startQuery returns a callback starting the span of a query of the given operation.
+func startQuery(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx, span := Tracer().Start(db.Statement.Context, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemNamePostgreSQL, semconv.DBOperationName(operation)),
+		)
+		db.Statement.Context = ctx
+		db.InstanceSet(querySpanKey, span)
+	}
+}
+
+// endQuery ends the span of a query with the table and the SQL statement; the statement
+// holds placeholders, not the values bound to them. Record not found is not an error.
+func endQuery(db *gorm.DB) {
+	value, ok := db.InstanceGet(querySpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+
+	span.SetAttributes(
+		semconv.DBCollectionName(db.Statement.Table),
+		semconv.DBQueryText(db.Statement.SQL.String()),
+	)
+	err := db.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	End(span, err)
+}
@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type gormPluginTestRow struct {
+	ID   int
+	Name string
+}
+
+func TestGORMPlugin(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&gormPluginTestRow{}))
+	assert.NoError(t, db.Use(NewGORMPlugin()))
+	ctx, spans := InitMockTracer(t)
+
+	assert.NoError(t, db.WithContext(ctx).Create(&gormPluginTestRow{ID: 1, Name: "first"}).Error)
+	var row gormPluginTestRow
+	assert.Error(t, db.WithContext(ctx).First(&row, 2).Error)
+	assert.Error(t, db.WithContext(ctx).Table("missing_table").Find(&[]gormPluginTestRow{}).Error)
+
+	recorded := spans()
+	assert.Len(t, recorded, 3)
+	assert.Equal(t, "gorm.create", recorded[0].Name)
+	assert.Contains(t, recorded[0].Attributes, attribute.String("db.collection.name", "gorm_plugin_test_rows"))
+	assert.Equal(t, codes.Unset, recorded[0].Status.Code)
+	assert.Equal(t, "gorm.query", recorded[1].Name)
+	assert.Equal(t, codes.Unset, recorded[1].Status.Code)
+	assert.Equal(t, "gorm.query", recorded[2].Name)
+	assert.Equal(t, codes.Error, recorded[2].Status.Code)
+}
@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var (
+	mockExporter     *tracetest.InMemoryExporter
+	mockExporterOnce sync.Once
+)
+
+// InitMockTracer installs a global tracer provider recording the spans in memory, and
+// starts a root span for the test. It returns the context holding the root span and a
+// function returning the ended spans of the trace, excluding the root span, in the order
+// they ended. Tests running in parallel share the provider but each see their own trace.
+func InitMockTracer(t *testing.T) (context.Context, func() tracetest.SpanStubs) {
+	mockExporterOnce.Do(func() {
+		mockExporter = tracetest.NewInMemoryExporter()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(mockExporter)))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+	})
+
+	ctx, root := Start(t.Context(), t.Name())
+	t.Cleanup(func() { root.End() })
+	traceID := root.SpanContext().TraceID()
+
+	return ctx, func() tracetest.SpanStubs {
+		var spans tracetest.SpanStubs
+		for _, span := range mockExporter.GetSpans() {
+			if span.SpanContext.TraceID() == traceID && span.SpanContext.SpanID() != root.SpanContext().SpanID() {
+				spans = append(spans, span)
+			}
+		}
+
+		return spans
+	}
+}
@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/redis/go-redis/v9"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// redisHook is a go-redis hook recording a span for every command.
+type redisHook struct{}
+
+// NewRedisHook creates a go-redis hook that records a client span for each command sent
+// through the client, as a child of the span in the command context. Only the command
+// name is recorded, as arguments may hold user data. Install it with client.AddHook.
+func NewRedisHook() redis.Hook {
+	return redisHook{}
+}
+
+// DialHook leaves dialing unchanged.
+func (redisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook records the span of a single command.
+func (redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := Tracer().Start(ctx, "redis."+cmd.Name(),
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemNameRedis, semconv.DBOperationName(cmd.Name())),
+		)
+		err := next(ctx, cmd)
+		End(span, commandError(err))
+
+		return err
+	}
+}
+
+// ProcessPipelineHook records a single span for a pipeline or transaction.
+func (redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		ctx, span := Tracer().Start(ctx, "redis.pipeline",
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemNameRedis, semconv.DBOperationBatchSize(len(cmds))),
+		)
+		err := next(ctx, cmds)
+		End(span, commandError(err))
+
+		return err
+	}
+}
+
+// commandError returns the error of a command, or nil for a missing key, which is a
+// normal outcome.
+func commandError(err error) error {
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+
+	return err
+}
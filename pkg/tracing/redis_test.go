@@ -0,0 +1,41 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestRedisHook(t *testing.T) {
+	t.Parallel()
+
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	client.AddHook(NewRedisHook())
+	assert.NoError(t, client.Ping(t.Context()).Err())
+	ctx, spans := InitMockTracer(t)
+
+	assert.NoError(t, client.Set(ctx, "key", "value", 0).Err())
+	assert.ErrorIs(t, client.Get(ctx, "missing").Err(), redis.Nil)
+	assert.Error(t, client.LPush(ctx, "key", "value").Err())
+	_, err := client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Get(ctx, "key")
+		pipe.Get(ctx, "key")
+		return nil
+	})
+	assert.NoError(t, err)
+
+	recorded := spans()
+	assert.Len(t, recorded, 4)
+	assert.Equal(t, "redis.set", recorded[0].Name)
+	assert.Contains(t, recorded[0].Attributes, attribute.String("db.system.name", "redis"))
+	assert.Equal(t, "redis.get", recorded[1].Name)
+	assert.Equal(t, codes.Unset, recorded[1].Status.Code)
+	assert.Equal(t, "redis.lpush", recorded[2].Name)
+	assert.Equal(t, codes.Error, recorded[2].Status.Code)
+	assert.Equal(t, "redis.pipeline", recorded[3].Name)
+	assert.Contains(t, recorded[3].Attributes, attribute.Int("db.operation.batch.size", 2))
+}
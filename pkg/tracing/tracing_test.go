@@ -0,0 +1,119 @@
+package tracing
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestStartEnd(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		err            error
+		expectedStatus codes.Code
+		expectedEvents int
+	}{
+		{
+			name:           "success",
+			expectedStatus: codes.Unset,
+		},
+		{
+			name:           "error",
+			err:            errors.New("failed"),
+			expectedStatus: codes.Error,
+			expectedEvents: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, spans := InitMockTracer(t)
+
+			_, span := Start(ctx, "operation", attribute.String("key", "value"))
+			End(span, tc.err)
+
+			recorded := spans()
+			assert.Len(t, recorded, 1)
+			assert.Equal(t, "operation", recorded[0].Name)
+			assert.Contains(t, recorded[0].Attributes, attribute.String("key", "value"))
+			assert.Equal(t, tc.expectedStatus, recorded[0].Status.Code)
+			assert.Len(t, recorded[0].Events, tc.expectedEvents)
+		})
+	}
+}
+
+func TestNewExporter(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		cfg      func(t *testing.T) *Config
+		hasError bool
+	}{
+		{
+			name: "otlp",
+			cfg: func(t *testing.T) *Config {
+				return &Config{Exporter: ExporterOTLP, OTLPEndpoint: "localhost:4318", OTLPInsecure: true}
+			},
+		},
+		{
+			name: "stdout",
+			cfg: func(t *testing.T) *Config {
+				return &Config{Exporter: ExporterStdout}
+			},
+		},
+		{
+			name: "file",
+			cfg: func(t *testing.T) *Config {
+				return &Config{Exporter: ExporterFile, FilePath: filepath.Join(t.TempDir(), "traces.json")}
+			},
+		},
+		{
+			name: "file in missing directory",
+			cfg: func(t *testing.T) *Config {
+				return &Config{Exporter: ExporterFile, FilePath: filepath.Join(t.TempDir(), "missing", "traces.json")}
+			},
+			hasError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := tc.cfg(t)
+			exporter, closeOutput, err := newExporter(t.Context(), cfg)
+			if tc.hasError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NoError(t, exporter.Shutdown(t.Context()))
+			assert.NoError(t, closeOutput())
+			if cfg.Exporter == ExporterFile {
+				assert.FileExists(t, cfg.FilePath)
+			}
+		})
+	}
+}
+
+func TestSetup_None(t *testing.T) {
+	t.Parallel()
+
+	shutdown, err := Setup(t.Context(), &Config{Exporter: ExporterNone})
+
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(t.Context()))
+	_, err = os.Stat("traces.json")
+	assert.True(t, os.IsNotExist(err))
+}
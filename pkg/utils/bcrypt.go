@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// hashBcrypt hashes the password with bcrypt at the given cost, in the modular crypt format
+// $2a$<cost>$<salt and hash> that PHC strings extend. Passwords longer than 72 bytes are
+// rejected with ErrPasswordTooLong.
+func hashBcrypt(password string, cost int) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return "", ErrPasswordTooLong
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// verifyBcrypt compares the password with a bcrypt hash.
+func verifyBcrypt(password, hash string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// bcryptCost returns the cost of a bcrypt hash.
+func bcryptCost(hash string) (int, error) {
+	return bcrypt.Cost([]byte(hash))
+}
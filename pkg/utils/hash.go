@@ -1,55 +1,131 @@
 // Package utils provides utility functions for common operations such as password hashing and verification.
 package utils
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedAlgorithm is returned when hashing with an algorithm other than
+	// AlgorithmArgon2id or AlgorithmBcrypt.
+	ErrUnsupportedAlgorithm = errors.New("unsupported password hashing algorithm")
+	// ErrInvalidHash is returned when a password hash is not in a supported format.
+	ErrInvalidHash = errors.New("invalid password hash")
+	// ErrPasswordTooLong is returned when hashing with bcrypt a password longer than the
+	// 72 bytes bcrypt can hash.
+	ErrPasswordTooLong = errors.New("password must not be longer than 72 bytes")
+)
 
-//go:generate mockery --name Hasher --filename hash.go
 // Hasher defines the interface for password hashing operations.
-// It provides methods to hash passwords and verify passwords against their hashes.
+// It provides methods to hash passwords, verify passwords against their hashes, and tell
+// whether a hash should be upgraded to the configured algorithm and parameters.
+//
+//go:generate mockery --name Hasher --filename hash.go
 type Hasher interface {
-	// HashPassword generates a hash from the given plain text password.
-	// Returns the hashed password string.
-	HashPassword(password string) string
-	// VerifyPassword checks if the given plain text password matches the provided hash.
+	// HashPassword generates a hash from the given plain text password with the configured
+	// algorithm and parameters. Returns the hash in PHC string format.
+	HashPassword(password string) (string, error)
+	// VerifyPassword checks if the given plain text password matches the provided hash, whatever
+	// supported algorithm and parameters produced it.
 	// Returns true if the password matches the hash, false otherwise.
 	VerifyPassword(password, hash string) bool
+	// NeedsRehash reports whether the hash was produced by another algorithm or with weaker
+	// parameters than configured, so the password should be hashed again once it is known.
+	NeedsRehash(hash string) bool
+}
+
+// hasher implements the Hasher interface. It hashes new passwords with the configured
+// algorithm and verifies hashes of every supported algorithm, recognized by their prefix.
+type hasher struct {
+	cfg *HashConfig
+}
+
+// NewHasher creates a new instance of Hasher hashing passwords as configured by cfg.
+// When cfg is nil, passwords are hashed with argon2id and the default parameters.
+// Returns a Hasher interface that can be used for password hashing and verification.
+func NewHasher(cfg *HashConfig) Hasher {
+	if cfg == nil {
+		cfg = DefaultHashConfig()
+	}
+
+	return &hasher{
+		cfg: cfg,
+	}
 }
 
-// bcryptHasher implements the Hasher interface using bcrypt algorithm.
-type bcryptHasher struct{}
+// HashPassword hashes the password with the configured algorithm.
+func (h *hasher) HashPassword(password string) (string, error) {
+	switch h.cfg.Algorithm {
+	case AlgorithmArgon2id:
+		return hashArgon2id(password, h.cfg.argon2Params())
+	case AlgorithmBcrypt:
+		return hashBcrypt(password, h.cfg.BcryptCost)
+	default:
+		return "", ErrUnsupportedAlgorithm
+	}
+}
 
-// HashPassword generates a bcrypt hash from the given plain text password.
-// Uses bcrypt.DefaultCost for hashing. Returns the hashed password string.
-func (b *bcryptHasher) HashPassword(password string) string {
-	hashBytes, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	return string(hashBytes)
+// VerifyPassword verifies the password against a hash of any supported algorithm.
+func (h *hasher) VerifyPassword(password, hash string) bool {
+	switch hashAlgorithm(hash) {
+	case AlgorithmArgon2id:
+		return verifyArgon2id(password, hash)
+	case AlgorithmBcrypt:
+		return verifyBcrypt(password, hash)
+	default:
+		return false
+	}
 }
 
-// VerifyPassword verifies if the given plain text password matches the bcrypt hash.
-// Returns true if the password matches the hash, false otherwise.
-func (b *bcryptHasher) VerifyPassword(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+// NeedsRehash reports whether the hash uses another algorithm than configured or weaker
+// parameters. Hashes in an unknown format never need a rehash, since no password can be
+// verified against them.
+func (h *hasher) NeedsRehash(hash string) bool {
+	algorithm := hashAlgorithm(hash)
+	switch {
+	case algorithm == "":
+		return false
+	case algorithm != h.cfg.Algorithm:
+		return true
+	case algorithm == AlgorithmArgon2id:
+		params, _, _, err := decodeArgon2id(hash)
+		if err != nil {
+			return false
+		}
+		return params.weakerThan(h.cfg.argon2Params())
+	default:
+		cost, err := bcryptCost(hash)
+		if err != nil {
+			return false
+		}
+		return cost < h.cfg.BcryptCost
+	}
 }
 
-// NewHasher creates a new instance of Hasher using bcrypt implementation.
-// Returns a Hasher interface that can be used for password hashing and verification.
-func NewHasher() Hasher {
-	return &bcryptHasher{}
+// hashAlgorithm returns the algorithm of a hash from its PHC identifier, or an empty
+// string for an unknown format.
+func hashAlgorithm(hash string) string {
+	switch {
+	case strings.HasPrefix(hash, "$"+AlgorithmArgon2id+"$"):
+		return AlgorithmArgon2id
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return AlgorithmBcrypt
+	default:
+		return ""
+	}
 }
 
 // HashPassword is a convenience function that hashes a password using the default Hasher.
 // It creates a new Hasher instance and hashes the given password.
 // Returns the hashed password string.
-func HashPassword(password string) string {
-	hasher := NewHasher()
-	return hasher.HashPassword(password)
+func HashPassword(password string) (string, error) {
+	return NewHasher(nil).HashPassword(password)
 }
 
 // VerifyPassword is a convenience function that verifies a password against a hash using the default Hasher.
 // It creates a new Hasher instance and verifies if the password matches the hash.
 // Returns true if the password matches the hash, false otherwise.
 func VerifyPassword(password, hash string) bool {
-	hasher := NewHasher()
-	return hasher.VerifyPassword(password, hash)
+	return NewHasher(nil).VerifyPassword(password, hash)
 }
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Supported password hashing algorithms.
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+// Default hashing parameters. The argon2id ones follow the OWASP recommendation of 19 MiB
+// of memory, 2 iterations and 1 degree of parallelism.
+const (
+	DefaultBcryptCost        = 12
+	DefaultArgon2Memory      = 19 * 1024
+	DefaultArgon2Iterations  = 2
+	DefaultArgon2Parallelism = 1
+)
+
+// HashConfig holds the password hashing settings loaded from environment variables.
+//
+// Algorithm selects the algorithm of new hashes, argon2id or bcrypt. BcryptCost is the cost
+// of bcrypt hashes; Argon2Memory (in KiB), Argon2Iterations and Argon2Parallelism are the
+// parameters of argon2id hashes. Hashes of another algorithm or with weaker parameters are
+// still verified, and replaced on the next successful login.
+type HashConfig struct {
+	Algorithm         string `default:"argon2id" envconfig:"PASSWORD_HASH_ALGORITHM"`
+	BcryptCost        int    `default:"12" envconfig:"PASSWORD_HASH_BCRYPT_COST"`
+	Argon2Memory      uint32 `default:"19456" envconfig:"PASSWORD_HASH_ARGON2_MEMORY"`
+	Argon2Iterations  uint32 `default:"2" envconfig:"PASSWORD_HASH_ARGON2_ITERATIONS"`
+	Argon2Parallelism uint8  `default:"1" envconfig:"PASSWORD_HASH_ARGON2_PARALLELISM"`
+}
+
+// NewHashConfig creates a new configuration instance by reading environment variables.
+// Unset variables fall back to the defaults declared on HashConfig. It returns
+// ErrUnsupportedAlgorithm for an unknown algorithm.
+func NewHashConfig() (*HashConfig, error) {
+	cfg := &HashConfig{}
+	if err := envconfig.Process("", cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Algorithm != AlgorithmArgon2id && cfg.Algorithm != AlgorithmBcrypt {
+		return nil, fmt.Errorf("PASSWORD_HASH_ALGORITHM %q: %w", cfg.Algorithm, ErrUnsupportedAlgorithm)
+	}
+
+	return cfg, nil
+}
+
+// DefaultHashConfig returns the configuration hashing passwords with argon2id and the
+// default parameters.
+func DefaultHashConfig() *HashConfig {
+	return &HashConfig{
+		Algorithm:         AlgorithmArgon2id,
+		BcryptCost:        DefaultBcryptCost,
+		Argon2Memory:      DefaultArgon2Memory,
+		Argon2Iterations:  DefaultArgon2Iterations,
+		Argon2Parallelism: DefaultArgon2Parallelism,
+	}
+}
+
+// argon2Params returns the configured argon2id parameters.
+func (c *HashConfig) argon2Params() argon2Params {
+	return argon2Params{
+		memory:      c.Argon2Memory,
+		iterations:  c.Argon2Iterations,
+		parallelism: c.Argon2Parallelism,
+	}
+}
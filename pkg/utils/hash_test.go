@@ -1,53 +1,77 @@
 package utils
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 )
 
-func TestBcryptHasher_HashPassword(t *testing.T) {
-	t.Parallel()
+// testArgon2Config and testBcryptConfig use cheap parameters to keep the tests fast.
+var (
+	testArgon2Config = &HashConfig{
+		Algorithm:         AlgorithmArgon2id,
+		BcryptCost:        bcrypt.MinCost,
+		Argon2Memory:      1024,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+	}
+	testBcryptConfig = &HashConfig{
+		Algorithm:         AlgorithmBcrypt,
+		BcryptCost:        bcrypt.MinCost,
+		Argon2Memory:      1024,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+	}
+)
 
-	hasher := NewHasher()
+func TestHasher_HashPassword(t *testing.T) {
+	t.Parallel()
 
 	testCases := []struct {
-		name     string
-		password string
-		verify   func(t *testing.T, hash string)
+		name          string
+		cfg           *HashConfig
+		password      string
+		expectedError error
+		verify        func(t *testing.T, hash string)
 	}{
 		{
-			name:     "success - hash password",
+			name:     "success - argon2id",
+			cfg:      testArgon2Config,
 			password: "password123",
 			verify: func(t *testing.T, hash string) {
-				assert.NotEmpty(t, hash)
-				assert.NotEqual(t, "password123", hash)
-				assert.Contains(t, hash, "$2a$")
+				assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+				assert.Len(t, strings.Split(hash, "$"), 6)
 			},
 		},
 		{
-			name:     "success - hash empty password",
+			name:     "success - argon2id empty password",
+			cfg:      testArgon2Config,
 			password: "",
 			verify: func(t *testing.T, hash string) {
-				assert.NotEmpty(t, hash)
-				assert.Contains(t, hash, "$2a$")
+				assert.True(t, strings.HasPrefix(hash, "$argon2id$"))
 			},
 		},
 		{
-			name:     "success - hash long password (within bcrypt limit)",
-			password: "this is a long password that is still within bcrypt 72 byte limit",
+			name:     "success - bcrypt",
+			cfg:      testBcryptConfig,
+			password: "p@ssw0rd!#$%^&*()",
 			verify: func(t *testing.T, hash string) {
-				assert.NotEmpty(t, hash)
-				assert.Contains(t, hash, "$2a$")
+				assert.True(t, strings.HasPrefix(hash, "$2a$04$"))
 			},
 		},
 		{
-			name:     "success - hash special characters",
-			password: "p@ssw0rd!#$%^&*()",
-			verify: func(t *testing.T, hash string) {
-				assert.NotEmpty(t, hash)
-				assert.Contains(t, hash, "$2a$")
-			},
+			name:          "error - bcrypt password longer than 72 bytes",
+			cfg:           testBcryptConfig,
+			password:      strings.Repeat("a", 73),
+			expectedError: ErrPasswordTooLong,
+		},
+		{
+			name:          "error - unsupported algorithm",
+			cfg:           &HashConfig{Algorithm: "md5"},
+			password:      "password123",
+			expectedError: ErrUnsupportedAlgorithm,
 		},
 	}
 
@@ -55,61 +79,80 @@ func TestBcryptHasher_HashPassword(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			hash := hasher.HashPassword(tc.password)
+			hash, err := NewHasher(tc.cfg).HashPassword(tc.password)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				assert.Empty(t, hash)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEqual(t, tc.password, hash)
 			tc.verify(t, hash)
 		})
 	}
 }
 
-func TestBcryptHasher_VerifyPassword(t *testing.T) {
+func TestHasher_VerifyPassword(t *testing.T) {
 	t.Parallel()
 
-	hasher := NewHasher()
+	argon2Hash, err := NewHasher(testArgon2Config).HashPassword("password123")
+	assert.NoError(t, err)
+	bcryptHash, err := NewHasher(testBcryptConfig).HashPassword("password123")
+	assert.NoError(t, err)
 
 	testCases := []struct {
 		name          string
 		password      string
 		hash          string
 		expectedMatch bool
-		setupHash     bool
 	}{
 		{
-			name:          "success - verify correct password",
+			name:          "success - argon2id",
 			password:      "password123",
-			setupHash:     true,
+			hash:          argon2Hash,
 			expectedMatch: true,
 		},
 		{
-			name:          "error - verify incorrect password",
+			name:          "success - bcrypt hash verified by any configuration",
+			password:      "password123",
+			hash:          bcryptHash,
+			expectedMatch: true,
+		},
+		{
+			name:          "error - argon2id incorrect password",
 			password:      "wrongpassword",
-			setupHash:     true,
+			hash:          argon2Hash,
 			expectedMatch: false,
 		},
 		{
-			name:          "success - verify with empty password (bcrypt supports empty passwords)",
-			password:      "",
-			setupHash:     true,
-			expectedMatch: true,
+			name:          "error - bcrypt incorrect password",
+			password:      "wrongpassword",
+			hash:          bcryptHash,
+			expectedMatch: false,
 		},
 		{
-			name:          "error - verify with invalid hash",
+			name:          "error - invalid hash",
 			password:      "password123",
 			hash:          "invalid-hash",
-			setupHash:     false,
 			expectedMatch: false,
 		},
 		{
-			name:          "error - verify with empty hash",
+			name:          "error - empty hash",
 			password:      "password123",
 			hash:          "",
-			setupHash:     false,
 			expectedMatch: false,
 		},
 		{
-			name:          "error - verify with malformed hash",
+			name:          "error - malformed bcrypt hash",
 			password:      "password123",
 			hash:          "$2a$10$short",
-			setupHash:     false,
+			expectedMatch: false,
+		},
+		{
+			name:          "error - malformed argon2id hash",
+			password:      "password123",
+			hash:          "$argon2id$v=19$m=1024,t=1$c2FsdA$a2V5",
 			expectedMatch: false,
 		},
 	}
@@ -118,37 +161,84 @@ func TestBcryptHasher_VerifyPassword(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			var hash string
-			if tc.setupHash {
-				hash = hasher.HashPassword(tc.password)
-				if !tc.expectedMatch && tc.name == "error - verify incorrect password" {
-					hash = hasher.HashPassword("differentpassword")
-				}
-			} else {
-				hash = tc.hash
+			for _, cfg := range []*HashConfig{testArgon2Config, testBcryptConfig} {
+				assert.Equal(t, tc.expectedMatch, NewHasher(cfg).VerifyPassword(tc.password, tc.hash))
 			}
-
-			result := hasher.VerifyPassword(tc.password, hash)
-			assert.Equal(t, tc.expectedMatch, result)
 		})
 	}
 }
 
-func TestHashPassword(t *testing.T) {
+func TestHasher_NeedsRehash(t *testing.T) {
 	t.Parallel()
 
+	argon2Hash, err := NewHasher(testArgon2Config).HashPassword("password123")
+	assert.NoError(t, err)
+	bcryptHash, err := NewHasher(testBcryptConfig).HashPassword("password123")
+	assert.NoError(t, err)
+
 	testCases := []struct {
 		name     string
-		password string
-		verify   func(t *testing.T, hash string)
+		cfg      *HashConfig
+		hash     string
+		expected bool
 	}{
 		{
-			name:     "success - convenience function works",
-			password: "testpassword",
-			verify: func(t *testing.T, hash string) {
-				assert.NotEmpty(t, hash)
-				assert.Contains(t, hash, "$2a$")
+			name:     "same argon2id parameters",
+			cfg:      testArgon2Config,
+			hash:     argon2Hash,
+			expected: false,
+		},
+		{
+			name: "stronger argon2id memory configured",
+			cfg: &HashConfig{
+				Algorithm:         AlgorithmArgon2id,
+				Argon2Memory:      2048,
+				Argon2Iterations:  1,
+				Argon2Parallelism: 1,
 			},
+			hash:     argon2Hash,
+			expected: true,
+		},
+		{
+			name: "weaker argon2id parameters configured",
+			cfg: &HashConfig{
+				Algorithm:         AlgorithmArgon2id,
+				Argon2Memory:      512,
+				Argon2Iterations:  1,
+				Argon2Parallelism: 1,
+			},
+			hash:     argon2Hash,
+			expected: false,
+		},
+		{
+			name:     "bcrypt hash with argon2id configured",
+			cfg:      testArgon2Config,
+			hash:     bcryptHash,
+			expected: true,
+		},
+		{
+			name:     "same bcrypt cost",
+			cfg:      testBcryptConfig,
+			hash:     bcryptHash,
+			expected: false,
+		},
+		{
+			name:     "higher bcrypt cost configured",
+			cfg:      &HashConfig{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost + 1},
+			hash:     bcryptHash,
+			expected: true,
+		},
+		{
+			name:     "argon2id hash with bcrypt configured",
+			cfg:      testBcryptConfig,
+			hash:     argon2Hash,
+			expected: true,
+		},
+		{
+			name:     "unknown format",
+			cfg:      testArgon2Config,
+			hash:     "invalid-hash",
+			expected: false,
 		},
 	}
 
@@ -156,50 +246,61 @@ func TestHashPassword(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			hash := HashPassword(tc.password)
-			tc.verify(t, hash)
+			assert.Equal(t, tc.expected, NewHasher(tc.cfg).NeedsRehash(tc.hash))
 		})
 	}
 }
 
-func TestVerifyPassword(t *testing.T) {
-	t.Parallel()
-
+func TestNewHashConfig(t *testing.T) {
 	testCases := []struct {
 		name          string
-		password      string
-		setupHash     bool
-		expectedMatch bool
+		algorithm     string
+		expectedCfg   *HashConfig
+		expectedError error
 	}{
 		{
-			name:          "success - convenience function works with correct password",
-			password:      "testpassword",
-			setupHash:     true,
-			expectedMatch: true,
+			name:        "success - defaults",
+			expectedCfg: DefaultHashConfig(),
 		},
 		{
-			name:          "error - convenience function works with incorrect password",
-			password:      "wrongpassword",
-			setupHash:     true,
-			expectedMatch: false,
+			name:      "success - bcrypt",
+			algorithm: AlgorithmBcrypt,
+			expectedCfg: &HashConfig{
+				Algorithm:         AlgorithmBcrypt,
+				BcryptCost:        DefaultBcryptCost,
+				Argon2Memory:      DefaultArgon2Memory,
+				Argon2Iterations:  DefaultArgon2Iterations,
+				Argon2Parallelism: DefaultArgon2Parallelism,
+			},
+		},
+		{
+			name:          "error - unsupported algorithm",
+			algorithm:     "scrypt",
+			expectedError: ErrUnsupportedAlgorithm,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
-
-			var hash string
-			if tc.setupHash {
-				if tc.expectedMatch {
-					hash = HashPassword(tc.password)
-				} else {
-					hash = HashPassword("differentpassword")
-				}
+			if tc.algorithm != "" {
+				t.Setenv("PASSWORD_HASH_ALGORITHM", tc.algorithm)
 			}
 
-			result := VerifyPassword(tc.password, hash)
-			assert.Equal(t, tc.expectedMatch, result)
+			cfg, err := NewHashConfig()
+
+			assert.ErrorIs(t, err, tc.expectedError)
+			assert.Equal(t, tc.expectedCfg, cfg)
 		})
 	}
 }
+
+func TestHashPassword(t *testing.T) {
+	t.Parallel()
+
+	hash, err := HashPassword("testpassword")
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=19456,t=2,p=1$"))
+	assert.True(t, VerifyPassword("testpassword", hash))
+	assert.False(t, VerifyPassword("wrongpassword", hash))
+}